@@ -0,0 +1,223 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+)
+
+var errAgentRevoked = errors.New("agent token is invalid or has been revoked")
+
+// Client 是运行在远程代理一侧的客户端。
+// 它主动连接到 Certimate 服务端，接收并在本机执行部署任务，同时将日志与结果回传。
+type Client struct {
+	serverUrl string
+	token     string
+	logger    *slog.Logger
+	dialer    *websocket.Dialer
+}
+
+func NewClient(serverUrl, token string, logger *slog.Logger) *Client {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Client{
+		serverUrl: serverUrl,
+		token:     token,
+		logger:    logger,
+		dialer:    websocket.DefaultDialer,
+	}
+}
+
+// Run 连接到服务端并处理部署任务，连接断开后会自动重连，直至上下文被取消或代理被吊销。
+func (c *Client) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		connectedAt := time.Now()
+		err := c.runOnce(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, errAgentRevoked) {
+			return err
+		}
+
+		if time.Since(connectedAt) > time.Minute {
+			backoff = time.Second
+		}
+		c.logger.Warn("agent disconnected, reconnecting ...", slog.Any("error", err), slog.Duration("backoff", backoff))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, time.Minute)
+	}
+}
+
+func (c *Client) runOnce(ctx context.Context) error {
+	wsUrl, err := buildConnectUrl(c.serverUrl)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+c.token)
+	ws, resp, err := c.dialer.DialContext(ctx, wsUrl, header)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			return errAgentRevoked
+		}
+		return fmt.Errorf("failed to connect to server: %w", err)
+	}
+	defer ws.Close()
+
+	c.logger.Info("agent connected", slog.String("server", c.serverUrl))
+
+	session := &clientSession{
+		ws:      ws,
+		logger:  c.logger,
+		cancels: make(map[string]context.CancelFunc),
+	}
+	defer session.cancelAll()
+
+	go func() {
+		<-ctx.Done()
+		ws.Close()
+	}()
+
+	ws.SetReadDeadline(time.Now().Add(pongTimeout))
+	ws.SetPingHandler(func(data string) error {
+		ws.SetReadDeadline(time.Now().Add(pongTimeout))
+		return ws.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(writeTimeout))
+	})
+
+	for {
+		msg := &message{}
+		if err := ws.ReadJSON(msg); err != nil {
+			return err
+		}
+
+		switch msg.Type {
+		case messageTypeDeploy:
+			if msg.Deploy != nil {
+				go session.runDeployTask(ctx, msg.TaskId, msg.Deploy)
+			}
+
+		case messageTypeCancel:
+			session.cancel(msg.TaskId)
+		}
+	}
+}
+
+type clientSession struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+	logger  *slog.Logger
+
+	cancelsMu sync.Mutex
+	cancels   map[string]context.CancelFunc
+}
+
+func (s *clientSession) runDeployTask(ctx context.Context, taskId string, payload *deployPayload) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancelsMu.Lock()
+	s.cancels[taskId] = cancel
+	s.cancelsMu.Unlock()
+
+	defer func() {
+		s.cancel(taskId)
+	}()
+
+	s.logger.Info("deploy task received", slog.String("taskId", taskId))
+
+	// 日志同时输出到本地和服务端
+	logger := slog.New(newStreamHandler(s.logger.Handler(), func(log *logPayload) {
+		_ = s.send(&message{Type: messageTypeLog, TaskId: taskId, Log: log})
+	}))
+
+	result := &resultPayload{}
+	config := payload.Config
+	if config == nil {
+		config = &local.DeployerConfig{}
+	}
+	d, err := local.NewDeployer(config)
+	if err == nil {
+		var res *deployer.DeployResult
+		res, err = d.WithLogger(logger).Deploy(ctx, payload.Certificate, payload.PrivateKey)
+		if res != nil {
+			result.ExtendedData = res.ExtendedData
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+		s.logger.Error("deploy task failed", slog.String("taskId", taskId), slog.Any("error", err))
+	} else {
+		s.logger.Info("deploy task succeeded", slog.String("taskId", taskId))
+	}
+
+	if err := s.send(&message{Type: messageTypeResult, TaskId: taskId, Result: result}); err != nil {
+		s.logger.Error("failed to send task result", slog.String("taskId", taskId), slog.Any("error", err))
+	}
+}
+
+func (s *clientSession) send(msg *message) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return s.ws.WriteJSON(msg)
+}
+
+func (s *clientSession) cancel(taskId string) {
+	s.cancelsMu.Lock()
+	defer s.cancelsMu.Unlock()
+
+	if cancel, ok := s.cancels[taskId]; ok {
+		cancel()
+		delete(s.cancels, taskId)
+	}
+}
+
+func (s *clientSession) cancelAll() {
+	s.cancelsMu.Lock()
+	defer s.cancelsMu.Unlock()
+
+	for taskId, cancel := range s.cancels {
+		cancel()
+		delete(s.cancels, taskId)
+	}
+}
+
+func buildConnectUrl(serverUrl string) (string, error) {
+	u, err := url.Parse(serverUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid server url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "ws":
+		u.Scheme = "ws"
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("invalid server url scheme '%s'", u.Scheme)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/") + connectPath
+	return u.String(), nil
+}
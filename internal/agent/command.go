@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommand 创建 `agent` 子命令，用于以远程代理的身份运行。
+func NewCommand() *cobra.Command {
+	var (
+		flagServer string
+		flagToken  string
+	)
+
+	command := &cobra.Command{
+		Use:          "agent",
+		Short:        "Runs as a remote agent that deploys certificates into a private network",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if flagServer == "" {
+				flagServer = os.Getenv("CERTIMATE_AGENT_SERVER")
+			}
+			if flagToken == "" {
+				flagToken = os.Getenv("CERTIMATE_AGENT_TOKEN")
+			}
+			if flagServer == "" || flagToken == "" {
+				return errors.New("missing server url or agent token")
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return NewClient(flagServer, flagToken, slog.Default()).Run(ctx)
+		},
+	}
+
+	command.Flags().StringVar(&flagServer, "server", "", "Certimate server url, e.g. https://certimate.example.com (or env CERTIMATE_AGENT_SERVER)")
+	command.Flags().StringVar(&flagToken, "token", "", "agent token (or env CERTIMATE_AGENT_TOKEN)")
+
+	return command
+}
@@ -0,0 +1,24 @@
+package agent
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+
+	"github.com/usual2970/certimate/internal/app"
+	"github.com/usual2970/certimate/internal/domain"
+)
+
+func Register() {
+	app := app.GetApp()
+
+	// 在管理后台中停用或删除远程代理时，同时断开其连接
+	app.OnRecordAfterUpdateSuccess(domain.CollectionNameAgent).BindFunc(func(e *core.RecordEvent) error {
+		if !e.Record.GetBool("enabled") {
+			GetHub().Disconnect(e.Record.Id)
+		}
+		return e.Next()
+	})
+	app.OnRecordAfterDeleteSuccess(domain.CollectionNameAgent).BindFunc(func(e *core.RecordEvent) error {
+		GetHub().Disconnect(e.Record.Id)
+		return e.Next()
+	})
+}
@@ -0,0 +1,241 @@
+package agent
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+)
+
+var errAgentDisconnected = errors.New("agent disconnected")
+
+// Hub 管理所有已连接的远程代理，并将部署任务路由到对应的连接上。
+type Hub struct {
+	mu    sync.RWMutex
+	conns map[string]*agentConn
+}
+
+var hubInstance = &Hub{conns: make(map[string]*agentConn)}
+
+func GetHub() *Hub {
+	return hubInstance
+}
+
+type agentConn struct {
+	agentId string
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+
+	tasksMu sync.Mutex
+	tasks   map[string]*agentTask
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+type agentTask struct {
+	ctx    context.Context
+	logger *slog.Logger
+	result chan *resultPayload
+}
+
+// Serve 接管已完成鉴权的远程代理连接，直至连接断开。
+// 同一代理重复连接时，旧的连接会被关闭。
+func (h *Hub) Serve(agentId string, ws *websocket.Conn) {
+	conn := &agentConn{
+		agentId: agentId,
+		ws:      ws,
+		tasks:   make(map[string]*agentTask),
+		closed:  make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	previous := h.conns[agentId]
+	h.conns[agentId] = conn
+	h.mu.Unlock()
+
+	if previous != nil {
+		previous.close()
+	}
+
+	defer func() {
+		h.mu.Lock()
+		if h.conns[agentId] == conn {
+			delete(h.conns, agentId)
+		}
+		h.mu.Unlock()
+
+		conn.close()
+	}()
+
+	go conn.keepalive()
+	conn.readLoop()
+}
+
+// Disconnect 断开指定远程代理的连接，通常在代理被吊销时调用。
+func (h *Hub) Disconnect(agentId string) {
+	h.mu.Lock()
+	conn := h.conns[agentId]
+	delete(h.conns, agentId)
+	h.mu.Unlock()
+
+	if conn != nil {
+		conn.close()
+	}
+}
+
+// IsConnected 判断指定远程代理当前是否在线。
+func (h *Hub) IsConnected(agentId string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	_, ok := h.conns[agentId]
+	return ok
+}
+
+// Deploy 实现了 [agent.Channel] 接口。
+func (h *Hub) Deploy(ctx context.Context, agentId string, config *local.DeployerConfig, certPEM string, privkeyPEM string, logger *slog.Logger) (map[string]any, error) {
+	h.mu.RLock()
+	conn := h.conns[agentId]
+	h.mu.RUnlock()
+
+	if conn == nil {
+		return nil, fmt.Errorf("agent '%s' is not connected", agentId)
+	}
+
+	taskId, err := generateTaskId()
+	if err != nil {
+		return nil, err
+	}
+
+	task := &agentTask{
+		ctx:    ctx,
+		logger: logger,
+		result: make(chan *resultPayload, 1),
+	}
+	conn.tasksMu.Lock()
+	conn.tasks[taskId] = task
+	conn.tasksMu.Unlock()
+
+	defer func() {
+		conn.tasksMu.Lock()
+		delete(conn.tasks, taskId)
+		conn.tasksMu.Unlock()
+	}()
+
+	err = conn.send(&message{
+		Type:   messageTypeDeploy,
+		TaskId: taskId,
+		Deploy: &deployPayload{
+			Config:      config,
+			Certificate: certPEM,
+			PrivateKey:  privkeyPEM,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send task to agent: %w", err)
+	}
+
+	select {
+	case result := <-task.result:
+		if result.Error != "" {
+			return nil, errors.New(result.Error)
+		}
+		return result.ExtendedData, nil
+
+	case <-conn.closed:
+		return nil, errAgentDisconnected
+
+	case <-ctx.Done():
+		_ = conn.send(&message{Type: messageTypeCancel, TaskId: taskId})
+		return nil, ctx.Err()
+	}
+}
+
+func (c *agentConn) readLoop() {
+	c.ws.SetReadDeadline(time.Now().Add(pongTimeout))
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(pongTimeout))
+	})
+
+	for {
+		msg := &message{}
+		if err := c.ws.ReadJSON(msg); err != nil {
+			return
+		}
+
+		c.tasksMu.Lock()
+		task := c.tasks[msg.TaskId]
+		c.tasksMu.Unlock()
+		if task == nil {
+			continue
+		}
+
+		switch msg.Type {
+		case messageTypeLog:
+			if msg.Log != nil {
+				record := msg.Log.toRecord()
+				if task.logger.Enabled(task.ctx, record.Level) {
+					_ = task.logger.Handler().Handle(task.ctx, record)
+				}
+			}
+
+		case messageTypeResult:
+			if msg.Result != nil {
+				select {
+				case task.result <- msg.Result:
+				default:
+				}
+			}
+		}
+	}
+}
+
+func (c *agentConn) keepalive() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.closed:
+			return
+
+		case <-ticker.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
+
+func (c *agentConn) send(msg *message) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return c.ws.WriteJSON(msg)
+}
+
+func (c *agentConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.ws.Close()
+	})
+}
+
+func generateTaskId() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,184 @@
+package agent_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/usual2970/certimate/internal/agent"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+)
+
+type recordHandler struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordHandler) Handle(_ context.Context, record slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.messages = append(h.messages, record.Message)
+	return nil
+}
+
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *recordHandler) WithGroup(string) slog.Handler { return h }
+
+func (h *recordHandler) contains(message string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, m := range h.messages {
+		if m == message {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestKeyPair(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+// startTestAgent 启动一个模拟的服务端，并让一个远程代理客户端连接上来。
+func startTestAgent(t *testing.T, agentId string) {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		agent.GetHub().Serve(agentId, ws)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		agent.NewClient(server.URL, "test-token", slog.New(slog.DiscardHandler)).Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		agent.GetHub().Disconnect(agentId)
+		<-done
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !agent.GetHub().IsConnected(agentId) {
+		if time.Now().After(deadline) {
+			t.Fatal("agent did not connect in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDeploy(t *testing.T) {
+	startTestAgent(t, "agent-deploy")
+
+	certPEM, keyPEM := newTestKeyPair(t)
+	outputDir := t.TempDir()
+	config := &local.DeployerConfig{
+		OutputFormat:   local.OUTPUT_FORMAT_PEM,
+		OutputCertPath: filepath.Join(outputDir, "cert.pem"),
+		OutputKeyPath:  filepath.Join(outputDir, "key.pem"),
+	}
+
+	handler := &recordHandler{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := agent.GetHub().Deploy(ctx, "agent-deploy", config, certPEM, keyPEM, slog.New(handler)); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	if data, err := os.ReadFile(config.OutputCertPath); err != nil {
+		t.Fatalf("err: %+v", err)
+	} else if string(data) != certPEM {
+		t.Errorf("unexpected certificate content: %s", data)
+	}
+	if _, err := os.Stat(config.OutputKeyPath); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	// 日志可能晚于结果到达
+	deadline := time.Now().Add(5 * time.Second)
+	for !handler.contains("ssl certificate file saved") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected agent logs to be streamed back, got %v", handler.messages)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDeployFailure(t *testing.T) {
+	startTestAgent(t, "agent-failure")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := agent.GetHub().Deploy(ctx, "agent-failure", &local.DeployerConfig{OutputFormat: local.OUTPUT_FORMAT_PEM}, "invalid", "invalid", slog.New(slog.DiscardHandler))
+	if err == nil {
+		t.Fatal("expected error from agent")
+	}
+}
+
+func TestDeployDisconnected(t *testing.T) {
+	startTestAgent(t, "agent-revoked")
+	agent.GetHub().Disconnect("agent-revoked")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := agent.GetHub().Deploy(ctx, "agent-revoked", &local.DeployerConfig{}, "", "", slog.New(slog.DiscardHandler))
+	if err == nil {
+		t.Fatal("expected error for disconnected agent")
+	}
+}
@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+)
+
+// streamHandler 是一个 [slog.Handler]，它在写入下游 Handler 的同时，将日志通过回调函数回传到服务端。
+type streamHandler struct {
+	next   slog.Handler
+	emit   func(*logPayload)
+	attrs  map[string]any
+	prefix string
+}
+
+var _ slog.Handler = (*streamHandler)(nil)
+
+func newStreamHandler(next slog.Handler, emit func(*logPayload)) *streamHandler {
+	return &streamHandler{
+		next:  next,
+		emit:  emit,
+		attrs: make(map[string]any),
+	}
+}
+
+func (h *streamHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	// 是否输出由服务端的日志记录器决定，因此总是回传
+	return true
+}
+
+func (h *streamHandler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := maps.Clone(h.attrs)
+	record.Attrs(func(attr slog.Attr) bool {
+		h.collectAttr(attrs, h.prefix, attr)
+		return true
+	})
+
+	h.emit(&logPayload{
+		Time:    record.Time,
+		Level:   record.Level.String(),
+		Message: record.Message,
+		Attrs:   attrs,
+	})
+
+	if h.next.Enabled(ctx, record.Level) {
+		return h.next.Handle(ctx, record)
+	}
+	return nil
+}
+
+func (h *streamHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := &streamHandler{
+		next:   h.next.WithAttrs(attrs),
+		emit:   h.emit,
+		attrs:  maps.Clone(h.attrs),
+		prefix: h.prefix,
+	}
+	for _, attr := range attrs {
+		h.collectAttr(clone.attrs, h.prefix, attr)
+	}
+	return clone
+}
+
+func (h *streamHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &streamHandler{
+		next:   h.next.WithGroup(name),
+		emit:   h.emit,
+		attrs:  maps.Clone(h.attrs),
+		prefix: h.prefix + name + ".",
+	}
+}
+
+func (h *streamHandler) collectAttr(dst map[string]any, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			h.collectAttr(dst, groupPrefix, groupAttr)
+		}
+		return
+	}
+
+	// 非基本类型统一转换为字符串，以保证可以被序列化为 JSON
+	switch attr.Value.Kind() {
+	case slog.KindBool, slog.KindFloat64, slog.KindInt64, slog.KindString, slog.KindUint64:
+		dst[prefix+attr.Key] = attr.Value.Any()
+	default:
+		dst[prefix+attr.Key] = attr.Value.String()
+	}
+}
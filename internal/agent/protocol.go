@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+)
+
+// 服务端与远程代理之间通过 WebSocket 交换 JSON 格式的消息。
+const (
+	messageTypeDeploy = "deploy" // 服务端 -> 代理：下发部署任务
+	messageTypeCancel = "cancel" // 服务端 -> 代理：取消部署任务
+	messageTypeLog    = "log"    // 代理 -> 服务端：回传任务日志
+	messageTypeResult = "result" // 代理 -> 服务端：回传任务结果
+)
+
+const connectPath = "/api/agents/connect"
+
+const (
+	pingInterval = 30 * time.Second
+	pongTimeout  = 90 * time.Second
+	writeTimeout = 10 * time.Second
+)
+
+type message struct {
+	Type   string         `json:"type"`
+	TaskId string         `json:"taskId"`
+	Deploy *deployPayload `json:"deploy,omitempty"`
+	Log    *logPayload    `json:"log,omitempty"`
+	Result *resultPayload `json:"result,omitempty"`
+}
+
+type deployPayload struct {
+	Config      *local.DeployerConfig `json:"config"`
+	Certificate string                `json:"certificate"`
+	PrivateKey  string                `json:"privateKey"`
+}
+
+type logPayload struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Message string         `json:"message"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+type resultPayload struct {
+	ExtendedData map[string]any `json:"extendedData,omitempty"`
+	Error        string         `json:"error,omitempty"`
+}
+
+func (p *logPayload) toRecord() slog.Record {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(p.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	record := slog.NewRecord(p.Time, level, p.Message, 0)
+	for key, value := range p.Attrs {
+		record.AddAttrs(slog.Any(key, value))
+	}
+	return record
+}
@@ -0,0 +1,119 @@
+package agent
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/usual2970/certimate/internal/app"
+	"github.com/usual2970/certimate/internal/domain"
+	"github.com/usual2970/certimate/internal/domain/dtos"
+)
+
+const tokenPrefix = "cmat_"
+
+type agentRepository interface {
+	GetById(ctx context.Context, id string) (*domain.Agent, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Agent, error)
+	Save(ctx context.Context, agent *domain.Agent) (*domain.Agent, error)
+}
+
+type AgentService struct {
+	agentRepo agentRepository
+	hub       *Hub
+}
+
+func NewAgentService(agentRepo agentRepository) *AgentService {
+	return &AgentService{
+		agentRepo: agentRepo,
+		hub:       GetHub(),
+	}
+}
+
+// RegisterAgent 注册一个新的远程代理。令牌明文仅在此时返回一次，数据库中只保存其摘要。
+func (s *AgentService) RegisterAgent(ctx context.Context, req *dtos.AgentRegisterReq) (*dtos.AgentRegisterResp, error) {
+	if req.Name == "" {
+		return nil, domain.ErrInvalidParams
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	plainToken := tokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	agent, err := s.agentRepo.Save(ctx, &domain.Agent{
+		Name:      req.Name,
+		TokenHash: hashToken(plainToken),
+		Enabled:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.AgentRegisterResp{
+		AgentId: agent.Id,
+		Token:   plainToken,
+	}, nil
+}
+
+// RevokeAgent 吊销一个远程代理，并立即断开其连接。
+func (s *AgentService) RevokeAgent(ctx context.Context, req *dtos.AgentRevokeReq) error {
+	agent, err := s.agentRepo.GetById(ctx, req.AgentId)
+	if err != nil {
+		return err
+	}
+
+	agent.Enabled = false
+	if _, err := s.agentRepo.Save(ctx, agent); err != nil {
+		return err
+	}
+
+	s.hub.Disconnect(agent.Id)
+	return nil
+}
+
+// Authenticate 校验远程代理的令牌，并记录其最近一次连接的时间与来源 IP。
+func (s *AgentService) Authenticate(ctx context.Context, token string, clientIp string) (*domain.Agent, error) {
+	if token == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	agent, err := s.agentRepo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if domain.IsRecordNotFoundError(err) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	if !agent.Enabled {
+		return nil, domain.ErrUnauthorized
+	}
+
+	now := time.Now()
+	agent.LastSeenAt = &now
+	agent.LastIp = clientIp
+	if _, err := s.agentRepo.Save(ctx, agent); err != nil {
+		app.GetLogger().Error("failed to update agent", "err", err)
+	}
+
+	return agent, nil
+}
+
+// Serve 接管已完成鉴权的远程代理连接，直至连接断开。
+func (s *AgentService) Serve(agentId string, ws *websocket.Conn) {
+	app.GetLogger().Info("agent connected", "agentId", agentId)
+	s.hub.Serve(agentId, ws)
+	app.GetLogger().Info("agent disconnected", "agentId", agentId)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,6 +1,7 @@
 package applicant
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-acme/lego/v4/challenge"
@@ -35,6 +36,7 @@ import (
 	pNetcup "github.com/usual2970/certimate/internal/pkg/core/applicant/acme-dns-01/lego-providers/netcup"
 	pNetlify "github.com/usual2970/certimate/internal/pkg/core/applicant/acme-dns-01/lego-providers/netlify"
 	pNS1 "github.com/usual2970/certimate/internal/pkg/core/applicant/acme-dns-01/lego-providers/ns1"
+	pPlugin "github.com/usual2970/certimate/internal/pkg/core/applicant/acme-dns-01/lego-providers/plugin"
 	pPorkbun "github.com/usual2970/certimate/internal/pkg/core/applicant/acme-dns-01/lego-providers/porkbun"
 	pPowerDNS "github.com/usual2970/certimate/internal/pkg/core/applicant/acme-dns-01/lego-providers/powerdns"
 	pRainYun "github.com/usual2970/certimate/internal/pkg/core/applicant/acme-dns-01/lego-providers/rainyun"
@@ -45,6 +47,8 @@ import (
 	pVolcEngine "github.com/usual2970/certimate/internal/pkg/core/applicant/acme-dns-01/lego-providers/volcengine"
 	pWestcn "github.com/usual2970/certimate/internal/pkg/core/applicant/acme-dns-01/lego-providers/westcn"
 	maputil "github.com/usual2970/certimate/internal/pkg/utils/map"
+	"github.com/usual2970/certimate/internal/plugin"
+	"github.com/usual2970/certimate/internal/registry"
 )
 
 type applicantProviderOptions struct {
@@ -67,19 +71,54 @@ type applicantProviderOptions struct {
 	ARIReplaceCert          string
 }
 
+type applicantProviderFactory func(options *applicantProviderOptions) (challenge.Provider, error)
+
 func createApplicantProvider(options *applicantProviderOptions) (challenge.Provider, error) {
+	provider, ok := registry.Get(registry.CapabilityDns, string(options.Provider))
+	if !ok {
+		return nil, fmt.Errorf("unsupported applicant provider '%s'", string(options.Provider))
+	}
+
+	return provider.Factory.(applicantProviderFactory)(options)
+}
+
+// registerApplicantProvider 向注册表中注册ACME DNS-01 提供商。
+// 工厂函数被调用前，授权配置与节点配置会先被分别填充到 TAccess 与 TConfig 中；
+// 节点配置的 Schema 由 TConfig 生成，将在保存工作流时用于校验。
+func registerApplicantProvider[TAccess any, TConfig any](accessProvider domain.AccessProviderType, providerTypes []domain.ACMEDns01ProviderType, factory func(options *applicantProviderOptions, access TAccess, config TConfig) (challenge.Provider, error)) {
+	for _, providerType := range providerTypes {
+		registry.Register(&registry.Provider{
+			Type:           string(providerType),
+			Capability:     registry.CapabilityDns,
+			AccessProvider: string(accessProvider),
+			ConfigSchema:   registry.SchemaOf(*new(TConfig)),
+			AccessSchema:   registry.SchemaOf(*new(TAccess)),
+			Factory: applicantProviderFactory(func(options *applicantProviderOptions) (challenge.Provider, error) {
+				var access TAccess
+				if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
+					return nil, fmt.Errorf("failed to populate provider access config: %w", err)
+				}
+
+				var config TConfig
+				if err := maputil.Populate(options.ProviderServiceConfig, &config); err != nil {
+					return nil, fmt.Errorf("failed to populate provider service config: %w", err)
+				}
+
+				return factory(options, access, config)
+			}),
+		})
+	}
+}
+
+func init() {
 	/*
-	  注意：如果追加新的常量值，请保持以 ASCII 排序。
-	  NOTICE: If you add new constant, please keep ASCII order.
+	  注意：如果追加新的提供商，请保持以 ASCII 排序。
+	  NOTICE: If you add new provider, please keep ASCII order.
 	*/
-	switch options.Provider {
-	case domain.ACMEDns01ProviderTypeACMEHttpReq:
-		{
-			access := domain.AccessConfigForACMEHttpReq{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+	registerApplicantProvider(
+		domain.AccessProviderTypeACMEHttpReq,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeACMEHttpReq},
+		func(options *applicantProviderOptions, access domain.AccessConfigForACMEHttpReq, config struct{}) (challenge.Provider, error) {
 			applicant, err := pACMEHttpReq.NewChallengeProvider(&pACMEHttpReq.ChallengeProviderConfig{
 				Endpoint:              access.Endpoint,
 				Mode:                  access.Mode,
@@ -88,65 +127,63 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 			})
 			return applicant, err
-		}
+		},
+	)
 
-	case domain.ACMEDns01ProviderTypeAliyun, domain.ACMEDns01ProviderTypeAliyunDNS, domain.ACMEDns01ProviderTypeAliyunESA:
-		{
-			access := domain.AccessConfigForAliyun{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.ACMEDns01ProviderTypeAliyun, domain.ACMEDns01ProviderTypeAliyunDNS:
-				applicant, err := pAliyun.NewChallengeProvider(&pAliyun.ChallengeProviderConfig{
-					AccessKeyId:           access.AccessKeyId,
-					AccessKeySecret:       access.AccessKeySecret,
-					DnsPropagationTimeout: options.DnsPropagationTimeout,
-					DnsTTL:                options.DnsTTL,
-				})
-				return applicant, err
-
-			case domain.ACMEDns01ProviderTypeAliyunESA:
-				applicant, err := pAliyunESA.NewChallengeProvider(&pAliyunESA.ChallengeProviderConfig{
-					AccessKeyId:           access.AccessKeyId,
-					AccessKeySecret:       access.AccessKeySecret,
-					Region:                maputil.GetString(options.ProviderServiceConfig, "region"),
-					DnsPropagationTimeout: options.DnsPropagationTimeout,
-					DnsTTL:                options.DnsTTL,
-				})
-				return applicant, err
-
-			default:
-				break
-			}
-		}
+	registerApplicantProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeAliyun, domain.ACMEDns01ProviderTypeAliyunDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForAliyun, config struct{}) (challenge.Provider, error) {
+			applicant, err := pAliyun.NewChallengeProvider(&pAliyun.ChallengeProviderConfig{
+				AccessKeyId:           access.AccessKeyId,
+				AccessKeySecret:       access.AccessKeySecret,
+				DnsPropagationTimeout: options.DnsPropagationTimeout,
+				DnsTTL:                options.DnsTTL,
+			})
+			return applicant, err
+		},
+	)
 
-	case domain.ACMEDns01ProviderTypeAWS, domain.ACMEDns01ProviderTypeAWSRoute53:
-		{
-			access := domain.AccessConfigForAWS{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+	registerApplicantProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeAliyunESA},
+		func(options *applicantProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region string `json:"region,omitempty"`
+		}) (challenge.Provider, error) {
+			applicant, err := pAliyunESA.NewChallengeProvider(&pAliyunESA.ChallengeProviderConfig{
+				AccessKeyId:           access.AccessKeyId,
+				AccessKeySecret:       access.AccessKeySecret,
+				Region:                config.Region,
+				DnsPropagationTimeout: options.DnsPropagationTimeout,
+				DnsTTL:                options.DnsTTL,
+			})
+			return applicant, err
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeAWS,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeAWS, domain.ACMEDns01ProviderTypeAWSRoute53},
+		func(options *applicantProviderOptions, access domain.AccessConfigForAWS, config struct {
+			Region       string `json:"region,omitempty"`
+			HostedZoneId string `json:"hostedZoneId,omitempty"`
+		}) (challenge.Provider, error) {
 			applicant, err := pAWSRoute53.NewChallengeProvider(&pAWSRoute53.ChallengeProviderConfig{
 				AccessKeyId:           access.AccessKeyId,
 				SecretAccessKey:       access.SecretAccessKey,
-				Region:                maputil.GetString(options.ProviderServiceConfig, "region"),
-				HostedZoneId:          maputil.GetString(options.ProviderServiceConfig, "hostedZoneId"),
+				Region:                config.Region,
+				HostedZoneId:          config.HostedZoneId,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeAzure, domain.ACMEDns01ProviderTypeAzureDNS:
-		{
-			access := domain.AccessConfigForAzure{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeAzure,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeAzure, domain.ACMEDns01ProviderTypeAzureDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForAzure, config struct{}) (challenge.Provider, error) {
 			applicant, err := pAzureDNS.NewChallengeProvider(&pAzureDNS.ChallengeProviderConfig{
 				TenantId:              access.TenantId,
 				ClientId:              access.ClientId,
@@ -156,15 +193,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeBaiduCloud, domain.ACMEDns01ProviderTypeBaiduCloudDNS:
-		{
-			access := domain.AccessConfigForBaiduCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeBaiduCloud,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeBaiduCloud, domain.ACMEDns01ProviderTypeBaiduCloudDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForBaiduCloud, config struct{}) (challenge.Provider, error) {
 			applicant, err := pBaiduCloud.NewChallengeProvider(&pBaiduCloud.ChallengeProviderConfig{
 				AccessKeyId:           access.AccessKeyId,
 				SecretAccessKey:       access.SecretAccessKey,
@@ -172,30 +207,26 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeBunny:
-		{
-			access := domain.AccessConfigForBunny{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeBunny,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeBunny},
+		func(options *applicantProviderOptions, access domain.AccessConfigForBunny, config struct{}) (challenge.Provider, error) {
 			applicant, err := pBunny.NewChallengeProvider(&pBunny.ChallengeProviderConfig{
 				ApiKey:                access.ApiKey,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeCloudflare:
-		{
-			access := domain.AccessConfigForCloudflare{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeCloudflare,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeCloudflare},
+		func(options *applicantProviderOptions, access domain.AccessConfigForCloudflare, config struct{}) (challenge.Provider, error) {
 			applicant, err := pCloudflare.NewChallengeProvider(&pCloudflare.ChallengeProviderConfig{
 				DnsApiToken:           access.DnsApiToken,
 				ZoneApiToken:          access.ZoneApiToken,
@@ -203,15 +234,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeClouDNS:
-		{
-			access := domain.AccessConfigForClouDNS{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeClouDNS,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeClouDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForClouDNS, config struct{}) (challenge.Provider, error) {
 			applicant, err := pClouDNS.NewChallengeProvider(&pClouDNS.ChallengeProviderConfig{
 				AuthId:                access.AuthId,
 				AuthPassword:          access.AuthPassword,
@@ -219,15 +248,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeCMCCCloud, domain.ACMEDns01ProviderTypeCMCCCloudDNS:
-		{
-			access := domain.AccessConfigForCMCCCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeCMCCCloud,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeCMCCCloud, domain.ACMEDns01ProviderTypeCMCCCloudDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForCMCCCloud, config struct{}) (challenge.Provider, error) {
 			applicant, err := pCMCCCloud.NewChallengeProvider(&pCMCCCloud.ChallengeProviderConfig{
 				AccessKeyId:           access.AccessKeyId,
 				AccessKeySecret:       access.AccessKeySecret,
@@ -235,15 +262,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeConstellix:
-		{
-			access := domain.AccessConfigForConstellix{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeConstellix,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeConstellix},
+		func(options *applicantProviderOptions, access domain.AccessConfigForConstellix, config struct{}) (challenge.Provider, error) {
 			applicant, err := pConstellix.NewChallengeProvider(&pConstellix.ChallengeProviderConfig{
 				ApiKey:                access.ApiKey,
 				SecretKey:             access.SecretKey,
@@ -251,15 +276,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeCTCCCloud, domain.ACMEDns01ProviderTypeCTCCCloudSmartDNS:
-		{
-			access := domain.AccessConfigForCTCCCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeCTCCCloud,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeCTCCCloud, domain.ACMEDns01ProviderTypeCTCCCloudSmartDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForCTCCCloud, config struct{}) (challenge.Provider, error) {
 			applicant, err := pCTCCCloud.NewChallengeProvider(&pCTCCCloud.ChallengeProviderConfig{
 				AccessKeyId:           access.AccessKeyId,
 				SecretAccessKey:       access.SecretAccessKey,
@@ -267,45 +290,39 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeDeSEC:
-		{
-			access := domain.AccessConfigForDeSEC{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeDeSEC,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeDeSEC},
+		func(options *applicantProviderOptions, access domain.AccessConfigForDeSEC, config struct{}) (challenge.Provider, error) {
 			applicant, err := pDeSEC.NewChallengeProvider(&pDeSEC.ChallengeProviderConfig{
 				Token:                 access.Token,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeDigitalOcean:
-		{
-			access := domain.AccessConfigForDigitalOcean{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeDigitalOcean,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeDigitalOcean},
+		func(options *applicantProviderOptions, access domain.AccessConfigForDigitalOcean, config struct{}) (challenge.Provider, error) {
 			applicant, err := pDigitalOcean.NewChallengeProvider(&pDigitalOcean.ChallengeProviderConfig{
 				AccessToken:           access.AccessToken,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeDNSLA:
-		{
-			access := domain.AccessConfigForDNSLA{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeDNSLA,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeDNSLA},
+		func(options *applicantProviderOptions, access domain.AccessConfigForDNSLA, config struct{}) (challenge.Provider, error) {
 			applicant, err := pDNSLA.NewChallengeProvider(&pDNSLA.ChallengeProviderConfig{
 				ApiId:                 access.ApiId,
 				ApiSecret:             access.ApiSecret,
@@ -313,59 +330,51 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeDuckDNS:
-		{
-			access := domain.AccessConfigForDuckDNS{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeDuckDNS,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeDuckDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForDuckDNS, config struct{}) (challenge.Provider, error) {
 			applicant, err := pDuckDNS.NewChallengeProvider(&pDuckDNS.ChallengeProviderConfig{
 				Token:                 access.Token,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeDynv6:
-		{
-			access := domain.AccessConfigForDynv6{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeDynv6,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeDynv6},
+		func(options *applicantProviderOptions, access domain.AccessConfigForDynv6, config struct{}) (challenge.Provider, error) {
 			applicant, err := pDynv6.NewChallengeProvider(&pDynv6.ChallengeProviderConfig{
 				HttpToken:             access.HttpToken,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeGcore:
-		{
-			access := domain.AccessConfigForGcore{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeGcore,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeGcore},
+		func(options *applicantProviderOptions, access domain.AccessConfigForGcore, config struct{}) (challenge.Provider, error) {
 			applicant, err := pGcore.NewChallengeProvider(&pGcore.ChallengeProviderConfig{
 				ApiToken:              access.ApiToken,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeGname:
-		{
-			access := domain.AccessConfigForGname{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeGname,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeGname},
+		func(options *applicantProviderOptions, access domain.AccessConfigForGname, config struct{}) (challenge.Provider, error) {
 			applicant, err := pGname.NewChallengeProvider(&pGname.ChallengeProviderConfig{
 				AppId:                 access.AppId,
 				AppKey:                access.AppKey,
@@ -373,15 +382,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeGoDaddy:
-		{
-			access := domain.AccessConfigForGoDaddy{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeGoDaddy,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeGoDaddy},
+		func(options *applicantProviderOptions, access domain.AccessConfigForGoDaddy, config struct{}) (challenge.Provider, error) {
 			applicant, err := pGoDaddy.NewChallengeProvider(&pGoDaddy.ChallengeProviderConfig{
 				ApiKey:                access.ApiKey,
 				ApiSecret:             access.ApiSecret,
@@ -389,64 +396,60 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeHetzner:
-		{
-			access := domain.AccessConfigForHetzner{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeHetzner,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeHetzner},
+		func(options *applicantProviderOptions, access domain.AccessConfigForHetzner, config struct{}) (challenge.Provider, error) {
 			applicant, err := pHetzner.NewChallengeProvider(&pHetzner.ChallengeProviderConfig{
 				ApiToken:              access.ApiToken,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeHuaweiCloud, domain.ACMEDns01ProviderTypeHuaweiCloudDNS:
-		{
-			access := domain.AccessConfigForHuaweiCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeHuaweiCloud,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeHuaweiCloud, domain.ACMEDns01ProviderTypeHuaweiCloudDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForHuaweiCloud, config struct {
+			Region string `json:"region,omitempty"`
+		}) (challenge.Provider, error) {
 			applicant, err := pHuaweiCloud.NewChallengeProvider(&pHuaweiCloud.ChallengeProviderConfig{
 				AccessKeyId:           access.AccessKeyId,
 				SecretAccessKey:       access.SecretAccessKey,
-				Region:                maputil.GetString(options.ProviderServiceConfig, "region"),
+				Region:                config.Region,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeJDCloud, domain.ACMEDns01ProviderTypeJDCloudDNS:
-		{
-			access := domain.AccessConfigForJDCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeJDCloud,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeJDCloud, domain.ACMEDns01ProviderTypeJDCloudDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForJDCloud, config struct {
+			RegionId string `json:"regionId,omitempty"`
+		}) (challenge.Provider, error) {
 			applicant, err := pJDCloud.NewChallengeProvider(&pJDCloud.ChallengeProviderConfig{
 				AccessKeyId:           access.AccessKeyId,
 				AccessKeySecret:       access.AccessKeySecret,
-				RegionId:              maputil.GetString(options.ProviderServiceConfig, "regionId"),
+				RegionId:              config.RegionId,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeNamecheap:
-		{
-			access := domain.AccessConfigForNamecheap{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeNamecheap,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeNamecheap},
+		func(options *applicantProviderOptions, access domain.AccessConfigForNamecheap, config struct{}) (challenge.Provider, error) {
 			applicant, err := pNamecheap.NewChallengeProvider(&pNamecheap.ChallengeProviderConfig{
 				Username:              access.Username,
 				ApiKey:                access.ApiKey,
@@ -454,15 +457,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeNameDotCom:
-		{
-			access := domain.AccessConfigForNameDotCom{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeNameDotCom,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeNameDotCom},
+		func(options *applicantProviderOptions, access domain.AccessConfigForNameDotCom, config struct{}) (challenge.Provider, error) {
 			applicant, err := pNameDotCom.NewChallengeProvider(&pNameDotCom.ChallengeProviderConfig{
 				Username:              access.Username,
 				ApiToken:              access.ApiToken,
@@ -470,30 +471,26 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeNameSilo:
-		{
-			access := domain.AccessConfigForNameSilo{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeNameSilo,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeNameSilo},
+		func(options *applicantProviderOptions, access domain.AccessConfigForNameSilo, config struct{}) (challenge.Provider, error) {
 			applicant, err := pNameSilo.NewChallengeProvider(&pNameSilo.ChallengeProviderConfig{
 				ApiKey:                access.ApiKey,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeNetcup:
-		{
-			access := domain.AccessConfigForNetcup{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeNetcup,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeNetcup},
+		func(options *applicantProviderOptions, access domain.AccessConfigForNetcup, config struct{}) (challenge.Provider, error) {
 			applicant, err := pNetcup.NewChallengeProvider(&pNetcup.ChallengeProviderConfig{
 				CustomerNumber:        access.CustomerNumber,
 				ApiKey:                access.ApiKey,
@@ -502,45 +499,59 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeNetlify:
-		{
-			access := domain.AccessConfigForNetlify{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeNetlify,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeNetlify},
+		func(options *applicantProviderOptions, access domain.AccessConfigForNetlify, config struct{}) (challenge.Provider, error) {
 			applicant, err := pNetlify.NewChallengeProvider(&pNetlify.ChallengeProviderConfig{
 				ApiToken:              access.ApiToken,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeNS1:
-		{
-			access := domain.AccessConfigForNS1{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeNS1,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeNS1},
+		func(options *applicantProviderOptions, access domain.AccessConfigForNS1, config struct{}) (challenge.Provider, error) {
 			applicant, err := pNS1.NewChallengeProvider(&pNS1.ChallengeProviderConfig{
 				ApiKey:                access.ApiKey,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
+		},
+	)
 
-	case domain.ACMEDns01ProviderTypePorkbun:
-		{
-			access := domain.AccessConfigForPorkbun{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
+	registerApplicantProvider(
+		domain.AccessProviderTypePlugin,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypePlugin},
+		func(options *applicantProviderOptions, access domain.AccessConfigForPlugin, config struct{}) (challenge.Provider, error) {
+			client, err := plugin.GetClient(context.Background(), access.PluginName)
+			if err != nil {
+				return nil, err
 			}
 
+			applicant, err := pPlugin.NewChallengeProvider(&pPlugin.ChallengeProviderConfig{
+				PluginName:            access.PluginName,
+				AccessConfig:          options.ProviderAccessConfig,
+				ServiceConfig:         options.ProviderServiceConfig,
+				DnsPropagationTimeout: options.DnsPropagationTimeout,
+				Client:                client,
+			})
+			return applicant, err
+		},
+	)
+
+	registerApplicantProvider(
+		domain.AccessProviderTypePorkbun,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypePorkbun},
+		func(options *applicantProviderOptions, access domain.AccessConfigForPorkbun, config struct{}) (challenge.Provider, error) {
 			applicant, err := pPorkbun.NewChallengeProvider(&pPorkbun.ChallengeProviderConfig{
 				ApiKey:                access.ApiKey,
 				SecretApiKey:          access.SecretApiKey,
@@ -548,15 +559,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypePowerDNS:
-		{
-			access := domain.AccessConfigForPowerDNS{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypePowerDNS,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypePowerDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForPowerDNS, config struct{}) (challenge.Provider, error) {
 			applicant, err := pPowerDNS.NewChallengeProvider(&pPowerDNS.ChallengeProviderConfig{
 				ServerUrl:                access.ServerUrl,
 				ApiKey:                   access.ApiKey,
@@ -565,62 +574,57 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                   options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeRainYun:
-		{
-			access := domain.AccessConfigForRainYun{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeRainYun,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeRainYun},
+		func(options *applicantProviderOptions, access domain.AccessConfigForRainYun, config struct{}) (challenge.Provider, error) {
 			applicant, err := pRainYun.NewChallengeProvider(&pRainYun.ChallengeProviderConfig{
 				ApiKey:                access.ApiKey,
 				DnsPropagationTimeout: options.DnsPropagationTimeout,
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
+		},
+	)
 
-	case domain.ACMEDns01ProviderTypeTencentCloud, domain.ACMEDns01ProviderTypeTencentCloudDNS, domain.ACMEDns01ProviderTypeTencentCloudEO:
-		{
-			access := domain.AccessConfigForTencentCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.ACMEDns01ProviderTypeTencentCloud, domain.ACMEDns01ProviderTypeTencentCloudDNS:
-				applicant, err := pTencentCloud.NewChallengeProvider(&pTencentCloud.ChallengeProviderConfig{
-					SecretId:              access.SecretId,
-					SecretKey:             access.SecretKey,
-					DnsPropagationTimeout: options.DnsPropagationTimeout,
-					DnsTTL:                options.DnsTTL,
-				})
-				return applicant, err
-
-			case domain.ACMEDns01ProviderTypeTencentCloudEO:
-				applicant, err := pTencentCloudEO.NewChallengeProvider(&pTencentCloudEO.ChallengeProviderConfig{
-					SecretId:              access.SecretId,
-					SecretKey:             access.SecretKey,
-					ZoneId:                maputil.GetString(options.ProviderServiceConfig, "zoneId"),
-					DnsPropagationTimeout: options.DnsPropagationTimeout,
-					DnsTTL:                options.DnsTTL,
-				})
-				return applicant, err
-
-			default:
-				break
-			}
-		}
+	registerApplicantProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeTencentCloud, domain.ACMEDns01ProviderTypeTencentCloudDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForTencentCloud, config struct{}) (challenge.Provider, error) {
+			applicant, err := pTencentCloud.NewChallengeProvider(&pTencentCloud.ChallengeProviderConfig{
+				SecretId:              access.SecretId,
+				SecretKey:             access.SecretKey,
+				DnsPropagationTimeout: options.DnsPropagationTimeout,
+				DnsTTL:                options.DnsTTL,
+			})
+			return applicant, err
+		},
+	)
 
-	case domain.ACMEDns01ProviderTypeUCloudUDNR:
-		{
-			access := domain.AccessConfigForUCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+	registerApplicantProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeTencentCloudEO},
+		func(options *applicantProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			ZoneId string `json:"zoneId,omitempty"`
+		}) (challenge.Provider, error) {
+			applicant, err := pTencentCloudEO.NewChallengeProvider(&pTencentCloudEO.ChallengeProviderConfig{
+				SecretId:              access.SecretId,
+				SecretKey:             access.SecretKey,
+				ZoneId:                config.ZoneId,
+				DnsPropagationTimeout: options.DnsPropagationTimeout,
+				DnsTTL:                options.DnsTTL,
+			})
+			return applicant, err
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeUCloud,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeUCloudUDNR},
+		func(options *applicantProviderOptions, access domain.AccessConfigForUCloud, config struct{}) (challenge.Provider, error) {
 			applicant, err := pUCloudUDNR.NewChallengeProvider(&pUCloudUDNR.ChallengeProviderConfig{
 				PrivateKey:            access.PrivateKey,
 				PublicKey:             access.PublicKey,
@@ -628,15 +632,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeVercel:
-		{
-			access := domain.AccessConfigForVercel{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeVercel,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeVercel},
+		func(options *applicantProviderOptions, access domain.AccessConfigForVercel, config struct{}) (challenge.Provider, error) {
 			applicant, err := pVercel.NewChallengeProvider(&pVercel.ChallengeProviderConfig{
 				ApiAccessToken:        access.ApiAccessToken,
 				TeamId:                access.TeamId,
@@ -644,15 +646,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeVolcEngine, domain.ACMEDns01ProviderTypeVolcEngineDNS:
-		{
-			access := domain.AccessConfigForVolcEngine{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeVolcEngine,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeVolcEngine, domain.ACMEDns01ProviderTypeVolcEngineDNS},
+		func(options *applicantProviderOptions, access domain.AccessConfigForVolcEngine, config struct{}) (challenge.Provider, error) {
 			applicant, err := pVolcEngine.NewChallengeProvider(&pVolcEngine.ChallengeProviderConfig{
 				AccessKeyId:           access.AccessKeyId,
 				SecretAccessKey:       access.SecretAccessKey,
@@ -660,15 +660,13 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-
-	case domain.ACMEDns01ProviderTypeWestcn:
-		{
-			access := domain.AccessConfigForWestcn{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerApplicantProvider(
+		domain.AccessProviderTypeWestcn,
+		[]domain.ACMEDns01ProviderType{domain.ACMEDns01ProviderTypeWestcn},
+		func(options *applicantProviderOptions, access domain.AccessConfigForWestcn, config struct{}) (challenge.Provider, error) {
 			applicant, err := pWestcn.NewChallengeProvider(&pWestcn.ChallengeProviderConfig{
 				Username:              access.Username,
 				ApiPassword:           access.ApiPassword,
@@ -676,8 +674,6 @@ func createApplicantProvider(options *applicantProviderOptions) (challenge.Provi
 				DnsTTL:                options.DnsTTL,
 			})
 			return applicant, err
-		}
-	}
-
-	return nil, fmt.Errorf("unsupported applicant provider '%s'", string(options.Provider))
+		},
+	)
 }
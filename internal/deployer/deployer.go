@@ -11,7 +11,7 @@ import (
 )
 
 type Deployer interface {
-	Deploy(ctx context.Context) error
+	Deploy(ctx context.Context) (*deployer.DeployResult, error)
 }
 
 type DeployerWithWorkflowNodeConfig struct {
@@ -19,6 +19,8 @@ type DeployerWithWorkflowNodeConfig struct {
 	Logger         *slog.Logger
 	CertificatePEM string
 	PrivateKeyPEM  string
+	// 先前已部署成功的主机数组，仅对支持批量部署到多台主机的提供商有效。
+	SucceededHosts []string
 }
 
 func NewWithWorkflowNode(config DeployerWithWorkflowNodeConfig) (Deployer, error) {
@@ -32,8 +34,10 @@ func NewWithWorkflowNode(config DeployerWithWorkflowNodeConfig) (Deployer, error
 	nodeCfg := config.Node.GetConfigForDeploy()
 	options := &deployerProviderOptions{
 		Provider:              domain.DeploymentProviderType(nodeCfg.Provider),
+		ProviderAccessId:      nodeCfg.ProviderAccessId,
 		ProviderAccessConfig:  make(map[string]any),
 		ProviderServiceConfig: nodeCfg.ProviderConfig,
+		SucceededHosts:        config.SucceededHosts,
 	}
 
 	accessRepo := repository.NewAccessRepository()
@@ -66,7 +70,38 @@ type deployerImpl struct {
 
 var _ Deployer = (*deployerImpl)(nil)
 
-func (d *deployerImpl) Deploy(ctx context.Context) error {
-	_, err := d.provider.Deploy(ctx, d.certPEM, d.privkeyPEM)
-	return err
+func (d *deployerImpl) Deploy(ctx context.Context) (*deployer.DeployResult, error) {
+	return d.provider.Deploy(ctx, d.certPEM, d.privkeyPEM)
+}
+
+func getAccessConfig(accessId string) (map[string]any, error) {
+	accessRepo := repository.NewAccessRepository()
+	access, err := accessRepo.GetById(context.Background(), accessId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access #%s record: %w", accessId, err)
+	}
+
+	return access.Config, nil
+}
+
+func updateAccessConfig(accessId string, key string, value any) error {
+	if accessId == "" {
+		return nil
+	}
+
+	accessRepo := repository.NewAccessRepository()
+	access, err := accessRepo.GetById(context.Background(), accessId)
+	if err != nil {
+		return fmt.Errorf("failed to get access #%s record: %w", accessId, err)
+	}
+
+	if access.Config == nil {
+		access.Config = make(map[string]any)
+	}
+	access.Config[key] = value
+	if _, err := accessRepo.Save(context.Background(), access); err != nil {
+		return fmt.Errorf("failed to save access #%s record: %w", accessId, err)
+	}
+
+	return nil
 }
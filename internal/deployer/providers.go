@@ -1,14 +1,19 @@
 package deployer
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/usual2970/certimate/internal/agent"
 	"github.com/usual2970/certimate/internal/domain"
 	"github.com/usual2970/certimate/internal/pkg/core/deployer"
 	p1PanelConsole "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/1panel-console"
 	p1PanelSite "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/1panel-site"
+	pAgent "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/agent"
+	pAkamaiCPS "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/akamai-cps"
 	pAliyunALB "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aliyun-alb"
 	pAliyunAPIGW "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aliyun-apigw"
 	pAliyunCAS "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aliyun-cas"
@@ -27,8 +32,13 @@ import (
 	pAliyunWAF "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aliyun-waf"
 	pAPISIX "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/apisix"
 	pAWSACM "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aws-acm"
+	pAWSAPIGateway "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aws-apigateway"
 	pAWSCloudFront "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aws-cloudfront"
+	pAWSELB "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aws-elb"
 	pAWSIAM "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aws-iam"
+	pAzureAppGateway "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/azure-appgateway"
+	pAzureAppService "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/azure-appservice"
+	pAzureFrontDoor "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/azure-frontdoor"
 	pAzureKeyVault "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/azure-keyvault"
 	pBaiduCloudAppBLB "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/baiducloud-appblb"
 	pBaiduCloudBLB "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/baiducloud-blb"
@@ -43,17 +53,26 @@ import (
 	pBytePlusCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/byteplus-cdn"
 	pCacheFly "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/cachefly"
 	pCdnfly "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/cdnfly"
+	pCloudflareSSL "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/cloudflare-ssl"
 	pCTCCCloudAO "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ctcccloud-ao"
 	pCTCCCloudCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ctcccloud-cdn"
 	pCTCCCloudCMS "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ctcccloud-cms"
 	pCTCCCloudELB "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ctcccloud-elb"
 	pCTCCCloudICDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ctcccloud-icdn"
 	pCTCCCloudLVDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ctcccloud-lvdn"
+	pDocker "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/docker"
 	pDogeCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/dogecloud-cdn"
 	pEdgioApplications "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/edgio-applications"
+	pF5BIGIP "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/f5bigip"
+	pFastly "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/fastly"
 	pFlexCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/flexcdn"
+	pFTP "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ftp"
 	pGcoreCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/gcore-cdn"
+	pGit "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/git"
 	pGoEdge "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/goedge"
+	pGoogleCloudCertManager "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/googlecloud-certmanager"
+	pGoogleCloudLB "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/googlecloud-lb"
+	pHAProxy "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/haproxy"
 	pHuaweiCloudCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/huaweicloud-cdn"
 	pHuaweiCloudELB "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/huaweicloud-elb"
 	pHuaweiCloudSCM "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/huaweicloud-scm"
@@ -65,15 +84,22 @@ import (
 	pK8sSecret "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/k8s-secret"
 	pLeCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/lecdn"
 	pLocal "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+	pMikroTik "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/mikrotik"
 	pNetlifySite "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/netlify-site"
+	pNginxProxyManager "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/nginxproxymanager"
+	pOPNsense "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/opnsense"
+	pPfSense "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/pfsense"
+	pPlugin "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/plugin"
 	pProxmoxVE "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/proxmoxve"
 	pQiniuCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/qiniu-cdn"
 	pQiniuPili "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/qiniu-pili"
 	pRainYunRCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/rainyun-rcdn"
 	pRatPanelConsole "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ratpanel-console"
 	pRatPanelSite "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ratpanel-site"
+	pS3 "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/s3"
 	pSafeLine "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/safeline"
 	pSSH "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ssh"
+	pSynologyDSM "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/synology-dsm"
 	pTencentCloudCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/tencentcloud-cdn"
 	pTencentCloudCLB "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/tencentcloud-clb"
 	pTencentCloudCOS "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/tencentcloud-cos"
@@ -86,10 +112,12 @@ import (
 	pTencentCloudSSLDeploy "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/tencentcloud-ssl-deploy"
 	pTencentCloudVOD "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/tencentcloud-vod"
 	pTencentCloudWAF "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/tencentcloud-waf"
+	pTrueNASScale "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/truenas-scale"
 	pUCloudUCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ucloud-ucdn"
 	pUCloudUS3 "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ucloud-us3"
 	pUniCloudWebHost "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/unicloud-webhost"
 	pUpyunCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/upyun-cdn"
+	pVault "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/vault"
 	pVolcEngineALB "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/volcengine-alb"
 	pVolcEngineCDN "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/volcengine-cdn"
 	pVolcEngineCertCenter "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/volcengine-certcenter"
@@ -105,791 +133,1577 @@ import (
 	httputil "github.com/usual2970/certimate/internal/pkg/utils/http"
 	maputil "github.com/usual2970/certimate/internal/pkg/utils/map"
 	sliceutil "github.com/usual2970/certimate/internal/pkg/utils/slice"
+	"github.com/usual2970/certimate/internal/plugin"
+	"github.com/usual2970/certimate/internal/registry"
 )
 
 type deployerProviderOptions struct {
 	Provider              domain.DeploymentProviderType
+	ProviderAccessId      string
 	ProviderAccessConfig  map[string]any
 	ProviderServiceConfig map[string]any
+	SucceededHosts        []string
 }
 
+type deployerProviderFactory func(options *deployerProviderOptions) (deployer.Deployer, error)
+
 func createDeployerProvider(options *deployerProviderOptions) (deployer.Deployer, error) {
-	/*
-	  注意：如果追加新的常量值，请保持以 ASCII 排序。
-	  NOTICE: If you add new constant, please keep ASCII order.
-	*/
-	switch options.Provider {
-	case domain.DeploymentProviderType1PanelConsole, domain.DeploymentProviderType1PanelSite:
-		{
-			access := domain.AccessConfigFor1Panel{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+	provider, ok := registry.Get(registry.CapabilityHosting, string(options.Provider))
+	if !ok {
+		return nil, fmt.Errorf("unsupported deployer provider '%s'", string(options.Provider))
+	}
 
-			switch options.Provider {
-			case domain.DeploymentProviderType1PanelConsole:
-				deployer, err := p1PanelConsole.NewDeployer(&p1PanelConsole.DeployerConfig{
-					ServerUrl:                access.ServerUrl,
-					ApiVersion:               access.ApiVersion,
-					ApiKey:                   access.ApiKey,
-					AllowInsecureConnections: access.AllowInsecureConnections,
-					AutoRestart:              maputil.GetBool(options.ProviderServiceConfig, "autoRestart"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderType1PanelSite:
-				deployer, err := p1PanelSite.NewDeployer(&p1PanelSite.DeployerConfig{
-					ServerUrl:                access.ServerUrl,
-					ApiVersion:               access.ApiVersion,
-					ApiKey:                   access.ApiKey,
-					AllowInsecureConnections: access.AllowInsecureConnections,
-					ResourceType:             p1PanelSite.ResourceType(maputil.GetOrDefaultString(options.ProviderServiceConfig, "resourceType", string(p1PanelSite.RESOURCE_TYPE_WEBSITE))),
-					WebsiteId:                maputil.GetInt64(options.ProviderServiceConfig, "websiteId"),
-					CertificateId:            maputil.GetInt64(options.ProviderServiceConfig, "certificateId"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
+	return provider.Factory.(deployerProviderFactory)(options)
+}
 
-	case domain.DeploymentProviderTypeAliyunALB, domain.DeploymentProviderTypeAliyunAPIGW, domain.DeploymentProviderTypeAliyunCAS, domain.DeploymentProviderTypeAliyunCASDeploy, domain.DeploymentProviderTypeAliyunCDN, domain.DeploymentProviderTypeAliyunCLB, domain.DeploymentProviderTypeAliyunDCDN, domain.DeploymentProviderTypeAliyunDDoS, domain.DeploymentProviderTypeAliyunESA, domain.DeploymentProviderTypeAliyunFC, domain.DeploymentProviderTypeAliyunGA, domain.DeploymentProviderTypeAliyunLive, domain.DeploymentProviderTypeAliyunNLB, domain.DeploymentProviderTypeAliyunOSS, domain.DeploymentProviderTypeAliyunVOD, domain.DeploymentProviderTypeAliyunWAF:
-		{
-			access := domain.AccessConfigForAliyun{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+// registerDeployerProvider 向注册表中注册部署提供商。
+// 工厂函数被调用前，授权配置与节点配置会先被分别填充到 TAccess 与 TConfig 中；
+// 节点配置的 Schema 由 TConfig 生成，将在保存工作流时用于校验。
+func registerDeployerProvider[TAccess any, TConfig any](accessProvider domain.AccessProviderType, providerTypes []domain.DeploymentProviderType, factory func(options *deployerProviderOptions, access TAccess, config TConfig) (deployer.Deployer, error)) {
+	for _, providerType := range providerTypes {
+		registry.Register(&registry.Provider{
+			Type:           string(providerType),
+			Capability:     registry.CapabilityHosting,
+			AccessProvider: string(accessProvider),
+			ConfigSchema:   registry.SchemaOf(*new(TConfig)),
+			AccessSchema:   registry.SchemaOf(*new(TAccess)),
+			Factory: deployerProviderFactory(func(options *deployerProviderOptions) (deployer.Deployer, error) {
+				var access TAccess
+				if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
+					return nil, fmt.Errorf("failed to populate provider access config: %w", err)
+				}
 
-			switch options.Provider {
-			case domain.DeploymentProviderTypeAliyunALB:
-				deployer, err := pAliyunALB.NewDeployer(&pAliyunALB.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:    pAliyunALB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId:  maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerId:      maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunAPIGW:
-				deployer, err := pAliyunAPIGW.NewDeployer(&pAliyunAPIGW.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ServiceType:     pAliyunAPIGW.ServiceType(maputil.GetString(options.ProviderServiceConfig, "serviceType")),
-					GatewayId:       maputil.GetString(options.ProviderServiceConfig, "gatewayId"),
-					GroupId:         maputil.GetString(options.ProviderServiceConfig, "groupId"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunCAS:
-				deployer, err := pAliyunCAS.NewDeployer(&pAliyunCAS.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunCASDeploy:
-				deployer, err := pAliyunCASDeploy.NewDeployer(&pAliyunCASDeploy.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceIds:     sliceutil.Filter(strings.Split(maputil.GetString(options.ProviderServiceConfig, "resourceIds"), ";"), func(s string) bool { return s != "" }),
-					ContactIds:      sliceutil.Filter(strings.Split(maputil.GetString(options.ProviderServiceConfig, "contactIds"), ";"), func(s string) bool { return s != "" }),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunCDN:
-				deployer, err := pAliyunCDN.NewDeployer(&pAliyunCDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunCLB:
-				deployer, err := pAliyunCLB.NewDeployer(&pAliyunCLB.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:    pAliyunCLB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId:  maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerPort:    maputil.GetOrDefaultInt32(options.ProviderServiceConfig, "listenerPort", 443),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunDCDN:
-				deployer, err := pAliyunDCDN.NewDeployer(&pAliyunDCDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunDDoS:
-				deployer, err := pAliyunDDoS.NewDeployer(&pAliyunDDoS.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunESA:
-				deployer, err := pAliyunESA.NewDeployer(&pAliyunESA.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					SiteId:          maputil.GetInt64(options.ProviderServiceConfig, "siteId"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunFC:
-				deployer, err := pAliyunFC.NewDeployer(&pAliyunFC.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ServiceVersion:  maputil.GetOrDefaultString(options.ProviderServiceConfig, "serviceVersion", "3.0"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunGA:
-				deployer, err := pAliyunGA.NewDeployer(&pAliyunGA.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					ResourceType:    pAliyunGA.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					AcceleratorId:   maputil.GetString(options.ProviderServiceConfig, "acceleratorId"),
-					ListenerId:      maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunLive:
-				deployer, err := pAliyunLive.NewDeployer(&pAliyunLive.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunNLB:
-				deployer, err := pAliyunNLB.NewDeployer(&pAliyunNLB.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:    pAliyunNLB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId:  maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerId:      maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunOSS:
-				deployer, err := pAliyunOSS.NewDeployer(&pAliyunOSS.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					Bucket:          maputil.GetString(options.ProviderServiceConfig, "bucket"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunVOD:
-				deployer, err := pAliyunVOD.NewDeployer(&pAliyunVOD.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAliyunWAF:
-				deployer, err := pAliyunWAF.NewDeployer(&pAliyunWAF.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ResourceGroupId: access.ResourceGroupId,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ServiceVersion:  maputil.GetOrDefaultString(options.ProviderServiceConfig, "serviceVersion", "3.0"),
-					InstanceId:      maputil.GetString(options.ProviderServiceConfig, "instanceId"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
+				var config TConfig
+				if err := maputil.Populate(options.ProviderServiceConfig, &config); err != nil {
+					return nil, fmt.Errorf("failed to populate provider service config: %w", err)
+				}
 
-	case domain.DeploymentProviderTypeAPISIX:
-		{
-			access := domain.AccessConfigForAPISIX{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+				return factory(options, access, config)
+			}),
+		})
+	}
+}
 
+func init() {
+	/*
+	  注意：如果追加新的提供商，请保持以 ASCII 排序。
+	  NOTICE: If you add new provider, please keep ASCII order.
+	*/
+	registerDeployerProvider(
+		domain.AccessProviderType1Panel,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderType1PanelConsole},
+		func(options *deployerProviderOptions, access domain.AccessConfigFor1Panel, config struct {
+			AutoRestart bool `json:"autoRestart,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := p1PanelConsole.NewDeployer(&p1PanelConsole.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				ApiVersion:               access.ApiVersion,
+				ApiKey:                   access.ApiKey,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				AutoRestart:              config.AutoRestart,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderType1Panel,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderType1PanelSite},
+		func(options *deployerProviderOptions, access domain.AccessConfigFor1Panel, config struct {
+			ResourceType  string `json:"resourceType,omitempty"`
+			WebsiteId     int64  `json:"websiteId,omitempty"`
+			CertificateId int64  `json:"certificateId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := p1PanelSite.NewDeployer(&p1PanelSite.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				ApiVersion:               access.ApiVersion,
+				ApiKey:                   access.ApiKey,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				ResourceType:             p1PanelSite.ResourceType(cmp.Or(config.ResourceType, string(p1PanelSite.RESOURCE_TYPE_WEBSITE))),
+				WebsiteId:                config.WebsiteId,
+				CertificateId:            config.CertificateId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAgent,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAgent},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAgent, config localDeployerConfig) (deployer.Deployer, error) {
+			deployer, err := pAgent.NewDeployer(&pAgent.DeployerConfig{
+				AgentId:     access.AgentId,
+				LocalConfig: buildLocalDeployerConfig(config),
+				Channel:     agent.GetHub(),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAkamai,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAkamaiCPS},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAkamai, config struct {
+			EnrollmentId int64 `json:"enrollmentId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAkamaiCPS.NewDeployer(&pAkamaiCPS.DeployerConfig{
+				Host:         access.Host,
+				ClientToken:  access.ClientToken,
+				ClientSecret: access.ClientSecret,
+				AccessToken:  access.AccessToken,
+				EnrollmentId: config.EnrollmentId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunALB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region         string `json:"region,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerId     string `json:"listenerId,omitempty"`
+			Domain         string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunALB.NewDeployer(&pAliyunALB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				ResourceType:    pAliyunALB.ResourceType(config.ResourceType),
+				LoadbalancerId:  config.LoadbalancerId,
+				ListenerId:      config.ListenerId,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunAPIGW},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region      string `json:"region,omitempty"`
+			ServiceType string `json:"serviceType,omitempty"`
+			GatewayId   string `json:"gatewayId,omitempty"`
+			GroupId     string `json:"groupId,omitempty"`
+			Domain      string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunAPIGW.NewDeployer(&pAliyunAPIGW.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				ServiceType:     pAliyunAPIGW.ServiceType(config.ServiceType),
+				GatewayId:       config.GatewayId,
+				GroupId:         config.GroupId,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunCAS},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region string `json:"region,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunCAS.NewDeployer(&pAliyunCAS.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunCASDeploy},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region      string `json:"region,omitempty"`
+			ResourceIds string `json:"resourceIds,omitempty"`
+			ContactIds  string `json:"contactIds,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunCASDeploy.NewDeployer(&pAliyunCASDeploy.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				ResourceIds:     sliceutil.Filter(strings.Split(config.ResourceIds, ";"), func(s string) bool { return s != "" }),
+				ContactIds:      sliceutil.Filter(strings.Split(config.ContactIds, ";"), func(s string) bool { return s != "" }),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunCDN.NewDeployer(&pAliyunCDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunCLB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region         string `json:"region,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerPort   int32  `json:"listenerPort,omitempty"`
+			Domain         string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunCLB.NewDeployer(&pAliyunCLB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				ResourceType:    pAliyunCLB.ResourceType(config.ResourceType),
+				LoadbalancerId:  config.LoadbalancerId,
+				ListenerPort:    cmp.Or(config.ListenerPort, 443),
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunDCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunDCDN.NewDeployer(&pAliyunDCDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunDDoS},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region string `json:"region,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunDDoS.NewDeployer(&pAliyunDDoS.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunESA},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region string `json:"region,omitempty"`
+			SiteId int64  `json:"siteId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunESA.NewDeployer(&pAliyunESA.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				Region:          config.Region,
+				SiteId:          config.SiteId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunFC},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region         string `json:"region,omitempty"`
+			ServiceVersion string `json:"serviceVersion,omitempty"`
+			Domain         string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunFC.NewDeployer(&pAliyunFC.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				ServiceVersion:  cmp.Or(config.ServiceVersion, "3.0"),
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunGA},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			ResourceType  string `json:"resourceType,omitempty"`
+			AcceleratorId string `json:"acceleratorId,omitempty"`
+			ListenerId    string `json:"listenerId,omitempty"`
+			Domain        string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunGA.NewDeployer(&pAliyunGA.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				ResourceType:    pAliyunGA.ResourceType(config.ResourceType),
+				AcceleratorId:   config.AcceleratorId,
+				ListenerId:      config.ListenerId,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunLive},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region string `json:"region,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunLive.NewDeployer(&pAliyunLive.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				Region:          config.Region,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunNLB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region         string `json:"region,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerId     string `json:"listenerId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunNLB.NewDeployer(&pAliyunNLB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				ResourceType:    pAliyunNLB.ResourceType(config.ResourceType),
+				LoadbalancerId:  config.LoadbalancerId,
+				ListenerId:      config.ListenerId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunOSS},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region string `json:"region,omitempty"`
+			Bucket string `json:"bucket,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunOSS.NewDeployer(&pAliyunOSS.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				Bucket:          config.Bucket,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunVOD},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region string `json:"region,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunVOD.NewDeployer(&pAliyunVOD.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAliyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAliyunWAF},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAliyun, config struct {
+			Region         string `json:"region,omitempty"`
+			ServiceVersion string `json:"serviceVersion,omitempty"`
+			InstanceId     string `json:"instanceId,omitempty"`
+			Domain         string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAliyunWAF.NewDeployer(&pAliyunWAF.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ResourceGroupId: access.ResourceGroupId,
+				Region:          config.Region,
+				ServiceVersion:  cmp.Or(config.ServiceVersion, "3.0"),
+				InstanceId:      config.InstanceId,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAPISIX,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAPISIX},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAPISIX, config struct {
+			ResourceType  string `json:"resourceType,omitempty"`
+			CertificateId string `json:"certificateId,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pAPISIX.NewDeployer(&pAPISIX.DeployerConfig{
 				ServerUrl:                access.ServerUrl,
 				ApiKey:                   access.ApiKey,
 				AllowInsecureConnections: access.AllowInsecureConnections,
-				ResourceType:             pAPISIX.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-				CertificateId:            maputil.GetString(options.ProviderServiceConfig, "certificateId"),
+				ResourceType:             pAPISIX.ResourceType(config.ResourceType),
+				CertificateId:            config.CertificateId,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeAWSACM, domain.DeploymentProviderTypeAWSCloudFront, domain.DeploymentProviderTypeAWSIAM:
-		{
-			access := domain.AccessConfigForAWS{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeAWSACM:
-				deployer, err := pAWSACM.NewDeployer(&pAWSACM.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					CertificateArn:  maputil.GetString(options.ProviderServiceConfig, "certificateArn"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAWSCloudFront:
-				deployer, err := pAWSCloudFront.NewDeployer(&pAWSCloudFront.DeployerConfig{
-					AccessKeyId:       access.AccessKeyId,
-					SecretAccessKey:   access.SecretAccessKey,
-					Region:            maputil.GetString(options.ProviderServiceConfig, "region"),
-					DistributionId:    maputil.GetString(options.ProviderServiceConfig, "distributionId"),
-					CertificateSource: maputil.GetOrDefaultString(options.ProviderServiceConfig, "certificateSource", "ACM"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeAWSIAM:
-				deployer, err := pAWSIAM.NewDeployer(&pAWSIAM.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					CertificatePath: maputil.GetOrDefaultString(options.ProviderServiceConfig, "certificatePath", "/"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeAzureKeyVault:
-		{
-			access := domain.AccessConfigForAzure{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeAzureKeyVault:
-				deployer, err := pAzureKeyVault.NewDeployer(&pAzureKeyVault.DeployerConfig{
-					TenantId:        access.TenantId,
-					ClientId:        access.ClientId,
-					ClientSecret:    access.ClientSecret,
-					CloudName:       access.CloudName,
-					KeyVaultName:    maputil.GetString(options.ProviderServiceConfig, "keyvaultName"),
-					CertificateName: maputil.GetString(options.ProviderServiceConfig, "certificateName"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeBaiduCloudAppBLB, domain.DeploymentProviderTypeBaiduCloudBLB, domain.DeploymentProviderTypeBaiduCloudCDN, domain.DeploymentProviderTypeBaiduCloudCert:
-		{
-			access := domain.AccessConfigForBaiduCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeBaiduCloudAppBLB:
-				deployer, err := pBaiduCloudAppBLB.NewDeployer(&pBaiduCloudAppBLB.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:    pBaiduCloudAppBLB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId:  maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerPort:    maputil.GetInt32(options.ProviderServiceConfig, "listenerPort"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeBaiduCloudBLB:
-				deployer, err := pBaiduCloudBLB.NewDeployer(&pBaiduCloudBLB.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:    pBaiduCloudBLB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId:  maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerPort:    maputil.GetInt32(options.ProviderServiceConfig, "listenerPort"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeBaiduCloudCDN:
-				deployer, err := pBaiduCloudCDN.NewDeployer(&pBaiduCloudCDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeBaiduCloudCert:
-				deployer, err := pBaiduCloudCert.NewDeployer(&pBaiduCloudCert.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeBaishanCDN:
-		{
-			access := domain.AccessConfigForBaishan{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeBaishanCDN:
-				deployer, err := pBaishanCDN.NewDeployer(&pBaishanCDN.DeployerConfig{
-					ApiToken:      access.ApiToken,
-					Domain:        maputil.GetString(options.ProviderServiceConfig, "domain"),
-					CertificateId: maputil.GetString(options.ProviderServiceConfig, "certificateId"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeBaotaPanelConsole, domain.DeploymentProviderTypeBaotaPanelSite:
-		{
-			access := domain.AccessConfigForBaotaPanel{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeBaotaPanelConsole:
-				deployer, err := pBaotaPanelConsole.NewDeployer(&pBaotaPanelConsole.DeployerConfig{
-					ServerUrl:                access.ServerUrl,
-					ApiKey:                   access.ApiKey,
-					AllowInsecureConnections: access.AllowInsecureConnections,
-					AutoRestart:              maputil.GetBool(options.ProviderServiceConfig, "autoRestart"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeBaotaPanelSite:
-				deployer, err := pBaotaPanelSite.NewDeployer(&pBaotaPanelSite.DeployerConfig{
-					ServerUrl:                access.ServerUrl,
-					ApiKey:                   access.ApiKey,
-					AllowInsecureConnections: access.AllowInsecureConnections,
-					SiteType:                 maputil.GetOrDefaultString(options.ProviderServiceConfig, "siteType", "other"),
-					SiteName:                 maputil.GetString(options.ProviderServiceConfig, "siteName"),
-					SiteNames:                sliceutil.Filter(strings.Split(maputil.GetString(options.ProviderServiceConfig, "siteNames"), ";"), func(s string) bool { return s != "" }),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeBaotaWAFConsole, domain.DeploymentProviderTypeBaotaWAFSite:
-		{
-			access := domain.AccessConfigForBaotaWAF{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeBaotaWAFConsole:
-				deployer, err := pBaotaWAFConsole.NewDeployer(&pBaotaWAFConsole.DeployerConfig{
-					ServerUrl:                access.ServerUrl,
-					ApiKey:                   access.ApiKey,
-					AllowInsecureConnections: access.AllowInsecureConnections,
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeBaotaWAFSite:
-				deployer, err := pBaotaWAFSite.NewDeployer(&pBaotaWAFSite.DeployerConfig{
-					ServerUrl:                access.ServerUrl,
-					ApiKey:                   access.ApiKey,
-					AllowInsecureConnections: access.AllowInsecureConnections,
-					SiteName:                 maputil.GetString(options.ProviderServiceConfig, "siteName"),
-					SitePort:                 maputil.GetOrDefaultInt32(options.ProviderServiceConfig, "sitePort", 443),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeBunnyCDN:
-		{
-			access := domain.AccessConfigForBunny{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAWS,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAWSACM},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAWS, config struct {
+			Region         string `json:"region,omitempty"`
+			CertificateArn string `json:"certificateArn,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAWSACM.NewDeployer(&pAWSACM.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Region:          config.Region,
+				CertificateArn:  config.CertificateArn,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAWS,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAWSAPIGateway},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAWS, config struct {
+			Region     string `json:"region,omitempty"`
+			DomainName string `json:"domainName,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAWSAPIGateway.NewDeployer(&pAWSAPIGateway.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Region:          config.Region,
+				Endpoint:        access.Endpoint,
+				DomainName:      config.DomainName,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAWS,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAWSCloudFront},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAWS, config struct {
+			Region            string `json:"region,omitempty"`
+			DistributionId    string `json:"distributionId,omitempty"`
+			CertificateSource string `json:"certificateSource,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAWSCloudFront.NewDeployer(&pAWSCloudFront.DeployerConfig{
+				AccessKeyId:       access.AccessKeyId,
+				SecretAccessKey:   access.SecretAccessKey,
+				Region:            config.Region,
+				DistributionId:    config.DistributionId,
+				CertificateSource: cmp.Or(config.CertificateSource, "ACM"),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAWS,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAWSELB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAWS, config struct {
+			Region          string `json:"region,omitempty"`
+			ResourceType    string `json:"resourceType,omitempty"`
+			LoadbalancerArn string `json:"loadbalancerArn,omitempty"`
+			ListenerArn     string `json:"listenerArn,omitempty"`
+			CertificateType string `json:"certificateType,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAWSELB.NewDeployer(&pAWSELB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Region:          config.Region,
+				Endpoint:        access.Endpoint,
+				ResourceType:    pAWSELB.ResourceType(config.ResourceType),
+				LoadbalancerArn: config.LoadbalancerArn,
+				ListenerArn:     config.ListenerArn,
+				CertificateType: pAWSELB.CertificateType(config.CertificateType),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAWS,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAWSIAM},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAWS, config struct {
+			Region          string `json:"region,omitempty"`
+			CertificatePath string `json:"certificatePath,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAWSIAM.NewDeployer(&pAWSIAM.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Region:          config.Region,
+				CertificatePath: cmp.Or(config.CertificatePath, "/"),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAzure,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAzureAppGateway},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAzure, config struct {
+			SubscriptionId         string `json:"subscriptionId,omitempty"`
+			ResourceGroupName      string `json:"resourceGroupName,omitempty"`
+			KeyvaultName           string `json:"keyvaultName,omitempty"`
+			ApplicationGatewayName string `json:"applicationGatewayName,omitempty"`
+			ListenerName           string `json:"listenerName,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAzureAppGateway.NewDeployer(&pAzureAppGateway.DeployerConfig{
+				TenantId:               access.TenantId,
+				ClientId:               access.ClientId,
+				ClientSecret:           access.ClientSecret,
+				CloudName:              access.CloudName,
+				SubscriptionId:         config.SubscriptionId,
+				ResourceGroupName:      config.ResourceGroupName,
+				KeyVaultName:           config.KeyvaultName,
+				ApplicationGatewayName: config.ApplicationGatewayName,
+				ListenerName:           config.ListenerName,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAzure,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAzureAppService},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAzure, config struct {
+			SubscriptionId    string `json:"subscriptionId,omitempty"`
+			ResourceGroupName string `json:"resourceGroupName,omitempty"`
+			KeyvaultName      string `json:"keyvaultName,omitempty"`
+			SiteName          string `json:"siteName,omitempty"`
+			SlotName          string `json:"slotName,omitempty"`
+			Domain            string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAzureAppService.NewDeployer(&pAzureAppService.DeployerConfig{
+				TenantId:          access.TenantId,
+				ClientId:          access.ClientId,
+				ClientSecret:      access.ClientSecret,
+				CloudName:         access.CloudName,
+				SubscriptionId:    config.SubscriptionId,
+				ResourceGroupName: config.ResourceGroupName,
+				KeyVaultName:      config.KeyvaultName,
+				SiteName:          config.SiteName,
+				SlotName:          config.SlotName,
+				Domain:            config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAzure,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAzureFrontDoor},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAzure, config struct {
+			SubscriptionId    string `json:"subscriptionId,omitempty"`
+			ResourceGroupName string `json:"resourceGroupName,omitempty"`
+			KeyvaultName      string `json:"keyvaultName,omitempty"`
+			ProfileName       string `json:"profileName,omitempty"`
+			CustomDomainName  string `json:"customDomainName,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAzureFrontDoor.NewDeployer(&pAzureFrontDoor.DeployerConfig{
+				TenantId:          access.TenantId,
+				ClientId:          access.ClientId,
+				ClientSecret:      access.ClientSecret,
+				CloudName:         access.CloudName,
+				SubscriptionId:    config.SubscriptionId,
+				ResourceGroupName: config.ResourceGroupName,
+				KeyVaultName:      config.KeyvaultName,
+				ProfileName:       config.ProfileName,
+				CustomDomainName:  config.CustomDomainName,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeAzure,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeAzureKeyVault},
+		func(options *deployerProviderOptions, access domain.AccessConfigForAzure, config struct {
+			KeyvaultName    string `json:"keyvaultName,omitempty"`
+			CertificateName string `json:"certificateName,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pAzureKeyVault.NewDeployer(&pAzureKeyVault.DeployerConfig{
+				TenantId:        access.TenantId,
+				ClientId:        access.ClientId,
+				ClientSecret:    access.ClientSecret,
+				CloudName:       access.CloudName,
+				KeyVaultName:    config.KeyvaultName,
+				CertificateName: config.CertificateName,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBaiduCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBaiduCloudAppBLB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBaiduCloud, config struct {
+			Region         string `json:"region,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerPort   int32  `json:"listenerPort,omitempty"`
+			Domain         string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pBaiduCloudAppBLB.NewDeployer(&pBaiduCloudAppBLB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Region:          config.Region,
+				ResourceType:    pBaiduCloudAppBLB.ResourceType(config.ResourceType),
+				LoadbalancerId:  config.LoadbalancerId,
+				ListenerPort:    config.ListenerPort,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBaiduCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBaiduCloudBLB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBaiduCloud, config struct {
+			Region         string `json:"region,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerPort   int32  `json:"listenerPort,omitempty"`
+			Domain         string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pBaiduCloudBLB.NewDeployer(&pBaiduCloudBLB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Region:          config.Region,
+				ResourceType:    pBaiduCloudBLB.ResourceType(config.ResourceType),
+				LoadbalancerId:  config.LoadbalancerId,
+				ListenerPort:    config.ListenerPort,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBaiduCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBaiduCloudCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBaiduCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pBaiduCloudCDN.NewDeployer(&pBaiduCloudCDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBaiduCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBaiduCloudCert},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBaiduCloud, config struct{}) (deployer.Deployer, error) {
+			deployer, err := pBaiduCloudCert.NewDeployer(&pBaiduCloudCert.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBaishan,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBaishanCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBaishan, config struct {
+			Domain        string `json:"domain,omitempty"`
+			CertificateId string `json:"certificateId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pBaishanCDN.NewDeployer(&pBaishanCDN.DeployerConfig{
+				ApiToken:      access.ApiToken,
+				Domain:        config.Domain,
+				CertificateId: config.CertificateId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBaotaPanel,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBaotaPanelConsole},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBaotaPanel, config struct {
+			AutoRestart bool `json:"autoRestart,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pBaotaPanelConsole.NewDeployer(&pBaotaPanelConsole.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				ApiKey:                   access.ApiKey,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				AutoRestart:              config.AutoRestart,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBaotaPanel,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBaotaPanelSite},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBaotaPanel, config struct {
+			SiteType  string `json:"siteType,omitempty"`
+			SiteName  string `json:"siteName,omitempty"`
+			SiteNames string `json:"siteNames,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pBaotaPanelSite.NewDeployer(&pBaotaPanelSite.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				ApiKey:                   access.ApiKey,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				SiteType:                 cmp.Or(config.SiteType, "other"),
+				SiteName:                 config.SiteName,
+				SiteNames:                sliceutil.Filter(strings.Split(config.SiteNames, ";"), func(s string) bool { return s != "" }),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBaotaWAF,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBaotaWAFConsole},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBaotaWAF, config struct{}) (deployer.Deployer, error) {
+			deployer, err := pBaotaWAFConsole.NewDeployer(&pBaotaWAFConsole.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				ApiKey:                   access.ApiKey,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBaotaWAF,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBaotaWAFSite},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBaotaWAF, config struct {
+			SiteName string `json:"siteName,omitempty"`
+			SitePort int32  `json:"sitePort,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pBaotaWAFSite.NewDeployer(&pBaotaWAFSite.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				ApiKey:                   access.ApiKey,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				SiteName:                 config.SiteName,
+				SitePort:                 cmp.Or(config.SitePort, 443),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBunny,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBunnyCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBunny, config struct {
+			PullZoneId string `json:"pullZoneId,omitempty"`
+			Hostname   string `json:"hostname,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pBunnyCDN.NewDeployer(&pBunnyCDN.DeployerConfig{
 				ApiKey:     access.ApiKey,
-				PullZoneId: maputil.GetString(options.ProviderServiceConfig, "pullZoneId"),
-				Hostname:   maputil.GetString(options.ProviderServiceConfig, "hostname"),
+				PullZoneId: config.PullZoneId,
+				Hostname:   config.Hostname,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeBytePlusCDN:
-		{
-			access := domain.AccessConfigForBytePlus{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeBytePlusCDN:
-				deployer, err := pBytePlusCDN.NewDeployer(&pBytePlusCDN.DeployerConfig{
-					AccessKey: access.AccessKey,
-					SecretKey: access.SecretKey,
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeCacheFly:
-		{
-			access := domain.AccessConfigForCacheFly{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeBytePlus,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeBytePlusCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForBytePlus, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pBytePlusCDN.NewDeployer(&pBytePlusCDN.DeployerConfig{
+				AccessKey: access.AccessKey,
+				SecretKey: access.SecretKey,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
 
+	registerDeployerProvider(
+		domain.AccessProviderTypeCacheFly,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeCacheFly},
+		func(options *deployerProviderOptions, access domain.AccessConfigForCacheFly, config struct{}) (deployer.Deployer, error) {
 			deployer, err := pCacheFly.NewDeployer(&pCacheFly.DeployerConfig{
 				ApiToken: access.ApiToken,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeCdnfly:
-		{
-			access := domain.AccessConfigForCdnfly{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeCdnfly,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeCdnfly},
+		func(options *deployerProviderOptions, access domain.AccessConfigForCdnfly, config struct {
+			ResourceType  string `json:"resourceType,omitempty"`
+			SiteId        string `json:"siteId,omitempty"`
+			CertificateId string `json:"certificateId,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pCdnfly.NewDeployer(&pCdnfly.DeployerConfig{
 				ServerUrl:                access.ServerUrl,
 				ApiKey:                   access.ApiKey,
 				ApiSecret:                access.ApiSecret,
 				AllowInsecureConnections: access.AllowInsecureConnections,
-				ResourceType:             pCdnfly.ResourceType(maputil.GetOrDefaultString(options.ProviderServiceConfig, "resourceType", string(pCdnfly.RESOURCE_TYPE_SITE))),
-				SiteId:                   maputil.GetString(options.ProviderServiceConfig, "siteId"),
-				CertificateId:            maputil.GetString(options.ProviderServiceConfig, "certificateId"),
+				ResourceType:             pCdnfly.ResourceType(cmp.Or(config.ResourceType, string(pCdnfly.RESOURCE_TYPE_SITE))),
+				SiteId:                   config.SiteId,
+				CertificateId:            config.CertificateId,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeCTCCCloudAO, domain.DeploymentProviderTypeCTCCCloudCDN, domain.DeploymentProviderTypeCTCCCloudCMS, domain.DeploymentProviderTypeCTCCCloudELB, domain.DeploymentProviderTypeCTCCCloudICDN, domain.DeploymentProviderTypeCTCCCloudLVDN:
-		{
-			access := domain.AccessConfigForCTCCCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeCTCCCloudAO:
-				deployer, err := pCTCCCloudAO.NewDeployer(&pCTCCCloudAO.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeCTCCCloudCDN:
-				deployer, err := pCTCCCloudCDN.NewDeployer(&pCTCCCloudCDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeCTCCCloudCMS:
-				deployer, err := pCTCCCloudCMS.NewDeployer(&pCTCCCloudCMS.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeCTCCCloudELB:
-				deployer, err := pCTCCCloudELB.NewDeployer(&pCTCCCloudELB.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					RegionId:        maputil.GetString(options.ProviderServiceConfig, "regionId"),
-					ResourceType:    pCTCCCloudELB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId:  maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerId:      maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeCTCCCloudICDN:
-				deployer, err := pCTCCCloudICDN.NewDeployer(&pCTCCCloudICDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeCTCCCloudLVDN:
-				deployer, err := pCTCCCloudLVDN.NewDeployer(&pCTCCCloudLVDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					SecretAccessKey: access.SecretAccessKey,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeDogeCloudCDN:
-		{
-			access := domain.AccessConfigForDogeCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeCloudflare,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeCloudflareSSL},
+		func(options *deployerProviderOptions, access domain.AccessConfigForCloudflare, config struct {
+			ZoneId         string `json:"zoneId,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			CertificateId  string `json:"certificateId,omitempty"`
+			CustomHostname string `json:"customHostname,omitempty"`
+			BundleMethod   string `json:"bundleMethod,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pCloudflareSSL.NewDeployer(&pCloudflareSSL.DeployerConfig{
+				ApiToken:       access.DnsApiToken,
+				ZoneId:         config.ZoneId,
+				ResourceType:   pCloudflareSSL.ResourceType(cmp.Or(config.ResourceType, string(pCloudflareSSL.RESOURCE_TYPE_CERTIFICATE))),
+				CertificateId:  config.CertificateId,
+				CustomHostname: config.CustomHostname,
+				BundleMethod:   config.BundleMethod,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeCTCCCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeCTCCCloudAO},
+		func(options *deployerProviderOptions, access domain.AccessConfigForCTCCCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pCTCCCloudAO.NewDeployer(&pCTCCCloudAO.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeCTCCCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeCTCCCloudCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForCTCCCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pCTCCCloudCDN.NewDeployer(&pCTCCCloudCDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeCTCCCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeCTCCCloudCMS},
+		func(options *deployerProviderOptions, access domain.AccessConfigForCTCCCloud, config struct{}) (deployer.Deployer, error) {
+			deployer, err := pCTCCCloudCMS.NewDeployer(&pCTCCCloudCMS.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeCTCCCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeCTCCCloudELB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForCTCCCloud, config struct {
+			RegionId       string `json:"regionId,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerId     string `json:"listenerId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pCTCCCloudELB.NewDeployer(&pCTCCCloudELB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				RegionId:        config.RegionId,
+				ResourceType:    pCTCCCloudELB.ResourceType(config.ResourceType),
+				LoadbalancerId:  config.LoadbalancerId,
+				ListenerId:      config.ListenerId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeCTCCCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeCTCCCloudICDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForCTCCCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pCTCCCloudICDN.NewDeployer(&pCTCCCloudICDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeCTCCCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeCTCCCloudLVDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForCTCCCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pCTCCCloudLVDN.NewDeployer(&pCTCCCloudLVDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				SecretAccessKey: access.SecretAccessKey,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeDocker,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeDocker},
+		func(options *deployerProviderOptions, access domain.AccessConfigForDocker, config struct {
+			TargetType                     string `json:"targetType,omitempty"`
+			ContainerId                    string `json:"containerId,omitempty"`
+			VolumeName                     string `json:"volumeName,omitempty"`
+			HelperImage                    string `json:"helperImage,omitempty"`
+			Format                         string `json:"format,omitempty"`
+			CertPath                       string `json:"certPath,omitempty"`
+			CertPathForServerOnly          string `json:"certPathForServerOnly,omitempty"`
+			CertPathForIntermediaOnly      string `json:"certPathForIntermediaOnly,omitempty"`
+			KeyPath                        string `json:"keyPath,omitempty"`
+			CertPathForRootOnly            string `json:"certPathForRootOnly,omitempty"`
+			CertFetchIssuerForRootOnly     bool   `json:"certFetchIssuerForRootOnly,omitempty"`
+			PemChainReversed               bool   `json:"pemChainReversed,omitempty"`
+			PemKeyFormat                   string `json:"pemKeyFormat,omitempty"`
+			PemKeyPassword                 string `json:"pemKeyPassword,omitempty"`
+			PfxPassword                    string `json:"pfxPassword,omitempty"`
+			JksAlias                       string `json:"jksAlias,omitempty"`
+			JksKeypass                     string `json:"jksKeypass,omitempty"`
+			JksStorepass                   string `json:"jksStorepass,omitempty"`
+			CertFileMode                   string `json:"certFileMode,omitempty"`
+			CertFileOwner                  string `json:"certFileOwner,omitempty"`
+			CertFileGroup                  string `json:"certFileGroup,omitempty"`
+			CertFileModeForServerOnly      string `json:"certFileModeForServerOnly,omitempty"`
+			CertFileOwnerForServerOnly     string `json:"certFileOwnerForServerOnly,omitempty"`
+			CertFileGroupForServerOnly     string `json:"certFileGroupForServerOnly,omitempty"`
+			CertFileModeForIntermediaOnly  string `json:"certFileModeForIntermediaOnly,omitempty"`
+			CertFileOwnerForIntermediaOnly string `json:"certFileOwnerForIntermediaOnly,omitempty"`
+			CertFileGroupForIntermediaOnly string `json:"certFileGroupForIntermediaOnly,omitempty"`
+			KeyFileMode                    string `json:"keyFileMode,omitempty"`
+			KeyFileOwner                   string `json:"keyFileOwner,omitempty"`
+			KeyFileGroup                   string `json:"keyFileGroup,omitempty"`
+			CertFileModeForRootOnly        string `json:"certFileModeForRootOnly,omitempty"`
+			CertFileOwnerForRootOnly       string `json:"certFileOwnerForRootOnly,omitempty"`
+			CertFileGroupForRootOnly       string `json:"certFileGroupForRootOnly,omitempty"`
+			PostAction                     string `json:"postAction,omitempty"`
+			PostCommand                    string `json:"postCommand,omitempty"`
+			PostSignal                     string `json:"postSignal,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pDocker.NewDeployer(&pDocker.DeployerConfig{
+				DockerHost:                access.DockerHost,
+				TlsCaCertificate:          access.TlsCaCertificate,
+				TlsClientCertificate:      access.TlsClientCertificate,
+				TlsClientKey:              access.TlsClientKey,
+				AllowInsecureConnections:  access.AllowInsecureConnections,
+				TargetType:                pDocker.TargetType(cmp.Or(config.TargetType, string(pDocker.TARGET_TYPE_CONTAINER))),
+				ContainerId:               config.ContainerId,
+				VolumeName:                config.VolumeName,
+				HelperImage:               config.HelperImage,
+				OutputFormat:              pLocal.OutputFormatType(cmp.Or(config.Format, string(pLocal.OUTPUT_FORMAT_PEM))),
+				OutputCertPath:            config.CertPath,
+				OutputServerCertPath:      config.CertPathForServerOnly,
+				OutputIntermediaCertPath:  config.CertPathForIntermediaOnly,
+				OutputKeyPath:             config.KeyPath,
+				OutputRootCertPath:        config.CertPathForRootOnly,
+				OutputRootCertFetchIssuer: config.CertFetchIssuerForRootOnly,
+				PemChainReversed:          config.PemChainReversed,
+				PemKeyFormat:              pLocal.PrivateKeyFormatType(config.PemKeyFormat),
+				PemKeyPassword:            config.PemKeyPassword,
+				PfxPassword:               config.PfxPassword,
+				JksAlias:                  config.JksAlias,
+				JksKeypass:                config.JksKeypass,
+				JksStorepass:              config.JksStorepass,
+				OutputCertFileAttrs: pDocker.FileAttrs{
+					Mode:  config.CertFileMode,
+					Owner: config.CertFileOwner,
+					Group: config.CertFileGroup,
+				},
+				OutputServerCertFileAttrs: pDocker.FileAttrs{
+					Mode:  config.CertFileModeForServerOnly,
+					Owner: config.CertFileOwnerForServerOnly,
+					Group: config.CertFileGroupForServerOnly,
+				},
+				OutputIntermediaCertFileAttrs: pDocker.FileAttrs{
+					Mode:  config.CertFileModeForIntermediaOnly,
+					Owner: config.CertFileOwnerForIntermediaOnly,
+					Group: config.CertFileGroupForIntermediaOnly,
+				},
+				OutputKeyFileAttrs: pDocker.FileAttrs{
+					Mode:  config.KeyFileMode,
+					Owner: config.KeyFileOwner,
+					Group: config.KeyFileGroup,
+				},
+				OutputRootCertFileAttrs: pDocker.FileAttrs{
+					Mode:  config.CertFileModeForRootOnly,
+					Owner: config.CertFileOwnerForRootOnly,
+					Group: config.CertFileGroupForRootOnly,
+				},
+				PostAction:  pDocker.PostActionType(config.PostAction),
+				PostCommand: config.PostCommand,
+				PostSignal:  config.PostSignal,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeDogeCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeDogeCloudCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForDogeCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pDogeCDN.NewDeployer(&pDogeCDN.DeployerConfig{
 				AccessKey: access.AccessKey,
 				SecretKey: access.SecretKey,
-				Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
+				Domain:    config.Domain,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeEdgioApplications:
-		{
-			access := domain.AccessConfigForEdgio{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeEdgio,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeEdgioApplications},
+		func(options *deployerProviderOptions, access domain.AccessConfigForEdgio, config struct {
+			EnvironmentId string `json:"environmentId,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pEdgioApplications.NewDeployer(&pEdgioApplications.DeployerConfig{
 				ClientId:      access.ClientId,
 				ClientSecret:  access.ClientSecret,
-				EnvironmentId: maputil.GetString(options.ProviderServiceConfig, "environmentId"),
+				EnvironmentId: config.EnvironmentId,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeFlexCDN:
-		{
-			access := domain.AccessConfigForFlexCDN{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeF5BIGIP,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeF5BIGIP},
+		func(options *deployerProviderOptions, access domain.AccessConfigForF5BIGIP, config struct {
+			Partition        string `json:"partition,omitempty"`
+			ProfileName      string `json:"profileName,omitempty"`
+			ObjectNamePrefix string `json:"objectNamePrefix,omitempty"`
+			DeviceGroup      string `json:"deviceGroup,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pF5BIGIP.NewDeployer(&pF5BIGIP.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				Username:                 access.Username,
+				Password:                 access.Password,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				Partition:                config.Partition,
+				ProfileName:              config.ProfileName,
+				ObjectNamePrefix:         config.ObjectNamePrefix,
+				DeviceGroup:              config.DeviceGroup,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeFastly,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeFastly},
+		func(options *deployerProviderOptions, access domain.AccessConfigForFastly, config struct {
+			Domain             string `json:"domain,omitempty"`
+			TlsConfigurationId string `json:"tlsConfigurationId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pFastly.NewDeployer(&pFastly.DeployerConfig{
+				ApiToken:           access.ApiToken,
+				Domain:             config.Domain,
+				TlsConfigurationId: config.TlsConfigurationId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeFlexCDN,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeFlexCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForFlexCDN, config struct {
+			ResourceType  string `json:"resourceType,omitempty"`
+			CertificateId int64  `json:"certificateId,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pFlexCDN.NewDeployer(&pFlexCDN.DeployerConfig{
 				ServerUrl:                access.ServerUrl,
 				ApiRole:                  access.ApiRole,
 				AccessKeyId:              access.AccessKeyId,
 				AccessKey:                access.AccessKey,
 				AllowInsecureConnections: access.AllowInsecureConnections,
-				ResourceType:             pFlexCDN.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-				CertificateId:            maputil.GetInt64(options.ProviderServiceConfig, "certificateId"),
+				ResourceType:             pFlexCDN.ResourceType(config.ResourceType),
+				CertificateId:            config.CertificateId,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeGcoreCDN:
-		{
-			access := domain.AccessConfigForGcore{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeGcoreCDN:
-				deployer, err := pGcoreCDN.NewDeployer(&pGcoreCDN.DeployerConfig{
-					ApiToken:      access.ApiToken,
-					ResourceId:    maputil.GetInt64(options.ProviderServiceConfig, "resourceId"),
-					CertificateId: maputil.GetInt64(options.ProviderServiceConfig, "certificateId"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeGoEdge:
-		{
-			access := domain.AccessConfigForGoEdge{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeFTP,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeFTP},
+		func(options *deployerProviderOptions, access domain.AccessConfigForFTP, config struct {
+			Format                     string `json:"format,omitempty"`
+			CertPath                   string `json:"certPath,omitempty"`
+			CertPathForServerOnly      string `json:"certPathForServerOnly,omitempty"`
+			CertPathForIntermediaOnly  string `json:"certPathForIntermediaOnly,omitempty"`
+			KeyPath                    string `json:"keyPath,omitempty"`
+			CertPathForRootOnly        string `json:"certPathForRootOnly,omitempty"`
+			CertFetchIssuerForRootOnly bool   `json:"certFetchIssuerForRootOnly,omitempty"`
+			PemChainReversed           bool   `json:"pemChainReversed,omitempty"`
+			PemKeyFormat               string `json:"pemKeyFormat,omitempty"`
+			PemKeyPassword             string `json:"pemKeyPassword,omitempty"`
+			Backup                     bool   `json:"backup,omitempty"`
+			PfxPassword                string `json:"pfxPassword,omitempty"`
+			JksAlias                   string `json:"jksAlias,omitempty"`
+			JksKeypass                 string `json:"jksKeypass,omitempty"`
+			JksStorepass               string `json:"jksStorepass,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pFTP.NewDeployer(&pFTP.DeployerConfig{
+				FtpHost:                   access.Host,
+				FtpPort:                   access.Port,
+				FtpUsername:               access.Username,
+				FtpPassword:               access.Password,
+				TlsMode:                   pFTP.TlsModeType(access.TlsMode),
+				AllowInsecureConnections:  access.AllowInsecureConnections,
+				DisableEPSV:               access.DisableEPSV,
+				OutputFormat:              pLocal.OutputFormatType(cmp.Or(config.Format, string(pLocal.OUTPUT_FORMAT_PEM))),
+				OutputCertPath:            config.CertPath,
+				OutputServerCertPath:      config.CertPathForServerOnly,
+				OutputIntermediaCertPath:  config.CertPathForIntermediaOnly,
+				OutputKeyPath:             config.KeyPath,
+				OutputRootCertPath:        config.CertPathForRootOnly,
+				OutputRootCertFetchIssuer: config.CertFetchIssuerForRootOnly,
+				PemChainReversed:          config.PemChainReversed,
+				PemKeyFormat:              pLocal.PrivateKeyFormatType(config.PemKeyFormat),
+				PemKeyPassword:            config.PemKeyPassword,
+				OutputBackup:              config.Backup,
+				PfxPassword:               config.PfxPassword,
+				JksAlias:                  config.JksAlias,
+				JksKeypass:                config.JksKeypass,
+				JksStorepass:              config.JksStorepass,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeGcore,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeGcoreCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForGcore, config struct {
+			ResourceId    int64 `json:"resourceId,omitempty"`
+			CertificateId int64 `json:"certificateId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pGcoreCDN.NewDeployer(&pGcoreCDN.DeployerConfig{
+				ApiToken:      access.ApiToken,
+				ResourceId:    config.ResourceId,
+				CertificateId: config.CertificateId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeGit,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeGit},
+		func(options *deployerProviderOptions, access domain.AccessConfigForGit, config struct {
+			RepositoryUrl              string `json:"repositoryUrl,omitempty"`
+			Branch                     string `json:"branch,omitempty"`
+			Format                     string `json:"format,omitempty"`
+			CertPath                   string `json:"certPath,omitempty"`
+			CertPathForServerOnly      string `json:"certPathForServerOnly,omitempty"`
+			CertPathForIntermediaOnly  string `json:"certPathForIntermediaOnly,omitempty"`
+			KeyPath                    string `json:"keyPath,omitempty"`
+			CertPathForRootOnly        string `json:"certPathForRootOnly,omitempty"`
+			CertFetchIssuerForRootOnly bool   `json:"certFetchIssuerForRootOnly,omitempty"`
+			PemChainReversed           bool   `json:"pemChainReversed,omitempty"`
+			PemKeyFormat               string `json:"pemKeyFormat,omitempty"`
+			PemKeyPassword             string `json:"pemKeyPassword,omitempty"`
+			PfxPassword                string `json:"pfxPassword,omitempty"`
+			JksAlias                   string `json:"jksAlias,omitempty"`
+			JksKeypass                 string `json:"jksKeypass,omitempty"`
+			JksStorepass               string `json:"jksStorepass,omitempty"`
+			Encryption                 string `json:"encryption,omitempty"`
+			AgeRecipients              string `json:"ageRecipients,omitempty"`
+			CommitMessage              string `json:"commitMessage,omitempty"`
+			CommitAuthorName           string `json:"commitAuthorName,omitempty"`
+			CommitAuthorEmail          string `json:"commitAuthorEmail,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pGit.NewDeployer(&pGit.DeployerConfig{
+				RepositoryUrl:    config.RepositoryUrl,
+				Username:         access.Username,
+				Password:         access.Password,
+				SshKey:           access.SshKey,
+				SshKeyPassphrase: access.SshKeyPassphrase,
+				SshKnownHosts:    access.SshKnownHosts,
+				OnKnownHostsUpdated: func(knownHosts string) error {
+					return updateAccessConfig(options.ProviderAccessId, "sshKnownHosts", knownHosts)
+				},
+				Branch:                    config.Branch,
+				OutputFormat:              pLocal.OutputFormatType(cmp.Or(config.Format, string(pLocal.OUTPUT_FORMAT_PEM))),
+				OutputCertPath:            config.CertPath,
+				OutputServerCertPath:      config.CertPathForServerOnly,
+				OutputIntermediaCertPath:  config.CertPathForIntermediaOnly,
+				OutputKeyPath:             config.KeyPath,
+				OutputRootCertPath:        config.CertPathForRootOnly,
+				OutputRootCertFetchIssuer: config.CertFetchIssuerForRootOnly,
+				PemChainReversed:          config.PemChainReversed,
+				PemKeyFormat:              pLocal.PrivateKeyFormatType(config.PemKeyFormat),
+				PemKeyPassword:            config.PemKeyPassword,
+				PfxPassword:               config.PfxPassword,
+				JksAlias:                  config.JksAlias,
+				JksKeypass:                config.JksKeypass,
+				JksStorepass:              config.JksStorepass,
+				Encryption:                pGit.EncryptionType(config.Encryption),
+				AgeRecipients:             sliceutil.Filter(strings.Split(config.AgeRecipients, ";"), func(s string) bool { return s != "" }),
+				CommitMessage:             config.CommitMessage,
+				CommitAuthorName:          config.CommitAuthorName,
+				CommitAuthorEmail:         config.CommitAuthorEmail,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeGoEdge,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeGoEdge},
+		func(options *deployerProviderOptions, access domain.AccessConfigForGoEdge, config struct {
+			ResourceType  string `json:"resourceType,omitempty"`
+			CertificateId int64  `json:"certificateId,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pGoEdge.NewDeployer(&pGoEdge.DeployerConfig{
 				ServerUrl:                access.ServerUrl,
 				ApiRole:                  access.ApiRole,
 				AccessKeyId:              access.AccessKeyId,
 				AccessKey:                access.AccessKey,
 				AllowInsecureConnections: access.AllowInsecureConnections,
-				ResourceType:             pGoEdge.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-				CertificateId:            maputil.GetInt64(options.ProviderServiceConfig, "certificateId"),
+				ResourceType:             pGoEdge.ResourceType(config.ResourceType),
+				CertificateId:            config.CertificateId,
 			})
 			return deployer, err
-		}
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeGoogleCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeGoogleCloudCertManager},
+		func(options *deployerProviderOptions, access domain.AccessConfigForGoogleCloud, config struct {
+			Location        string `json:"location,omitempty"`
+			CertificateName string `json:"certificateName,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pGoogleCloudCertManager.NewDeployer(&pGoogleCloudCertManager.DeployerConfig{
+				ServiceAccountKey: access.ServiceAccountKey,
+				ProjectId:         access.ProjectId,
+				Location:          config.Location,
+				CertificateName:   config.CertificateName,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeGoogleCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeGoogleCloudLB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForGoogleCloud, config struct {
+			Region                  string `json:"region,omitempty"`
+			ResourceType            string `json:"resourceType,omitempty"`
+			TargetProxyName         string `json:"targetProxyName,omitempty"`
+			CertificateMapName      string `json:"certificateMapName,omitempty"`
+			CertificateMapEntryName string `json:"certificateMapEntryName,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pGoogleCloudLB.NewDeployer(&pGoogleCloudLB.DeployerConfig{
+				ServiceAccountKey:       access.ServiceAccountKey,
+				ProjectId:               access.ProjectId,
+				Region:                  config.Region,
+				ResourceType:            pGoogleCloudLB.ResourceType(config.ResourceType),
+				TargetProxyName:         config.TargetProxyName,
+				CertificateMapName:      config.CertificateMapName,
+				CertificateMapEntryName: config.CertificateMapEntryName,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeHAProxy,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeHAProxy},
+		func(options *deployerProviderOptions, access domain.AccessConfigForHAProxy, config struct {
+			PersistMode     string `json:"persistMode,omitempty"`
+			PersistAccessId string `json:"persistAccessId,omitempty"`
+			CertPath        string `json:"certPath,omitempty"`
+			Backup          bool   `json:"backup,omitempty"`
+			CertFileMode    string `json:"certFileMode,omitempty"`
+			CertFileOwner   string `json:"certFileOwner,omitempty"`
+			CertFileGroup   string `json:"certFileGroup,omitempty"`
+		}) (deployer.Deployer, error) {
+			// 持久化证书文件，复用本地部署或 SSH 部署，输出为证书链与私钥合并的单文件
+			var persister deployer.Deployer
+			if persistMode := config.PersistMode; persistMode != "" {
+				persistOptions := &deployerProviderOptions{
+					ProviderAccessId:     config.PersistAccessId,
+					ProviderAccessConfig: make(map[string]any),
+					ProviderServiceConfig: map[string]any{
+						"format":        string(pLocal.OUTPUT_FORMAT_PEM_COMBINED),
+						"certPath":      config.CertPath,
+						"backup":        config.Backup,
+						"certFileMode":  config.CertFileMode,
+						"certFileOwner": config.CertFileOwner,
+						"certFileGroup": config.CertFileGroup,
+					},
+				}
 
-	case domain.DeploymentProviderTypeHuaweiCloudCDN, domain.DeploymentProviderTypeHuaweiCloudELB, domain.DeploymentProviderTypeHuaweiCloudSCM, domain.DeploymentProviderTypeHuaweiCloudWAF:
-		{
-			access := domain.AccessConfigForHuaweiCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+				switch persistMode {
+				case "local":
+					persistOptions.Provider = domain.DeploymentProviderTypeLocal
 
-			switch options.Provider {
-			case domain.DeploymentProviderTypeHuaweiCloudCDN:
-				deployer, err := pHuaweiCloudCDN.NewDeployer(&pHuaweiCloudCDN.DeployerConfig{
-					AccessKeyId:         access.AccessKeyId,
-					SecretAccessKey:     access.SecretAccessKey,
-					EnterpriseProjectId: access.EnterpriseProjectId,
-					Region:              maputil.GetString(options.ProviderServiceConfig, "region"),
-					Domain:              maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeHuaweiCloudELB:
-				deployer, err := pHuaweiCloudELB.NewDeployer(&pHuaweiCloudELB.DeployerConfig{
-					AccessKeyId:         access.AccessKeyId,
-					SecretAccessKey:     access.SecretAccessKey,
-					EnterpriseProjectId: access.EnterpriseProjectId,
-					Region:              maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:        pHuaweiCloudELB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					CertificateId:       maputil.GetString(options.ProviderServiceConfig, "certificateId"),
-					LoadbalancerId:      maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerId:          maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeHuaweiCloudSCM:
-				deployer, err := pHuaweiCloudSCM.NewDeployer(&pHuaweiCloudSCM.DeployerConfig{
-					AccessKeyId:         access.AccessKeyId,
-					SecretAccessKey:     access.SecretAccessKey,
-					EnterpriseProjectId: access.EnterpriseProjectId,
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeHuaweiCloudWAF:
-				deployer, err := pHuaweiCloudWAF.NewDeployer(&pHuaweiCloudWAF.DeployerConfig{
-					AccessKeyId:         access.AccessKeyId,
-					SecretAccessKey:     access.SecretAccessKey,
-					EnterpriseProjectId: access.EnterpriseProjectId,
-					Region:              maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:        pHuaweiCloudWAF.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					CertificateId:       maputil.GetString(options.ProviderServiceConfig, "certificateId"),
-					Domain:              maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
+				case "ssh":
+					persistOptions.Provider = domain.DeploymentProviderTypeSSH
+					if persistOptions.ProviderAccessId == "" {
+						return nil, fmt.Errorf("config `persistAccessId` is required when persist mode is '%s'", persistMode)
+					}
 
-	case domain.DeploymentProviderTypeJDCloudALB, domain.DeploymentProviderTypeJDCloudCDN, domain.DeploymentProviderTypeJDCloudLive, domain.DeploymentProviderTypeJDCloudVOD:
-		{
-			access := domain.AccessConfigForJDCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+					persistAccessConfig, err := getAccessConfig(persistOptions.ProviderAccessId)
+					if err != nil {
+						return nil, err
+					}
+					persistOptions.ProviderAccessConfig = persistAccessConfig
 
-			switch options.Provider {
-			case domain.DeploymentProviderTypeJDCloudALB:
-				deployer, err := pJDCloudALB.NewDeployer(&pJDCloudALB.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					RegionId:        maputil.GetString(options.ProviderServiceConfig, "regionId"),
-					ResourceType:    pJDCloudALB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId:  maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerId:      maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeJDCloudCDN:
-				deployer, err := pJDCloudCDN.NewDeployer(&pJDCloudCDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeJDCloudLive:
-				deployer, err := pJDCloudLive.NewDeployer(&pJDCloudLive.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeJDCloudVOD:
-				deployer, err := pJDCloudVOD.NewDeployer(&pJDCloudVOD.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
+				default:
+					return nil, fmt.Errorf("unsupported persist mode '%s'", persistMode)
+				}
 
-	case domain.DeploymentProviderTypeLeCDN:
-		{
-			access := domain.AccessConfigForLeCDN{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
+				p, err := createDeployerProvider(persistOptions)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create persister: %w", err)
+				}
+				persister = p
 			}
 
+			deployer, err := pHAProxy.NewDeployer(&pHAProxy.DeployerConfig{
+				RuntimeApiNetwork: access.RuntimeApiNetwork,
+				RuntimeApiAddress: access.RuntimeApiAddress,
+				CertPath:          config.CertPath,
+				Persister:         persister,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeHuaweiCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeHuaweiCloudCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForHuaweiCloud, config struct {
+			Region string `json:"region,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pHuaweiCloudCDN.NewDeployer(&pHuaweiCloudCDN.DeployerConfig{
+				AccessKeyId:         access.AccessKeyId,
+				SecretAccessKey:     access.SecretAccessKey,
+				EnterpriseProjectId: access.EnterpriseProjectId,
+				Region:              config.Region,
+				Domain:              config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeHuaweiCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeHuaweiCloudELB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForHuaweiCloud, config struct {
+			Region         string `json:"region,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			CertificateId  string `json:"certificateId,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerId     string `json:"listenerId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pHuaweiCloudELB.NewDeployer(&pHuaweiCloudELB.DeployerConfig{
+				AccessKeyId:         access.AccessKeyId,
+				SecretAccessKey:     access.SecretAccessKey,
+				EnterpriseProjectId: access.EnterpriseProjectId,
+				Region:              config.Region,
+				ResourceType:        pHuaweiCloudELB.ResourceType(config.ResourceType),
+				CertificateId:       config.CertificateId,
+				LoadbalancerId:      config.LoadbalancerId,
+				ListenerId:          config.ListenerId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeHuaweiCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeHuaweiCloudSCM},
+		func(options *deployerProviderOptions, access domain.AccessConfigForHuaweiCloud, config struct{}) (deployer.Deployer, error) {
+			deployer, err := pHuaweiCloudSCM.NewDeployer(&pHuaweiCloudSCM.DeployerConfig{
+				AccessKeyId:         access.AccessKeyId,
+				SecretAccessKey:     access.SecretAccessKey,
+				EnterpriseProjectId: access.EnterpriseProjectId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeHuaweiCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeHuaweiCloudWAF},
+		func(options *deployerProviderOptions, access domain.AccessConfigForHuaweiCloud, config struct {
+			Region        string `json:"region,omitempty"`
+			ResourceType  string `json:"resourceType,omitempty"`
+			CertificateId string `json:"certificateId,omitempty"`
+			Domain        string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pHuaweiCloudWAF.NewDeployer(&pHuaweiCloudWAF.DeployerConfig{
+				AccessKeyId:         access.AccessKeyId,
+				SecretAccessKey:     access.SecretAccessKey,
+				EnterpriseProjectId: access.EnterpriseProjectId,
+				Region:              config.Region,
+				ResourceType:        pHuaweiCloudWAF.ResourceType(config.ResourceType),
+				CertificateId:       config.CertificateId,
+				Domain:              config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeJDCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeJDCloudALB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForJDCloud, config struct {
+			RegionId       string `json:"regionId,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerId     string `json:"listenerId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pJDCloudALB.NewDeployer(&pJDCloudALB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				RegionId:        config.RegionId,
+				ResourceType:    pJDCloudALB.ResourceType(config.ResourceType),
+				LoadbalancerId:  config.LoadbalancerId,
+				ListenerId:      config.ListenerId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeJDCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeJDCloudCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForJDCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pJDCloudCDN.NewDeployer(&pJDCloudCDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeJDCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeJDCloudLive},
+		func(options *deployerProviderOptions, access domain.AccessConfigForJDCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pJDCloudLive.NewDeployer(&pJDCloudLive.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeJDCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeJDCloudVOD},
+		func(options *deployerProviderOptions, access domain.AccessConfigForJDCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pJDCloudVOD.NewDeployer(&pJDCloudVOD.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeLeCDN,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeLeCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForLeCDN, config struct {
+			ResourceType  string `json:"resourceType,omitempty"`
+			CertificateId int64  `json:"certificateId,omitempty"`
+			ClientId      int64  `json:"clientId,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pLeCDN.NewDeployer(&pLeCDN.DeployerConfig{
 				ServerUrl:                access.ServerUrl,
 				ApiVersion:               access.ApiVersion,
@@ -897,557 +1711,1041 @@ func createDeployerProvider(options *deployerProviderOptions) (deployer.Deployer
 				Username:                 access.Username,
 				Password:                 access.Password,
 				AllowInsecureConnections: access.AllowInsecureConnections,
-				ResourceType:             pLeCDN.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-				CertificateId:            maputil.GetInt64(options.ProviderServiceConfig, "certificateId"),
-				ClientId:                 maputil.GetInt64(options.ProviderServiceConfig, "clientId"),
-			})
-			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeLocal:
-		{
-			deployer, err := pLocal.NewDeployer(&pLocal.DeployerConfig{
-				ShellEnv:                 pLocal.ShellEnvType(maputil.GetString(options.ProviderServiceConfig, "shellEnv")),
-				PreCommand:               maputil.GetString(options.ProviderServiceConfig, "preCommand"),
-				PostCommand:              maputil.GetString(options.ProviderServiceConfig, "postCommand"),
-				OutputFormat:             pLocal.OutputFormatType(maputil.GetOrDefaultString(options.ProviderServiceConfig, "format", string(pLocal.OUTPUT_FORMAT_PEM))),
-				OutputCertPath:           maputil.GetString(options.ProviderServiceConfig, "certPath"),
-				OutputServerCertPath:     maputil.GetString(options.ProviderServiceConfig, "certPathForServerOnly"),
-				OutputIntermediaCertPath: maputil.GetString(options.ProviderServiceConfig, "certPathForIntermediaOnly"),
-				OutputKeyPath:            maputil.GetString(options.ProviderServiceConfig, "keyPath"),
-				PfxPassword:              maputil.GetString(options.ProviderServiceConfig, "pfxPassword"),
-				JksAlias:                 maputil.GetString(options.ProviderServiceConfig, "jksAlias"),
-				JksKeypass:               maputil.GetString(options.ProviderServiceConfig, "jksKeypass"),
-				JksStorepass:             maputil.GetString(options.ProviderServiceConfig, "jksStorepass"),
-			})
-			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeKubernetesSecret:
-		{
-			access := domain.AccessConfigForKubernetes{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+				ResourceType:             pLeCDN.ResourceType(config.ResourceType),
+				CertificateId:            config.CertificateId,
+				ClientId:                 config.ClientId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeLocal,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeLocal},
+		func(options *deployerProviderOptions, access struct{}, config localDeployerConfig) (deployer.Deployer, error) {
+			deployer, err := pLocal.NewDeployer(buildLocalDeployerConfig(config))
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeKubernetes,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeKubernetesSecret},
+		func(options *deployerProviderOptions, access domain.AccessConfigForKubernetes, config struct {
+			Namespace           string `json:"namespace,omitempty"`
+			SecretName          string `json:"secretName,omitempty"`
+			SecretLabelSelector string `json:"secretLabelSelector,omitempty"`
+			SecretNamespaces    string `json:"secretNamespaces,omitempty"`
+			SecretType          string `json:"secretType,omitempty"`
+			SecretDataKeyForCrt string `json:"secretDataKeyForCrt,omitempty"`
+			SecretDataKeyForKey string `json:"secretDataKeyForKey,omitempty"`
+			PatchIngresses      bool   `json:"patchIngresses,omitempty"`
+			PatchGateways       bool   `json:"patchGateways,omitempty"`
+			RolloutRestart      bool   `json:"rolloutRestart,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pK8sSecret.NewDeployer(&pK8sSecret.DeployerConfig{
-				KubeConfig:          access.KubeConfig,
-				Namespace:           maputil.GetOrDefaultString(options.ProviderServiceConfig, "namespace", "default"),
-				SecretName:          maputil.GetString(options.ProviderServiceConfig, "secretName"),
-				SecretType:          maputil.GetOrDefaultString(options.ProviderServiceConfig, "secretType", "kubernetes.io/tls"),
-				SecretDataKeyForCrt: maputil.GetOrDefaultString(options.ProviderServiceConfig, "secretDataKeyForCrt", "tls.crt"),
-				SecretDataKeyForKey: maputil.GetOrDefaultString(options.ProviderServiceConfig, "secretDataKeyForKey", "tls.key"),
+				AuthMethod:               pK8sSecret.AuthMethodType(access.AuthMethod),
+				KubeConfig:               access.KubeConfig,
+				ApiServer:                access.ApiServer,
+				Token:                    access.Token,
+				CaCertificate:            access.CaCertificate,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				Namespace:                cmp.Or(config.Namespace, "default"),
+				SecretName:               config.SecretName,
+				SecretLabelSelector:      config.SecretLabelSelector,
+				SecretNamespaces:         sliceutil.Filter(strings.Split(config.SecretNamespaces, ";"), func(s string) bool { return s != "" }),
+				SecretType:               cmp.Or(config.SecretType, "kubernetes.io/tls"),
+				SecretDataKeyForCrt:      cmp.Or(config.SecretDataKeyForCrt, "tls.crt"),
+				SecretDataKeyForKey:      cmp.Or(config.SecretDataKeyForKey, "tls.key"),
+				PatchIngresses:           config.PatchIngresses,
+				PatchGateways:            config.PatchGateways,
+				RolloutRestart:           config.RolloutRestart,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeNetlifySite:
-		{
-			access := domain.AccessConfigForNetlify{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeMikroTik,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeMikroTik},
+		func(options *deployerProviderOptions, access domain.AccessConfigForMikroTik, config struct {
+			Services string `json:"services,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pMikroTik.NewDeployer(&pMikroTik.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				Username:                 access.Username,
+				Password:                 access.Password,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				Services:                 sliceutil.Filter(strings.Split(config.Services, ";"), func(s string) bool { return s != "" }),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeNetlify,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeNetlifySite},
+		func(options *deployerProviderOptions, access domain.AccessConfigForNetlify, config struct {
+			SiteId string `json:"siteId,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pNetlifySite.NewDeployer(&pNetlifySite.DeployerConfig{
 				ApiToken: access.ApiToken,
-				SiteId:   maputil.GetString(options.ProviderServiceConfig, "siteId"),
+				SiteId:   config.SiteId,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeProxmoxVE:
-		{
-			access := domain.AccessConfigForProxmoxVE{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeNginxProxyManager,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeNginxProxyManager},
+		func(options *deployerProviderOptions, access domain.AccessConfigForNginxProxyManager, config struct {
+			CertificateId      int64  `json:"certificateId,omitempty"`
+			CertificateName    string `json:"certificateName,omitempty"`
+			AttachToProxyHosts bool   `json:"attachToProxyHosts,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pNginxProxyManager.NewDeployer(&pNginxProxyManager.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				Email:                    access.Email,
+				Password:                 access.Password,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				CertificateId:            config.CertificateId,
+				CertificateName:          config.CertificateName,
+				AttachToProxyHosts:       config.AttachToProxyHosts,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeOPNsense,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeOPNsense},
+		func(options *deployerProviderOptions, access domain.AccessConfigForOPNsense, config struct {
+			CertificateUuid        string `json:"certificateUuid,omitempty"`
+			CertificateDescription string `json:"certificateDescription,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pOPNsense.NewDeployer(&pOPNsense.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				ApiKey:                   access.ApiKey,
+				ApiSecret:                access.ApiSecret,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				CertificateUuid:          config.CertificateUuid,
+				CertificateDescription:   config.CertificateDescription,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypePfSense,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypePfSense},
+		func(options *deployerProviderOptions, access domain.AccessConfigForPfSense, config struct {
+			CertificateDescription string `json:"certificateDescription,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pPfSense.NewDeployer(&pPfSense.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				ApiKey:                   access.ApiKey,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				CertificateDescription:   config.CertificateDescription,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypePlugin,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypePlugin},
+		func(options *deployerProviderOptions, access domain.AccessConfigForPlugin, config struct{}) (deployer.Deployer, error) {
+			client, err := plugin.GetClient(context.Background(), access.PluginName)
+			if err != nil {
+				return nil, err
 			}
 
+			deployer, err := pPlugin.NewDeployer(&pPlugin.DeployerConfig{
+				PluginName:    access.PluginName,
+				AccessConfig:  options.ProviderAccessConfig,
+				ServiceConfig: options.ProviderServiceConfig,
+				Client:        client,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeProxmoxVE,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeProxmoxVE},
+		func(options *deployerProviderOptions, access domain.AccessConfigForProxmoxVE, config struct {
+			NodeName    string `json:"nodeName,omitempty"`
+			AutoRestart bool   `json:"autoRestart,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pProxmoxVE.NewDeployer(&pProxmoxVE.DeployerConfig{
 				ServerUrl:                access.ServerUrl,
 				ApiToken:                 access.ApiToken,
 				ApiTokenSecret:           access.ApiTokenSecret,
 				AllowInsecureConnections: access.AllowInsecureConnections,
-				NodeName:                 maputil.GetString(options.ProviderServiceConfig, "nodeName"),
-				AutoRestart:              maputil.GetBool(options.ProviderServiceConfig, "autoRestart"),
+				NodeName:                 config.NodeName,
+				AutoRestart:              config.AutoRestart,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeQiniuCDN, domain.DeploymentProviderTypeQiniuKodo, domain.DeploymentProviderTypeQiniuPili:
-		{
-			access := domain.AccessConfigForQiniu{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeQiniuCDN, domain.DeploymentProviderTypeQiniuKodo:
-				deployer, err := pQiniuCDN.NewDeployer(&pQiniuCDN.DeployerConfig{
-					AccessKey: access.AccessKey,
-					SecretKey: access.SecretKey,
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeQiniuPili:
-				deployer, err := pQiniuPili.NewDeployer(&pQiniuPili.DeployerConfig{
-					AccessKey: access.AccessKey,
-					SecretKey: access.SecretKey,
-					Hub:       maputil.GetString(options.ProviderServiceConfig, "hub"),
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeRainYunRCDN:
-		{
-			access := domain.AccessConfigForRainYun{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeTencentCloudCDN:
-				deployer, err := pRainYunRCDN.NewDeployer(&pRainYunRCDN.DeployerConfig{
-					ApiKey:     access.ApiKey,
-					InstanceId: maputil.GetInt32(options.ProviderServiceConfig, "instanceId"),
-					Domain:     maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeRatPanelConsole, domain.DeploymentProviderTypeRatPanelSite:
-		{
-			access := domain.AccessConfigForRatPanel{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeRatPanelConsole:
-				deployer, err := pRatPanelConsole.NewDeployer(&pRatPanelConsole.DeployerConfig{
-					ServerUrl:                access.ServerUrl,
-					AccessTokenId:            access.AccessTokenId,
-					AccessToken:              access.AccessToken,
-					AllowInsecureConnections: access.AllowInsecureConnections,
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeRatPanelSite:
-				deployer, err := pRatPanelSite.NewDeployer(&pRatPanelSite.DeployerConfig{
-					ServerUrl:                access.ServerUrl,
-					AccessTokenId:            access.AccessTokenId,
-					AccessToken:              access.AccessToken,
-					AllowInsecureConnections: access.AllowInsecureConnections,
-					SiteName:                 maputil.GetString(options.ProviderServiceConfig, "siteName"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeSafeLine:
-		{
-			access := domain.AccessConfigForSafeLine{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeQiniu,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeQiniuCDN, domain.DeploymentProviderTypeQiniuKodo},
+		func(options *deployerProviderOptions, access domain.AccessConfigForQiniu, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pQiniuCDN.NewDeployer(&pQiniuCDN.DeployerConfig{
+				AccessKey: access.AccessKey,
+				SecretKey: access.SecretKey,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeQiniu,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeQiniuPili},
+		func(options *deployerProviderOptions, access domain.AccessConfigForQiniu, config struct {
+			Hub    string `json:"hub,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pQiniuPili.NewDeployer(&pQiniuPili.DeployerConfig{
+				AccessKey: access.AccessKey,
+				SecretKey: access.SecretKey,
+				Hub:       config.Hub,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeRainYun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeRainYunRCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForRainYun, config struct {
+			InstanceId int32  `json:"instanceId,omitempty"`
+			Domain     string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pRainYunRCDN.NewDeployer(&pRainYunRCDN.DeployerConfig{
+				ApiKey:     access.ApiKey,
+				InstanceId: config.InstanceId,
+				Domain:     config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeRatPanel,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeRatPanelConsole},
+		func(options *deployerProviderOptions, access domain.AccessConfigForRatPanel, config struct{}) (deployer.Deployer, error) {
+			deployer, err := pRatPanelConsole.NewDeployer(&pRatPanelConsole.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				AccessTokenId:            access.AccessTokenId,
+				AccessToken:              access.AccessToken,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeRatPanel,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeRatPanelSite},
+		func(options *deployerProviderOptions, access domain.AccessConfigForRatPanel, config struct {
+			SiteName string `json:"siteName,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pRatPanelSite.NewDeployer(&pRatPanelSite.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				AccessTokenId:            access.AccessTokenId,
+				AccessToken:              access.AccessToken,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				SiteName:                 config.SiteName,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeS3,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeS3},
+		func(options *deployerProviderOptions, access domain.AccessConfigForS3, config struct {
+			Bucket                         string `json:"bucket,omitempty"`
+			Format                         string `json:"format,omitempty"`
+			CertObjectKey                  string `json:"certObjectKey,omitempty"`
+			CertObjectKeyForServerOnly     string `json:"certObjectKeyForServerOnly,omitempty"`
+			CertObjectKeyForIntermediaOnly string `json:"certObjectKeyForIntermediaOnly,omitempty"`
+			KeyObjectKey                   string `json:"keyObjectKey,omitempty"`
+			CertObjectKeyForRootOnly       string `json:"certObjectKeyForRootOnly,omitempty"`
+			CertFetchIssuerForRootOnly     bool   `json:"certFetchIssuerForRootOnly,omitempty"`
+			PemChainReversed               bool   `json:"pemChainReversed,omitempty"`
+			PemKeyFormat                   string `json:"pemKeyFormat,omitempty"`
+			PemKeyPassword                 string `json:"pemKeyPassword,omitempty"`
+			PfxPassword                    string `json:"pfxPassword,omitempty"`
+			JksAlias                       string `json:"jksAlias,omitempty"`
+			JksKeypass                     string `json:"jksKeypass,omitempty"`
+			JksStorepass                   string `json:"jksStorepass,omitempty"`
+			ServerSideEncryption           string `json:"serverSideEncryption,omitempty"`
+			SseKmsKeyId                    string `json:"sseKmsKeyId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pS3.NewDeployer(&pS3.DeployerConfig{
+				Endpoint:                      access.Endpoint,
+				Region:                        access.Region,
+				AccessKeyId:                   access.AccessKeyId,
+				SecretAccessKey:               access.SecretAccessKey,
+				UsePathStyle:                  access.UsePathStyle,
+				AllowInsecureConnections:      access.AllowInsecureConnections,
+				Bucket:                        config.Bucket,
+				OutputFormat:                  pLocal.OutputFormatType(cmp.Or(config.Format, string(pLocal.OUTPUT_FORMAT_PEM))),
+				OutputCertObjectKey:           config.CertObjectKey,
+				OutputServerCertObjectKey:     config.CertObjectKeyForServerOnly,
+				OutputIntermediaCertObjectKey: config.CertObjectKeyForIntermediaOnly,
+				OutputKeyObjectKey:            config.KeyObjectKey,
+				OutputRootCertObjectKey:       config.CertObjectKeyForRootOnly,
+				OutputRootCertFetchIssuer:     config.CertFetchIssuerForRootOnly,
+				PemChainReversed:              config.PemChainReversed,
+				PemKeyFormat:                  pLocal.PrivateKeyFormatType(config.PemKeyFormat),
+				PemKeyPassword:                config.PemKeyPassword,
+				PfxPassword:                   config.PfxPassword,
+				JksAlias:                      config.JksAlias,
+				JksKeypass:                    config.JksKeypass,
+				JksStorepass:                  config.JksStorepass,
+				ServerSideEncryption:          pS3.ServerSideEncryptionType(config.ServerSideEncryption),
+				SSEKMSKeyId:                   config.SseKmsKeyId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeSafeLine,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeSafeLine},
+		func(options *deployerProviderOptions, access domain.AccessConfigForSafeLine, config struct {
+			ResourceType  string `json:"resourceType,omitempty"`
+			CertificateId int32  `json:"certificateId,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pSafeLine.NewDeployer(&pSafeLine.DeployerConfig{
 				ServerUrl:                access.ServerUrl,
 				ApiToken:                 access.ApiToken,
 				AllowInsecureConnections: access.AllowInsecureConnections,
-				ResourceType:             pSafeLine.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-				CertificateId:            maputil.GetInt32(options.ProviderServiceConfig, "certificateId"),
+				ResourceType:             pSafeLine.ResourceType(config.ResourceType),
+				CertificateId:            config.CertificateId,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeSSH:
-		{
-			access := domain.AccessConfigForSSH{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeSSH,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeSSH},
+		func(options *deployerProviderOptions, access domain.AccessConfigForSSH, config struct {
+			Hosts                          string `json:"hosts,omitempty"`
+			Parallelism                    int32  `json:"parallelism,omitempty"`
+			SuccessThreshold               string `json:"successThreshold,omitempty"`
+			UseSCP                         bool   `json:"useSCP,omitempty"`
+			SftpOnly                       bool   `json:"sftpOnly,omitempty"`
+			PreCommand                     string `json:"preCommand,omitempty"`
+			PostCommand                    string `json:"postCommand,omitempty"`
+			Format                         string `json:"format,omitempty"`
+			CertPath                       string `json:"certPath,omitempty"`
+			CertPathForServerOnly          string `json:"certPathForServerOnly,omitempty"`
+			CertPathForIntermediaOnly      string `json:"certPathForIntermediaOnly,omitempty"`
+			KeyPath                        string `json:"keyPath,omitempty"`
+			CertPathForRootOnly            string `json:"certPathForRootOnly,omitempty"`
+			CertFetchIssuerForRootOnly     bool   `json:"certFetchIssuerForRootOnly,omitempty"`
+			PemChainReversed               bool   `json:"pemChainReversed,omitempty"`
+			PemKeyFormat                   string `json:"pemKeyFormat,omitempty"`
+			PemKeyPassword                 string `json:"pemKeyPassword,omitempty"`
+			PfxPassword                    string `json:"pfxPassword,omitempty"`
+			JksAlias                       string `json:"jksAlias,omitempty"`
+			JksKeypass                     string `json:"jksKeypass,omitempty"`
+			JksStorepass                   string `json:"jksStorepass,omitempty"`
+			Backup                         bool   `json:"backup,omitempty"`
+			CertFileMode                   string `json:"certFileMode,omitempty"`
+			CertFileOwner                  string `json:"certFileOwner,omitempty"`
+			CertFileGroup                  string `json:"certFileGroup,omitempty"`
+			CertFileModeForServerOnly      string `json:"certFileModeForServerOnly,omitempty"`
+			CertFileOwnerForServerOnly     string `json:"certFileOwnerForServerOnly,omitempty"`
+			CertFileGroupForServerOnly     string `json:"certFileGroupForServerOnly,omitempty"`
+			CertFileModeForIntermediaOnly  string `json:"certFileModeForIntermediaOnly,omitempty"`
+			CertFileOwnerForIntermediaOnly string `json:"certFileOwnerForIntermediaOnly,omitempty"`
+			CertFileGroupForIntermediaOnly string `json:"certFileGroupForIntermediaOnly,omitempty"`
+			KeyFileMode                    string `json:"keyFileMode,omitempty"`
+			KeyFileOwner                   string `json:"keyFileOwner,omitempty"`
+			KeyFileGroup                   string `json:"keyFileGroup,omitempty"`
+			CertFileModeForRootOnly        string `json:"certFileModeForRootOnly,omitempty"`
+			CertFileOwnerForRootOnly       string `json:"certFileOwnerForRootOnly,omitempty"`
+			CertFileGroupForRootOnly       string `json:"certFileGroupForRootOnly,omitempty"`
+		}) (deployer.Deployer, error) {
 			jumpServers := make([]pSSH.JumpServerConfig, len(access.JumpServers))
 			for i, jumpServer := range access.JumpServers {
 				jumpServers[i] = pSSH.JumpServerConfig{
-					SshHost:          jumpServer.Host,
-					SshPort:          jumpServer.Port,
-					SshAuthMethod:    jumpServer.AuthMethod,
-					SshUsername:      jumpServer.Username,
-					SshPassword:      jumpServer.Password,
-					SshKey:           jumpServer.Key,
-					SshKeyPassphrase: jumpServer.KeyPassphrase,
+					SshHost:                jumpServer.Host,
+					SshPort:                jumpServer.Port,
+					SshAuthMethod:          jumpServer.AuthMethod,
+					SshUsername:            jumpServer.Username,
+					SshPassword:            jumpServer.Password,
+					SshKey:                 jumpServer.Key,
+					SshKeyPassphrase:       jumpServer.KeyPassphrase,
+					SshKeyCertificate:      jumpServer.KeyCertificate,
+					SshAgentSocket:         jumpServer.AgentSocket,
+					SshHostKeyFingerprints: jumpServer.HostKeyFingerprints,
 				}
 			}
 
-			deployer, err := pSSH.NewDeployer(&pSSH.DeployerConfig{
-				SshHost:                  access.Host,
-				SshPort:                  access.Port,
-				SshAuthMethod:            access.AuthMethod,
-				SshUsername:              access.Username,
-				SshPassword:              access.Password,
-				SshKey:                   access.Key,
-				SshKeyPassphrase:         access.KeyPassphrase,
-				JumpServers:              jumpServers,
-				UseSCP:                   maputil.GetBool(options.ProviderServiceConfig, "useSCP"),
-				PreCommand:               maputil.GetString(options.ProviderServiceConfig, "preCommand"),
-				PostCommand:              maputil.GetString(options.ProviderServiceConfig, "postCommand"),
-				OutputFormat:             pSSH.OutputFormatType(maputil.GetOrDefaultString(options.ProviderServiceConfig, "format", string(pSSH.OUTPUT_FORMAT_PEM))),
-				OutputCertPath:           maputil.GetString(options.ProviderServiceConfig, "certPath"),
-				OutputServerCertPath:     maputil.GetString(options.ProviderServiceConfig, "certPathForServerOnly"),
-				OutputIntermediaCertPath: maputil.GetString(options.ProviderServiceConfig, "certPathForIntermediaOnly"),
-				OutputKeyPath:            maputil.GetString(options.ProviderServiceConfig, "keyPath"),
-				PfxPassword:              maputil.GetString(options.ProviderServiceConfig, "pfxPassword"),
-				JksAlias:                 maputil.GetString(options.ProviderServiceConfig, "jksAlias"),
-				JksKeypass:               maputil.GetString(options.ProviderServiceConfig, "jksKeypass"),
-				JksStorepass:             maputil.GetString(options.ProviderServiceConfig, "jksStorepass"),
-			})
-			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeTencentCloudCDN, domain.DeploymentProviderTypeTencentCloudCLB, domain.DeploymentProviderTypeTencentCloudCOS, domain.DeploymentProviderTypeTencentCloudCSS, domain.DeploymentProviderTypeTencentCloudECDN, domain.DeploymentProviderTypeTencentCloudEO, domain.DeploymentProviderTypeTencentCloudGAAP, domain.DeploymentProviderTypeTencentCloudSCF, domain.DeploymentProviderTypeTencentCloudSSL, domain.DeploymentProviderTypeTencentCloudSSLDeploy, domain.DeploymentProviderTypeTencentCloudVOD, domain.DeploymentProviderTypeTencentCloudWAF:
-		{
-			access := domain.AccessConfigForTencentCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeTencentCloudCDN:
-				deployer, err := pTencentCloudCDN.NewDeployer(&pTencentCloudCDN.DeployerConfig{
-					SecretId:  access.SecretId,
-					SecretKey: access.SecretKey,
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudCLB:
-				deployer, err := pTencentCloudCLB.NewDeployer(&pTencentCloudCLB.DeployerConfig{
-					SecretId:       access.SecretId,
-					SecretKey:      access.SecretKey,
-					Region:         maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:   pTencentCloudCLB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId: maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerId:     maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-					Domain:         maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudCOS:
-				deployer, err := pTencentCloudCOS.NewDeployer(&pTencentCloudCOS.DeployerConfig{
-					SecretId:  access.SecretId,
-					SecretKey: access.SecretKey,
-					Region:    maputil.GetString(options.ProviderServiceConfig, "region"),
-					Bucket:    maputil.GetString(options.ProviderServiceConfig, "bucket"),
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudCSS:
-				deployer, err := pTencentCloudCSS.NewDeployer(&pTencentCloudCSS.DeployerConfig{
-					SecretId:  access.SecretId,
-					SecretKey: access.SecretKey,
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudECDN:
-				deployer, err := pTencentCloudECDN.NewDeployer(&pTencentCloudECDN.DeployerConfig{
-					SecretId:  access.SecretId,
-					SecretKey: access.SecretKey,
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudEO:
-				deployer, err := pTencentCloudEO.NewDeployer(&pTencentCloudEO.DeployerConfig{
-					SecretId:  access.SecretId,
-					SecretKey: access.SecretKey,
-					ZoneId:    maputil.GetString(options.ProviderServiceConfig, "zoneId"),
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudGAAP:
-				deployer, err := pTencentCloudGAAP.NewDeployer(&pTencentCloudGAAP.DeployerConfig{
-					SecretId:     access.SecretId,
-					SecretKey:    access.SecretKey,
-					ResourceType: pTencentCloudGAAP.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					ProxyId:      maputil.GetString(options.ProviderServiceConfig, "proxyId"),
-					ListenerId:   maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudSCF:
-				deployer, err := pTencentCloudSCF.NewDeployer(&pTencentCloudSCF.DeployerConfig{
-					SecretId:  access.SecretId,
-					SecretKey: access.SecretKey,
-					Region:    maputil.GetString(options.ProviderServiceConfig, "region"),
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudSSL:
-				deployer, err := pTencentCloudSSL.NewDeployer(&pTencentCloudSSL.DeployerConfig{
-					SecretId:  access.SecretId,
-					SecretKey: access.SecretKey,
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudSSLDeploy:
-				deployer, err := pTencentCloudSSLDeploy.NewDeployer(&pTencentCloudSSLDeploy.DeployerConfig{
-					SecretId:     access.SecretId,
-					SecretKey:    access.SecretKey,
-					Region:       maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType: maputil.GetString(options.ProviderServiceConfig, "resourceType"),
-					ResourceIds:  sliceutil.Filter(strings.Split(maputil.GetString(options.ProviderServiceConfig, "resourceIds"), ";"), func(s string) bool { return s != "" }),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudVOD:
-				deployer, err := pTencentCloudVOD.NewDeployer(&pTencentCloudVOD.DeployerConfig{
-					SecretId:  access.SecretId,
-					SecretKey: access.SecretKey,
-					SubAppId:  maputil.GetInt64(options.ProviderServiceConfig, "subAppId"),
-					Domain:    maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeTencentCloudWAF:
-				deployer, err := pTencentCloudWAF.NewDeployer(&pTencentCloudWAF.DeployerConfig{
-					SecretId:   access.SecretId,
-					SecretKey:  access.SecretKey,
-					Domain:     maputil.GetString(options.ProviderServiceConfig, "domain"),
-					DomainId:   maputil.GetString(options.ProviderServiceConfig, "domainId"),
-					InstanceId: maputil.GetString(options.ProviderServiceConfig, "instanceId"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeUCloudUCDN, domain.DeploymentProviderTypeUCloudUS3:
-		{
-			access := domain.AccessConfigForUCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeUCloudUCDN:
-				deployer, err := pUCloudUCDN.NewDeployer(&pUCloudUCDN.DeployerConfig{
-					PrivateKey: access.PrivateKey,
-					PublicKey:  access.PublicKey,
-					ProjectId:  access.ProjectId,
-					DomainId:   maputil.GetString(options.ProviderServiceConfig, "domainId"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeUCloudUS3:
-				deployer, err := pUCloudUS3.NewDeployer(&pUCloudUS3.DeployerConfig{
-					PrivateKey: access.PrivateKey,
-					PublicKey:  access.PublicKey,
-					ProjectId:  access.ProjectId,
-					Region:     maputil.GetString(options.ProviderServiceConfig, "region"),
-					Bucket:     maputil.GetString(options.ProviderServiceConfig, "bucket"),
-					Domain:     maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeUniCloudWebHost:
-		{
-			access := domain.AccessConfigForUniCloud{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
+			// 优先使用节点配置中的主机列表，其次使用授权中的主机清单
+			hosts := sliceutil.Filter(strings.Split(config.Hosts, ";"), func(s string) bool { return strings.TrimSpace(s) != "" })
+			if len(hosts) == 0 {
+				hosts = access.Hosts
 			}
 
+			deployer, err := pSSH.NewDeployer(&pSSH.DeployerConfig{
+				SshHost:                access.Host,
+				SshPort:                access.Port,
+				SshHosts:               hosts,
+				Parallelism:            int(config.Parallelism),
+				SuccessThreshold:       config.SuccessThreshold,
+				SucceededHosts:         options.SucceededHosts,
+				SshAuthMethod:          access.AuthMethod,
+				SshUsername:            access.Username,
+				SshPassword:            access.Password,
+				SshKey:                 access.Key,
+				SshKeyPassphrase:       access.KeyPassphrase,
+				SshKeyCertificate:      access.KeyCertificate,
+				SshAgentSocket:         access.AgentSocket,
+				SshHostKeyFingerprints: access.HostKeyFingerprints,
+				HostKeyVerification:    pSSH.HostKeyVerificationType(access.HostKeyVerification),
+				KnownHosts:             access.KnownHosts,
+				OnKnownHostsUpdated: func(knownHosts string) error {
+					return updateAccessConfig(options.ProviderAccessId, "knownHosts", knownHosts)
+				},
+				JumpServers:               jumpServers,
+				UseSCP:                    config.UseSCP,
+				SftpOnly:                  config.SftpOnly,
+				PreCommand:                config.PreCommand,
+				PostCommand:               config.PostCommand,
+				OutputFormat:              pSSH.OutputFormatType(cmp.Or(config.Format, string(pSSH.OUTPUT_FORMAT_PEM))),
+				OutputCertPath:            config.CertPath,
+				OutputServerCertPath:      config.CertPathForServerOnly,
+				OutputIntermediaCertPath:  config.CertPathForIntermediaOnly,
+				OutputKeyPath:             config.KeyPath,
+				OutputRootCertPath:        config.CertPathForRootOnly,
+				OutputRootCertFetchIssuer: config.CertFetchIssuerForRootOnly,
+				PemChainReversed:          config.PemChainReversed,
+				PemKeyFormat:              pSSH.PrivateKeyFormatType(config.PemKeyFormat),
+				PemKeyPassword:            config.PemKeyPassword,
+				PfxPassword:               config.PfxPassword,
+				JksAlias:                  config.JksAlias,
+				JksKeypass:                config.JksKeypass,
+				JksStorepass:              config.JksStorepass,
+				OutputBackup:              config.Backup,
+				OutputCertFileAttrs: pSSH.FileAttrs{
+					Mode:  config.CertFileMode,
+					Owner: config.CertFileOwner,
+					Group: config.CertFileGroup,
+				},
+				OutputServerCertFileAttrs: pSSH.FileAttrs{
+					Mode:  config.CertFileModeForServerOnly,
+					Owner: config.CertFileOwnerForServerOnly,
+					Group: config.CertFileGroupForServerOnly,
+				},
+				OutputIntermediaCertFileAttrs: pSSH.FileAttrs{
+					Mode:  config.CertFileModeForIntermediaOnly,
+					Owner: config.CertFileOwnerForIntermediaOnly,
+					Group: config.CertFileGroupForIntermediaOnly,
+				},
+				OutputKeyFileAttrs: pSSH.FileAttrs{
+					Mode:  config.KeyFileMode,
+					Owner: config.KeyFileOwner,
+					Group: config.KeyFileGroup,
+				},
+				OutputRootCertFileAttrs: pSSH.FileAttrs{
+					Mode:  config.CertFileModeForRootOnly,
+					Owner: config.CertFileOwnerForRootOnly,
+					Group: config.CertFileGroupForRootOnly,
+				},
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeSynology,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeSynologyDSM},
+		func(options *deployerProviderOptions, access domain.AccessConfigForSynology, config struct {
+			CertificateId          string `json:"certificateId,omitempty"`
+			CertificateDescription string `json:"certificateDescription,omitempty"`
+			SetAsDefault           bool   `json:"setAsDefault,omitempty"`
+			BindServices           string `json:"bindServices,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pSynologyDSM.NewDeployer(&pSynologyDSM.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				Username:                 access.Username,
+				Password:                 access.Password,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				CertificateId:            config.CertificateId,
+				CertificateDescription:   config.CertificateDescription,
+				SetAsDefault:             config.SetAsDefault,
+				BindServices:             sliceutil.Filter(strings.Split(config.BindServices, ";"), func(s string) bool { return s != "" }),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudCDN.NewDeployer(&pTencentCloudCDN.DeployerConfig{
+				SecretId:  access.SecretId,
+				SecretKey: access.SecretKey,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudCLB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			Region         string `json:"region,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerId     string `json:"listenerId,omitempty"`
+			Domain         string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudCLB.NewDeployer(&pTencentCloudCLB.DeployerConfig{
+				SecretId:       access.SecretId,
+				SecretKey:      access.SecretKey,
+				Region:         config.Region,
+				ResourceType:   pTencentCloudCLB.ResourceType(config.ResourceType),
+				LoadbalancerId: config.LoadbalancerId,
+				ListenerId:     config.ListenerId,
+				Domain:         config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudCOS},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			Region string `json:"region,omitempty"`
+			Bucket string `json:"bucket,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudCOS.NewDeployer(&pTencentCloudCOS.DeployerConfig{
+				SecretId:  access.SecretId,
+				SecretKey: access.SecretKey,
+				Region:    config.Region,
+				Bucket:    config.Bucket,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudCSS},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudCSS.NewDeployer(&pTencentCloudCSS.DeployerConfig{
+				SecretId:  access.SecretId,
+				SecretKey: access.SecretKey,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudECDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudECDN.NewDeployer(&pTencentCloudECDN.DeployerConfig{
+				SecretId:  access.SecretId,
+				SecretKey: access.SecretKey,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudEO},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			ZoneId string `json:"zoneId,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudEO.NewDeployer(&pTencentCloudEO.DeployerConfig{
+				SecretId:  access.SecretId,
+				SecretKey: access.SecretKey,
+				ZoneId:    config.ZoneId,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudGAAP},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			ResourceType string `json:"resourceType,omitempty"`
+			ProxyId      string `json:"proxyId,omitempty"`
+			ListenerId   string `json:"listenerId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudGAAP.NewDeployer(&pTencentCloudGAAP.DeployerConfig{
+				SecretId:     access.SecretId,
+				SecretKey:    access.SecretKey,
+				ResourceType: pTencentCloudGAAP.ResourceType(config.ResourceType),
+				ProxyId:      config.ProxyId,
+				ListenerId:   config.ListenerId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudSCF},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			Region string `json:"region,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudSCF.NewDeployer(&pTencentCloudSCF.DeployerConfig{
+				SecretId:  access.SecretId,
+				SecretKey: access.SecretKey,
+				Region:    config.Region,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudSSL},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct{}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudSSL.NewDeployer(&pTencentCloudSSL.DeployerConfig{
+				SecretId:  access.SecretId,
+				SecretKey: access.SecretKey,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudSSLDeploy},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			Region       string `json:"region,omitempty"`
+			ResourceType string `json:"resourceType,omitempty"`
+			ResourceIds  string `json:"resourceIds,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudSSLDeploy.NewDeployer(&pTencentCloudSSLDeploy.DeployerConfig{
+				SecretId:     access.SecretId,
+				SecretKey:    access.SecretKey,
+				Region:       config.Region,
+				ResourceType: config.ResourceType,
+				ResourceIds:  sliceutil.Filter(strings.Split(config.ResourceIds, ";"), func(s string) bool { return s != "" }),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudVOD},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			SubAppId int64  `json:"subAppId,omitempty"`
+			Domain   string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudVOD.NewDeployer(&pTencentCloudVOD.DeployerConfig{
+				SecretId:  access.SecretId,
+				SecretKey: access.SecretKey,
+				SubAppId:  config.SubAppId,
+				Domain:    config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTencentCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTencentCloudWAF},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTencentCloud, config struct {
+			Domain     string `json:"domain,omitempty"`
+			DomainId   string `json:"domainId,omitempty"`
+			InstanceId string `json:"instanceId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTencentCloudWAF.NewDeployer(&pTencentCloudWAF.DeployerConfig{
+				SecretId:   access.SecretId,
+				SecretKey:  access.SecretKey,
+				Domain:     config.Domain,
+				DomainId:   config.DomainId,
+				InstanceId: config.InstanceId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeTrueNAS,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeTrueNASScale},
+		func(options *deployerProviderOptions, access domain.AccessConfigForTrueNAS, config struct {
+			CertificateNamePrefix string `json:"certificateNamePrefix,omitempty"`
+			UpdateUiCertificate   bool   `json:"updateUiCertificate,omitempty"`
+			UpdateApps            bool   `json:"updateApps,omitempty"`
+			AppNames              string `json:"appNames,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pTrueNASScale.NewDeployer(&pTrueNASScale.DeployerConfig{
+				ServerUrl:                access.ServerUrl,
+				ApiKey:                   access.ApiKey,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				CertificateNamePrefix:    config.CertificateNamePrefix,
+				UpdateUiCertificate:      config.UpdateUiCertificate,
+				UpdateApps:               config.UpdateApps,
+				AppNames:                 sliceutil.Filter(strings.Split(config.AppNames, ";"), func(s string) bool { return s != "" }),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeUCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeUCloudUCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForUCloud, config struct {
+			DomainId string `json:"domainId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pUCloudUCDN.NewDeployer(&pUCloudUCDN.DeployerConfig{
+				PrivateKey: access.PrivateKey,
+				PublicKey:  access.PublicKey,
+				ProjectId:  access.ProjectId,
+				DomainId:   config.DomainId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeUCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeUCloudUS3},
+		func(options *deployerProviderOptions, access domain.AccessConfigForUCloud, config struct {
+			Region string `json:"region,omitempty"`
+			Bucket string `json:"bucket,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pUCloudUS3.NewDeployer(&pUCloudUS3.DeployerConfig{
+				PrivateKey: access.PrivateKey,
+				PublicKey:  access.PublicKey,
+				ProjectId:  access.ProjectId,
+				Region:     config.Region,
+				Bucket:     config.Bucket,
+				Domain:     config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeUniCloud,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeUniCloudWebHost},
+		func(options *deployerProviderOptions, access domain.AccessConfigForUniCloud, config struct {
+			SpaceProvider string `json:"spaceProvider,omitempty"`
+			SpaceId       string `json:"spaceId,omitempty"`
+			Domain        string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
 			deployer, err := pUniCloudWebHost.NewDeployer(&pUniCloudWebHost.DeployerConfig{
 				Username:      access.Username,
 				Password:      access.Password,
-				SpaceProvider: maputil.GetString(options.ProviderServiceConfig, "spaceProvider"),
-				SpaceId:       maputil.GetString(options.ProviderServiceConfig, "spaceId"),
-				Domain:        maputil.GetString(options.ProviderServiceConfig, "domain"),
+				SpaceProvider: config.SpaceProvider,
+				SpaceId:       config.SpaceId,
+				Domain:        config.Domain,
 			})
 			return deployer, err
-		}
-
-	case domain.DeploymentProviderTypeUpyunCDN, domain.DeploymentProviderTypeUpyunFile:
-		{
-			access := domain.AccessConfigForUpyun{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeUpyunCDN, domain.DeploymentProviderTypeUpyunFile:
-				deployer, err := pUpyunCDN.NewDeployer(&pUpyunCDN.DeployerConfig{
-					Username: access.Username,
-					Password: access.Password,
-					Domain:   maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeVolcEngineALB, domain.DeploymentProviderTypeVolcEngineCDN, domain.DeploymentProviderTypeVolcEngineCertCenter, domain.DeploymentProviderTypeVolcEngineCLB, domain.DeploymentProviderTypeVolcEngineDCDN, domain.DeploymentProviderTypeVolcEngineImageX, domain.DeploymentProviderTypeVolcEngineLive, domain.DeploymentProviderTypeVolcEngineTOS:
-		{
-			access := domain.AccessConfigForVolcEngine{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeVolcEngineALB:
-				deployer, err := pVolcEngineALB.NewDeployer(&pVolcEngineALB.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.SecretAccessKey,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:    pVolcEngineALB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId:  maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerId:      maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeVolcEngineCDN:
-				deployer, err := pVolcEngineCDN.NewDeployer(&pVolcEngineCDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.SecretAccessKey,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeVolcEngineCertCenter:
-				deployer, err := pVolcEngineCertCenter.NewDeployer(&pVolcEngineCertCenter.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.SecretAccessKey,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeVolcEngineCLB:
-				deployer, err := pVolcEngineCLB.NewDeployer(&pVolcEngineCLB.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.SecretAccessKey,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ResourceType:    pVolcEngineCLB.ResourceType(maputil.GetString(options.ProviderServiceConfig, "resourceType")),
-					LoadbalancerId:  maputil.GetString(options.ProviderServiceConfig, "loadbalancerId"),
-					ListenerId:      maputil.GetString(options.ProviderServiceConfig, "listenerId"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeVolcEngineDCDN:
-				deployer, err := pVolcEngineDCDN.NewDeployer(&pVolcEngineDCDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.SecretAccessKey,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeVolcEngineImageX:
-				deployer, err := pVolcEngineImageX.NewDeployer(&pVolcEngineImageX.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.SecretAccessKey,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					ServiceId:       maputil.GetString(options.ProviderServiceConfig, "serviceId"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeVolcEngineLive:
-				deployer, err := pVolcEngineLive.NewDeployer(&pVolcEngineLive.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.SecretAccessKey,
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeVolcEngineTOS:
-				deployer, err := pVolcEngineTOS.NewDeployer(&pVolcEngineTOS.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.SecretAccessKey,
-					Region:          maputil.GetString(options.ProviderServiceConfig, "region"),
-					Bucket:          maputil.GetString(options.ProviderServiceConfig, "bucket"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeWangsuCDN, domain.DeploymentProviderTypeWangsuCDNPro, domain.DeploymentProviderTypeWangsuCertificate:
-		{
-			access := domain.AccessConfigForWangsu{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
-			switch options.Provider {
-			case domain.DeploymentProviderTypeWangsuCDN:
-				deployer, err := pWangsuCDN.NewDeployer(&pWangsuCDN.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					Domains:         sliceutil.Filter(strings.Split(maputil.GetString(options.ProviderServiceConfig, "domains"), ";"), func(s string) bool { return s != "" }),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeWangsuCDNPro:
-				deployer, err := pWangsuCDNPro.NewDeployer(&pWangsuCDNPro.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					ApiKey:          access.ApiKey,
-					Environment:     maputil.GetOrDefaultString(options.ProviderServiceConfig, "environment", "production"),
-					Domain:          maputil.GetString(options.ProviderServiceConfig, "domain"),
-					CertificateId:   maputil.GetString(options.ProviderServiceConfig, "certificateId"),
-					WebhookId:       maputil.GetString(options.ProviderServiceConfig, "webhookId"),
-				})
-				return deployer, err
-
-			case domain.DeploymentProviderTypeWangsuCertificate:
-				deployer, err := pWangsuCertificate.NewDeployer(&pWangsuCertificate.DeployerConfig{
-					AccessKeyId:     access.AccessKeyId,
-					AccessKeySecret: access.AccessKeySecret,
-					CertificateId:   maputil.GetString(options.ProviderServiceConfig, "certificateId"),
-				})
-				return deployer, err
-
-			default:
-				break
-			}
-		}
-
-	case domain.DeploymentProviderTypeWebhook:
-		{
-			access := domain.AccessConfigForWebhook{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeUpyun,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeUpyunCDN, domain.DeploymentProviderTypeUpyunFile},
+		func(options *deployerProviderOptions, access domain.AccessConfigForUpyun, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pUpyunCDN.NewDeployer(&pUpyunCDN.DeployerConfig{
+				Username: access.Username,
+				Password: access.Password,
+				Domain:   config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeVault,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeVault},
+		func(options *deployerProviderOptions, access domain.AccessConfigForVault, config struct {
+			CustomMetadata        map[string]any `json:"customMetadata,omitempty"`
+			KvMountPath           string         `json:"kvMountPath,omitempty"`
+			SecretPath            string         `json:"secretPath,omitempty"`
+			FieldNameForCert      string         `json:"fieldNameForCert,omitempty"`
+			FieldNameForChain     string         `json:"fieldNameForChain,omitempty"`
+			FieldNameForFullchain string         `json:"fieldNameForFullchain,omitempty"`
+			FieldNameForKey       string         `json:"fieldNameForKey,omitempty"`
+			MaxVersions           int32          `json:"maxVersions,omitempty"`
+			ResourceType          string         `json:"resourceType,omitempty"`
+			PkiMountPath          string         `json:"pkiMountPath,omitempty"`
+			PkiIssuerName         string         `json:"pkiIssuerName,omitempty"`
+			PkiSetDefault         bool           `json:"pkiSetDefault,omitempty"`
+		}) (deployer.Deployer, error) {
+			customMetadata := make(map[string]string)
+			for k, v := range config.CustomMetadata {
+				customMetadata[k] = fmt.Sprintf("%v", v)
 			}
 
+			deployer, err := pVault.NewDeployer(&pVault.DeployerConfig{
+				Address:                  access.Address,
+				Namespace:                access.Namespace,
+				AllowInsecureConnections: access.AllowInsecureConnections,
+				AuthMethod:               pVault.AuthMethodType(access.AuthMethod),
+				Token:                    access.Token,
+				AppRoleMountPath:         access.AppRoleMountPath,
+				AppRoleRoleId:            access.AppRoleRoleId,
+				AppRoleSecretId:          access.AppRoleSecretId,
+				KubernetesMountPath:      access.KubernetesMountPath,
+				KubernetesRole:           access.KubernetesRole,
+				KubernetesJwt:            access.KubernetesJwt,
+				ResourceType:             pVault.ResourceType(cmp.Or(config.ResourceType, string(pVault.RESOURCE_TYPE_KV))),
+				KVMountPath:              cmp.Or(config.KvMountPath, "secret"),
+				SecretPath:               config.SecretPath,
+				FieldNameForCert:         cmp.Or(config.FieldNameForCert, "certificate"),
+				FieldNameForChain:        config.FieldNameForChain,
+				FieldNameForFullchain:    config.FieldNameForFullchain,
+				FieldNameForKey:          cmp.Or(config.FieldNameForKey, "private_key"),
+				MaxVersions:              int64(config.MaxVersions),
+				CustomMetadata:           customMetadata,
+				PKIMountPath:             cmp.Or(config.PkiMountPath, "pki"),
+				PKIIssuerName:            config.PkiIssuerName,
+				PKISetDefault:            config.PkiSetDefault,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeVolcEngine,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeVolcEngineALB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForVolcEngine, config struct {
+			Region         string `json:"region,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerId     string `json:"listenerId,omitempty"`
+			Domain         string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pVolcEngineALB.NewDeployer(&pVolcEngineALB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.SecretAccessKey,
+				Region:          config.Region,
+				ResourceType:    pVolcEngineALB.ResourceType(config.ResourceType),
+				LoadbalancerId:  config.LoadbalancerId,
+				ListenerId:      config.ListenerId,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeVolcEngine,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeVolcEngineCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForVolcEngine, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pVolcEngineCDN.NewDeployer(&pVolcEngineCDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.SecretAccessKey,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeVolcEngine,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeVolcEngineCertCenter},
+		func(options *deployerProviderOptions, access domain.AccessConfigForVolcEngine, config struct {
+			Region string `json:"region,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pVolcEngineCertCenter.NewDeployer(&pVolcEngineCertCenter.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.SecretAccessKey,
+				Region:          config.Region,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeVolcEngine,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeVolcEngineCLB},
+		func(options *deployerProviderOptions, access domain.AccessConfigForVolcEngine, config struct {
+			Region         string `json:"region,omitempty"`
+			ResourceType   string `json:"resourceType,omitempty"`
+			LoadbalancerId string `json:"loadbalancerId,omitempty"`
+			ListenerId     string `json:"listenerId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pVolcEngineCLB.NewDeployer(&pVolcEngineCLB.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.SecretAccessKey,
+				Region:          config.Region,
+				ResourceType:    pVolcEngineCLB.ResourceType(config.ResourceType),
+				LoadbalancerId:  config.LoadbalancerId,
+				ListenerId:      config.ListenerId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeVolcEngine,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeVolcEngineDCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForVolcEngine, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pVolcEngineDCDN.NewDeployer(&pVolcEngineDCDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.SecretAccessKey,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeVolcEngine,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeVolcEngineImageX},
+		func(options *deployerProviderOptions, access domain.AccessConfigForVolcEngine, config struct {
+			Region    string `json:"region,omitempty"`
+			ServiceId string `json:"serviceId,omitempty"`
+			Domain    string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pVolcEngineImageX.NewDeployer(&pVolcEngineImageX.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.SecretAccessKey,
+				Region:          config.Region,
+				ServiceId:       config.ServiceId,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeVolcEngine,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeVolcEngineLive},
+		func(options *deployerProviderOptions, access domain.AccessConfigForVolcEngine, config struct {
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pVolcEngineLive.NewDeployer(&pVolcEngineLive.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.SecretAccessKey,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeVolcEngine,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeVolcEngineTOS},
+		func(options *deployerProviderOptions, access domain.AccessConfigForVolcEngine, config struct {
+			Region string `json:"region,omitempty"`
+			Bucket string `json:"bucket,omitempty"`
+			Domain string `json:"domain,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pVolcEngineTOS.NewDeployer(&pVolcEngineTOS.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.SecretAccessKey,
+				Region:          config.Region,
+				Bucket:          config.Bucket,
+				Domain:          config.Domain,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeWangsu,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeWangsuCDN},
+		func(options *deployerProviderOptions, access domain.AccessConfigForWangsu, config struct {
+			Domains string `json:"domains,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pWangsuCDN.NewDeployer(&pWangsuCDN.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				Domains:         sliceutil.Filter(strings.Split(config.Domains, ";"), func(s string) bool { return s != "" }),
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeWangsu,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeWangsuCDNPro},
+		func(options *deployerProviderOptions, access domain.AccessConfigForWangsu, config struct {
+			Environment   string `json:"environment,omitempty"`
+			Domain        string `json:"domain,omitempty"`
+			CertificateId string `json:"certificateId,omitempty"`
+			WebhookId     string `json:"webhookId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pWangsuCDNPro.NewDeployer(&pWangsuCDNPro.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				ApiKey:          access.ApiKey,
+				Environment:     cmp.Or(config.Environment, "production"),
+				Domain:          config.Domain,
+				CertificateId:   config.CertificateId,
+				WebhookId:       config.WebhookId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeWangsu,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeWangsuCertificate},
+		func(options *deployerProviderOptions, access domain.AccessConfigForWangsu, config struct {
+			CertificateId string `json:"certificateId,omitempty"`
+		}) (deployer.Deployer, error) {
+			deployer, err := pWangsuCertificate.NewDeployer(&pWangsuCertificate.DeployerConfig{
+				AccessKeyId:     access.AccessKeyId,
+				AccessKeySecret: access.AccessKeySecret,
+				CertificateId:   config.CertificateId,
+			})
+			return deployer, err
+		},
+	)
+
+	registerDeployerProvider(
+		domain.AccessProviderTypeWebhook,
+		[]domain.DeploymentProviderType{domain.DeploymentProviderTypeWebhook},
+		func(options *deployerProviderOptions, access domain.AccessConfigForWebhook, config struct {
+			Headers     string `json:"headers,omitempty"`
+			WebhookData string `json:"webhookData,omitempty"`
+		}) (deployer.Deployer, error) {
 			mergedHeaders := make(map[string]string)
 			if defaultHeadersString := access.HeadersString; defaultHeadersString != "" {
 				h, err := httputil.ParseHeaders(defaultHeadersString)
@@ -1458,7 +2756,7 @@ func createDeployerProvider(options *deployerProviderOptions) (deployer.Deployer
 					mergedHeaders[http.CanonicalHeaderKey(key)] = h.Get(key)
 				}
 			}
-			if extendedHeadersString := maputil.GetString(options.ProviderServiceConfig, "headers"); extendedHeadersString != "" {
+			if extendedHeadersString := config.Headers; extendedHeadersString != "" {
 				h, err := httputil.ParseHeaders(extendedHeadersString)
 				if err != nil {
 					return nil, fmt.Errorf("failed to parse webhook headers: %w", err)
@@ -1470,14 +2768,97 @@ func createDeployerProvider(options *deployerProviderOptions) (deployer.Deployer
 
 			deployer, err := pWebhook.NewDeployer(&pWebhook.DeployerConfig{
 				WebhookUrl:               access.Url,
-				WebhookData:              maputil.GetOrDefaultString(options.ProviderServiceConfig, "webhookData", access.DefaultDataForDeployment),
+				WebhookData:              cmp.Or(config.WebhookData, access.DefaultDataForDeployment),
 				Method:                   access.Method,
 				Headers:                  mergedHeaders,
 				AllowInsecureConnections: access.AllowInsecureConnections,
 			})
 			return deployer, err
-		}
-	}
+		},
+	)
+}
 
-	return nil, fmt.Errorf("unsupported deployer provider '%s'", string(options.Provider))
+// localDeployerConfig 是本地部署与代理部署共用的节点配置。
+type localDeployerConfig struct {
+	ShellEnv                       string `json:"shellEnv,omitempty"`
+	PreCommand                     string `json:"preCommand,omitempty"`
+	PostCommand                    string `json:"postCommand,omitempty"`
+	Format                         string `json:"format,omitempty"`
+	CertPath                       string `json:"certPath,omitempty"`
+	CertPathForServerOnly          string `json:"certPathForServerOnly,omitempty"`
+	CertPathForIntermediaOnly      string `json:"certPathForIntermediaOnly,omitempty"`
+	KeyPath                        string `json:"keyPath,omitempty"`
+	CertPathForRootOnly            string `json:"certPathForRootOnly,omitempty"`
+	CertFetchIssuerForRootOnly     bool   `json:"certFetchIssuerForRootOnly,omitempty"`
+	PemChainReversed               bool   `json:"pemChainReversed,omitempty"`
+	PemKeyFormat                   string `json:"pemKeyFormat,omitempty"`
+	PemKeyPassword                 string `json:"pemKeyPassword,omitempty"`
+	PfxPassword                    string `json:"pfxPassword,omitempty"`
+	JksAlias                       string `json:"jksAlias,omitempty"`
+	JksKeypass                     string `json:"jksKeypass,omitempty"`
+	JksStorepass                   string `json:"jksStorepass,omitempty"`
+	Backup                         bool   `json:"backup,omitempty"`
+	CertFileMode                   string `json:"certFileMode,omitempty"`
+	CertFileOwner                  string `json:"certFileOwner,omitempty"`
+	CertFileGroup                  string `json:"certFileGroup,omitempty"`
+	CertFileModeForServerOnly      string `json:"certFileModeForServerOnly,omitempty"`
+	CertFileOwnerForServerOnly     string `json:"certFileOwnerForServerOnly,omitempty"`
+	CertFileGroupForServerOnly     string `json:"certFileGroupForServerOnly,omitempty"`
+	CertFileModeForIntermediaOnly  string `json:"certFileModeForIntermediaOnly,omitempty"`
+	CertFileOwnerForIntermediaOnly string `json:"certFileOwnerForIntermediaOnly,omitempty"`
+	CertFileGroupForIntermediaOnly string `json:"certFileGroupForIntermediaOnly,omitempty"`
+	KeyFileMode                    string `json:"keyFileMode,omitempty"`
+	KeyFileOwner                   string `json:"keyFileOwner,omitempty"`
+	KeyFileGroup                   string `json:"keyFileGroup,omitempty"`
+	CertFileModeForRootOnly        string `json:"certFileModeForRootOnly,omitempty"`
+	CertFileOwnerForRootOnly       string `json:"certFileOwnerForRootOnly,omitempty"`
+	CertFileGroupForRootOnly       string `json:"certFileGroupForRootOnly,omitempty"`
+}
+
+func buildLocalDeployerConfig(config localDeployerConfig) *pLocal.DeployerConfig {
+	return &pLocal.DeployerConfig{
+		ShellEnv:                  pLocal.ShellEnvType(config.ShellEnv),
+		PreCommand:                config.PreCommand,
+		PostCommand:               config.PostCommand,
+		OutputFormat:              pLocal.OutputFormatType(cmp.Or(config.Format, string(pLocal.OUTPUT_FORMAT_PEM))),
+		OutputCertPath:            config.CertPath,
+		OutputServerCertPath:      config.CertPathForServerOnly,
+		OutputIntermediaCertPath:  config.CertPathForIntermediaOnly,
+		OutputKeyPath:             config.KeyPath,
+		OutputRootCertPath:        config.CertPathForRootOnly,
+		OutputRootCertFetchIssuer: config.CertFetchIssuerForRootOnly,
+		PemChainReversed:          config.PemChainReversed,
+		PemKeyFormat:              pLocal.PrivateKeyFormatType(config.PemKeyFormat),
+		PemKeyPassword:            config.PemKeyPassword,
+		PfxPassword:               config.PfxPassword,
+		JksAlias:                  config.JksAlias,
+		JksKeypass:                config.JksKeypass,
+		JksStorepass:              config.JksStorepass,
+		OutputBackup:              config.Backup,
+		OutputCertFileAttrs: pLocal.FileAttrs{
+			Mode:  config.CertFileMode,
+			Owner: config.CertFileOwner,
+			Group: config.CertFileGroup,
+		},
+		OutputServerCertFileAttrs: pLocal.FileAttrs{
+			Mode:  config.CertFileModeForServerOnly,
+			Owner: config.CertFileOwnerForServerOnly,
+			Group: config.CertFileGroupForServerOnly,
+		},
+		OutputIntermediaCertFileAttrs: pLocal.FileAttrs{
+			Mode:  config.CertFileModeForIntermediaOnly,
+			Owner: config.CertFileOwnerForIntermediaOnly,
+			Group: config.CertFileGroupForIntermediaOnly,
+		},
+		OutputKeyFileAttrs: pLocal.FileAttrs{
+			Mode:  config.KeyFileMode,
+			Owner: config.KeyFileOwner,
+			Group: config.KeyFileGroup,
+		},
+		OutputRootCertFileAttrs: pLocal.FileAttrs{
+			Mode:  config.CertFileModeForRootOnly,
+			Owner: config.CertFileOwnerForRootOnly,
+			Group: config.CertFileGroupForRootOnly,
+		},
+	}
 }
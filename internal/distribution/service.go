@@ -0,0 +1,300 @@
+package distribution
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+
+	"github.com/usual2970/certimate/internal/app"
+	"github.com/usual2970/certimate/internal/domain"
+	"github.com/usual2970/certimate/internal/domain/dtos"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+const tokenPrefix = "cmdt_"
+
+// 令牌最近使用时间的更新间隔。客户端轮询频繁，无需每次拉取都写库。
+const tokenLastUsedUpdateInterval = 10 * time.Minute
+
+const (
+	fileFullchain = "fullchain.pem"
+	fileCert      = "cert.pem"
+	fileChain     = "chain.pem"
+	filePrivkey   = "privkey.pem"
+)
+
+type distributionRepository interface {
+	GetByName(ctx context.Context, name string) (*domain.Distribution, error)
+}
+
+type distributionTokenRepository interface {
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.DistributionToken, error)
+	Save(ctx context.Context, token *domain.DistributionToken) (*domain.DistributionToken, error)
+}
+
+type distributionLogRepository interface {
+	Save(ctx context.Context, distributionLog *domain.DistributionLog) (*domain.DistributionLog, error)
+	DeleteWhere(ctx context.Context, exprs ...dbx.Expression) (int, error)
+}
+
+type certificateRepository interface {
+	GetByWorkflowNodeId(ctx context.Context, workflowNodeId string) (*domain.Certificate, error)
+	GetLatestByDomain(ctx context.Context, domainName string) (*domain.Certificate, error)
+}
+
+type settingsRepository interface {
+	GetByName(ctx context.Context, name string) (*domain.Settings, error)
+}
+
+type DistributionService struct {
+	distributionRepo      distributionRepository
+	distributionTokenRepo distributionTokenRepository
+	distributionLogRepo   distributionLogRepository
+	certificateRepo       certificateRepository
+	settingsRepo          settingsRepository
+}
+
+func NewDistributionService(distributionRepo distributionRepository, distributionTokenRepo distributionTokenRepository, distributionLogRepo distributionLogRepository, certificateRepo certificateRepository, settingsRepo settingsRepository) *DistributionService {
+	return &DistributionService{
+		distributionRepo:      distributionRepo,
+		distributionTokenRepo: distributionTokenRepo,
+		distributionLogRepo:   distributionLogRepo,
+		certificateRepo:       certificateRepo,
+		settingsRepo:          settingsRepo,
+	}
+}
+
+func (s *DistributionService) InitSchedule(ctx context.Context) error {
+	// 每日清理证书分发日志
+	app.GetScheduler().MustAdd("distributionLogsCleanup", "0 0 * * *", func() {
+		settings, err := s.settingsRepo.GetByName(ctx, "persistence")
+		if err != nil {
+			app.GetLogger().Error("failed to get persistence settings", "err", err)
+			return
+		}
+
+		var settingsContent *domain.PersistenceSettingsContent
+		json.Unmarshal([]byte(settings.Content), &settingsContent)
+		if settingsContent != nil && settingsContent.DistributionLogsMaxDaysRetention != 0 {
+			ret, err := s.distributionLogRepo.DeleteWhere(
+				context.Background(),
+				dbx.NewExp(fmt.Sprintf("created<DATETIME('now', '-%d days')", settingsContent.DistributionLogsMaxDaysRetention)),
+			)
+			if err != nil {
+				app.GetLogger().Error("failed to delete distribution logs", "err", err)
+			}
+
+			if ret > 0 {
+				app.GetLogger().Info(fmt.Sprintf("cleanup %d distribution logs", ret))
+			}
+		}
+	})
+
+	return nil
+}
+
+// CreateToken 生成一个新的客户端令牌。令牌明文仅在此时返回一次，数据库中只保存其摘要。
+func (s *DistributionService) CreateToken(ctx context.Context, req *dtos.DistributionCreateTokenReq) (*dtos.DistributionCreateTokenResp, error) {
+	if req.Name == "" || len(req.DistributionIds) == 0 {
+		return nil, domain.ErrInvalidParams
+	}
+
+	for _, allowed := range req.AllowedIps {
+		if strings.Contains(allowed, "/") {
+			if _, _, err := net.ParseCIDR(allowed); err != nil {
+				return nil, fmt.Errorf("invalid ip range '%s': %w", allowed, err)
+			}
+		} else if net.ParseIP(allowed) == nil {
+			return nil, fmt.Errorf("invalid ip address '%s'", allowed)
+		}
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	plainToken := tokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	token, err := s.distributionTokenRepo.Save(ctx, &domain.DistributionToken{
+		Name:            req.Name,
+		TokenHash:       hashToken(plainToken),
+		DistributionIds: req.DistributionIds,
+		AllowedIps:      req.AllowedIps,
+		Enabled:         true,
+		ExpireAt:        req.ExpireAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.DistributionCreateTokenResp{
+		TokenId: token.Id,
+		Token:   plainToken,
+	}, nil
+}
+
+// Fetch 校验客户端令牌，并返回分发点当前的证书文件。
+// 每次拉取（包括被拒绝的请求）都会记录日志，以便排查仍在使用旧证书的客户端。
+func (s *DistributionService) Fetch(ctx context.Context, req *dtos.DistributionFetchReq) (*dtos.DistributionFetchResp, error) {
+	distribution, err := s.distributionRepo.GetByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	distributionLog := &domain.DistributionLog{
+		DistributionId:      distribution.Id,
+		File:                req.File,
+		ClientIp:            req.ClientIp,
+		UserAgent:           req.UserAgent,
+		RequestSerialNumber: parseIfNoneMatch(req.IfNoneMatch),
+	}
+
+	resp, err := s.fetch(ctx, distribution, req, distributionLog)
+	if err != nil {
+		distributionLog.StatusCode = 500
+		if xerr, ok := err.(*domain.Error); ok {
+			distributionLog.StatusCode = xerr.Code
+		}
+	} else if resp.NotModified {
+		distributionLog.StatusCode = 304
+	} else {
+		distributionLog.StatusCode = 200
+	}
+
+	if _, err := s.distributionLogRepo.Save(ctx, distributionLog); err != nil {
+		app.GetLogger().Error("failed to save distribution log", "err", err)
+	}
+
+	return resp, err
+}
+
+func (s *DistributionService) fetch(ctx context.Context, distribution *domain.Distribution, req *dtos.DistributionFetchReq, distributionLog *domain.DistributionLog) (*dtos.DistributionFetchResp, error) {
+	switch req.File {
+	case fileFullchain, fileCert, fileChain, filePrivkey:
+	default:
+		return nil, domain.ErrRecordNotFound
+	}
+
+	if req.Token == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	token, err := s.distributionTokenRepo.GetByTokenHash(ctx, hashToken(req.Token))
+	if err != nil {
+		if domain.IsRecordNotFoundError(err) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	distributionLog.TokenId = token.Id
+	if !token.IsUsable() {
+		return nil, domain.ErrUnauthorized
+	}
+	if !token.CanAccessDistribution(distribution.Id) || !token.IsIpAllowed(req.ClientIp) {
+		return nil, domain.ErrForbidden
+	}
+
+	var certificate *domain.Certificate
+	if distribution.WorkflowNodeId != "" {
+		certificate, err = s.certificateRepo.GetByWorkflowNodeId(ctx, distribution.WorkflowNodeId)
+	} else if distribution.Domain != "" {
+		certificate, err = s.certificateRepo.GetLatestByDomain(ctx, distribution.Domain)
+	} else {
+		err = domain.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	distributionLog.SerialNumber = certificate.SerialNumber
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenLastUsedUpdateInterval {
+		token.LastUsedAt = &now
+		if _, err := s.distributionTokenRepo.Save(ctx, token); err != nil {
+			app.GetLogger().Error("failed to update distribution token", "err", err)
+		}
+	}
+
+	resp := &dtos.DistributionFetchResp{
+		ETag:         fmt.Sprintf("\"%s\"", certificate.SerialNumber),
+		SerialNumber: certificate.SerialNumber,
+	}
+	if matchIfNoneMatch(req.IfNoneMatch, certificate.SerialNumber) {
+		resp.NotModified = true
+		return resp, nil
+	}
+
+	switch req.File {
+	case fileFullchain:
+		resp.Content = []byte(certificate.Certificate)
+
+	case fileCert, fileChain:
+		serverCertPEM, intermediaCertPEM, err := certutil.ExtractCertificatesFromPEM(certificate.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract certs: %w", err)
+		}
+
+		if req.File == fileCert {
+			resp.Content = []byte(serverCertPEM)
+		} else if intermediaCertPEM != "" {
+			resp.Content = []byte(intermediaCertPEM)
+		} else {
+			resp.Content = []byte(certificate.IssuerCertificate)
+		}
+
+	case filePrivkey:
+		resp.Content = []byte(certificate.PrivateKey)
+	}
+
+	return resp, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func parseIfNoneMatch(header string) string {
+	for _, etag := range strings.Split(header, ",") {
+		etag = strings.TrimSpace(etag)
+		etag = strings.TrimPrefix(etag, "W/")
+		etag = strings.Trim(etag, "\"")
+		if etag != "" && etag != "*" {
+			return etag
+		}
+	}
+
+	return ""
+}
+
+func matchIfNoneMatch(header string, serialNumber string) bool {
+	if header == "" || serialNumber == "" {
+		return false
+	}
+
+	for _, etag := range strings.Split(header, ",") {
+		etag = strings.TrimSpace(etag)
+		if etag == "*" {
+			return true
+		}
+
+		etag = strings.TrimPrefix(etag, "W/")
+		etag = strings.Trim(etag, "\"")
+		if strings.EqualFold(etag, serialNumber) {
+			return true
+		}
+	}
+
+	return false
+}
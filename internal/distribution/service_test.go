@@ -0,0 +1,179 @@
+package distribution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+
+	"github.com/usual2970/certimate/internal/domain"
+	"github.com/usual2970/certimate/internal/domain/dtos"
+)
+
+type fakeDistributionRepository struct {
+	distributions map[string]*domain.Distribution
+}
+
+func (r *fakeDistributionRepository) GetByName(ctx context.Context, name string) (*domain.Distribution, error) {
+	if distribution, ok := r.distributions[name]; ok {
+		return distribution, nil
+	}
+	return nil, domain.ErrRecordNotFound
+}
+
+type fakeDistributionTokenRepository struct {
+	tokens map[string]*domain.DistributionToken
+	saves  int
+}
+
+func (r *fakeDistributionTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.DistributionToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, domain.ErrRecordNotFound
+}
+
+func (r *fakeDistributionTokenRepository) Save(ctx context.Context, token *domain.DistributionToken) (*domain.DistributionToken, error) {
+	if token.Id == "" {
+		token.Id = "token" + time.Now().Format("150405.000000000")
+	}
+	r.tokens[token.Id] = token
+	r.saves++
+	return token, nil
+}
+
+type fakeDistributionLogRepository struct {
+	logs []*domain.DistributionLog
+}
+
+func (r *fakeDistributionLogRepository) Save(ctx context.Context, distributionLog *domain.DistributionLog) (*domain.DistributionLog, error) {
+	r.logs = append(r.logs, distributionLog)
+	return distributionLog, nil
+}
+
+func (r *fakeDistributionLogRepository) DeleteWhere(ctx context.Context, exprs ...dbx.Expression) (int, error) {
+	return 0, nil
+}
+
+type fakeCertificateRepository struct {
+	certificate *domain.Certificate
+}
+
+func (r *fakeCertificateRepository) GetByWorkflowNodeId(ctx context.Context, workflowNodeId string) (*domain.Certificate, error) {
+	return r.certificate, nil
+}
+
+func (r *fakeCertificateRepository) GetLatestByDomain(ctx context.Context, domainName string) (*domain.Certificate, error) {
+	return r.certificate, nil
+}
+
+func TestFetch(t *testing.T) {
+	distributionRepo := &fakeDistributionRepository{distributions: map[string]*domain.Distribution{
+		"web":   {Meta: domain.Meta{Id: "dist1"}, Name: "web", Domain: "example.com"},
+		"other": {Meta: domain.Meta{Id: "dist2"}, Name: "other", Domain: "example.org"},
+	}}
+	tokenRepo := &fakeDistributionTokenRepository{tokens: make(map[string]*domain.DistributionToken)}
+	logRepo := &fakeDistributionLogRepository{}
+	certificateRepo := &fakeCertificateRepository{certificate: &domain.Certificate{
+		SerialNumber: "0a1b2c",
+		Certificate:  "fullchain",
+		PrivateKey:   "privkey",
+	}}
+	service := NewDistributionService(distributionRepo, tokenRepo, logRepo, certificateRepo, nil)
+
+	created, err := service.CreateToken(context.Background(), &dtos.DistributionCreateTokenReq{
+		Name:            "edge",
+		DistributionIds: []string{"dist1"},
+		AllowedIps:      []string{"10.0.0.0/8", "192.168.1.10"},
+	})
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	if tokenRepo.tokens[created.TokenId].TokenHash == created.Token {
+		t.Fatal("token should be stored as a hash")
+	}
+
+	testCases := []struct {
+		name         string
+		req          dtos.DistributionFetchReq
+		expectedCode int
+		expectedBody string
+	}{
+		{"Ok", dtos.DistributionFetchReq{Name: "web", File: "fullchain.pem", Token: created.Token, ClientIp: "10.1.2.3"}, 200, "fullchain"},
+		{"PrivateKey", dtos.DistributionFetchReq{Name: "web", File: "privkey.pem", Token: created.Token, ClientIp: "192.168.1.10"}, 200, "privkey"},
+		{"NotModified", dtos.DistributionFetchReq{Name: "web", File: "fullchain.pem", Token: created.Token, ClientIp: "10.1.2.3", IfNoneMatch: `W/"0A1B2C"`}, 304, ""},
+		{"ModifiedSerial", dtos.DistributionFetchReq{Name: "web", File: "fullchain.pem", Token: created.Token, ClientIp: "10.1.2.3", IfNoneMatch: `"ffff"`}, 200, "fullchain"},
+		{"MissingToken", dtos.DistributionFetchReq{Name: "web", File: "fullchain.pem", ClientIp: "10.1.2.3"}, 401, ""},
+		{"InvalidToken", dtos.DistributionFetchReq{Name: "web", File: "fullchain.pem", Token: "cmdt_invalid", ClientIp: "10.1.2.3"}, 401, ""},
+		{"IpNotAllowed", dtos.DistributionFetchReq{Name: "web", File: "fullchain.pem", Token: created.Token, ClientIp: "172.16.0.1"}, 403, ""},
+		{"DistributionNotAllowed", dtos.DistributionFetchReq{Name: "other", File: "fullchain.pem", Token: created.Token, ClientIp: "10.1.2.3"}, 403, ""},
+		{"UnknownFile", dtos.DistributionFetchReq{Name: "web", File: "secret.txt", Token: created.Token, ClientIp: "10.1.2.3"}, 404, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logsCount := len(logRepo.logs)
+
+			res, err := service.Fetch(context.Background(), &tc.req)
+			code := 200
+			if err != nil {
+				code = err.(*domain.Error).Code
+			} else if res.NotModified {
+				code = 304
+			}
+
+			if code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d (err: %v)", tc.expectedCode, code, err)
+			}
+			if tc.expectedBody != "" && string(res.Content) != tc.expectedBody {
+				t.Fatalf("expected body '%s', got '%s'", tc.expectedBody, string(res.Content))
+			}
+			if len(logRepo.logs) != logsCount+1 || logRepo.logs[logsCount].StatusCode != tc.expectedCode {
+				t.Fatalf("expected fetch to be logged with status %d", tc.expectedCode)
+			}
+		})
+	}
+
+	t.Run("Revoked", func(t *testing.T) {
+		tokenRepo.tokens[created.TokenId].Enabled = false
+		defer func() { tokenRepo.tokens[created.TokenId].Enabled = true }()
+
+		_, err := service.Fetch(context.Background(), &dtos.DistributionFetchReq{Name: "web", File: "fullchain.pem", Token: created.Token, ClientIp: "10.1.2.3"})
+		if err != domain.ErrUnauthorized {
+			t.Fatalf("expected unauthorized, got %v", err)
+		}
+	})
+
+	t.Run("LastUsedAt", func(t *testing.T) {
+		req := &dtos.DistributionFetchReq{Name: "web", File: "fullchain.pem", Token: created.Token, ClientIp: "10.1.2.3"}
+
+		// 更新间隔内的重复拉取不应写库
+		saves := tokenRepo.saves
+		if _, err := service.Fetch(context.Background(), req); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if tokenRepo.saves != saves {
+			t.Errorf("expected token not to be saved within the update interval, got %d saves", tokenRepo.saves-saves)
+		}
+
+		stale := time.Now().Add(-tokenLastUsedUpdateInterval)
+		tokenRepo.tokens[created.TokenId].LastUsedAt = &stale
+		if _, err := service.Fetch(context.Background(), req); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if tokenRepo.saves != saves+1 || !tokenRepo.tokens[created.TokenId].LastUsedAt.After(stale) {
+			t.Errorf("expected last used time to be updated after the interval")
+		}
+	})
+
+	t.Run("LoggedSerials", func(t *testing.T) {
+		for _, log := range logRepo.logs {
+			if log.StatusCode == 304 && (log.RequestSerialNumber != "0A1B2C" || log.SerialNumber != "0a1b2c") {
+				t.Fatalf("unexpected serials in log: %+v", log)
+			}
+		}
+	})
+}
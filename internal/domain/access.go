@@ -35,6 +35,17 @@ type AccessConfigForACMEHttpReq struct {
 	Password string `json:"password,omitempty"`
 }
 
+type AccessConfigForAgent struct {
+	AgentId string `json:"agentId"`
+}
+
+type AccessConfigForAkamai struct {
+	Host         string `json:"host"`
+	ClientToken  string `json:"clientToken"`
+	ClientSecret string `json:"clientSecret"`
+	AccessToken  string `json:"accessToken"`
+}
+
 type AccessConfigForAliyun struct {
 	AccessKeyId     string `json:"accessKeyId"`
 	AccessKeySecret string `json:"accessKeySecret"`
@@ -50,6 +61,7 @@ type AccessConfigForAPISIX struct {
 type AccessConfigForAWS struct {
 	AccessKeyId     string `json:"accessKeyId"`
 	SecretAccessKey string `json:"secretAccessKey"`
+	Endpoint        string `json:"endpoint,omitempty"`
 }
 
 type AccessConfigForAzure struct {
@@ -148,6 +160,14 @@ type AccessConfigForDNSLA struct {
 	ApiSecret string `json:"apiSecret"`
 }
 
+type AccessConfigForDocker struct {
+	DockerHost               string `json:"dockerHost,omitempty"`
+	TlsCaCertificate         string `json:"tlsCaCertificate,omitempty"`
+	TlsClientCertificate     string `json:"tlsClientCertificate,omitempty"`
+	TlsClientKey             string `json:"tlsClientKey,omitempty"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+}
+
 type AccessConfigForDogeCloud struct {
 	AccessKey string `json:"accessKey"`
 	SecretKey string `json:"secretKey"`
@@ -177,6 +197,17 @@ type AccessConfigForEmail struct {
 	DefaultReceiverAddress string `json:"defaultReceiverAddress,omitempty"`
 }
 
+type AccessConfigForF5BIGIP struct {
+	ServerUrl                string `json:"serverUrl"`
+	Username                 string `json:"username"`
+	Password                 string `json:"password"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+}
+
+type AccessConfigForFastly struct {
+	ApiToken string `json:"apiToken"`
+}
+
 type AccessConfigForFlexCDN struct {
 	ServerUrl                string `json:"serverUrl"`
 	ApiRole                  string `json:"apiRole"`
@@ -185,10 +216,28 @@ type AccessConfigForFlexCDN struct {
 	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
 }
 
+type AccessConfigForFTP struct {
+	Host                     string `json:"host"`
+	Port                     int32  `json:"port,omitempty"`
+	Username                 string `json:"username,omitempty"`
+	Password                 string `json:"password,omitempty"`
+	TlsMode                  string `json:"tlsMode,omitempty"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+	DisableEPSV              bool   `json:"disableEPSV,omitempty"`
+}
+
 type AccessConfigForGcore struct {
 	ApiToken string `json:"apiToken"`
 }
 
+type AccessConfigForGit struct {
+	Username         string `json:"username,omitempty"`
+	Password         string `json:"password,omitempty"`
+	SshKey           string `json:"sshKey,omitempty"`
+	SshKeyPassphrase string `json:"sshKeyPassphrase,omitempty"`
+	SshKnownHosts    string `json:"sshKnownHosts,omitempty"`
+}
+
 type AccessConfigForGname struct {
 	AppId  string `json:"appId"`
 	AppKey string `json:"appKey"`
@@ -207,11 +256,21 @@ type AccessConfigForGoEdge struct {
 	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
 }
 
+type AccessConfigForGoogleCloud struct {
+	ServiceAccountKey string `json:"serviceAccountKey"`
+	ProjectId         string `json:"projectId,omitempty"`
+}
+
 type AccessConfigForGoogleTrustServices struct {
 	EabKid     string `json:"eabKid"`
 	EabHmacKey string `json:"eabHmacKey"`
 }
 
+type AccessConfigForHAProxy struct {
+	RuntimeApiNetwork string `json:"runtimeApiNetwork,omitempty"`
+	RuntimeApiAddress string `json:"runtimeApiAddress"`
+}
+
 type AccessConfigForHetzner struct {
 	ApiToken string `json:"apiToken"`
 }
@@ -228,7 +287,12 @@ type AccessConfigForJDCloud struct {
 }
 
 type AccessConfigForKubernetes struct {
-	KubeConfig string `json:"kubeConfig,omitempty"`
+	AuthMethod               string `json:"authMethod,omitempty"`
+	KubeConfig               string `json:"kubeConfig,omitempty"`
+	ApiServer                string `json:"apiServer,omitempty"`
+	Token                    string `json:"token,omitempty"`
+	CaCertificate            string `json:"caCertificate,omitempty"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
 }
 
 type AccessConfigForLarkBot struct {
@@ -251,6 +315,13 @@ type AccessConfigForMattermost struct {
 	DefaultChannelId string `json:"defaultChannelId,omitempty"`
 }
 
+type AccessConfigForMikroTik struct {
+	ServerUrl                string `json:"serverUrl"`
+	Username                 string `json:"username"`
+	Password                 string `json:"password"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+}
+
 type AccessConfigForNamecheap struct {
 	Username string `json:"username"`
 	ApiKey   string `json:"apiKey"`
@@ -275,10 +346,34 @@ type AccessConfigForNetlify struct {
 	ApiToken string `json:"apiToken"`
 }
 
+type AccessConfigForNginxProxyManager struct {
+	ServerUrl                string `json:"serverUrl"`
+	Email                    string `json:"email"`
+	Password                 string `json:"password"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+}
+
 type AccessConfigForNS1 struct {
 	ApiKey string `json:"apiKey"`
 }
 
+type AccessConfigForOPNsense struct {
+	ServerUrl                string `json:"serverUrl"`
+	ApiKey                   string `json:"apiKey"`
+	ApiSecret                string `json:"apiSecret"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+}
+
+type AccessConfigForPfSense struct {
+	ServerUrl                string `json:"serverUrl"`
+	ApiKey                   string `json:"apiKey"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+}
+
+type AccessConfigForPlugin struct {
+	PluginName string `json:"pluginName"`
+}
+
 type AccessConfigForPorkbun struct {
 	ApiKey       string `json:"apiKey"`
 	SecretApiKey string `json:"secretApiKey"`
@@ -313,6 +408,15 @@ type AccessConfigForRatPanel struct {
 	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
 }
 
+type AccessConfigForS3 struct {
+	Endpoint                 string `json:"endpoint,omitempty"`
+	Region                   string `json:"region,omitempty"`
+	AccessKeyId              string `json:"accessKeyId"`
+	SecretAccessKey          string `json:"secretAccessKey"`
+	UsePathStyle             bool   `json:"usePathStyle,omitempty"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+}
+
 type AccessConfigForSafeLine struct {
 	ServerUrl                string `json:"serverUrl"`
 	ApiToken                 string `json:"apiToken"`
@@ -325,21 +429,30 @@ type AccessConfigForSlackBot struct {
 }
 
 type AccessConfigForSSH struct {
-	Host          string `json:"host"`
-	Port          int32  `json:"port"`
-	AuthMethod    string `json:"authMethod,omitempty"`
-	Username      string `json:"username,omitempty"`
-	Password      string `json:"password,omitempty"`
-	Key           string `json:"key,omitempty"`
-	KeyPassphrase string `json:"keyPassphrase,omitempty"`
-	JumpServers   []struct {
-		Host          string `json:"host"`
-		Port          int32  `json:"port"`
-		AuthMethod    string `json:"authMethod,omitempty"`
-		Username      string `json:"username,omitempty"`
-		Password      string `json:"password,omitempty"`
-		Key           string `json:"key,omitempty"`
-		KeyPassphrase string `json:"keyPassphrase,omitempty"`
+	Host                string   `json:"host"`
+	Port                int32    `json:"port"`
+	AuthMethod          string   `json:"authMethod,omitempty"`
+	Username            string   `json:"username,omitempty"`
+	Password            string   `json:"password,omitempty"`
+	Key                 string   `json:"key,omitempty"`
+	KeyPassphrase       string   `json:"keyPassphrase,omitempty"`
+	KeyCertificate      string   `json:"keyCertificate,omitempty"`
+	AgentSocket         string   `json:"agentSocket,omitempty"`
+	HostKeyFingerprints []string `json:"hostKeyFingerprints,omitempty"`
+	HostKeyVerification string   `json:"hostKeyVerification,omitempty"`
+	KnownHosts          string   `json:"knownHosts,omitempty"`
+	Hosts               []string `json:"hosts,omitempty"`
+	JumpServers         []struct {
+		Host                string   `json:"host"`
+		Port                int32    `json:"port"`
+		AuthMethod          string   `json:"authMethod,omitempty"`
+		Username            string   `json:"username,omitempty"`
+		Password            string   `json:"password,omitempty"`
+		Key                 string   `json:"key,omitempty"`
+		KeyPassphrase       string   `json:"keyPassphrase,omitempty"`
+		KeyCertificate      string   `json:"keyCertificate,omitempty"`
+		AgentSocket         string   `json:"agentSocket,omitempty"`
+		HostKeyFingerprints []string `json:"hostKeyFingerprints,omitempty"`
 	} `json:"jumpServers,omitempty"`
 }
 
@@ -348,6 +461,13 @@ type AccessConfigForSSLCom struct {
 	EabHmacKey string `json:"eabHmacKey"`
 }
 
+type AccessConfigForSynology struct {
+	ServerUrl                string `json:"serverUrl"`
+	Username                 string `json:"username"`
+	Password                 string `json:"password"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+}
+
 type AccessConfigForTelegramBot struct {
 	BotToken      string `json:"botToken"`
 	DefaultChatId int64  `json:"defaultChatId,omitempty"`
@@ -358,6 +478,12 @@ type AccessConfigForTencentCloud struct {
 	SecretKey string `json:"secretKey"`
 }
 
+type AccessConfigForTrueNAS struct {
+	ServerUrl                string `json:"serverUrl"`
+	ApiKey                   string `json:"apiKey"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+}
+
 type AccessConfigForUCloud struct {
 	PrivateKey string `json:"privateKey"`
 	PublicKey  string `json:"publicKey"`
@@ -374,6 +500,20 @@ type AccessConfigForUpyun struct {
 	Password string `json:"password"`
 }
 
+type AccessConfigForVault struct {
+	Address                  string `json:"address"`
+	Namespace                string `json:"namespace,omitempty"`
+	AllowInsecureConnections bool   `json:"allowInsecureConnections,omitempty"`
+	AuthMethod               string `json:"authMethod,omitempty"`
+	Token                    string `json:"token,omitempty"`
+	AppRoleMountPath         string `json:"appRoleMountPath,omitempty"`
+	AppRoleRoleId            string `json:"appRoleRoleId,omitempty"`
+	AppRoleSecretId          string `json:"appRoleSecretId,omitempty"`
+	KubernetesMountPath      string `json:"kubernetesMountPath,omitempty"`
+	KubernetesRole           string `json:"kubernetesRole,omitempty"`
+	KubernetesJwt            string `json:"kubernetesJwt,omitempty"`
+}
+
 type AccessConfigForVercel struct {
 	ApiAccessToken string `json:"apiAccessToken"`
 	TeamId         string `json:"teamId,omitempty"`
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+const CollectionNameAgent = "agent"
+
+// Agent 表示一个已注册的远程代理。
+// 远程代理运行在 Certimate 无法直接访问的网络中，通过主动建立的 WebSocket 连接接收部署任务。
+type Agent struct {
+	Meta
+	Name       string     `json:"name" db:"name"`
+	TokenHash  string     `json:"-" db:"tokenHash"`
+	Enabled    bool       `json:"enabled" db:"enabled"`
+	LastSeenAt *time.Time `json:"lastSeenAt" db:"lastSeenAt"`
+	LastIp     string     `json:"lastIp" db:"lastIp"`
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	CollectionNameDistribution      = "distribution"
+	CollectionNameDistributionToken = "distribution_token"
+	CollectionNameDistributionLog   = "distribution_logs"
+)
+
+// Distribution 表示一个可供客户端拉取证书的分发点。
+// 证书选择器二选一：优先按工作流节点选取其最新申请的证书，否则按域名选取最晚过期的有效证书。
+type Distribution struct {
+	Meta
+	Name           string `json:"name" db:"name"`
+	WorkflowNodeId string `json:"workflowNodeId" db:"workflowNodeId"`
+	Domain         string `json:"domain" db:"domain"`
+}
+
+// DistributionToken 表示一个拉取证书的客户端令牌，数据库中仅保存其 SHA-256 摘要。
+type DistributionToken struct {
+	Meta
+	Name            string     `json:"name" db:"name"`
+	TokenHash       string     `json:"-" db:"tokenHash"`
+	DistributionIds []string   `json:"distributionIds" db:"distributionIds"`
+	AllowedIps      []string   `json:"allowedIps" db:"allowedIps"`
+	Enabled         bool       `json:"enabled" db:"enabled"`
+	ExpireAt        *time.Time `json:"expireAt" db:"expireAt"`
+	LastUsedAt      *time.Time `json:"lastUsedAt" db:"lastUsedAt"`
+}
+
+// IsUsable 判断令牌当前是否可用。
+func (t *DistributionToken) IsUsable() bool {
+	if !t.Enabled {
+		return false
+	}
+
+	if t.ExpireAt != nil && !t.ExpireAt.IsZero() && time.Now().After(*t.ExpireAt) {
+		return false
+	}
+
+	return true
+}
+
+// CanAccessDistribution 判断令牌是否被授权访问指定的分发点。
+func (t *DistributionToken) CanAccessDistribution(distributionId string) bool {
+	for _, id := range t.DistributionIds {
+		if id == distributionId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsIpAllowed 判断客户端 IP 是否在令牌允许的范围内。允许的范围为空时不做限制。
+// 范围可以是单个 IP 地址，也可以是 CIDR 格式的网段。
+func (t *DistributionToken) IsIpAllowed(ip string) bool {
+	if len(t.AllowedIps) == 0 {
+		return true
+	}
+
+	clientIp := net.ParseIP(ip)
+	if clientIp == nil {
+		return false
+	}
+
+	for _, allowed := range t.AllowedIps {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "" {
+			continue
+		}
+
+		if strings.Contains(allowed, "/") {
+			if _, ipNet, err := net.ParseCIDR(allowed); err == nil && ipNet.Contains(clientIp) {
+				return true
+			}
+		} else if allowedIp := net.ParseIP(allowed); allowedIp != nil && allowedIp.Equal(clientIp) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type DistributionLog struct {
+	Meta
+	DistributionId      string `json:"distributionId" db:"distributionId"`
+	TokenId             string `json:"tokenId" db:"tokenId"`
+	File                string `json:"file" db:"file"`
+	ClientIp            string `json:"clientIp" db:"clientIp"`
+	UserAgent           string `json:"userAgent" db:"userAgent"`
+	StatusCode          int    `json:"statusCode" db:"statusCode"`
+	RequestSerialNumber string `json:"requestSerialNumber" db:"requestSerialNumber"`
+	SerialNumber        string `json:"serialNumber" db:"serialNumber"`
+}
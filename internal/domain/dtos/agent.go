@@ -0,0 +1,14 @@
+package dtos
+
+type AgentRegisterReq struct {
+	Name string `json:"name"`
+}
+
+type AgentRegisterResp struct {
+	AgentId string `json:"agentId"`
+	Token   string `json:"token"`
+}
+
+type AgentRevokeReq struct {
+	AgentId string `json:"-"`
+}
@@ -0,0 +1,31 @@
+package dtos
+
+import "time"
+
+type DistributionCreateTokenReq struct {
+	Name            string     `json:"name"`
+	DistributionIds []string   `json:"distributionIds"`
+	AllowedIps      []string   `json:"allowedIps"`
+	ExpireAt        *time.Time `json:"expireAt"`
+}
+
+type DistributionCreateTokenResp struct {
+	TokenId string `json:"tokenId"`
+	Token   string `json:"token"`
+}
+
+type DistributionFetchReq struct {
+	Name        string `json:"-"`
+	File        string `json:"-"`
+	Token       string `json:"-"`
+	ClientIp    string `json:"-"`
+	UserAgent   string `json:"-"`
+	IfNoneMatch string `json:"-"`
+}
+
+type DistributionFetchResp struct {
+	Content      []byte `json:"-"`
+	ETag         string `json:"-"`
+	SerialNumber string `json:"-"`
+	NotModified  bool   `json:"-"`
+}
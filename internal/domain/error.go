@@ -2,6 +2,8 @@ package domain
 
 var (
 	ErrInvalidParams  = NewError(400, "invalid params")
+	ErrUnauthorized   = NewError(401, "unauthorized")
+	ErrForbidden      = NewError(403, "forbidden")
 	ErrRecordNotFound = NewError(404, "record not found")
 )
 
@@ -42,4 +42,5 @@ func (s *Settings) GetNotifyChannelConfig(channel string) (map[string]any, error
 type PersistenceSettingsContent struct {
 	WorkflowRunsMaxDaysRetention        int `json:"workflowRunsMaxDaysRetention"`
 	ExpiredCertificatesMaxDaysRetention int `json:"expiredCertificatesMaxDaysRetention"`
+	DistributionLogsMaxDaysRetention    int `json:"distributionLogsMaxDaysRetention"`
 }
@@ -208,4 +208,7 @@ type WorkflowNodeIO struct {
 
 type WorkflowNodeIOValueSelector = expr.ExprValueSelector
 
-const WorkflowNodeIONameCertificate string = "certificate"
+const (
+	WorkflowNodeIONameCertificate string = "certificate"
+	WorkflowNodeIONameDeployHosts string = "hosts"
+)
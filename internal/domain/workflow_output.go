@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"encoding/json"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+)
+
 const CollectionNameWorkflowOutput = "workflow_output"
 
 type WorkflowOutput struct {
@@ -11,3 +17,29 @@ type WorkflowOutput struct {
 	Outputs    []WorkflowNodeIO `json:"outputs" db:"outputs"`
 	Succeeded  bool             `json:"succeeded" db:"succeeded"`
 }
+
+// 获取部署节点输出结果中记录的各主机部署结果。
+//
+// 出参:
+//   - 各主机部署结果数组。非批量部署时返回空数组。
+func (o *WorkflowOutput) GetDeployHosts() []deployer.DeployHostResult {
+	hosts := make([]deployer.DeployHostResult, 0)
+	for _, item := range o.Outputs {
+		if item.Name != WorkflowNodeIONameDeployHosts || item.Value == nil {
+			continue
+		}
+
+		data, err := json.Marshal(item.Value)
+		if err != nil {
+			break
+		}
+
+		temp := make([]deployer.DeployHostResult, 0)
+		if err := json.Unmarshal(data, &temp); err == nil {
+			hosts = temp
+		}
+		break
+	}
+
+	return hosts
+}
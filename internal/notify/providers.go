@@ -1,6 +1,8 @@
 package notify
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"net/http"
 
@@ -11,12 +13,15 @@ import (
 	pEmail "github.com/usual2970/certimate/internal/pkg/core/notifier/providers/email"
 	pLarkBot "github.com/usual2970/certimate/internal/pkg/core/notifier/providers/larkbot"
 	pMattermost "github.com/usual2970/certimate/internal/pkg/core/notifier/providers/mattermost"
+	pPlugin "github.com/usual2970/certimate/internal/pkg/core/notifier/providers/plugin"
 	pSlackBot "github.com/usual2970/certimate/internal/pkg/core/notifier/providers/slackbot"
 	pTelegramBot "github.com/usual2970/certimate/internal/pkg/core/notifier/providers/telegrambot"
 	pWebhook "github.com/usual2970/certimate/internal/pkg/core/notifier/providers/webhook"
 	pWeComBot "github.com/usual2970/certimate/internal/pkg/core/notifier/providers/wecombot"
 	httputil "github.com/usual2970/certimate/internal/pkg/utils/http"
 	maputil "github.com/usual2970/certimate/internal/pkg/utils/map"
+	"github.com/usual2970/certimate/internal/plugin"
+	"github.com/usual2970/certimate/internal/registry"
 )
 
 type notifierProviderOptions struct {
@@ -25,117 +30,171 @@ type notifierProviderOptions struct {
 	ProviderServiceConfig map[string]any
 }
 
+type notifierProviderFactory func(options *notifierProviderOptions) (notifier.Notifier, error)
+
 func createNotifierProvider(options *notifierProviderOptions) (notifier.Notifier, error) {
+	provider, ok := registry.Get(registry.CapabilityNotification, string(options.Provider))
+	if !ok {
+		return nil, fmt.Errorf("unsupported notifier provider '%s'", string(options.Provider))
+	}
+
+	return provider.Factory.(notifierProviderFactory)(options)
+}
+
+// registerNotifierProvider 向注册表中注册消息通知提供商。
+// 工厂函数被调用前，授权配置与节点配置会先被分别填充到 TAccess 与 TConfig 中；
+// 节点配置的 Schema 由 TConfig 生成，将在保存工作流时用于校验。
+func registerNotifierProvider[TAccess any, TConfig any](accessProvider domain.AccessProviderType, providerTypes []domain.NotificationProviderType, factory func(options *notifierProviderOptions, access TAccess, config TConfig) (notifier.Notifier, error)) {
+	for _, providerType := range providerTypes {
+		registry.Register(&registry.Provider{
+			Type:           string(providerType),
+			Capability:     registry.CapabilityNotification,
+			AccessProvider: string(accessProvider),
+			ConfigSchema:   registry.SchemaOf(*new(TConfig)),
+			AccessSchema:   registry.SchemaOf(*new(TAccess)),
+			Factory: notifierProviderFactory(func(options *notifierProviderOptions) (notifier.Notifier, error) {
+				var access TAccess
+				if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
+					return nil, fmt.Errorf("failed to populate provider access config: %w", err)
+				}
+
+				var config TConfig
+				if err := maputil.Populate(options.ProviderServiceConfig, &config); err != nil {
+					return nil, fmt.Errorf("failed to populate provider service config: %w", err)
+				}
+
+				return factory(options, access, config)
+			}),
+		})
+	}
+}
+
+func init() {
 	/*
-	  注意：如果追加新的常量值，请保持以 ASCII 排序。
-	  NOTICE: If you add new constant, please keep ASCII order.
+	  注意：如果追加新的提供商，请保持以 ASCII 排序。
+	  NOTICE: If you add new provider, please keep ASCII order.
 	*/
-	switch options.Provider {
-	case domain.NotificationProviderTypeDingTalkBot:
-		{
-			access := domain.AccessConfigForDingTalkBot{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+	registerNotifierProvider(
+		domain.AccessProviderTypeDingTalkBot,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypeDingTalkBot},
+		func(options *notifierProviderOptions, access domain.AccessConfigForDingTalkBot, config struct{}) (notifier.Notifier, error) {
 			return pDingTalkBot.NewNotifier(&pDingTalkBot.NotifierConfig{
 				WebhookUrl: access.WebhookUrl,
 				Secret:     access.Secret,
 			})
-		}
-
-	case domain.NotificationProviderTypeDiscordBot:
-		{
-			access := domain.AccessConfigForDiscordBot{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerNotifierProvider(
+		domain.AccessProviderTypeDiscordBot,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypeDiscordBot},
+		func(options *notifierProviderOptions, access domain.AccessConfigForDiscordBot, config struct {
+			ChannelId string `json:"channelId,omitempty"`
+		}) (notifier.Notifier, error) {
 			return pDiscordBot.NewNotifier(&pDiscordBot.NotifierConfig{
 				BotToken:  access.BotToken,
-				ChannelId: maputil.GetOrDefaultString(options.ProviderServiceConfig, "channelId", access.DefaultChannelId),
+				ChannelId: cmp.Or(config.ChannelId, access.DefaultChannelId),
 			})
-		}
-
-	case domain.NotificationProviderTypeEmail:
-		{
-			access := domain.AccessConfigForEmail{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerNotifierProvider(
+		domain.AccessProviderTypeEmail,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypeEmail},
+		func(options *notifierProviderOptions, access domain.AccessConfigForEmail, config struct {
+			SenderAddress   string `json:"senderAddress,omitempty"`
+			SenderName      string `json:"senderName,omitempty"`
+			ReceiverAddress string `json:"receiverAddress,omitempty"`
+		}) (notifier.Notifier, error) {
 			return pEmail.NewNotifier(&pEmail.NotifierConfig{
 				SmtpHost:        access.SmtpHost,
 				SmtpPort:        access.SmtpPort,
 				SmtpTls:         access.SmtpTls,
 				Username:        access.Username,
 				Password:        access.Password,
-				SenderAddress:   maputil.GetOrDefaultString(options.ProviderServiceConfig, "senderAddress", access.DefaultSenderAddress),
-				SenderName:      maputil.GetOrDefaultString(options.ProviderServiceConfig, "senderName", access.DefaultSenderName),
-				ReceiverAddress: maputil.GetOrDefaultString(options.ProviderServiceConfig, "receiverAddress", access.DefaultReceiverAddress),
+				SenderAddress:   cmp.Or(config.SenderAddress, access.DefaultSenderAddress),
+				SenderName:      cmp.Or(config.SenderName, access.DefaultSenderName),
+				ReceiverAddress: cmp.Or(config.ReceiverAddress, access.DefaultReceiverAddress),
 			})
-		}
-
-	case domain.NotificationProviderTypeLarkBot:
-		{
-			access := domain.AccessConfigForLarkBot{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerNotifierProvider(
+		domain.AccessProviderTypeLarkBot,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypeLarkBot},
+		func(options *notifierProviderOptions, access domain.AccessConfigForLarkBot, config struct{}) (notifier.Notifier, error) {
 			return pLarkBot.NewNotifier(&pLarkBot.NotifierConfig{
 				WebhookUrl: access.WebhookUrl,
 			})
-		}
-
-	case domain.NotificationProviderTypeMattermost:
-		{
-			access := domain.AccessConfigForMattermost{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerNotifierProvider(
+		domain.AccessProviderTypeMattermost,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypeMattermost},
+		func(options *notifierProviderOptions, access domain.AccessConfigForMattermost, config struct {
+			ChannelId string `json:"channelId,omitempty"`
+		}) (notifier.Notifier, error) {
 			return pMattermost.NewNotifier(&pMattermost.NotifierConfig{
 				ServerUrl: access.ServerUrl,
 				Username:  access.Username,
 				Password:  access.Password,
-				ChannelId: maputil.GetOrDefaultString(options.ProviderServiceConfig, "channelId", access.DefaultChannelId),
+				ChannelId: cmp.Or(config.ChannelId, access.DefaultChannelId),
 			})
-		}
-
-	case domain.NotificationProviderTypeSlackBot:
-		{
-			access := domain.AccessConfigForSlackBot{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
+		},
+	)
+
+	registerNotifierProvider(
+		domain.AccessProviderTypePlugin,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypePlugin},
+		func(options *notifierProviderOptions, access domain.AccessConfigForPlugin, config struct{}) (notifier.Notifier, error) {
+			client, err := plugin.GetClient(context.Background(), access.PluginName)
+			if err != nil {
+				return nil, err
 			}
 
+			return pPlugin.NewNotifier(&pPlugin.NotifierConfig{
+				PluginName:    access.PluginName,
+				AccessConfig:  options.ProviderAccessConfig,
+				ServiceConfig: options.ProviderServiceConfig,
+				Client:        client,
+			})
+		},
+	)
+
+	registerNotifierProvider(
+		domain.AccessProviderTypeSlackBot,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypeSlackBot},
+		func(options *notifierProviderOptions, access domain.AccessConfigForSlackBot, config struct {
+			ChannelId string `json:"channelId,omitempty"`
+		}) (notifier.Notifier, error) {
 			return pSlackBot.NewNotifier(&pSlackBot.NotifierConfig{
 				BotToken:  access.BotToken,
-				ChannelId: maputil.GetOrDefaultString(options.ProviderServiceConfig, "channelId", access.DefaultChannelId),
+				ChannelId: cmp.Or(config.ChannelId, access.DefaultChannelId),
 			})
-		}
-
-	case domain.NotificationProviderTypeTelegramBot:
-		{
-			access := domain.AccessConfigForTelegramBot{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerNotifierProvider(
+		domain.AccessProviderTypeTelegramBot,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypeTelegramBot},
+		func(options *notifierProviderOptions, access domain.AccessConfigForTelegramBot, config struct {
+			ChatId int64 `json:"chatId,omitempty"`
+		}) (notifier.Notifier, error) {
 			return pTelegramBot.NewNotifier(&pTelegramBot.NotifierConfig{
 				BotToken: access.BotToken,
-				ChatId:   maputil.GetOrDefaultInt64(options.ProviderServiceConfig, "chatId", access.DefaultChatId),
+				ChatId:   cmp.Or(config.ChatId, access.DefaultChatId),
 			})
-		}
-
-	case domain.NotificationProviderTypeWebhook:
-		{
-			access := domain.AccessConfigForWebhook{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
-
+		},
+	)
+
+	registerNotifierProvider(
+		domain.AccessProviderTypeWebhook,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypeWebhook},
+		func(options *notifierProviderOptions, access domain.AccessConfigForWebhook, config struct {
+			Headers     string `json:"headers,omitempty"`
+			WebhookData string `json:"webhookData,omitempty"`
+		}) (notifier.Notifier, error) {
 			mergedHeaders := make(map[string]string)
 			if defaultHeadersString := access.HeadersString; defaultHeadersString != "" {
 				h, err := httputil.ParseHeaders(defaultHeadersString)
@@ -146,7 +205,7 @@ func createNotifierProvider(options *notifierProviderOptions) (notifier.Notifier
 					mergedHeaders[http.CanonicalHeaderKey(key)] = h.Get(key)
 				}
 			}
-			if extendedHeadersString := maputil.GetString(options.ProviderServiceConfig, "headers"); extendedHeadersString != "" {
+			if extendedHeadersString := config.Headers; extendedHeadersString != "" {
 				h, err := httputil.ParseHeaders(extendedHeadersString)
 				if err != nil {
 					return nil, fmt.Errorf("failed to parse webhook headers: %w", err)
@@ -158,25 +217,21 @@ func createNotifierProvider(options *notifierProviderOptions) (notifier.Notifier
 
 			return pWebhook.NewNotifier(&pWebhook.NotifierConfig{
 				WebhookUrl:               access.Url,
-				WebhookData:              maputil.GetOrDefaultString(options.ProviderServiceConfig, "webhookData", access.DefaultDataForNotification),
+				WebhookData:              cmp.Or(config.WebhookData, access.DefaultDataForNotification),
 				Method:                   access.Method,
 				Headers:                  mergedHeaders,
 				AllowInsecureConnections: access.AllowInsecureConnections,
 			})
-		}
-
-	case domain.NotificationProviderTypeWeComBot:
-		{
-			access := domain.AccessConfigForWeComBot{}
-			if err := maputil.Populate(options.ProviderAccessConfig, &access); err != nil {
-				return nil, fmt.Errorf("failed to populate provider access config: %w", err)
-			}
+		},
+	)
 
+	registerNotifierProvider(
+		domain.AccessProviderTypeWeComBot,
+		[]domain.NotificationProviderType{domain.NotificationProviderTypeWeComBot},
+		func(options *notifierProviderOptions, access domain.AccessConfigForWeComBot, config struct{}) (notifier.Notifier, error) {
 			return pWeComBot.NewNotifier(&pWeComBot.NotifierConfig{
 				WebhookUrl: access.WebhookUrl,
 			})
-		}
-	}
-
-	return nil, fmt.Errorf("unsupported notifier provider '%s'", options.Provider)
+		},
+	)
 }
@@ -0,0 +1,97 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+
+	pluginsdk "github.com/usual2970/certimate/pkg/plugin"
+)
+
+type ChallengeProviderConfig struct {
+	PluginName            string            `json:"pluginName"`
+	AccessConfig          map[string]any    `json:"accessConfig,omitempty"`
+	ServiceConfig         map[string]any    `json:"serviceConfig,omitempty"`
+	DnsPropagationTimeout int32             `json:"dnsPropagationTimeout,omitempty"`
+	Client                *pluginsdk.Client `json:"-"`
+}
+
+func NewChallengeProvider(config *ChallengeProviderConfig) (challenge.Provider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	if config.Client == nil {
+		return nil, errors.New("plugin client is not available")
+	}
+
+	if !config.Client.Info().HasKind(pluginsdk.KindDns01) {
+		return nil, fmt.Errorf("plugin '%s' does not implement '%s'", config.PluginName, pluginsdk.KindDns01)
+	}
+
+	accessConfig, err := json.Marshal(config.AccessConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin access config: %w", err)
+	}
+
+	serviceConfig, err := json.Marshal(config.ServiceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin service config: %w", err)
+	}
+
+	provider := &dnsProvider{
+		client:             config.Client,
+		accessConfig:       accessConfig,
+		serviceConfig:      serviceConfig,
+		propagationTimeout: dns01.DefaultPropagationTimeout,
+	}
+	if config.DnsPropagationTimeout != 0 {
+		provider.propagationTimeout = time.Duration(config.DnsPropagationTimeout) * time.Second
+	}
+
+	return provider, nil
+}
+
+type dnsProvider struct {
+	client             *pluginsdk.Client
+	accessConfig       json.RawMessage
+	serviceConfig      json.RawMessage
+	propagationTimeout time.Duration
+}
+
+var _ challenge.ProviderTimeout = (*dnsProvider)(nil)
+
+func (d *dnsProvider) Present(domain, token, keyAuth string) error {
+	if err := d.client.Present(context.Background(), d.buildRequest(domain, token, keyAuth)); err != nil {
+		return fmt.Errorf("plugin: %w", err)
+	}
+
+	return nil
+}
+
+func (d *dnsProvider) CleanUp(domain, token, keyAuth string) error {
+	if err := d.client.CleanUp(context.Background(), d.buildRequest(domain, token, keyAuth)); err != nil {
+		return fmt.Errorf("plugin: %w", err)
+	}
+
+	return nil
+}
+
+func (d *dnsProvider) Timeout() (timeout, interval time.Duration) {
+	return d.propagationTimeout, dns01.DefaultPollingInterval
+}
+
+func (d *dnsProvider) buildRequest(domain, token, keyAuth string) *pluginsdk.ChallengeRequest {
+	return &pluginsdk.ChallengeRequest{
+		AccessConfig:  d.accessConfig,
+		ServiceConfig: d.serviceConfig,
+		Domain:        domain,
+		Token:         token,
+		KeyAuth:       keyAuth,
+	}
+}
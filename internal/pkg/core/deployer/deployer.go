@@ -27,3 +27,11 @@ type Deployer interface {
 type DeployResult struct {
 	ExtendedData map[string]any `json:"extendedData,omitempty"`
 }
+
+// 表示批量部署到多台主机时单台主机的部署结果。
+type DeployHostResult struct {
+	Host      string `json:"host"`
+	Succeeded bool   `json:"succeeded"`
+	Skipped   bool   `json:"skipped,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+)
+
+// Channel 表示与远程代理之间的通信通道。
+type Channel interface {
+	// Deploy 将部署任务发送给指定的远程代理执行，并等待其执行完成。
+	// 远程代理执行过程中产生的日志会实时回传并写入 logger。
+	Deploy(ctx context.Context, agentId string, config *local.DeployerConfig, certPEM string, privkeyPEM string, logger *slog.Logger) (map[string]any, error)
+}
+
+type DeployerConfig struct {
+	// 远程代理 ID。
+	AgentId string `json:"agentId"`
+	// 交由远程代理执行的本地部署配置。
+	LocalConfig *local.DeployerConfig `json:"localConfig"`
+	// 远程代理通信通道。
+	Channel Channel `json:"-"`
+}
+
+type DeployerProvider struct {
+	config *DeployerConfig
+	logger *slog.Logger
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	if config.Channel == nil {
+		return nil, errors.New("agent channel is not available")
+	}
+
+	return &DeployerProvider{
+		config: config,
+		logger: slog.Default(),
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.AgentId == "" {
+		return nil, errors.New("config `agentId` is required")
+	}
+
+	localConfig := d.config.LocalConfig
+	if localConfig == nil {
+		localConfig = &local.DeployerConfig{}
+	}
+
+	extendedData, err := d.config.Channel.Deploy(ctx, d.config.AgentId, localConfig, certPEM, privkeyPEM, d.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: extendedData,
+	}, nil
+}
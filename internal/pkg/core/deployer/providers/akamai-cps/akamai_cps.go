@@ -0,0 +1,198 @@
+package akamaicps
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	akamaisdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/akamai"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// Akamai EdgeGrid API 主机名。
+	Host string `json:"host"`
+	// Akamai EdgeGrid ClientToken。
+	ClientToken string `json:"clientToken"`
+	// Akamai EdgeGrid ClientSecret。
+	ClientSecret string `json:"clientSecret"`
+	// Akamai EdgeGrid AccessToken。
+	AccessToken string `json:"accessToken"`
+	// CPS 证书注册 ID。
+	// 证书注册的验证类型须为 "third-party"。
+	EnrollmentId int64 `json:"enrollmentId"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *akamaisdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.Host, config.ClientToken, config.ClientSecret, config.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+// Akamai CPS 不接受上传私钥，第三方证书须由 CPS 生成的 CSR 签发，
+// 因此此处仅上传证书及证书链，并校验证书公钥与 CSR 是否匹配。
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.EnrollmentId == 0 {
+		return nil, errors.New("config `enrollmentId` is required")
+	}
+
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 提取服务器证书和中间证书
+	serverCertPEM, intermediaCertPEM, err := certutil.ExtractCertificatesFromPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract certs: %w", err)
+	}
+
+	// 获取证书注册详情
+	// REF: https://techdocs.akamai.com/cps/reference/get-enrollment
+	getEnrollmentResp, err := d.sdkClient.GetEnrollment(d.config.EnrollmentId)
+	d.logger.Debug("sdk request 'cps.GetEnrollment'", slog.Int64("request.enrollmentId", d.config.EnrollmentId), slog.Any("response", getEnrollmentResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'cps.GetEnrollment': %w", err)
+	} else if getEnrollmentResp.ValidationType != "third-party" {
+		return nil, fmt.Errorf("enrollment '%d' is not a third-party enrollment (validation type: '%s')", d.config.EnrollmentId, getEnrollmentResp.ValidationType)
+	} else if len(getEnrollmentResp.PendingChanges) == 0 {
+		return nil, fmt.Errorf("enrollment '%d' has no pending change, please renew it in Akamai Control Center first", d.config.EnrollmentId)
+	}
+
+	// 获取最新的待处理变更状态
+	// REF: https://techdocs.akamai.com/cps/reference/get-enrollment-change
+	changeLocation := getEnrollmentResp.PendingChanges[len(getEnrollmentResp.PendingChanges)-1].Location
+	getChangeStatusResp, err := d.sdkClient.GetChangeStatus(changeLocation)
+	d.logger.Debug("sdk request 'cps.GetChangeStatus'", slog.String("request.changeLocation", changeLocation), slog.Any("response", getChangeStatusResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'cps.GetChangeStatus': %w", err)
+	}
+
+	infoLocation, updateLocation := "", ""
+	for _, input := range getChangeStatusResp.AllowedInput {
+		if input.Type == akamaisdk.ALLOWED_INPUT_TYPE_THIRD_PARTY_CERTIFICATE {
+			infoLocation = input.Info
+			updateLocation = input.Update
+			break
+		}
+	}
+	if getChangeStatusResp.StatusInfo == nil || getChangeStatusResp.StatusInfo.Status != akamaisdk.CHANGE_STATUS_WAIT_UPLOAD_THIRD_PARTY || updateLocation == "" {
+		status := ""
+		if getChangeStatusResp.StatusInfo != nil {
+			status = getChangeStatusResp.StatusInfo.Status
+		}
+		return nil, fmt.Errorf("change '%s' is not waiting for a third-party certificate (status: '%s')", changeLocation, status)
+	}
+
+	// 获取 CSR，并找到与证书公钥匹配的密钥算法
+	// REF: https://techdocs.akamai.com/cps/reference/get-change-third-party-csr
+	getThirdPartyCsrResp, err := d.sdkClient.GetThirdPartyCsr(infoLocation)
+	d.logger.Debug("sdk request 'cps.GetThirdPartyCsr'", slog.String("request.infoLocation", infoLocation), slog.Any("response", getThirdPartyCsrResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'cps.GetThirdPartyCsr': %w", err)
+	}
+
+	keyAlgorithm := ""
+	for _, csr := range getThirdPartyCsrResp.Csrs {
+		if isCsrMatchesPublicKey(csr.Csr, certX509.PublicKey) {
+			keyAlgorithm = csr.KeyAlgorithm
+			break
+		}
+	}
+	if keyAlgorithm == "" {
+		return nil, errors.New("the public key of the certificate does not match any csr generated by akamai cps, the certificate must be issued with the csr of the pending change")
+	}
+
+	// 上传第三方证书
+	// REF: https://techdocs.akamai.com/cps/reference/post-change-allowed-input
+	uploadThirdPartyCertificateReq := &akamaisdk.UploadThirdPartyCertificateRequest{
+		CertificatesAndTrustChains: []*akamaisdk.CertificateAndTrustChain{
+			{
+				Certificate:  serverCertPEM,
+				TrustChain:   intermediaCertPEM,
+				KeyAlgorithm: keyAlgorithm,
+			},
+		},
+	}
+	uploadThirdPartyCertificateResp, err := d.sdkClient.UploadThirdPartyCertificate(updateLocation, uploadThirdPartyCertificateReq)
+	d.logger.Debug("sdk request 'cps.UploadThirdPartyCertificate'", slog.String("request.updateLocation", updateLocation), slog.Any("response", uploadThirdPartyCertificateResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'cps.UploadThirdPartyCertificate': %w", err)
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"change": uploadThirdPartyCertificateResp.Change,
+		},
+	}, nil
+}
+
+func isCsrMatchesPublicKey(csrPEM string, publicKey crypto.PublicKey) bool {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil {
+		return false
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		return pub.Equal(csr.PublicKey)
+	case *ecdsa.PublicKey:
+		return pub.Equal(csr.PublicKey)
+	default:
+		return false
+	}
+}
+
+func createSdkClient(host, clientToken, clientSecret, accessToken string) (*akamaisdk.Client, error) {
+	if host == "" {
+		return nil, errors.New("invalid akamai host")
+	}
+
+	if clientToken == "" || clientSecret == "" || accessToken == "" {
+		return nil, errors.New("invalid akamai edgegrid credentials")
+	}
+
+	client := akamaisdk.NewClient(host, clientToken, clientSecret, accessToken)
+	return client, nil
+}
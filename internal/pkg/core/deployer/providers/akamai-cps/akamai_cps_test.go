@@ -0,0 +1,85 @@
+package akamaicps_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/akamai-cps"
+)
+
+var (
+	fInputCertPath string
+	fInputKeyPath  string
+	fHost          string
+	fClientToken   string
+	fClientSecret  string
+	fAccessToken   string
+	fEnrollmentId  int64
+)
+
+func init() {
+	argsPrefix := "CERTIMATE_DEPLOYER_AKAMAICPS_"
+
+	flag.StringVar(&fInputCertPath, argsPrefix+"INPUTCERTPATH", "", "")
+	flag.StringVar(&fInputKeyPath, argsPrefix+"INPUTKEYPATH", "", "")
+	flag.StringVar(&fHost, argsPrefix+"HOST", "", "")
+	flag.StringVar(&fClientToken, argsPrefix+"CLIENTTOKEN", "", "")
+	flag.StringVar(&fClientSecret, argsPrefix+"CLIENTSECRET", "", "")
+	flag.StringVar(&fAccessToken, argsPrefix+"ACCESSTOKEN", "", "")
+	flag.Int64Var(&fEnrollmentId, argsPrefix+"ENROLLMENTID", 0, "")
+}
+
+/*
+Shell command to run this test:
+
+	go test -v ./akamai_cps_test.go -args \
+	--CERTIMATE_DEPLOYER_AKAMAICPS_INPUTCERTPATH="/path/to/your-input-cert.pem" \
+	--CERTIMATE_DEPLOYER_AKAMAICPS_INPUTKEYPATH="/path/to/your-input-key.pem" \
+	--CERTIMATE_DEPLOYER_AKAMAICPS_HOST="akab-xxxx.luna.akamaiapis.net" \
+	--CERTIMATE_DEPLOYER_AKAMAICPS_CLIENTTOKEN="your-client-token" \
+	--CERTIMATE_DEPLOYER_AKAMAICPS_CLIENTSECRET="your-client-secret" \
+	--CERTIMATE_DEPLOYER_AKAMAICPS_ACCESSTOKEN="your-access-token" \
+	--CERTIMATE_DEPLOYER_AKAMAICPS_ENROLLMENTID=123456
+*/
+func TestDeploy(t *testing.T) {
+	flag.Parse()
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("HOST: %v", fHost),
+			fmt.Sprintf("CLIENTTOKEN: %v", fClientToken),
+			fmt.Sprintf("CLIENTSECRET: %v", fClientSecret),
+			fmt.Sprintf("ACCESSTOKEN: %v", fAccessToken),
+			fmt.Sprintf("ENROLLMENTID: %v", fEnrollmentId),
+		}, "\n"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			Host:         fHost,
+			ClientToken:  fClientToken,
+			ClientSecret: fClientSecret,
+			AccessToken:  fAccessToken,
+			EnrollmentId: fEnrollmentId,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+}
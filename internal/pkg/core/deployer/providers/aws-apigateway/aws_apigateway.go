@@ -0,0 +1,135 @@
+package awsapigateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	uploadersp "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/aws-acm"
+	apigatewaysdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/aws/apigateway"
+)
+
+type DeployerConfig struct {
+	// AWS AccessKeyId。
+	AccessKeyId string `json:"accessKeyId"`
+	// AWS SecretAccessKey。
+	SecretAccessKey string `json:"secretAccessKey"`
+	// AWS 区域。
+	Region string `json:"region"`
+	// AWS 服务地址。
+	// 选填。零值时使用 AWS 官方服务地址，可用于对接 LocalStack 等本地模拟服务。
+	Endpoint string `json:"endpoint,omitempty"`
+	// API Gateway 自定义域名。
+	DomainName string `json:"domainName"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *apigatewaysdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+// 边缘优化的自定义域名只能使用 us-east-1 区域的 ACM 证书。
+const edgeCertificateRegion = "us-east-1"
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := apigatewaysdk.NewClient(config.Endpoint, config.Region, config.AccessKeyId, config.SecretAccessKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.DomainName == "" {
+		return nil, errors.New("config `domainName` is required")
+	}
+
+	// 查询自定义域名
+	// REF: https://docs.aws.amazon.com/apigateway/latest/api/API_GetDomainName.html
+	getDomainNameResp, err := d.sdkClient.GetDomainName(ctx, d.config.DomainName)
+	d.logger.Debug("sdk request 'apigateway.GetDomainName'", slog.String("domainName", d.config.DomainName), slog.Any("response", getDomainNameResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'apigateway.GetDomainName': %w", err)
+	}
+
+	// 根据终端节点类型决定证书所在区域与待更新的字段
+	endpointType := getDomainNameResp.GetEndpointType()
+	certRegion := d.config.Region
+	patchPath := "/certificateArn"
+	currentCertArn := getDomainNameResp.CertificateArn
+	switch endpointType {
+	case apigatewaysdk.ENDPOINT_TYPE_EDGE:
+		certRegion = edgeCertificateRegion
+	case apigatewaysdk.ENDPOINT_TYPE_REGIONAL:
+		patchPath = "/regionalCertificateArn"
+		currentCertArn = getDomainNameResp.RegionalCertificateArn
+	case apigatewaysdk.ENDPOINT_TYPE_PRIVATE:
+	default:
+		return nil, fmt.Errorf("unsupported endpoint type '%s'", endpointType)
+	}
+
+	// 上传证书到 ACM
+	sslUploader, err := uploadersp.NewUploader(&uploadersp.UploaderConfig{
+		AccessKeyId:     d.config.AccessKeyId,
+		SecretAccessKey: d.config.SecretAccessKey,
+		Region:          certRegion,
+		Endpoint:        d.config.Endpoint,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+	}
+
+	upres, err := sslUploader.WithLogger(d.logger).Upload(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+	} else {
+		d.logger.Info("ssl certificate uploaded", slog.Any("result", upres))
+	}
+
+	if currentCertArn == upres.CertId {
+		d.logger.Info("ssl certificate already attached to domain name")
+		return &deployer.DeployResult{}, nil
+	}
+
+	// 更新自定义域名的证书
+	// REF: https://docs.aws.amazon.com/apigateway/latest/api/API_UpdateDomainName.html
+	updateDomainNameReq := &apigatewaysdk.UpdateDomainNameRequest{
+		PatchOperations: []*apigatewaysdk.PatchOperation{
+			{
+				Op:    "replace",
+				Path:  patchPath,
+				Value: upres.CertId,
+			},
+		},
+	}
+	updateDomainNameResp, err := d.sdkClient.UpdateDomainName(ctx, d.config.DomainName, updateDomainNameReq)
+	d.logger.Debug("sdk request 'apigateway.UpdateDomainName'", slog.Any("request", updateDomainNameReq), slog.Any("response", updateDomainNameResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'apigateway.UpdateDomainName': %w", err)
+	}
+
+	return &deployer.DeployResult{}, nil
+}
@@ -0,0 +1,220 @@
+package awsapigateway_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aws-apigateway"
+)
+
+// 模拟 ACM 与 API Gateway 接口的本地服务。
+type fakeAWS struct {
+	mu sync.Mutex
+
+	acmCerts    map[string]*x509.Certificate
+	acmPEMs     map[string]string
+	acmRegions  map[string]string
+	domainNames map[string]map[string]any
+}
+
+func newFakeAWS() *fakeAWS {
+	return &fakeAWS{
+		acmCerts:    make(map[string]*x509.Certificate),
+		acmPEMs:     make(map[string]string),
+		acmRegions:  make(map[string]string),
+		domainNames: make(map[string]map[string]any),
+	}
+}
+
+func (f *fakeAWS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// 从签名凭证范围中解析区域，形如 "Credential=AKID/20060102/us-east-1/acm/aws4_request"
+	region := ""
+	if _, after, ok := strings.Cut(r.Header.Get("Authorization"), "Credential="); ok {
+		if parts := strings.Split(strings.SplitN(after, ",", 2)[0], "/"); len(parts) == 5 {
+			region = parts[2]
+		}
+	}
+	if region == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if target := r.Header.Get("X-Amz-Target"); target != "" {
+		req := map[string]any{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch strings.TrimPrefix(target, "CertificateManager.") {
+		case "ListCertificates":
+			summaries := make([]map[string]any, 0)
+			for arn, c := range f.acmCerts {
+				if f.acmRegions[arn] != region {
+					continue
+				}
+				summaries = append(summaries, map[string]any{
+					"CertificateArn":                  arn,
+					"DomainName":                      c.Subject.CommonName,
+					"NotBefore":                       c.NotBefore.Unix(),
+					"NotAfter":                        c.NotAfter.Unix(),
+					"SubjectAlternativeNameSummaries": c.DNSNames,
+				})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"CertificateSummaryList": summaries})
+
+		case "GetCertificate":
+			json.NewEncoder(w).Encode(map[string]any{"Certificate": f.acmPEMs[req["CertificateArn"].(string)]})
+
+		case "ImportCertificate":
+			var certPEM []byte
+			json.Unmarshal([]byte(`"`+req["Certificate"].(string)+`"`), &certPEM)
+			block, _ := pem.Decode(certPEM)
+			certX509, _ := x509.ParseCertificate(block.Bytes)
+			arn := fmt.Sprintf("arn:aws:acm:%s:123456789012:certificate/imported-%d", region, len(f.acmCerts))
+			f.acmCerts[arn] = certX509
+			f.acmPEMs[arn] = string(certPEM)
+			f.acmRegions[arn] = region
+			json.NewEncoder(w).Encode(map[string]any{"CertificateArn": arn})
+
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/domainnames/")
+	domain, ok := f.domainNames[name]
+	if !ok {
+		w.Header().Set("X-Amzn-ErrorType", "NotFoundException")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{"message": "Invalid domain name identifier specified"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(domain)
+
+	case http.MethodPatch:
+		req := struct {
+			PatchOperations []struct {
+				Op    string `json:"op"`
+				Path  string `json:"path"`
+				Value string `json:"value"`
+			} `json:"patchOperations"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, op := range req.PatchOperations {
+			if op.Op == "replace" {
+				domain[strings.TrimPrefix(op.Path, "/")] = op.Value
+			}
+		}
+		json.NewEncoder(w).Encode(domain)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func generateCertificate(t *testing.T, domains ...string) (string, string) {
+	t.Helper()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: domains[0]},
+		DNSNames:     domains,
+		NotBefore:    time.Now().Add(-time.Hour).Truncate(time.Second),
+		NotAfter:     time.Now().Add(24 * time.Hour).Truncate(time.Second),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestDeploy(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t, "api.example.com")
+
+	fake := newFakeAWS()
+	fake.domainNames["edge.example.com"] = map[string]any{
+		"domainName":     "edge.example.com",
+		"certificateArn": "arn:aws:acm:us-east-1:123456789012:certificate/old",
+	}
+	fake.domainNames["regional.example.com"] = map[string]any{
+		"domainName":             "regional.example.com",
+		"regionalCertificateArn": "arn:aws:acm:eu-west-1:123456789012:certificate/old",
+		"endpointConfiguration":  map[string]any{"types": []string{"REGIONAL"}},
+	}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	deploy := func(domainName string) error {
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			AccessKeyId:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+			Region:          "eu-west-1",
+			Endpoint:        server.URL,
+			DomainName:      domainName,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deployer.Deploy(context.Background(), certPEM, keyPEM)
+		return err
+	}
+
+	t.Run("EdgeEndpoint", func(t *testing.T) {
+		if err := deploy("edge.example.com"); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		arn, _ := fake.domainNames["edge.example.com"]["certificateArn"].(string)
+		if !strings.HasPrefix(arn, "arn:aws:acm:us-east-1:") || !strings.Contains(arn, "/imported-") {
+			t.Errorf("expected certificate imported into us-east-1, got '%s'", arn)
+		}
+	})
+
+	t.Run("RegionalEndpoint", func(t *testing.T) {
+		if err := deploy("regional.example.com"); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		arn, _ := fake.domainNames["regional.example.com"]["regionalCertificateArn"].(string)
+		if !strings.HasPrefix(arn, "arn:aws:acm:eu-west-1:") || !strings.Contains(arn, "/imported-") {
+			t.Errorf("expected certificate imported into eu-west-1, got '%s'", arn)
+		}
+		if _, ok := fake.domainNames["regional.example.com"]["certificateArn"]; ok {
+			t.Errorf("expected edge certificate field to be untouched")
+		}
+	})
+
+	t.Run("DomainNameNotFound", func(t *testing.T) {
+		err := deploy("missing.example.com")
+		if err == nil || !strings.Contains(err.Error(), "NotFoundException") {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
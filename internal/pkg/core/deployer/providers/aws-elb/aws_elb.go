@@ -0,0 +1,354 @@
+package awselb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	aws "github.com/aws/aws-sdk-go-v2/aws"
+	awscfg "github.com/aws/aws-sdk-go-v2/config"
+	awscred "github.com/aws/aws-sdk-go-v2/credentials"
+	awsacm "github.com/aws/aws-sdk-go-v2/service/acm"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	uploadersp "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/aws-acm"
+	elbv2sdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/aws/elbv2"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// AWS AccessKeyId。
+	AccessKeyId string `json:"accessKeyId"`
+	// AWS SecretAccessKey。
+	SecretAccessKey string `json:"secretAccessKey"`
+	// AWS 区域。
+	Region string `json:"region"`
+	// AWS 服务地址。
+	// 选填。零值时使用 AWS 官方服务地址，可用于对接 LocalStack 等本地模拟服务。
+	Endpoint string `json:"endpoint,omitempty"`
+	// 部署资源类型。
+	ResourceType ResourceType `json:"resourceType"`
+	// 负载均衡器 ARN。
+	// 部署资源类型为 [RESOURCE_TYPE_LOADBALANCER] 时必填。
+	LoadbalancerArn string `json:"loadbalancerArn,omitempty"`
+	// 监听器 ARN。
+	// 部署资源类型为 [RESOURCE_TYPE_LISTENER] 时必填。
+	ListenerArn string `json:"listenerArn,omitempty"`
+	// 证书类型。
+	// 零值时默认值 [CERTIFICATE_TYPE_DEFAULT]。
+	CertificateType CertificateType `json:"certificateType,omitempty"`
+}
+
+type DeployerProvider struct {
+	config      *DeployerConfig
+	logger      *slog.Logger
+	sdkClients  *wSdkClients
+	sslUploader uploader.Uploader
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+type wSdkClients struct {
+	ELBv2 *elbv2sdk.Client
+	ACM   *awsacm.Client
+}
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	clients, err := createSdkClients(config.AccessKeyId, config.SecretAccessKey, config.Region, config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	uploader, err := uploadersp.NewUploader(&uploadersp.UploaderConfig{
+		AccessKeyId:     config.AccessKeyId,
+		SecretAccessKey: config.SecretAccessKey,
+		Region:          config.Region,
+		Endpoint:        config.Endpoint,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:      config,
+		logger:      slog.Default(),
+		sdkClients:  clients,
+		sslUploader: uploader,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	d.sslUploader.WithLogger(logger)
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	switch d.config.CertificateType {
+	case "", CERTIFICATE_TYPE_DEFAULT, CERTIFICATE_TYPE_SNI:
+	default:
+		return nil, fmt.Errorf("unsupported certificate type '%s'", d.config.CertificateType)
+	}
+
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 上传证书到 ACM
+	upres, err := d.sslUploader.Upload(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+	} else {
+		d.logger.Info("ssl certificate uploaded", slog.Any("result", upres))
+	}
+
+	// 根据部署资源类型决定部署方式
+	switch d.config.ResourceType {
+	case RESOURCE_TYPE_LOADBALANCER:
+		if err := d.deployToLoadbalancer(ctx, certX509.DNSNames, upres.CertId); err != nil {
+			return nil, err
+		}
+
+	case RESOURCE_TYPE_LISTENER:
+		if err := d.deployToListener(ctx, certX509.DNSNames, upres.CertId); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported resource type '%s'", d.config.ResourceType)
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func (d *DeployerProvider) deployToLoadbalancer(ctx context.Context, domains []string, cloudCertArn string) error {
+	if d.config.LoadbalancerArn == "" {
+		return errors.New("config `loadbalancerArn` is required")
+	}
+
+	// 查询监听器列表
+	// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeListeners.html
+	listenerArns := make([]string, 0)
+	describeListenersMarker := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		describeListenersReq := &elbv2sdk.DescribeListenersRequest{
+			LoadBalancerArn: d.config.LoadbalancerArn,
+			Marker:          describeListenersMarker,
+		}
+		describeListenersResp, err := d.sdkClients.ELBv2.DescribeListeners(ctx, describeListenersReq)
+		d.logger.Debug("sdk request 'elbv2.DescribeListeners'", slog.Any("request", describeListenersReq), slog.Any("response", describeListenersResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'elbv2.DescribeListeners': %w", err)
+		}
+
+		for _, listener := range describeListenersResp.Listeners {
+			if listener.Protocol == "HTTPS" || listener.Protocol == "TLS" {
+				listenerArns = append(listenerArns, listener.ListenerArn)
+			}
+		}
+
+		if describeListenersResp.NextMarker == "" {
+			break
+		}
+		describeListenersMarker = describeListenersResp.NextMarker
+	}
+
+	// 遍历更新监听器证书
+	if len(listenerArns) == 0 {
+		d.logger.Info("no elb listeners to deploy")
+	} else {
+		d.logger.Info("found https/tls listeners to deploy", slog.Any("listenerArns", listenerArns))
+		var errs []error
+
+		for _, listenerArn := range listenerArns {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				if err := d.updateListenerCertificate(ctx, listenerArn, domains, cloudCertArn); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+
+		if len(errs) > 0 {
+			return errors.Join(errs...)
+		}
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) deployToListener(ctx context.Context, domains []string, cloudCertArn string) error {
+	if d.config.ListenerArn == "" {
+		return errors.New("config `listenerArn` is required")
+	}
+
+	// 更新监听器证书
+	if err := d.updateListenerCertificate(ctx, d.config.ListenerArn, domains, cloudCertArn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) updateListenerCertificate(ctx context.Context, listenerArn string, domains []string, cloudCertArn string) error {
+	// 查询监听器已绑定的证书
+	// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeListenerCertificates.html
+	listenerCerts := make([]*elbv2sdk.Certificate, 0)
+	describeListenerCertificatesMarker := ""
+	for {
+		describeListenerCertificatesReq := &elbv2sdk.DescribeListenerCertificatesRequest{
+			ListenerArn: listenerArn,
+			Marker:      describeListenerCertificatesMarker,
+		}
+		describeListenerCertificatesResp, err := d.sdkClients.ELBv2.DescribeListenerCertificates(ctx, describeListenerCertificatesReq)
+		d.logger.Debug("sdk request 'elbv2.DescribeListenerCertificates'", slog.Any("request", describeListenerCertificatesReq), slog.Any("response", describeListenerCertificatesResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'elbv2.DescribeListenerCertificates': %w", err)
+		}
+
+		listenerCerts = append(listenerCerts, describeListenerCertificatesResp.Certificates...)
+		if describeListenerCertificatesResp.NextMarker == "" {
+			break
+		}
+		describeListenerCertificatesMarker = describeListenerCertificatesResp.NextMarker
+	}
+
+	isAttached := slices.ContainsFunc(listenerCerts, func(c *elbv2sdk.Certificate) bool {
+		if d.config.CertificateType == CERTIFICATE_TYPE_SNI {
+			return c.CertificateArn == cloudCertArn && !c.IsDefault
+		}
+		return c.CertificateArn == cloudCertArn && c.IsDefault
+	})
+	if isAttached {
+		d.logger.Info("ssl certificate already attached to listener", slog.String("listenerArn", listenerArn))
+	} else if d.config.CertificateType == CERTIFICATE_TYPE_SNI {
+		// 添加 SNI 证书
+		// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_AddListenerCertificates.html
+		addListenerCertificatesReq := &elbv2sdk.AddListenerCertificatesRequest{
+			ListenerArn:     listenerArn,
+			CertificateArns: []string{cloudCertArn},
+		}
+		addListenerCertificatesResp, err := d.sdkClients.ELBv2.AddListenerCertificates(ctx, addListenerCertificatesReq)
+		d.logger.Debug("sdk request 'elbv2.AddListenerCertificates'", slog.Any("request", addListenerCertificatesReq), slog.Any("response", addListenerCertificatesResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'elbv2.AddListenerCertificates': %w", err)
+		}
+	} else {
+		// 替换默认证书
+		// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_ModifyListener.html
+		modifyListenerReq := &elbv2sdk.ModifyListenerRequest{
+			ListenerArn:    listenerArn,
+			CertificateArn: cloudCertArn,
+		}
+		modifyListenerResp, err := d.sdkClients.ELBv2.ModifyListener(ctx, modifyListenerReq)
+		d.logger.Debug("sdk request 'elbv2.ModifyListener'", slog.Any("request", modifyListenerReq), slog.Any("response", modifyListenerResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'elbv2.ModifyListener': %w", err)
+		}
+	}
+
+	// 移除已被替换的旧 SNI 证书，即域名与新证书完全相同的其他扩展证书
+	// 默认证书无法通过 RemoveListenerCertificates 移除，因此仅处理非默认证书
+	staleCertArns := make([]string, 0)
+	for _, listenerCert := range listenerCerts {
+		if listenerCert.IsDefault || listenerCert.CertificateArn == cloudCertArn {
+			continue
+		}
+		if !strings.HasPrefix(listenerCert.CertificateArn, "arn:") || !strings.Contains(listenerCert.CertificateArn, ":acm:") {
+			continue
+		}
+
+		// 查询 ACM 证书详情
+		// REF: https://docs.aws.amazon.com/acm/latest/APIReference/API_DescribeCertificate.html
+		describeCertificateReq := &awsacm.DescribeCertificateInput{
+			CertificateArn: aws.String(listenerCert.CertificateArn),
+		}
+		describeCertificateResp, err := d.sdkClients.ACM.DescribeCertificate(ctx, describeCertificateReq)
+		d.logger.Debug("sdk request 'acm.DescribeCertificate'", slog.Any("request", describeCertificateReq), slog.Any("response", describeCertificateResp))
+		if err != nil {
+			d.logger.Warn("failed to describe listener certificate, skip removing it", slog.String("certificateArn", listenerCert.CertificateArn), slog.Any("error", err))
+			continue
+		}
+		if describeCertificateResp.Certificate == nil {
+			continue
+		}
+
+		if slices.Equal(normalizeDomains(describeCertificateResp.Certificate.SubjectAlternativeNames), normalizeDomains(domains)) {
+			staleCertArns = append(staleCertArns, listenerCert.CertificateArn)
+		}
+	}
+
+	if len(staleCertArns) > 0 {
+		// 移除旧证书
+		// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_RemoveListenerCertificates.html
+		removeListenerCertificatesReq := &elbv2sdk.RemoveListenerCertificatesRequest{
+			ListenerArn:     listenerArn,
+			CertificateArns: staleCertArns,
+		}
+		removeListenerCertificatesResp, err := d.sdkClients.ELBv2.RemoveListenerCertificates(ctx, removeListenerCertificatesReq)
+		d.logger.Debug("sdk request 'elbv2.RemoveListenerCertificates'", slog.Any("request", removeListenerCertificatesReq), slog.Any("response", removeListenerCertificatesResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'elbv2.RemoveListenerCertificates': %w", err)
+		}
+
+		d.logger.Info("old listener certificates removed", slog.String("listenerArn", listenerArn), slog.Any("certificateArns", staleCertArns))
+	}
+
+	return nil
+}
+
+func normalizeDomains(domains []string) []string {
+	result := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		result = append(result, strings.ToLower(domain))
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+func createSdkClients(accessKeyId, secretAccessKey, region, endpoint string) (*wSdkClients, error) {
+	cfg, err := awscfg.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	acmClient := awsacm.NewFromConfig(cfg, func(o *awsacm.Options) {
+		o.Region = region
+		o.Credentials = aws.NewCredentialsCache(awscred.NewStaticCredentialsProvider(accessKeyId, secretAccessKey, ""))
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+
+	elbv2Client, err := elbv2sdk.NewClient(endpoint, region, accessKeyId, secretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wSdkClients{
+		ELBv2: elbv2Client,
+		ACM:   acmClient,
+	}, nil
+}
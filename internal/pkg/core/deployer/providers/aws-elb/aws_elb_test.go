@@ -0,0 +1,331 @@
+package awselb_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/aws-elb"
+)
+
+// 模拟 ACM 与 ELBv2 接口的本地服务。
+type fakeAWS struct {
+	mu sync.Mutex
+	t  *testing.T
+
+	acmCerts  map[string]*fakeACMCert
+	listeners map[string]*fakeListener
+
+	calls []string
+}
+
+type fakeACMCert struct {
+	Arn  string
+	PEM  string
+	X509 *x509.Certificate
+	SANs []string
+}
+
+type fakeListener struct {
+	Arn          string
+	Protocol     string
+	DefaultCert  string
+	SniCertArns  []string
+	LoadBalancer string
+}
+
+func newFakeAWS(t *testing.T) *fakeAWS {
+	return &fakeAWS{
+		t:         t,
+		acmCerts:  make(map[string]*fakeACMCert),
+		listeners: make(map[string]*fakeListener),
+	}
+}
+
+func (f *fakeAWS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	if target := r.Header.Get("X-Amz-Target"); target != "" {
+		f.serveACM(w, strings.TrimPrefix(target, "CertificateManager."), body)
+		return
+	}
+
+	form, _ := url.ParseQuery(string(body))
+	f.serveELB(w, form)
+}
+
+func (f *fakeAWS) serveACM(w http.ResponseWriter, action string, body []byte) {
+	f.calls = append(f.calls, "acm."+action)
+
+	req := map[string]any{}
+	json.Unmarshal(body, &req)
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	switch action {
+	case "ListCertificates":
+		summaries := make([]map[string]any, 0)
+		for _, c := range f.acmCerts {
+			if c.X509 == nil {
+				continue
+			}
+			summaries = append(summaries, map[string]any{
+				"CertificateArn":                       c.Arn,
+				"DomainName":                           c.X509.Subject.CommonName,
+				"NotBefore":                            c.X509.NotBefore.Unix(),
+				"NotAfter":                             c.X509.NotAfter.Unix(),
+				"SubjectAlternativeNameSummaries":      c.X509.DNSNames,
+				"HasAdditionalSubjectAlternativeNames": false,
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"CertificateSummaryList": summaries})
+
+	case "GetCertificate":
+		c := f.acmCerts[req["CertificateArn"].(string)]
+		json.NewEncoder(w).Encode(map[string]any{"Certificate": c.PEM})
+
+	case "ImportCertificate":
+		certPEM, _ := base64Decode(req["Certificate"].(string))
+		block, _ := pem.Decode(certPEM)
+		certX509, _ := x509.ParseCertificate(block.Bytes)
+		arn := fmt.Sprintf("arn:aws:acm:us-east-1:123456789012:certificate/imported-%d", len(f.acmCerts))
+		f.acmCerts[arn] = &fakeACMCert{Arn: arn, PEM: string(certPEM), X509: certX509, SANs: certX509.DNSNames}
+		json.NewEncoder(w).Encode(map[string]any{"CertificateArn": arn})
+
+	case "DescribeCertificate":
+		c, ok := f.acmCerts[req["CertificateArn"].(string)]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{"__type": "ResourceNotFoundException", "message": "not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"Certificate": map[string]any{"CertificateArn": c.Arn, "SubjectAlternativeNames": c.SANs}})
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (f *fakeAWS) serveELB(w http.ResponseWriter, form url.Values) {
+	action := form.Get("Action")
+	f.calls = append(f.calls, "elbv2."+action)
+
+	members := func(prefix string) []string {
+		result := make([]string, 0)
+		for i := 1; ; i++ {
+			v := form.Get(fmt.Sprintf(prefix, i))
+			if v == "" {
+				return result
+			}
+			result = append(result, v)
+		}
+	}
+	listener := f.listeners[form.Get("ListenerArn")]
+
+	w.Header().Set("Content-Type", "text/xml")
+	switch action {
+	case "DescribeListeners":
+		var sb strings.Builder
+		for _, l := range f.listeners {
+			if l.LoadBalancer == form.Get("LoadBalancerArn") {
+				fmt.Fprintf(&sb, "<member><ListenerArn>%s</ListenerArn><Protocol>%s</Protocol></member>", l.Arn, l.Protocol)
+			}
+		}
+		fmt.Fprintf(w, "<DescribeListenersResponse><DescribeListenersResult><Listeners>%s</Listeners></DescribeListenersResult></DescribeListenersResponse>", sb.String())
+
+	case "DescribeListenerCertificates":
+		var sb strings.Builder
+		if listener.DefaultCert != "" {
+			fmt.Fprintf(&sb, "<member><CertificateArn>%s</CertificateArn><IsDefault>true</IsDefault></member>", listener.DefaultCert)
+		}
+		for _, arn := range listener.SniCertArns {
+			fmt.Fprintf(&sb, "<member><CertificateArn>%s</CertificateArn><IsDefault>false</IsDefault></member>", arn)
+		}
+		fmt.Fprintf(w, "<DescribeListenerCertificatesResponse><DescribeListenerCertificatesResult><Certificates>%s</Certificates></DescribeListenerCertificatesResult></DescribeListenerCertificatesResponse>", sb.String())
+
+	case "ModifyListener":
+		listener.DefaultCert = form.Get("Certificates.member.1.CertificateArn")
+		fmt.Fprint(w, "<ModifyListenerResponse><ModifyListenerResult><Listeners/></ModifyListenerResult></ModifyListenerResponse>")
+
+	case "AddListenerCertificates":
+		listener.SniCertArns = append(listener.SniCertArns, members("Certificates.member.%d.CertificateArn")...)
+		fmt.Fprint(w, "<AddListenerCertificatesResponse><AddListenerCertificatesResult><Certificates/></AddListenerCertificatesResult></AddListenerCertificatesResponse>")
+
+	case "RemoveListenerCertificates":
+		removed := members("Certificates.member.%d.CertificateArn")
+		listener.SniCertArns = slices.DeleteFunc(listener.SniCertArns, func(arn string) bool { return slices.Contains(removed, arn) })
+		fmt.Fprint(w, "<RemoveListenerCertificatesResponse><RemoveListenerCertificatesResult/></RemoveListenerCertificatesResponse>")
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "<ErrorResponse><Error><Type>Sender</Type><Code>InvalidAction</Code><Message>unknown action %s</Message></Error></ErrorResponse>", action)
+	}
+}
+
+func base64Decode(s string) ([]byte, error) {
+	var b []byte
+	err := json.Unmarshal([]byte(`"`+s+`"`), &b)
+	return b, err
+}
+
+func generateCertificate(t *testing.T, domains ...string) (string, string) {
+	t.Helper()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: domains[0]},
+		DNSNames:     domains,
+		NotBefore:    time.Now().Add(-time.Hour).Truncate(time.Second),
+		NotAfter:     time.Now().Add(24 * time.Hour).Truncate(time.Second),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestDeployRotation(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t, "example.com", "www.example.com")
+
+	const (
+		lbArn        = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/test/1"
+		httpsArn     = "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/app/test/1/https"
+		httpArn      = "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/app/test/1/http"
+		oldDefault   = "arn:aws:acm:us-east-1:123456789012:certificate/old-default"
+		oldSni       = "arn:aws:acm:us-east-1:123456789012:certificate/old-sni"
+		otherSni     = "arn:aws:acm:us-east-1:123456789012:certificate/other-sni"
+		iamSni       = "arn:aws:iam::123456789012:server-certificate/legacy"
+		accessKeyId  = "AKIDEXAMPLE"
+		accessSecret = "secret"
+	)
+
+	setup := func(t *testing.T) (*fakeAWS, *httptest.Server) {
+		fake := newFakeAWS(t)
+		fake.acmCerts[oldDefault] = &fakeACMCert{Arn: oldDefault, SANs: []string{"example.com", "www.example.com"}}
+		fake.acmCerts[oldSni] = &fakeACMCert{Arn: oldSni, SANs: []string{"www.example.com", "EXAMPLE.com"}}
+		fake.acmCerts[otherSni] = &fakeACMCert{Arn: otherSni, SANs: []string{"other.com"}}
+		fake.listeners[httpsArn] = &fakeListener{Arn: httpsArn, Protocol: "HTTPS", LoadBalancer: lbArn, DefaultCert: oldDefault, SniCertArns: []string{oldSni, otherSni, iamSni}}
+		fake.listeners[httpArn] = &fakeListener{Arn: httpArn, Protocol: "HTTP", LoadBalancer: lbArn}
+
+		server := httptest.NewServer(fake)
+		t.Cleanup(server.Close)
+		return fake, server
+	}
+
+	t.Run("DefaultCertificate_Loadbalancer", func(t *testing.T) {
+		fake, server := setup(t)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			AccessKeyId:     accessKeyId,
+			SecretAccessKey: accessSecret,
+			Region:          "us-east-1",
+			Endpoint:        server.URL,
+			ResourceType:    provider.RESOURCE_TYPE_LOADBALANCER,
+			LoadbalancerArn: lbArn,
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, keyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		listener := fake.listeners[httpsArn]
+		if !strings.Contains(listener.DefaultCert, "/imported-") {
+			t.Errorf("expected default certificate to be replaced, got '%s'", listener.DefaultCert)
+		}
+		if !slices.Equal(listener.SniCertArns, []string{otherSni, iamSni}) {
+			t.Errorf("expected old sni certificate to be removed, got %v", listener.SniCertArns)
+		}
+		if fake.listeners[httpArn].DefaultCert != "" {
+			t.Errorf("expected http listener to be untouched")
+		}
+
+		// 再次部署相同证书时不应重复导入或修改监听器
+		fake.calls = nil
+		if _, err := deployer.Deploy(context.Background(), certPEM, keyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if slices.Contains(fake.calls, "acm.ImportCertificate") || slices.Contains(fake.calls, "elbv2.ModifyListener") {
+			t.Errorf("expected no import or modification on redeploy, got calls %v", fake.calls)
+		}
+	})
+
+	t.Run("SniCertificate_Listener", func(t *testing.T) {
+		fake, server := setup(t)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			AccessKeyId:     accessKeyId,
+			SecretAccessKey: accessSecret,
+			Region:          "us-east-1",
+			Endpoint:        server.URL,
+			ResourceType:    provider.RESOURCE_TYPE_LISTENER,
+			ListenerArn:     httpsArn,
+			CertificateType: provider.CERTIFICATE_TYPE_SNI,
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, keyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		listener := fake.listeners[httpsArn]
+		if listener.DefaultCert != oldDefault {
+			t.Errorf("expected default certificate to be kept, got '%s'", listener.DefaultCert)
+		}
+		if len(listener.SniCertArns) != 3 || listener.SniCertArns[0] != otherSni || listener.SniCertArns[1] != iamSni || !strings.Contains(listener.SniCertArns[2], "/imported-") {
+			t.Errorf("unexpected sni certificates %v", listener.SniCertArns)
+		}
+	})
+
+	t.Run("InvalidCredentials", func(t *testing.T) {
+		_, server := setup(t)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			AccessKeyId:     "AKIDINVALID",
+			SecretAccessKey: accessSecret,
+			Region:          "us-east-1",
+			Endpoint:        server.URL,
+			ResourceType:    provider.RESOURCE_TYPE_LISTENER,
+			ListenerArn:     httpsArn,
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, keyPEM); err == nil {
+			t.Errorf("expected error with invalid credentials")
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package awselb
+
+type ResourceType string
+
+const (
+	// 资源类型：部署到指定负载均衡器的全部 HTTPS/TLS 监听器。
+	RESOURCE_TYPE_LOADBALANCER = ResourceType("loadbalancer")
+	// 资源类型：部署到指定监听器。
+	RESOURCE_TYPE_LISTENER = ResourceType("listener")
+)
+
+type CertificateType string
+
+const (
+	// 证书类型：替换监听器的默认证书。
+	CERTIFICATE_TYPE_DEFAULT = CertificateType("default")
+	// 证书类型：添加为监听器的 SNI 扩展证书。
+	CERTIFICATE_TYPE_SNI = CertificateType("sni")
+)
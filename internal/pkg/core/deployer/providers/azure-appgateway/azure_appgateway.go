@@ -0,0 +1,245 @@
+package azureappgateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	uploadersp "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/azure-keyvault"
+	armsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/azure/resourcemanager"
+)
+
+type DeployerConfig struct {
+	// Azure TenantId。
+	TenantId string `json:"tenantId"`
+	// Azure ClientId。
+	ClientId string `json:"clientId"`
+	// Azure ClientSecret。
+	ClientSecret string `json:"clientSecret"`
+	// Azure 主权云环境。
+	CloudName string `json:"cloudName,omitempty"`
+	// Azure 订阅 ID。
+	SubscriptionId string `json:"subscriptionId"`
+	// 资源组名称。
+	ResourceGroupName string `json:"resourceGroupName"`
+	// Key Vault 名称。
+	// 应用程序网关需配置有权读取该 Key Vault 机密的托管标识。
+	KeyVaultName string `json:"keyvaultName"`
+	// 应用程序网关名称。
+	ApplicationGatewayName string `json:"applicationGatewayName"`
+	// 侦听器名称。
+	ListenerName string `json:"listenerName"`
+}
+
+type DeployerProvider struct {
+	config      *DeployerConfig
+	logger      *slog.Logger
+	sdkClient   *armsdk.Client
+	sslUploader uploader.Uploader
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := armsdk.NewClient(config.TenantId, config.ClientId, config.ClientSecret, config.CloudName, config.SubscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	uploader, err := uploadersp.NewUploader(&uploadersp.UploaderConfig{
+		TenantId:     config.TenantId,
+		ClientId:     config.ClientId,
+		ClientSecret: config.ClientSecret,
+		CloudName:    config.CloudName,
+		KeyVaultName: config.KeyVaultName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:      config,
+		logger:      slog.Default(),
+		sdkClient:   client,
+		sslUploader: uploader,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	d.sslUploader.WithLogger(logger)
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.ResourceGroupName == "" {
+		return nil, errors.New("config `resourceGroupName` is required")
+	}
+	if d.config.ApplicationGatewayName == "" {
+		return nil, errors.New("config `applicationGatewayName` is required")
+	}
+	if d.config.ListenerName == "" {
+		return nil, errors.New("config `listenerName` is required")
+	}
+
+	// 上传证书到 KeyVault
+	upres, err := d.sslUploader.Upload(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+	} else {
+		d.logger.Info("ssl certificate uploaded", slog.Any("result", upres))
+	}
+
+	// 生成不含版本号的 KeyVault 机密标识符，形如 "https://{vault}.vault.azure.net/secrets/{name}"
+	keyvaultSecretId, err := parseKeyVaultSecretId(upres.CertId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取应用程序网关详情
+	// REF: https://learn.microsoft.com/en-us/rest/api/application-gateway/application-gateways/get
+	getApplicationGatewayResp, err := d.sdkClient.GetApplicationGateway(ctx, d.config.ResourceGroupName, d.config.ApplicationGatewayName)
+	d.logger.Debug("sdk request 'network.GetApplicationGateway'", slog.String("request.applicationGatewayName", d.config.ApplicationGatewayName), slog.Any("response", getApplicationGatewayResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'network.GetApplicationGateway': %w", err)
+	}
+
+	gateway := getApplicationGatewayResp
+	gatewayId, _ := gateway["id"].(string)
+	gatewayProps := getObject(gateway, "properties")
+	if gatewayId == "" || gatewayProps == nil {
+		return nil, fmt.Errorf("could not get properties of application gateway '%s'", d.config.ApplicationGatewayName)
+	}
+
+	// 查找引用相同 KeyVault 机密的 SSL 证书，不存在则新增
+	sslCertName := ""
+	sslCerts := getArray(gatewayProps, "sslCertificates")
+	for _, item := range sslCerts {
+		sslCert, _ := item.(map[string]any)
+		if sslCert == nil {
+			continue
+		}
+
+		secretId, _ := getObject(sslCert, "properties")["keyVaultSecretId"].(string)
+		if strings.EqualFold(strings.TrimSuffix(secretId, "/"), keyvaultSecretId) {
+			sslCertName, _ = sslCert["name"].(string)
+			break
+		}
+	}
+	if sslCertName == "" {
+		sslCertName = upres.CertName
+		sslCerts = append(sslCerts, map[string]any{
+			"name": sslCertName,
+			"properties": map[string]any{
+				"keyVaultSecretId": keyvaultSecretId,
+			},
+		})
+		gatewayProps["sslCertificates"] = sslCerts
+	}
+	sslCertId := fmt.Sprintf("%s/sslCertificates/%s", gatewayId, sslCertName)
+
+	// 查找侦听器，HTTPS 侦听器位于 httpListeners 中，TLS 侦听器位于 listeners 中
+	var listenerProps map[string]any
+	for _, key := range []string{"httpListeners", "listeners"} {
+		for _, item := range getArray(gatewayProps, key) {
+			listener, _ := item.(map[string]any)
+			if name, _ := listener["name"].(string); name == d.config.ListenerName {
+				listenerProps = getObject(listener, "properties")
+				break
+			}
+		}
+		if listenerProps != nil {
+			break
+		}
+	}
+	if listenerProps == nil {
+		return nil, fmt.Errorf("could not find listener '%s' in application gateway '%s'", d.config.ListenerName, d.config.ApplicationGatewayName)
+	}
+
+	oldSslCertId, _ := getObject(listenerProps, "sslCertificate")["id"].(string)
+	if strings.EqualFold(oldSslCertId, sslCertId) {
+		d.logger.Info("ssl certificate already bound to the listener")
+		return &deployer.DeployResult{}, nil
+	}
+
+	listenerProps["sslCertificate"] = map[string]any{"id": sslCertId}
+
+	// 移除不再被任何侦听器引用的旧证书（仅限由本程序创建的证书）
+	if oldSslCertId != "" && !isSslCertificateReferenced(gatewayProps, oldSslCertId) {
+		oldSslCertName := oldSslCertId[strings.LastIndex(oldSslCertId, "/")+1:]
+		if strings.HasPrefix(oldSslCertName, "certimate-") {
+			sslCerts = getArray(gatewayProps, "sslCertificates")
+			for i, item := range sslCerts {
+				sslCert, _ := item.(map[string]any)
+				if name, _ := sslCert["name"].(string); name == oldSslCertName {
+					gatewayProps["sslCertificates"] = append(sslCerts[:i], sslCerts[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+
+	// 更新应用程序网关
+	// REF: https://learn.microsoft.com/en-us/rest/api/application-gateway/application-gateways/create-or-update
+	createOrUpdateApplicationGatewayResp, err := d.sdkClient.CreateOrUpdateApplicationGateway(ctx, d.config.ResourceGroupName, d.config.ApplicationGatewayName, gateway)
+	d.logger.Debug("sdk request 'network.CreateOrUpdateApplicationGateway'", slog.String("request.applicationGatewayName", d.config.ApplicationGatewayName), slog.Any("request", gateway), slog.Any("response", createOrUpdateApplicationGatewayResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'network.CreateOrUpdateApplicationGateway': %w", err)
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func parseKeyVaultSecretId(certificateId string) (string, error) {
+	// KeyVault 证书标识符形如 "https://{vault}.vault.azure.net/certificates/{name}/{version}"
+	idx := strings.Index(certificateId, "/certificates/")
+	if idx < 0 {
+		return "", fmt.Errorf("invalid key vault certificate id '%s'", certificateId)
+	}
+
+	name := strings.SplitN(certificateId[idx+len("/certificates/"):], "/", 2)[0]
+	return fmt.Sprintf("%s/secrets/%s", certificateId[:idx], name), nil
+}
+
+func isSslCertificateReferenced(gatewayProps map[string]any, sslCertId string) bool {
+	for _, key := range []string{"httpListeners", "listeners"} {
+		for _, item := range getArray(gatewayProps, key) {
+			listener, _ := item.(map[string]any)
+			if id, _ := getObject(getObject(listener, "properties"), "sslCertificate")["id"].(string); strings.EqualFold(id, sslCertId) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func getObject(m map[string]any, key string) map[string]any {
+	if m == nil {
+		return nil
+	}
+
+	v, _ := m[key].(map[string]any)
+	return v
+}
+
+func getArray(m map[string]any, key string) []any {
+	if m == nil {
+		return nil
+	}
+
+	v, _ := m[key].([]any)
+	return v
+}
@@ -0,0 +1,100 @@
+package azureappgateway_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/azure-appgateway"
+)
+
+var (
+	fInputCertPath          string
+	fInputKeyPath           string
+	fTenantId               string
+	fClientId               string
+	fClientSecret           string
+	fSubscriptionId         string
+	fResourceGroupName      string
+	fKeyVaultName           string
+	fApplicationGatewayName string
+	fListenerName           string
+)
+
+func init() {
+	argsPrefix := "CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_"
+
+	flag.StringVar(&fInputCertPath, argsPrefix+"INPUTCERTPATH", "", "")
+	flag.StringVar(&fInputKeyPath, argsPrefix+"INPUTKEYPATH", "", "")
+	flag.StringVar(&fTenantId, argsPrefix+"TENANTID", "", "")
+	flag.StringVar(&fClientId, argsPrefix+"CLIENTID", "", "")
+	flag.StringVar(&fClientSecret, argsPrefix+"CLIENTSECRET", "", "")
+	flag.StringVar(&fSubscriptionId, argsPrefix+"SUBSCRIPTIONID", "", "")
+	flag.StringVar(&fResourceGroupName, argsPrefix+"RESOURCEGROUPNAME", "", "")
+	flag.StringVar(&fKeyVaultName, argsPrefix+"KEYVAULTNAME", "", "")
+	flag.StringVar(&fApplicationGatewayName, argsPrefix+"APPLICATIONGATEWAYNAME", "", "")
+	flag.StringVar(&fListenerName, argsPrefix+"LISTENERNAME", "", "")
+}
+
+/*
+Shell command to run this test:
+
+	go test -v ./azure_appgateway_test.go -args \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_INPUTCERTPATH="/path/to/your-input-cert.pem" \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_INPUTKEYPATH="/path/to/your-input-key.pem" \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_TENANTID="your-tenant-id" \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_CLIENTID="your-app-registration-client-id" \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_CLIENTSECRET="your-app-registration-client-secret" \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_SUBSCRIPTIONID="your-subscription-id" \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_RESOURCEGROUPNAME="your-resource-group-name" \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_KEYVAULTNAME="your-keyvault-name" \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_APPLICATIONGATEWAYNAME="your-application-gateway-name" \
+	--CERTIMATE_DEPLOYER_AZUREAPPGATEWAY_LISTENERNAME="your-listener-name"
+*/
+func TestDeploy(t *testing.T) {
+	flag.Parse()
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("TENANTID: %v", fTenantId),
+			fmt.Sprintf("CLIENTID: %v", fClientId),
+			fmt.Sprintf("CLIENTSECRET: %v", fClientSecret),
+			fmt.Sprintf("SUBSCRIPTIONID: %v", fSubscriptionId),
+			fmt.Sprintf("RESOURCEGROUPNAME: %v", fResourceGroupName),
+			fmt.Sprintf("KEYVAULTNAME: %v", fKeyVaultName),
+			fmt.Sprintf("APPLICATIONGATEWAYNAME: %v", fApplicationGatewayName),
+			fmt.Sprintf("LISTENERNAME: %v", fListenerName),
+		}, "\n"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			TenantId:               fTenantId,
+			ClientId:               fClientId,
+			ClientSecret:           fClientSecret,
+			SubscriptionId:         fSubscriptionId,
+			ResourceGroupName:      fResourceGroupName,
+			KeyVaultName:           fKeyVaultName,
+			ApplicationGatewayName: fApplicationGatewayName,
+			ListenerName:           fListenerName,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+}
@@ -0,0 +1,175 @@
+package azureappservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	uploadersp "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/azure-keyvault"
+	armsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/azure/resourcemanager"
+)
+
+type DeployerConfig struct {
+	// Azure TenantId。
+	TenantId string `json:"tenantId"`
+	// Azure ClientId。
+	ClientId string `json:"clientId"`
+	// Azure ClientSecret。
+	ClientSecret string `json:"clientSecret"`
+	// Azure 主权云环境。
+	CloudName string `json:"cloudName,omitempty"`
+	// Azure 订阅 ID。
+	SubscriptionId string `json:"subscriptionId"`
+	// 资源组名称。
+	ResourceGroupName string `json:"resourceGroupName"`
+	// Key Vault 名称。
+	// 需与应用服务位于同一订阅下，且已授予 App Service 资源提供程序读取机密的权限。
+	KeyVaultName string `json:"keyvaultName"`
+	// 应用服务名称。
+	SiteName string `json:"siteName"`
+	// 部署槽位名称。
+	// 选填。零值时表示生产槽位。
+	SlotName string `json:"slotName,omitempty"`
+	// 自定义域名（不支持泛域名）。
+	Domain string `json:"domain"`
+}
+
+type DeployerProvider struct {
+	config      *DeployerConfig
+	logger      *slog.Logger
+	sdkClient   *armsdk.Client
+	sslUploader uploader.Uploader
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := armsdk.NewClient(config.TenantId, config.ClientId, config.ClientSecret, config.CloudName, config.SubscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	uploader, err := uploadersp.NewUploader(&uploadersp.UploaderConfig{
+		TenantId:     config.TenantId,
+		ClientId:     config.ClientId,
+		ClientSecret: config.ClientSecret,
+		CloudName:    config.CloudName,
+		KeyVaultName: config.KeyVaultName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:      config,
+		logger:      slog.Default(),
+		sdkClient:   client,
+		sslUploader: uploader,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	d.sslUploader.WithLogger(logger)
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.ResourceGroupName == "" {
+		return nil, errors.New("config `resourceGroupName` is required")
+	}
+	if d.config.SiteName == "" {
+		return nil, errors.New("config `siteName` is required")
+	}
+	if d.config.Domain == "" {
+		return nil, errors.New("config `domain` is required")
+	}
+
+	// 上传证书到 KeyVault
+	upres, err := d.sslUploader.Upload(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+	} else {
+		d.logger.Info("ssl certificate uploaded", slog.Any("result", upres))
+	}
+
+	// 查询 KeyVault 的资源 ID
+	keyvaultId, err := d.sdkClient.FindKeyVaultId(ctx, d.config.KeyVaultName)
+	d.logger.Debug("sdk request 'resources.ListResources'", slog.String("request.keyvaultName", d.config.KeyVaultName), slog.String("response.keyvaultId", keyvaultId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'resources.ListResources': %w", err)
+	}
+
+	// 获取应用服务详情，证书需与应用服务计划位于同一区域
+	// REF: https://learn.microsoft.com/en-us/rest/api/appservice/web-apps/get
+	getWebAppResp, err := d.sdkClient.GetWebApp(ctx, d.config.ResourceGroupName, d.config.SiteName, d.config.SlotName)
+	d.logger.Debug("sdk request 'appservice.GetWebApp'", slog.String("request.siteName", d.config.SiteName), slog.String("request.slotName", d.config.SlotName), slog.Any("response", getWebAppResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'appservice.GetWebApp': %w", err)
+	} else if getWebAppResp.Properties == nil {
+		return nil, fmt.Errorf("could not get server farm of web app '%s'", d.config.SiteName)
+	}
+
+	// 从 KeyVault 导入应用服务证书
+	// 证书名称与 KeyVault 中的证书名称保持一致，重复部署相同证书时该操作是幂等的
+	// REF: https://learn.microsoft.com/en-us/rest/api/appservice/certificates/create-or-update
+	createOrUpdateWebCertificateReq := &armsdk.WebCertificate{
+		Location: getWebAppResp.Location,
+		Properties: &armsdk.WebCertificateProperties{
+			KeyVaultId:         keyvaultId,
+			KeyVaultSecretName: upres.CertName,
+			ServerFarmId:       getWebAppResp.Properties.ServerFarmId,
+		},
+	}
+	createOrUpdateWebCertificateResp, err := d.sdkClient.CreateOrUpdateWebCertificate(ctx, d.config.ResourceGroupName, upres.CertName, createOrUpdateWebCertificateReq)
+	d.logger.Debug("sdk request 'appservice.CreateOrUpdateWebCertificate'", slog.String("request.certificateName", upres.CertName), slog.Any("request", createOrUpdateWebCertificateReq), slog.Any("response", createOrUpdateWebCertificateResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'appservice.CreateOrUpdateWebCertificate': %w", err)
+	} else if createOrUpdateWebCertificateResp.Properties == nil || createOrUpdateWebCertificateResp.Properties.Thumbprint == "" {
+		return nil, fmt.Errorf("could not get thumbprint of certificate '%s'", upres.CertName)
+	}
+
+	thumbprint := createOrUpdateWebCertificateResp.Properties.Thumbprint
+
+	// 获取自定义域名绑定，若已绑定相同证书则跳过
+	// REF: https://learn.microsoft.com/en-us/rest/api/appservice/web-apps/get-host-name-binding
+	getHostNameBindingResp, err := d.sdkClient.GetWebAppHostNameBinding(ctx, d.config.ResourceGroupName, d.config.SiteName, d.config.SlotName, d.config.Domain)
+	d.logger.Debug("sdk request 'appservice.GetWebAppHostNameBinding'", slog.String("request.hostName", d.config.Domain), slog.Any("response", getHostNameBindingResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'appservice.GetWebAppHostNameBinding': %w", err)
+	} else if getHostNameBindingResp.Properties != nil &&
+		getHostNameBindingResp.Properties.SslState == armsdk.SSL_STATE_SNI_ENABLED &&
+		strings.EqualFold(getHostNameBindingResp.Properties.Thumbprint, thumbprint) {
+		d.logger.Info("ssl certificate already bound to the custom domain")
+		return &deployer.DeployResult{}, nil
+	}
+
+	// 更新自定义域名绑定，启用 SNI SSL
+	// REF: https://learn.microsoft.com/en-us/rest/api/appservice/web-apps/create-or-update-host-name-binding
+	createOrUpdateHostNameBindingReq := &armsdk.WebHostNameBinding{
+		Properties: &armsdk.WebHostNameBindingProperties{
+			SiteName:   d.config.SiteName,
+			SslState:   armsdk.SSL_STATE_SNI_ENABLED,
+			Thumbprint: thumbprint,
+		},
+	}
+	createOrUpdateHostNameBindingResp, err := d.sdkClient.CreateOrUpdateWebAppHostNameBinding(ctx, d.config.ResourceGroupName, d.config.SiteName, d.config.SlotName, d.config.Domain, createOrUpdateHostNameBindingReq)
+	d.logger.Debug("sdk request 'appservice.CreateOrUpdateWebAppHostNameBinding'", slog.String("request.hostName", d.config.Domain), slog.Any("request", createOrUpdateHostNameBindingReq), slog.Any("response", createOrUpdateHostNameBindingResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'appservice.CreateOrUpdateWebAppHostNameBinding': %w", err)
+	}
+
+	return &deployer.DeployResult{}, nil
+}
@@ -0,0 +1,100 @@
+package azureappservice_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/azure-appservice"
+)
+
+var (
+	fInputCertPath     string
+	fInputKeyPath      string
+	fTenantId          string
+	fClientId          string
+	fClientSecret      string
+	fSubscriptionId    string
+	fResourceGroupName string
+	fKeyVaultName      string
+	fSiteName          string
+	fDomain            string
+)
+
+func init() {
+	argsPrefix := "CERTIMATE_DEPLOYER_AZUREAPPSERVICE_"
+
+	flag.StringVar(&fInputCertPath, argsPrefix+"INPUTCERTPATH", "", "")
+	flag.StringVar(&fInputKeyPath, argsPrefix+"INPUTKEYPATH", "", "")
+	flag.StringVar(&fTenantId, argsPrefix+"TENANTID", "", "")
+	flag.StringVar(&fClientId, argsPrefix+"CLIENTID", "", "")
+	flag.StringVar(&fClientSecret, argsPrefix+"CLIENTSECRET", "", "")
+	flag.StringVar(&fSubscriptionId, argsPrefix+"SUBSCRIPTIONID", "", "")
+	flag.StringVar(&fResourceGroupName, argsPrefix+"RESOURCEGROUPNAME", "", "")
+	flag.StringVar(&fKeyVaultName, argsPrefix+"KEYVAULTNAME", "", "")
+	flag.StringVar(&fSiteName, argsPrefix+"SITENAME", "", "")
+	flag.StringVar(&fDomain, argsPrefix+"DOMAIN", "", "")
+}
+
+/*
+Shell command to run this test:
+
+	go test -v ./azure_appservice_test.go -args \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_INPUTCERTPATH="/path/to/your-input-cert.pem" \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_INPUTKEYPATH="/path/to/your-input-key.pem" \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_TENANTID="your-tenant-id" \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_CLIENTID="your-app-registration-client-id" \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_CLIENTSECRET="your-app-registration-client-secret" \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_SUBSCRIPTIONID="your-subscription-id" \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_RESOURCEGROUPNAME="your-resource-group-name" \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_KEYVAULTNAME="your-keyvault-name" \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_SITENAME="your-web-app-name" \
+	--CERTIMATE_DEPLOYER_AZUREAPPSERVICE_DOMAIN="www.example.com"
+*/
+func TestDeploy(t *testing.T) {
+	flag.Parse()
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("TENANTID: %v", fTenantId),
+			fmt.Sprintf("CLIENTID: %v", fClientId),
+			fmt.Sprintf("CLIENTSECRET: %v", fClientSecret),
+			fmt.Sprintf("SUBSCRIPTIONID: %v", fSubscriptionId),
+			fmt.Sprintf("RESOURCEGROUPNAME: %v", fResourceGroupName),
+			fmt.Sprintf("KEYVAULTNAME: %v", fKeyVaultName),
+			fmt.Sprintf("SITENAME: %v", fSiteName),
+			fmt.Sprintf("DOMAIN: %v", fDomain),
+		}, "\n"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			TenantId:          fTenantId,
+			ClientId:          fClientId,
+			ClientSecret:      fClientSecret,
+			SubscriptionId:    fSubscriptionId,
+			ResourceGroupName: fResourceGroupName,
+			KeyVaultName:      fKeyVaultName,
+			SiteName:          fSiteName,
+			Domain:            fDomain,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+}
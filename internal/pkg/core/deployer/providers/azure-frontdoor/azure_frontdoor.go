@@ -0,0 +1,174 @@
+package azurefrontdoor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	uploadersp "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/azure-keyvault"
+	armsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/azure/resourcemanager"
+)
+
+type DeployerConfig struct {
+	// Azure TenantId。
+	TenantId string `json:"tenantId"`
+	// Azure ClientId。
+	ClientId string `json:"clientId"`
+	// Azure ClientSecret。
+	ClientSecret string `json:"clientSecret"`
+	// Azure 主权云环境。
+	CloudName string `json:"cloudName,omitempty"`
+	// Azure 订阅 ID。
+	SubscriptionId string `json:"subscriptionId"`
+	// 资源组名称。
+	ResourceGroupName string `json:"resourceGroupName"`
+	// Key Vault 名称。
+	// 需与 Front Door 配置文件位于同一订阅下，且已授予 Front Door 读取机密的权限。
+	KeyVaultName string `json:"keyvaultName"`
+	// Front Door 配置文件名称。
+	ProfileName string `json:"profileName"`
+	// Front Door 自定义域名资源名称。
+	CustomDomainName string `json:"customDomainName"`
+}
+
+type DeployerProvider struct {
+	config      *DeployerConfig
+	logger      *slog.Logger
+	sdkClient   *armsdk.Client
+	sslUploader uploader.Uploader
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := armsdk.NewClient(config.TenantId, config.ClientId, config.ClientSecret, config.CloudName, config.SubscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	uploader, err := uploadersp.NewUploader(&uploadersp.UploaderConfig{
+		TenantId:     config.TenantId,
+		ClientId:     config.ClientId,
+		ClientSecret: config.ClientSecret,
+		CloudName:    config.CloudName,
+		KeyVaultName: config.KeyVaultName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:      config,
+		logger:      slog.Default(),
+		sdkClient:   client,
+		sslUploader: uploader,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	d.sslUploader.WithLogger(logger)
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.ResourceGroupName == "" {
+		return nil, errors.New("config `resourceGroupName` is required")
+	}
+	if d.config.ProfileName == "" {
+		return nil, errors.New("config `profileName` is required")
+	}
+	if d.config.CustomDomainName == "" {
+		return nil, errors.New("config `customDomainName` is required")
+	}
+
+	// 上传证书到 KeyVault
+	upres, err := d.sslUploader.Upload(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+	} else {
+		d.logger.Info("ssl certificate uploaded", slog.Any("result", upres))
+	}
+
+	// 查询 KeyVault 的资源 ID
+	keyvaultId, err := d.sdkClient.FindKeyVaultId(ctx, d.config.KeyVaultName)
+	d.logger.Debug("sdk request 'resources.ListResources'", slog.String("request.keyvaultName", d.config.KeyVaultName), slog.String("response.keyvaultId", keyvaultId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'resources.ListResources': %w", err)
+	}
+
+	// 创建或更新 Front Door 机密，引用 KeyVault 中的证书
+	// 机密名称与 KeyVault 中的证书名称保持一致，重复部署相同证书时该操作是幂等的
+	// REF: https://learn.microsoft.com/en-us/rest/api/frontdoor/azurefrontdoorstandardpremium/secrets/create
+	createOrUpdateSecretReq := &armsdk.AfdSecret{
+		Properties: &armsdk.AfdSecretProperties{
+			Parameters: &armsdk.AfdSecretParameters{
+				Type:             armsdk.AFD_SECRET_TYPE_CUSTOMER_CERTIFICATE,
+				SecretSource:     &armsdk.ResourceRef{Id: fmt.Sprintf("%s/secrets/%s", keyvaultId, upres.CertName)},
+				UseLatestVersion: true,
+			},
+		},
+	}
+	createOrUpdateSecretResp, err := d.sdkClient.CreateOrUpdateAfdSecret(ctx, d.config.ResourceGroupName, d.config.ProfileName, upres.CertName, createOrUpdateSecretReq)
+	d.logger.Debug("sdk request 'cdn.CreateOrUpdateAfdSecret'", slog.String("request.secretName", upres.CertName), slog.Any("request", createOrUpdateSecretReq), slog.Any("response", createOrUpdateSecretResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'cdn.CreateOrUpdateAfdSecret': %w", err)
+	}
+
+	secretId := createOrUpdateSecretResp.Id
+	if secretId == "" {
+		secretId = d.sdkClient.ResourceGroupId(d.config.ResourceGroupName) + fmt.Sprintf("/providers/Microsoft.Cdn/profiles/%s/secrets/%s", d.config.ProfileName, upres.CertName)
+	}
+
+	// 获取自定义域名详情，若已使用相同机密则跳过
+	// REF: https://learn.microsoft.com/en-us/rest/api/frontdoor/azurefrontdoorstandardpremium/afd-custom-domains/get
+	getCustomDomainResp, err := d.sdkClient.GetAfdCustomDomain(ctx, d.config.ResourceGroupName, d.config.ProfileName, d.config.CustomDomainName)
+	d.logger.Debug("sdk request 'cdn.GetAfdCustomDomain'", slog.String("request.customDomainName", d.config.CustomDomainName), slog.Any("response", getCustomDomainResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'cdn.GetAfdCustomDomain': %w", err)
+	}
+
+	minimumTlsVersion := "TLS12"
+	if getCustomDomainResp.Properties != nil && getCustomDomainResp.Properties.TlsSettings != nil {
+		tlsSettings := getCustomDomainResp.Properties.TlsSettings
+		if tlsSettings.Secret != nil && strings.EqualFold(tlsSettings.Secret.Id, secretId) {
+			d.logger.Info("ssl certificate already bound to the custom domain")
+			return &deployer.DeployResult{}, nil
+		}
+
+		if tlsSettings.MinimumTlsVersion != "" {
+			minimumTlsVersion = tlsSettings.MinimumTlsVersion
+		}
+	}
+
+	// 更新自定义域名，切换为自有证书
+	// REF: https://learn.microsoft.com/en-us/rest/api/frontdoor/azurefrontdoorstandardpremium/afd-custom-domains/update
+	updateCustomDomainReq := &armsdk.AfdCustomDomain{
+		Properties: &armsdk.AfdCustomDomainProperties{
+			TlsSettings: &armsdk.AfdDomainTlsSettings{
+				CertificateType:   armsdk.AFD_SECRET_TYPE_CUSTOMER_CERTIFICATE,
+				MinimumTlsVersion: minimumTlsVersion,
+				Secret:            &armsdk.ResourceRef{Id: secretId},
+			},
+		},
+	}
+	updateCustomDomainResp, err := d.sdkClient.UpdateAfdCustomDomain(ctx, d.config.ResourceGroupName, d.config.ProfileName, d.config.CustomDomainName, updateCustomDomainReq)
+	d.logger.Debug("sdk request 'cdn.UpdateAfdCustomDomain'", slog.String("request.customDomainName", d.config.CustomDomainName), slog.Any("request", updateCustomDomainReq), slog.Any("response", updateCustomDomainResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'cdn.UpdateAfdCustomDomain': %w", err)
+	}
+
+	return &deployer.DeployResult{}, nil
+}
@@ -0,0 +1,100 @@
+package azurefrontdoor_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/azure-frontdoor"
+)
+
+var (
+	fInputCertPath     string
+	fInputKeyPath      string
+	fTenantId          string
+	fClientId          string
+	fClientSecret      string
+	fSubscriptionId    string
+	fResourceGroupName string
+	fKeyVaultName      string
+	fProfileName       string
+	fCustomDomainName  string
+)
+
+func init() {
+	argsPrefix := "CERTIMATE_DEPLOYER_AZUREFRONTDOOR_"
+
+	flag.StringVar(&fInputCertPath, argsPrefix+"INPUTCERTPATH", "", "")
+	flag.StringVar(&fInputKeyPath, argsPrefix+"INPUTKEYPATH", "", "")
+	flag.StringVar(&fTenantId, argsPrefix+"TENANTID", "", "")
+	flag.StringVar(&fClientId, argsPrefix+"CLIENTID", "", "")
+	flag.StringVar(&fClientSecret, argsPrefix+"CLIENTSECRET", "", "")
+	flag.StringVar(&fSubscriptionId, argsPrefix+"SUBSCRIPTIONID", "", "")
+	flag.StringVar(&fResourceGroupName, argsPrefix+"RESOURCEGROUPNAME", "", "")
+	flag.StringVar(&fKeyVaultName, argsPrefix+"KEYVAULTNAME", "", "")
+	flag.StringVar(&fProfileName, argsPrefix+"PROFILENAME", "", "")
+	flag.StringVar(&fCustomDomainName, argsPrefix+"CUSTOMDOMAINNAME", "", "")
+}
+
+/*
+Shell command to run this test:
+
+	go test -v ./azure_frontdoor_test.go -args \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_INPUTCERTPATH="/path/to/your-input-cert.pem" \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_INPUTKEYPATH="/path/to/your-input-key.pem" \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_TENANTID="your-tenant-id" \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_CLIENTID="your-app-registration-client-id" \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_CLIENTSECRET="your-app-registration-client-secret" \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_SUBSCRIPTIONID="your-subscription-id" \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_RESOURCEGROUPNAME="your-resource-group-name" \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_KEYVAULTNAME="your-keyvault-name" \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_PROFILENAME="your-frontdoor-profile-name" \
+	--CERTIMATE_DEPLOYER_AZUREFRONTDOOR_CUSTOMDOMAINNAME="your-custom-domain-name"
+*/
+func TestDeploy(t *testing.T) {
+	flag.Parse()
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("TENANTID: %v", fTenantId),
+			fmt.Sprintf("CLIENTID: %v", fClientId),
+			fmt.Sprintf("CLIENTSECRET: %v", fClientSecret),
+			fmt.Sprintf("SUBSCRIPTIONID: %v", fSubscriptionId),
+			fmt.Sprintf("RESOURCEGROUPNAME: %v", fResourceGroupName),
+			fmt.Sprintf("KEYVAULTNAME: %v", fKeyVaultName),
+			fmt.Sprintf("PROFILENAME: %v", fProfileName),
+			fmt.Sprintf("CUSTOMDOMAINNAME: %v", fCustomDomainName),
+		}, "\n"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			TenantId:          fTenantId,
+			ClientId:          fClientId,
+			ClientSecret:      fClientSecret,
+			SubscriptionId:    fSubscriptionId,
+			ResourceGroupName: fResourceGroupName,
+			KeyVaultName:      fKeyVaultName,
+			ProfileName:       fProfileName,
+			CustomDomainName:  fCustomDomainName,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+}
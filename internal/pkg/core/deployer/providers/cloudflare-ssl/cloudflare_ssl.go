@@ -0,0 +1,252 @@
+package cloudflaressl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	cfsdk "github.com/cloudflare/cloudflare-go"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	uploadersp "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/cloudflare-ssl"
+	cfcommon "github.com/usual2970/certimate/internal/pkg/sdk3rd/cloudflare/common"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// Cloudflare API Token。
+	// 需具有区域的 "SSL and Certificates: Edit" 权限。
+	ApiToken string `json:"apiToken"`
+	// Cloudflare 区域 ID。
+	ZoneId string `json:"zoneId"`
+	// 部署资源类型。
+	ResourceType ResourceType `json:"resourceType"`
+	// 自定义证书 ID。
+	// 部署资源类型为 [RESOURCE_TYPE_CERTIFICATE] 时选填。零值时原地替换域名相同的证书，不存在时新建证书；否则表示原地替换指定证书并保留证书 ID。
+	CertificateId string `json:"certificateId,omitempty"`
+	// 自定义主机名。
+	// 部署资源类型为 [RESOURCE_TYPE_CUSTOM_HOSTNAME] 时必填。
+	CustomHostname string `json:"customHostname,omitempty"`
+	// 证书链捆绑方式。
+	// 选填。零值时默认值 [BUNDLE_METHOD_UBIQUITOUS]。
+	BundleMethod string `json:"bundleMethod,omitempty"`
+}
+
+type DeployerProvider struct {
+	config      *DeployerConfig
+	logger      *slog.Logger
+	sdkClient   *cfsdk.API
+	sslUploader uploader.Uploader
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ApiToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	uploader, err := uploadersp.NewUploader(&uploadersp.UploaderConfig{
+		ApiToken:     config.ApiToken,
+		ZoneId:       config.ZoneId,
+		BundleMethod: config.BundleMethod,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:      config,
+		logger:      slog.Default(),
+		sdkClient:   client,
+		sslUploader: uploader,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	d.sslUploader.WithLogger(logger)
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.ZoneId == "" {
+		return nil, errors.New("config `zoneId` is required")
+	}
+
+	// 根据部署资源类型决定部署方式
+	switch d.config.ResourceType {
+	case RESOURCE_TYPE_CERTIFICATE:
+		if err := d.deployToCertificate(ctx, certPEM, privkeyPEM); err != nil {
+			return nil, err
+		}
+
+	case RESOURCE_TYPE_CUSTOM_HOSTNAME:
+		if err := d.deployToCustomHostname(ctx, certPEM, privkeyPEM); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported resource type '%s'", d.config.ResourceType)
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func (d *DeployerProvider) deployToCertificate(ctx context.Context, certPEM string, privkeyPEM string) error {
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return err
+	}
+
+	certificateId := d.config.CertificateId
+	if certificateId == "" {
+		// 获取自定义证书列表，若证书未变化则跳过，否则查找域名相同的证书以原地替换
+		// REF: https://developers.cloudflare.com/api/resources/custom_certificates/methods/list/
+		listSSLResp, err := d.sdkClient.ListSSL(ctx, d.config.ZoneId)
+		d.logger.Debug("sdk request 'cloudflare.ListSSL'", slog.String("request.zoneId", d.config.ZoneId), slog.Any("response", listSSLResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'cloudflare.ListSSL': %w", err)
+		}
+
+		for _, customSSL := range listSSLResp {
+			if cfcommon.IsSameCertificate(&customSSL, certX509) {
+				d.logger.Info("ssl certificate is up to date", slog.String("certificateId", customSSL.ID))
+				return nil
+			}
+		}
+
+		for _, customSSL := range listSSLResp {
+			if cfcommon.IsSameHosts(&customSSL, certX509) {
+				certificateId = customSSL.ID
+				d.logger.Info("found existing certificate with the same hosts", slog.String("certificateId", certificateId))
+				break
+			}
+		}
+
+		if certificateId == "" {
+			// 上传证书
+			upres, err := d.sslUploader.Upload(ctx, certPEM, privkeyPEM)
+			if err != nil {
+				return fmt.Errorf("failed to upload certificate file: %w", err)
+			} else {
+				d.logger.Info("ssl certificate uploaded", slog.Any("result", upres))
+			}
+
+			return nil
+		}
+	} else {
+		// 获取自定义证书详情，若证书未变化则跳过
+		// REF: https://developers.cloudflare.com/api/resources/custom_certificates/methods/get/
+		sslDetailsResp, err := d.sdkClient.SSLDetails(ctx, d.config.ZoneId, certificateId)
+		d.logger.Debug("sdk request 'cloudflare.SSLDetails'", slog.String("request.zoneId", d.config.ZoneId), slog.String("request.certificateId", certificateId), slog.Any("response", sslDetailsResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'cloudflare.SSLDetails': %w", err)
+		} else if cfcommon.IsSameCertificate(&sslDetailsResp, certX509) {
+			d.logger.Info("ssl certificate is up to date")
+			return nil
+		}
+	}
+
+	// 原地替换自定义证书，证书 ID 保持不变
+	// REF: https://developers.cloudflare.com/api/resources/custom_certificates/methods/edit/
+	updateSSLReq := cfsdk.ZoneCustomSSLOptions{
+		Certificate:  certPEM,
+		PrivateKey:   privkeyPEM,
+		BundleMethod: d.getBundleMethod(),
+	}
+	updateSSLResp, err := d.sdkClient.UpdateSSL(ctx, d.config.ZoneId, certificateId, updateSSLReq)
+	d.logger.Debug("sdk request 'cloudflare.UpdateSSL'", slog.String("request.zoneId", d.config.ZoneId), slog.String("request.certificateId", certificateId), slog.Any("response", updateSSLResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'cloudflare.UpdateSSL': %w", err)
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) deployToCustomHostname(ctx context.Context, certPEM string, privkeyPEM string) error {
+	if d.config.CustomHostname == "" {
+		return errors.New("config `customHostname` is required")
+	}
+
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return err
+	}
+
+	// 根据主机名查找自定义主机名
+	// REF: https://developers.cloudflare.com/api/resources/custom_hostnames/methods/list/
+	customHostnamesResp, _, err := d.sdkClient.CustomHostnames(ctx, d.config.ZoneId, 1, cfsdk.CustomHostname{Hostname: d.config.CustomHostname})
+	d.logger.Debug("sdk request 'cloudflare.CustomHostnames'", slog.String("request.zoneId", d.config.ZoneId), slog.String("request.hostname", d.config.CustomHostname), slog.Any("response", customHostnamesResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'cloudflare.CustomHostnames': %w", err)
+	}
+
+	var customHostname *cfsdk.CustomHostname
+	for i, item := range customHostnamesResp {
+		if strings.EqualFold(item.Hostname, d.config.CustomHostname) {
+			customHostname = &customHostnamesResp[i]
+			break
+		}
+	}
+	if customHostname == nil {
+		return fmt.Errorf("could not find custom hostname '%s'", d.config.CustomHostname)
+	}
+
+	// 若已使用相同证书则跳过，序列号可能以十进制或十六进制表示
+	if customHostname.SSL != nil && customHostname.SSL.SerialNumber != "" {
+		serialNumber := strings.TrimLeft(strings.ReplaceAll(customHostname.SSL.SerialNumber, ":", ""), "0")
+		if serialNumber == certX509.SerialNumber.String() || strings.EqualFold(serialNumber, certX509.SerialNumber.Text(16)) {
+			d.logger.Info("ssl certificate is up to date")
+			return nil
+		}
+	}
+
+	// 更新自定义主机名证书，保留原有的 TLS 设置
+	// REF: https://developers.cloudflare.com/api/resources/custom_hostnames/methods/edit/
+	updateCustomHostnameSSLReq := &cfsdk.CustomHostnameSSL{
+		Method:            "http",
+		Type:              "dv",
+		CustomCertificate: certPEM,
+		CustomKey:         privkeyPEM,
+		BundleMethod:      d.getBundleMethod(),
+	}
+	if customHostname.SSL != nil {
+		if customHostname.SSL.Method != "" {
+			updateCustomHostnameSSLReq.Method = customHostname.SSL.Method
+		}
+		updateCustomHostnameSSLReq.Settings = customHostname.SSL.Settings
+	}
+	updateCustomHostnameSSLResp, err := d.sdkClient.UpdateCustomHostnameSSL(ctx, d.config.ZoneId, customHostname.ID, updateCustomHostnameSSLReq)
+	d.logger.Debug("sdk request 'cloudflare.UpdateCustomHostnameSSL'", slog.String("request.zoneId", d.config.ZoneId), slog.String("request.customHostnameId", customHostname.ID), slog.Any("response", updateCustomHostnameSSLResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'cloudflare.UpdateCustomHostnameSSL': %w", err)
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) getBundleMethod() string {
+	if d.config.BundleMethod == "" {
+		return BUNDLE_METHOD_UBIQUITOUS
+	}
+	return d.config.BundleMethod
+}
+
+func createSdkClient(apiToken string) (*cfsdk.API, error) {
+	return cfsdk.NewWithAPIToken(apiToken, cfsdk.UserAgent("certimate"))
+}
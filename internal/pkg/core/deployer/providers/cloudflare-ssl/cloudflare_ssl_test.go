@@ -0,0 +1,111 @@
+package cloudflaressl_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/cloudflare-ssl"
+)
+
+var (
+	fInputCertPath  string
+	fInputKeyPath   string
+	fApiToken       string
+	fZoneId         string
+	fCertificateId  string
+	fCustomHostname string
+)
+
+func init() {
+	argsPrefix := "CERTIMATE_DEPLOYER_CLOUDFLARESSL_"
+
+	flag.StringVar(&fInputCertPath, argsPrefix+"INPUTCERTPATH", "", "")
+	flag.StringVar(&fInputKeyPath, argsPrefix+"INPUTKEYPATH", "", "")
+	flag.StringVar(&fApiToken, argsPrefix+"APITOKEN", "", "")
+	flag.StringVar(&fZoneId, argsPrefix+"ZONEID", "", "")
+	flag.StringVar(&fCertificateId, argsPrefix+"CERTIFICATEID", "", "")
+	flag.StringVar(&fCustomHostname, argsPrefix+"CUSTOMHOSTNAME", "", "")
+}
+
+/*
+Shell command to run this test:
+
+	go test -v ./cloudflare_ssl_test.go -args \
+	--CERTIMATE_DEPLOYER_CLOUDFLARESSL_INPUTCERTPATH="/path/to/your-input-cert.pem" \
+	--CERTIMATE_DEPLOYER_CLOUDFLARESSL_INPUTKEYPATH="/path/to/your-input-key.pem" \
+	--CERTIMATE_DEPLOYER_CLOUDFLARESSL_APITOKEN="your-api-token" \
+	--CERTIMATE_DEPLOYER_CLOUDFLARESSL_ZONEID="your-zone-id" \
+	--CERTIMATE_DEPLOYER_CLOUDFLARESSL_CERTIFICATEID="your-custom-certificate-id" \
+	--CERTIMATE_DEPLOYER_CLOUDFLARESSL_CUSTOMHOSTNAME="app.customer.example.com"
+*/
+func TestDeploy(t *testing.T) {
+	flag.Parse()
+
+	t.Run("Deploy_ToCertificate", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("APITOKEN: %v", fApiToken),
+			fmt.Sprintf("ZONEID: %v", fZoneId),
+			fmt.Sprintf("CERTIFICATEID: %v", fCertificateId),
+		}, "\n"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			ApiToken:      fApiToken,
+			ZoneId:        fZoneId,
+			ResourceType:  provider.RESOURCE_TYPE_CERTIFICATE,
+			CertificateId: fCertificateId,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+
+	t.Run("Deploy_ToCustomHostname", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("APITOKEN: %v", fApiToken),
+			fmt.Sprintf("ZONEID: %v", fZoneId),
+			fmt.Sprintf("CUSTOMHOSTNAME: %v", fCustomHostname),
+		}, "\n"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			ApiToken:       fApiToken,
+			ZoneId:         fZoneId,
+			ResourceType:   provider.RESOURCE_TYPE_CUSTOM_HOSTNAME,
+			CustomHostname: fCustomHostname,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+}
@@ -0,0 +1,16 @@
+package cloudflaressl
+
+type ResourceType string
+
+const (
+	// 资源类型：区域自定义证书。
+	RESOURCE_TYPE_CERTIFICATE = ResourceType("certificate")
+	// 资源类型：Cloudflare for SaaS 自定义主机名。
+	RESOURCE_TYPE_CUSTOM_HOSTNAME = ResourceType("customhostname")
+)
+
+const (
+	BUNDLE_METHOD_UBIQUITOUS = "ubiquitous"
+	BUNDLE_METHOD_OPTIMAL    = "optimal"
+	BUNDLE_METHOD_FORCE      = "force"
+)
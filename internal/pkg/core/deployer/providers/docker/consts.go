@@ -0,0 +1,35 @@
+package docker
+
+type TargetType string
+
+const (
+	// 目标类型：容器内路径。
+	TARGET_TYPE_CONTAINER = TargetType("container")
+	// 目标类型：具名卷。
+	TARGET_TYPE_VOLUME = TargetType("volume")
+)
+
+type PostActionType string
+
+const (
+	// 后置操作：无。
+	POST_ACTION_NONE = PostActionType("")
+	// 后置操作：在容器内执行命令。
+	POST_ACTION_EXEC = PostActionType("exec")
+	// 后置操作：向容器发送信号。
+	POST_ACTION_SIGNAL = PostActionType("signal")
+	// 后置操作：重启容器。
+	POST_ACTION_RESTART = PostActionType("restart")
+)
+
+type FileAttrs struct {
+	// 文件权限，八进制表示，如 "0600"。
+	// 零值时默认值 "0644"，包含私钥的文件默认值 "0600"。
+	Mode string `json:"mode,omitempty"`
+	// 文件所有者的 UID。
+	// 零值时默认值 "0"。tar 包按数值 ID 解压，因此不支持用户名。
+	Owner string `json:"owner,omitempty"`
+	// 文件所属组的 GID。
+	// 零值时默认值 "0"。tar 包按数值 ID 解压，因此不支持组名。
+	Group string `json:"group,omitempty"`
+}
@@ -0,0 +1,524 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+	dockersdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/docker"
+	fileutil "github.com/usual2970/certimate/internal/pkg/utils/file"
+)
+
+type DeployerConfig struct {
+	// Docker Engine API 地址。
+	// 格式同 DOCKER_HOST 环境变量，如 "unix:///var/run/docker.sock"、"tcp://127.0.0.1:2376"。
+	// 零值时默认值 "unix:///var/run/docker.sock"。
+	DockerHost string `json:"dockerHost,omitempty"`
+	// TLS CA 证书 PEM 内容。
+	// 选填。
+	TlsCaCertificate string `json:"tlsCaCertificate,omitempty"`
+	// TLS 客户端证书 PEM 内容。
+	// 选填。
+	TlsClientCertificate string `json:"tlsClientCertificate,omitempty"`
+	// TLS 客户端私钥 PEM 内容。
+	// 选填。
+	TlsClientKey string `json:"tlsClientKey,omitempty"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 部署目标类型。
+	// 零值时默认值 [TARGET_TYPE_CONTAINER]。
+	TargetType TargetType `json:"targetType,omitempty"`
+	// 容器 ID 或名称。
+	// 部署目标类型为 [TARGET_TYPE_CONTAINER] 时必填；后置操作非空时必填。
+	ContainerId string `json:"containerId,omitempty"`
+	// 具名卷名称。
+	// 部署目标类型为 [TARGET_TYPE_VOLUME] 时必填。
+	VolumeName string `json:"volumeName,omitempty"`
+	// 写入具名卷时所用的辅助容器镜像。
+	// 零值时默认值 "busybox:latest"。
+	HelperImage string `json:"helperImage,omitempty"`
+	// 输出证书格式。
+	OutputFormat local.OutputFormatType `json:"outputFormat,omitempty"`
+	// 输出证书文件路径。
+	// 部署目标类型为 [TARGET_TYPE_CONTAINER] 时为容器内的绝对路径，且所在目录须已存在；
+	// 部署目标类型为 [TARGET_TYPE_VOLUME] 时为相对于卷根目录的路径。
+	OutputCertPath string `json:"outputCertPath"`
+	// 输出服务器证书文件路径。
+	// 选填。
+	OutputServerCertPath string `json:"outputServerCertPath,omitempty"`
+	// 输出中间证书文件路径。
+	// 选填。
+	OutputIntermediaCertPath string `json:"outputIntermediaCertPath,omitempty"`
+	// 输出私钥文件路径。
+	OutputKeyPath string `json:"outputKeyPath,omitempty"`
+	// 输出根证书文件路径。
+	// 选填。证书格式为 PEM 或 PEM_COMBINED 时有效。
+	OutputRootCertPath string `json:"outputRootCertPath,omitempty"`
+	// 证书链与系统信任的根证书中均未找到根证书时，是否根据 AIA 扩展联网下载颁发者证书。
+	// 选填。输出根证书文件路径不为空时有效。
+	OutputRootCertFetchIssuer bool `json:"outputRootCertFetchIssuer,omitempty"`
+	// 输出证书文件属性。
+	// 选填。证书格式为 PFX 或 JKS 时作用于证书库文件。
+	OutputCertFileAttrs FileAttrs `json:"outputCertFileAttrs,omitempty"`
+	// 输出服务器证书文件属性。
+	// 选填。
+	OutputServerCertFileAttrs FileAttrs `json:"outputServerCertFileAttrs,omitempty"`
+	// 输出中间证书文件属性。
+	// 选填。
+	OutputIntermediaCertFileAttrs FileAttrs `json:"outputIntermediaCertFileAttrs,omitempty"`
+	// 输出私钥文件属性。
+	// 选填。
+	OutputKeyFileAttrs FileAttrs `json:"outputKeyFileAttrs,omitempty"`
+	// 输出根证书文件属性。
+	// 选填。
+	OutputRootCertFileAttrs FileAttrs `json:"outputRootCertFileAttrs,omitempty"`
+	// 是否逆序输出证书链，即中间证书在前、服务器证书在后。
+	// 证书格式为 PEM 或 PEM_COMBINED 时有效。
+	PemChainReversed bool `json:"pemChainReversed,omitempty"`
+	// 私钥格式。
+	// 证书格式为 PEM、PEM_COMBINED 或 P7B 时有效。零值时保持原有格式。
+	PemKeyFormat local.PrivateKeyFormatType `json:"pemKeyFormat,omitempty"`
+	// 私钥加密密码。
+	// 私钥格式为 PKCS8_ENCRYPTED 时必填。
+	PemKeyPassword string `json:"pemKeyPassword,omitempty"`
+	// PFX 导出密码。
+	// 证书格式为 PFX 时必填。
+	PfxPassword string `json:"pfxPassword,omitempty"`
+	// JKS 别名。
+	// 证书格式为 JKS 时必填。
+	JksAlias string `json:"jksAlias,omitempty"`
+	// JKS 密钥密码。
+	// 证书格式为 JKS 时必填。
+	JksKeypass string `json:"jksKeypass,omitempty"`
+	// JKS 存储密码。
+	// 证书格式为 JKS 时必填。
+	JksStorepass string `json:"jksStorepass,omitempty"`
+	// 后置操作。
+	// 零值时默认值 [POST_ACTION_NONE]。
+	PostAction PostActionType `json:"postAction,omitempty"`
+	// 后置命令，在容器内通过 "sh -c" 执行。
+	// 后置操作为 [POST_ACTION_EXEC] 时必填。
+	PostCommand string `json:"postCommand,omitempty"`
+	// 后置信号，如 "SIGHUP"。
+	// 后置操作为 [POST_ACTION_SIGNAL] 时有效。零值时默认值 "SIGHUP"。
+	PostSignal string `json:"postSignal,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *dockersdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.DockerHost, config.TlsCaCertificate, config.TlsClientCertificate, config.TlsClientKey, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.OutputCertPath == "" {
+		return nil, errors.New("config `outputCertPath` is required")
+	}
+
+	// 生成输出文件
+	files, err := d.buildOutputFiles(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 根据部署目标类型决定上传方式
+	switch d.config.TargetType {
+	case "", TARGET_TYPE_CONTAINER:
+		if err := d.uploadToContainer(ctx, files); err != nil {
+			return nil, err
+		}
+
+	case TARGET_TYPE_VOLUME:
+		if err := d.uploadToVolume(ctx, files); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported target type '%s'", d.config.TargetType)
+	}
+
+	// 执行后置操作
+	if err := d.runPostAction(ctx); err != nil {
+		return nil, err
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func (d *DeployerProvider) buildOutputFiles(ctx context.Context, certPEM string, privkeyPEM string) ([]local.OutputFile, error) {
+	files, err := local.BuildOutputFiles(ctx, certPEM, privkeyPEM, &local.OutputOptions{
+		Format:              d.config.OutputFormat,
+		CertPath:            d.config.OutputCertPath,
+		ServerCertPath:      d.config.OutputServerCertPath,
+		IntermediaCertPath:  d.config.OutputIntermediaCertPath,
+		RootCertPath:        d.config.OutputRootCertPath,
+		RootCertFetchIssuer: d.config.OutputRootCertFetchIssuer,
+		KeyPath:             d.config.OutputKeyPath,
+		PemChainReversed:    d.config.PemChainReversed,
+		PemKeyFormat:        d.config.PemKeyFormat,
+		PemKeyPassword:      d.config.PemKeyPassword,
+		PfxPassword:         d.config.PfxPassword,
+		JksAlias:            d.config.JksAlias,
+		JksKeypass:          d.config.JksKeypass,
+		JksStorepass:        d.config.JksStorepass,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.Info(fmt.Sprintf("ssl certificate transformed to %s", strings.ToLower(string(d.config.OutputFormat))))
+
+	return files, nil
+}
+
+func (d *DeployerProvider) uploadToContainer(ctx context.Context, files []local.OutputFile) error {
+	if d.config.ContainerId == "" {
+		return errors.New("config `containerId` is required")
+	}
+
+	// 按所在目录分组，每个目录上传一个 tar 包
+	dirs := make([]string, 0)
+	filesByDir := make(map[string][]local.OutputFile)
+	for _, file := range files {
+		if !path.IsAbs(file.Path) {
+			return fmt.Errorf("output path '%s' must be absolute", file.Path)
+		}
+
+		dir := path.Dir(file.Path)
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+		filesByDir[dir] = append(filesByDir[dir], file)
+	}
+
+	// 先生成全部 tar 包，避免因部分文件属性无效而只上传了一部分文件
+	tarDataByDir := make(map[string][]byte)
+	for _, dir := range dirs {
+		tarData, err := createTarArchive(filesByDir[dir], func(file local.OutputFile) string { return path.Base(file.Path) }, d.fileAttrsOf, false)
+		if err != nil {
+			return fmt.Errorf("failed to create tar archive: %w", err)
+		}
+
+		tarDataByDir[dir] = tarData
+	}
+
+	for _, dir := range dirs {
+		tarData := tarDataByDir[dir]
+
+		// REF: https://docs.docker.com/reference/api/engine/version/v1.47/#tag/Container/operation/PutContainerArchive
+		if err := d.sdkClient.PutContainerArchive(ctx, d.config.ContainerId, dir, tarData); err != nil {
+			return fmt.Errorf("failed to execute sdk request 'docker.PutContainerArchive': %w", err)
+		}
+
+		for _, file := range filesByDir[dir] {
+			d.logger.Info("ssl certificate file uploaded", slog.String("container", d.config.ContainerId), slog.String("path", file.Path))
+		}
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) uploadToVolume(ctx context.Context, files []local.OutputFile) error {
+	if d.config.VolumeName == "" {
+		return errors.New("config `volumeName` is required")
+	}
+
+	helperImage := d.config.HelperImage
+	if helperImage == "" {
+		helperImage = "busybox:latest"
+	}
+
+	// 创建挂载了具名卷的辅助容器（无需启动），通过其上传文件到卷中
+	const mountPoint = "/certimate-volume"
+	createContainerReq := &dockersdk.CreateContainerRequest{
+		Name:  fmt.Sprintf("certimate-helper-%d", time.Now().UnixNano()),
+		Image: helperImage,
+		Cmd:   []string{"true"},
+		HostConfig: &dockersdk.ContainerHostConfig{
+			Binds: []string{fmt.Sprintf("%s:%s", d.config.VolumeName, mountPoint)},
+		},
+	}
+	createContainerResp, err := d.sdkClient.CreateContainer(ctx, createContainerReq)
+	d.logger.Debug("sdk request 'docker.CreateContainer'", slog.Any("request", createContainerReq), slog.Any("response", createContainerResp))
+	if err != nil {
+		// 镜像不存在时先拉取再重试
+		d.logger.Info("failed to create helper container, try to pull the image", slog.String("image", helperImage), slog.String("error", err.Error()))
+		if err := d.sdkClient.PullImage(ctx, helperImage); err != nil {
+			return fmt.Errorf("failed to execute sdk request 'docker.PullImage': %w", err)
+		}
+
+		createContainerResp, err = d.sdkClient.CreateContainer(ctx, createContainerReq)
+		d.logger.Debug("sdk request 'docker.CreateContainer'", slog.Any("request", createContainerReq), slog.Any("response", createContainerResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'docker.CreateContainer': %w", err)
+		}
+	}
+	defer func() {
+		// 即使上下文已取消也需清理辅助容器
+		if err := d.sdkClient.RemoveContainer(context.WithoutCancel(ctx), createContainerResp.Id, true); err != nil {
+			d.logger.Warn("failed to remove helper container", slog.String("container", createContainerResp.Id), slog.String("error", err.Error()))
+		}
+	}()
+
+	// 上传到卷根目录，tar 包中包含中间目录，不存在的目录将被自动创建
+	tarData, err := createTarArchive(files, func(file local.OutputFile) string { return strings.TrimPrefix(path.Clean("/"+file.Path), "/") }, d.fileAttrsOf, true)
+	if err != nil {
+		return fmt.Errorf("failed to create tar archive: %w", err)
+	}
+
+	if err := d.sdkClient.PutContainerArchive(ctx, createContainerResp.Id, mountPoint, tarData); err != nil {
+		return fmt.Errorf("failed to execute sdk request 'docker.PutContainerArchive': %w", err)
+	}
+
+	for _, file := range files {
+		d.logger.Info("ssl certificate file uploaded", slog.String("volume", d.config.VolumeName), slog.String("path", file.Path))
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) runPostAction(ctx context.Context) error {
+	if d.config.PostAction == POST_ACTION_NONE {
+		return nil
+	}
+
+	if d.config.ContainerId == "" {
+		return errors.New("config `containerId` is required")
+	}
+
+	switch d.config.PostAction {
+	case POST_ACTION_EXEC:
+		if d.config.PostCommand == "" {
+			return errors.New("config `postCommand` is required")
+		}
+
+		// REF: https://docs.docker.com/reference/api/engine/version/v1.47/#tag/Exec
+		createExecReq := &dockersdk.CreateExecRequest{
+			ContainerId:  d.config.ContainerId,
+			Cmd:          []string{"sh", "-c", d.config.PostCommand},
+			AttachStdout: true,
+			AttachStderr: true,
+		}
+		createExecResp, err := d.sdkClient.CreateExec(ctx, createExecReq)
+		d.logger.Debug("sdk request 'docker.CreateExec'", slog.Any("request", createExecReq), slog.Any("response", createExecResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'docker.CreateExec': %w", err)
+		}
+
+		startExecReq := &dockersdk.StartExecRequest{
+			ExecId: createExecResp.Id,
+		}
+		startExecResp, err := d.sdkClient.StartExec(ctx, startExecReq)
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'docker.StartExec': %w", err)
+		}
+		d.logger.Debug("run post-command", slog.String("stdout", startExecResp.Stdout), slog.String("stderr", startExecResp.Stderr))
+
+		inspectExecResp, err := d.sdkClient.InspectExec(ctx, createExecResp.Id)
+		d.logger.Debug("sdk request 'docker.InspectExec'", slog.String("execId", createExecResp.Id), slog.Any("response", inspectExecResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'docker.InspectExec': %w", err)
+		} else if inspectExecResp.ExitCode != 0 {
+			return fmt.Errorf("failed to execute post-command (exit code: %d, stdout: %s, stderr: %s)", inspectExecResp.ExitCode, startExecResp.Stdout, startExecResp.Stderr)
+		}
+
+		d.logger.Info("post-command executed", slog.String("container", d.config.ContainerId))
+
+	case POST_ACTION_SIGNAL:
+		signal := d.config.PostSignal
+		if signal == "" {
+			signal = "SIGHUP"
+		}
+
+		// REF: https://docs.docker.com/reference/api/engine/version/v1.47/#tag/Container/operation/ContainerKill
+		if err := d.sdkClient.KillContainer(ctx, d.config.ContainerId, signal); err != nil {
+			return fmt.Errorf("failed to execute sdk request 'docker.KillContainer': %w", err)
+		}
+
+		d.logger.Info("signal sent to the container", slog.String("container", d.config.ContainerId), slog.String("signal", signal))
+
+	case POST_ACTION_RESTART:
+		// REF: https://docs.docker.com/reference/api/engine/version/v1.47/#tag/Container/operation/ContainerRestart
+		if err := d.sdkClient.RestartContainer(ctx, d.config.ContainerId); err != nil {
+			return fmt.Errorf("failed to execute sdk request 'docker.RestartContainer': %w", err)
+		}
+
+		d.logger.Info("container restarted", slog.String("container", d.config.ContainerId))
+
+	default:
+		return fmt.Errorf("unsupported post action '%s'", d.config.PostAction)
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) fileAttrsOf(kind local.OutputFileKind) FileAttrs {
+	switch kind {
+	case local.OUTPUT_FILE_KIND_SERVER_CERT:
+		return d.config.OutputServerCertFileAttrs
+	case local.OUTPUT_FILE_KIND_INTERMEDIA_CERT:
+		return d.config.OutputIntermediaCertFileAttrs
+	case local.OUTPUT_FILE_KIND_ROOT_CERT:
+		return d.config.OutputRootCertFileAttrs
+	case local.OUTPUT_FILE_KIND_KEY:
+		return d.config.OutputKeyFileAttrs
+	default:
+		return d.config.OutputCertFileAttrs
+	}
+}
+
+func createTarArchive(files []local.OutputFile, nameFunc func(file local.OutputFile) string, attrsFunc func(kind local.OutputFileKind) FileAttrs, withDirs bool) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	now := time.Now()
+	writtenDirs := make(map[string]bool)
+	for _, file := range files {
+		name := nameFunc(file)
+
+		if withDirs {
+			dirs := make([]string, 0)
+			for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+				dirs = append([]string{dir}, dirs...)
+			}
+
+			for _, dir := range dirs {
+				if writtenDirs[dir] {
+					continue
+				}
+
+				if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: dir + "/", Mode: 0o755, ModTime: now}); err != nil {
+					return nil, err
+				}
+				writtenDirs[dir] = true
+			}
+		}
+
+		header, err := createTarFileHeader(name, file, attrsFunc(file.Kind))
+		if err != nil {
+			return nil, err
+		}
+
+		header.ModTime = now
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(file.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func createTarFileHeader(name string, file local.OutputFile, attrs FileAttrs) (*tar.Header, error) {
+	mode, err := fileutil.ParseFileMode(attrs.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	// 包含私钥的文件默认仅允许所有者读写
+	if mode == 0 {
+		mode = 0o644
+		if file.Sensitive {
+			mode = 0o600
+		}
+	}
+
+	// 容器内解压时按数值 ID 设置所有者，无法解析用户名或组名
+	uid, gid := 0, 0
+	if attrs.Owner != "" {
+		if uid, err = strconv.Atoi(attrs.Owner); err != nil || uid < 0 {
+			return nil, fmt.Errorf("file owner must be a numeric uid, got '%s'", attrs.Owner)
+		}
+	}
+	if attrs.Group != "" {
+		if gid, err = strconv.Atoi(attrs.Group); err != nil || gid < 0 {
+			return nil, fmt.Errorf("file group must be a numeric gid, got '%s'", attrs.Group)
+		}
+	}
+
+	return &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(mode),
+		Uid:      uid,
+		Gid:      gid,
+		Size:     int64(len(file.Data)),
+	}, nil
+}
+
+func createSdkClient(dockerHost, tlsCaCertificate, tlsClientCertificate, tlsClientKey string, skipTlsVerify bool) (*dockersdk.Client, error) {
+	client, err := dockersdk.NewClient(dockerHost)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsCaCertificate != "" || tlsClientCertificate != "" || skipTlsVerify {
+		tlsConfig := &tls.Config{InsecureSkipVerify: skipTlsVerify}
+
+		if tlsCaCertificate != "" {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(tlsCaCertificate)) {
+				return nil, errors.New("invalid docker tls ca certificate")
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		if tlsClientCertificate != "" {
+			keyPair, err := tls.X509KeyPair([]byte(tlsClientCertificate), []byte(tlsClientKey))
+			if err != nil {
+				return nil, fmt.Errorf("invalid docker tls client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{keyPair}
+		}
+
+		client.WithTLSConfig(tlsConfig)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,191 @@
+package docker_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/docker"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+)
+
+var (
+	fInputCertPath  string
+	fInputKeyPath   string
+	fDockerHost     string
+	fContainerId    string
+	fOutputCertPath string
+	fOutputKeyPath  string
+	fPostCommand    string
+)
+
+func init() {
+	argsPrefix := "CERTIMATE_DEPLOYER_DOCKER_"
+
+	flag.StringVar(&fInputCertPath, argsPrefix+"INPUTCERTPATH", "", "")
+	flag.StringVar(&fInputKeyPath, argsPrefix+"INPUTKEYPATH", "", "")
+	flag.StringVar(&fDockerHost, argsPrefix+"DOCKERHOST", "", "")
+	flag.StringVar(&fContainerId, argsPrefix+"CONTAINERID", "", "")
+	flag.StringVar(&fOutputCertPath, argsPrefix+"OUTPUTCERTPATH", "", "")
+	flag.StringVar(&fOutputKeyPath, argsPrefix+"OUTPUTKEYPATH", "", "")
+	flag.StringVar(&fPostCommand, argsPrefix+"POSTCOMMAND", "", "")
+}
+
+/*
+Shell command to run this test:
+
+	go test -v ./docker_test.go -args \
+	--CERTIMATE_DEPLOYER_DOCKER_INPUTCERTPATH="/path/to/your-input-cert.pem" \
+	--CERTIMATE_DEPLOYER_DOCKER_INPUTKEYPATH="/path/to/your-input-key.pem" \
+	--CERTIMATE_DEPLOYER_DOCKER_DOCKERHOST="unix:///var/run/docker.sock" \
+	--CERTIMATE_DEPLOYER_DOCKER_CONTAINERID="your-container-id" \
+	--CERTIMATE_DEPLOYER_DOCKER_OUTPUTCERTPATH="/path/to/your-output-cert.pem" \
+	--CERTIMATE_DEPLOYER_DOCKER_OUTPUTKEYPATH="/path/to/your-output-key.pem" \
+	--CERTIMATE_DEPLOYER_DOCKER_POSTCOMMAND="nginx -s reload"
+*/
+func TestDeploy(t *testing.T) {
+	flag.Parse()
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("DOCKERHOST: %v", fDockerHost),
+			fmt.Sprintf("CONTAINERID: %v", fContainerId),
+			fmt.Sprintf("OUTPUTCERTPATH: %v", fOutputCertPath),
+			fmt.Sprintf("OUTPUTKEYPATH: %v", fOutputKeyPath),
+			fmt.Sprintf("POSTCOMMAND: %v", fPostCommand),
+		}, "\n"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			DockerHost:     fDockerHost,
+			TargetType:     provider.TARGET_TYPE_CONTAINER,
+			ContainerId:    fContainerId,
+			OutputFormat:   local.OUTPUT_FORMAT_PEM,
+			OutputCertPath: fOutputCertPath,
+			OutputKeyPath:  fOutputKeyPath,
+			PostAction:     provider.POST_ACTION_EXEC,
+			PostCommand:    fPostCommand,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+}
+
+func generateCertificate(t *testing.T) (string, string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestDeployWithFakeServer(t *testing.T) {
+	certPEM, privkeyPEM := generateCertificate(t)
+
+	// 模拟 Docker Engine API，记录上传的 tar 包中的文件头
+	var mutex sync.Mutex
+	var headers map[string]*tar.Header
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /containers/{id}/archive", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		data, _ := io.ReadAll(r.Body)
+		tr := tar.NewReader(bytes.NewReader(data))
+		for {
+			header, err := tr.Next()
+			if err != nil {
+				break
+			}
+			headers[r.URL.Query().Get("path")+"/"+header.Name] = header
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	newProvider := func(keyFileAttrs provider.FileAttrs) *provider.DeployerProvider {
+		deployer, _ := provider.NewDeployer(&provider.DeployerConfig{
+			DockerHost:     "tcp://" + server.Listener.Addr().String(),
+			ContainerId:    "nginx",
+			OutputFormat:   local.OUTPUT_FORMAT_PEM,
+			OutputCertPath: "/etc/nginx/ssl/cert.pem",
+			OutputKeyPath:  "/etc/nginx/ssl/key.pem",
+			OutputCertFileAttrs: provider.FileAttrs{
+				Mode: "0640",
+			},
+			OutputKeyFileAttrs: keyFileAttrs,
+		})
+		deployer.WithLogger(slog.New(slog.DiscardHandler))
+		return deployer
+	}
+
+	t.Run("Deploy_FileAttrs", func(t *testing.T) {
+		headers = make(map[string]*tar.Header)
+		if _, err := newProvider(provider.FileAttrs{Owner: "101", Group: "102"}).Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if header := headers["/etc/nginx/ssl/cert.pem"]; header == nil || header.Mode != 0o640 || header.Uid != 0 || header.Gid != 0 {
+			t.Errorf("unexpected certificate file header: %+v", header)
+		}
+		// 未指定权限时包含私钥的文件仍默认仅允许所有者读写
+		if header := headers["/etc/nginx/ssl/key.pem"]; header == nil || header.Mode != 0o600 || header.Uid != 101 || header.Gid != 102 {
+			t.Errorf("unexpected private key file header: %+v", header)
+		}
+	})
+
+	t.Run("Deploy_NonNumericOwner", func(t *testing.T) {
+		headers = make(map[string]*tar.Header)
+		if _, err := newProvider(provider.FileAttrs{Owner: "nginx"}).Deploy(context.Background(), certPEM, privkeyPEM); err == nil {
+			t.Fatal("expected error for non-numeric owner, got nil")
+		}
+
+		if len(headers) != 0 {
+			t.Errorf("expected no files to be uploaded, got %d", len(headers))
+		}
+	})
+}
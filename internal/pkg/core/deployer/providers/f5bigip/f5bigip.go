@@ -0,0 +1,357 @@
+package f5bigip
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	f5sdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/f5bigip"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// BIG-IP 管理地址。
+	ServerUrl string `json:"serverUrl"`
+	// BIG-IP 用户名。
+	Username string `json:"username"`
+	// BIG-IP 密码。
+	Password string `json:"password"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 分区。
+	// 选填。零值时默认值 "Common"。
+	Partition string `json:"partition,omitempty"`
+	// Client SSL 配置文件名称。
+	ProfileName string `json:"profileName"`
+	// 证书对象名称前缀。
+	// 选填。零值时默认值为 "certimate_" 加证书通用名称。
+	// 证书对象名称形如 "{前缀}_{yyyyMMddHHmmss}"，仅会清理具有相同前缀的旧对象。
+	ObjectNamePrefix string `json:"objectNamePrefix,omitempty"`
+	// 设备组名称。
+	// 选填。非零值时部署完成后会将配置同步到该设备组。
+	DeviceGroup string `json:"deviceGroup,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *f5sdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServerUrl, config.Username, config.Password, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.ProfileName == "" {
+		return nil, errors.New("config `profileName` is required")
+	}
+
+	partition := d.config.Partition
+	if partition == "" {
+		partition = "Common"
+	}
+
+	profilePath := d.config.ProfileName
+	if !strings.HasPrefix(profilePath, "/") {
+		profilePath = fmt.Sprintf("/%s/%s", partition, profilePath)
+	}
+
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 提取服务器证书和中间证书
+	serverCertPEM, intermediaCertPEM, err := certutil.ExtractCertificatesFromPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract certs: %w", err)
+	}
+
+	// 获取 Client SSL 配置文件
+	// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_ltm_profile_client-ssl.html
+	getClientSslProfileResp, err := d.sdkClient.GetClientSslProfile(profilePath)
+	d.logger.Debug("sdk request 'f5bigip.GetClientSslProfile'", slog.String("request.profilePath", profilePath), slog.Any("response", getClientSslProfileResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'f5bigip.GetClientSslProfile': %w", err)
+	}
+
+	// 生成带版本号的对象名称
+	objectNamePrefix := d.config.ObjectNamePrefix
+	if objectNamePrefix == "" {
+		objectNamePrefix = "certimate_" + sanitizeObjectName(certX509.Subject.CommonName)
+	}
+	objectName := fmt.Sprintf("%s_%s", objectNamePrefix, time.Now().Format("20060102150405"))
+
+	// 上传并安装私钥、证书及证书链
+	// 后续任一步骤失败时，回滚本次已安装的对象
+	certObjectName := objectName + ".crt"
+	keyObjectName := objectName + ".key"
+	chainObjectName := ""
+	installedObjects := make([]cryptoObject, 0, 3)
+	rollback := func() {
+		for i := len(installedObjects) - 1; i >= 0; i-- {
+			object := installedObjects[i]
+			if err := d.deleteCryptoObject(object); err != nil {
+				d.logger.Warn("failed to roll back installed crypto object", slog.String("fullPath", object.FullPath), slog.Any("error", err))
+			} else {
+				d.logger.Info("installed crypto object rolled back", slog.String("fullPath", object.FullPath))
+			}
+		}
+	}
+	installObject := func(name string, data []byte, isKey bool) error {
+		if err := d.installCryptoObject(name, partition, data, isKey); err != nil {
+			rollback()
+			return err
+		}
+
+		installedObjects = append(installedObjects, cryptoObject{FullPath: fmt.Sprintf("/%s/%s", partition, name), IsKey: isKey})
+		return nil
+	}
+	if err := installObject(certObjectName, []byte(serverCertPEM), false); err != nil {
+		return nil, err
+	}
+	if err := installObject(keyObjectName, []byte(privkeyPEM), true); err != nil {
+		return nil, err
+	}
+	if intermediaCertPEM != "" {
+		chainObjectName = objectName + "_chain.crt"
+		if err := installObject(chainObjectName, []byte(intermediaCertPEM), false); err != nil {
+			return nil, err
+		}
+	}
+
+	newCertKeyChain := &f5sdk.CertKeyChain{
+		Name: objectName,
+		Cert: fmt.Sprintf("/%s/%s", partition, certObjectName),
+		Key:  fmt.Sprintf("/%s/%s", partition, keyObjectName),
+	}
+	if chainObjectName != "" {
+		newCertKeyChain.Chain = fmt.Sprintf("/%s/%s", partition, chainObjectName)
+	}
+
+	// 替换与新证书密钥类型相同的证书链条目，配置文件中的 RSA 与 ECDSA 证书可以共存
+	certKeyChains := getClientSslProfileResp.CertKeyChain
+	oldCertKeyChainIndex := -1
+	if len(certKeyChains) == 1 {
+		oldCertKeyChainIndex = 0
+	} else {
+		newKeyType := "rsa"
+		if _, ok := certX509.PublicKey.(*ecdsa.PublicKey); ok {
+			newKeyType = "ec"
+		}
+
+		for i, certKeyChain := range certKeyChains {
+			getSslCertResp, err := d.sdkClient.GetSslCert(certKeyChain.Cert)
+			d.logger.Debug("sdk request 'f5bigip.GetSslCert'", slog.String("request.fullPath", certKeyChain.Cert), slog.Any("response", getSslCertResp))
+			if err != nil {
+				continue
+			}
+
+			if strings.HasPrefix(getSslCertResp.KeyType, newKeyType+"-") {
+				oldCertKeyChainIndex = i
+				break
+			}
+		}
+	}
+
+	var oldCertKeyChain *f5sdk.CertKeyChain
+	updatedCertKeyChains := make([]*f5sdk.CertKeyChain, 0, len(certKeyChains)+1)
+	for i, certKeyChain := range certKeyChains {
+		if i == oldCertKeyChainIndex {
+			oldCertKeyChain = certKeyChain
+			newCertKeyChain.Name = certKeyChain.Name
+			updatedCertKeyChains = append(updatedCertKeyChains, newCertKeyChain)
+		} else {
+			updatedCertKeyChains = append(updatedCertKeyChains, certKeyChain)
+		}
+	}
+	if oldCertKeyChain == nil {
+		updatedCertKeyChains = append(updatedCertKeyChains, newCertKeyChain)
+	}
+
+	// 更新 Client SSL 配置文件
+	// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_ltm_profile_client-ssl.html
+	updateClientSslProfileReq := &f5sdk.UpdateClientSslProfileRequest{
+		CertKeyChain: updatedCertKeyChains,
+	}
+	updateClientSslProfileResp, err := d.sdkClient.UpdateClientSslProfile(profilePath, updateClientSslProfileReq)
+	d.logger.Debug("sdk request 'f5bigip.UpdateClientSslProfile'", slog.String("request.profilePath", profilePath), slog.Any("request", updateClientSslProfileReq), slog.Any("response", updateClientSslProfileResp))
+	if err != nil {
+		rollback()
+		return nil, fmt.Errorf("failed to execute sdk request 'f5bigip.UpdateClientSslProfile': %w", err)
+	}
+
+	// 同步配置到设备组
+	// 同步失败时还原配置文件并回滚本次安装的对象，避免设备组内各设备的配置不一致
+	// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_cm.html
+	if d.config.DeviceGroup != "" {
+		if err := d.sdkClient.ConfigSync(d.config.DeviceGroup); err != nil {
+			restoreClientSslProfileReq := &f5sdk.UpdateClientSslProfileRequest{
+				CertKeyChain: certKeyChains,
+			}
+			restoreClientSslProfileResp, rerr := d.sdkClient.UpdateClientSslProfile(profilePath, restoreClientSslProfileReq)
+			d.logger.Debug("sdk request 'f5bigip.UpdateClientSslProfile'", slog.String("request.profilePath", profilePath), slog.Any("request", restoreClientSslProfileReq), slog.Any("response", restoreClientSslProfileResp))
+			if rerr != nil {
+				d.logger.Warn("failed to restore client ssl profile", slog.String("profilePath", profilePath), slog.Any("error", rerr))
+			} else {
+				rollback()
+			}
+
+			return nil, fmt.Errorf("failed to execute sdk request 'f5bigip.ConfigSync': %w", err)
+		}
+	}
+
+	// 清理旧的证书对象
+	// 仅清理由本程序创建（即具有相同前缀）的对象，若仍被其他配置文件引用，BIG-IP 会拒绝删除
+	if oldCertKeyChain != nil {
+		isOwnedObject := func(fullPath string) bool {
+			name := fullPath[strings.LastIndex(fullPath, "/")+1:]
+			return strings.HasPrefix(name, objectNamePrefix+"_") && !strings.HasPrefix(name, objectName)
+		}
+
+		oldObjects := []cryptoObject{
+			{FullPath: oldCertKeyChain.Cert},
+			{FullPath: oldCertKeyChain.Chain},
+			{FullPath: oldCertKeyChain.Key, IsKey: true},
+		}
+		deleted := false
+		for _, object := range oldObjects {
+			if object.FullPath == "" || !isOwnedObject(object.FullPath) {
+				continue
+			}
+
+			if err := d.deleteCryptoObject(object); err != nil {
+				d.logger.Warn("failed to delete old crypto object", slog.String("fullPath", object.FullPath), slog.Any("error", err))
+			} else {
+				deleted = true
+				d.logger.Info("old crypto object deleted", slog.String("fullPath", object.FullPath))
+			}
+		}
+
+		// 再次同步，使设备组内其他设备上的旧对象也被清理
+		if deleted && d.config.DeviceGroup != "" {
+			if err := d.sdkClient.ConfigSync(d.config.DeviceGroup); err != nil {
+				d.logger.Warn("failed to sync config after cleaning up old crypto objects", slog.String("deviceGroup", d.config.DeviceGroup), slog.Any("error", err))
+			}
+		}
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"cert":  newCertKeyChain.Cert,
+			"key":   newCertKeyChain.Key,
+			"chain": newCertKeyChain.Chain,
+		},
+	}, nil
+}
+
+type cryptoObject struct {
+	FullPath string
+	IsKey    bool
+}
+
+func (d *DeployerProvider) installCryptoObject(name string, partition string, data []byte, isKey bool) error {
+	// 上传文件
+	// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_shared_file-transfer_uploads.html
+	if err := d.sdkClient.UploadFile(name, data); err != nil {
+		return fmt.Errorf("failed to execute sdk request 'f5bigip.UploadFile': %w", err)
+	}
+
+	// 无论安装成功与否，均删除已上传的文件，避免私钥明文残留在设备上
+	defer func() {
+		if err := d.sdkClient.DeleteUploadedFile(name); err != nil {
+			d.logger.Warn("failed to delete uploaded file", slog.String("fileName", name), slog.Any("error", err))
+		}
+	}()
+
+	// 安装为加密对象
+	// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_sys_crypto_cert.html
+	installCryptoObjectReq := &f5sdk.InstallCryptoObjectRequest{
+		Name:          name,
+		Partition:     partition,
+		FromLocalFile: f5sdk.UploadDirectory + "/" + name,
+	}
+	if isKey {
+		err := d.sdkClient.InstallCryptoKey(installCryptoObjectReq)
+		d.logger.Debug("sdk request 'f5bigip.InstallCryptoKey'", slog.Any("request", installCryptoObjectReq))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'f5bigip.InstallCryptoKey': %w", err)
+		}
+	} else {
+		err := d.sdkClient.InstallCryptoCert(installCryptoObjectReq)
+		d.logger.Debug("sdk request 'f5bigip.InstallCryptoCert'", slog.Any("request", installCryptoObjectReq))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'f5bigip.InstallCryptoCert': %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) deleteCryptoObject(object cryptoObject) error {
+	if object.IsKey {
+		return d.sdkClient.DeleteSslKey(object.FullPath)
+	}
+
+	return d.sdkClient.DeleteSslCert(object.FullPath)
+}
+
+func sanitizeObjectName(name string) string {
+	name = strings.ReplaceAll(name, "*", "wildcard")
+	return regexp.MustCompile(`[^A-Za-z0-9._-]`).ReplaceAllString(name, "_")
+}
+
+func createSdkClient(serverUrl, username, password string, skipTlsVerify bool) (*f5sdk.Client, error) {
+	if _, err := url.Parse(serverUrl); err != nil {
+		return nil, errors.New("invalid f5 big-ip server url")
+	}
+
+	if username == "" {
+		return nil, errors.New("invalid f5 big-ip username")
+	}
+
+	if password == "" {
+		return nil, errors.New("invalid f5 big-ip password")
+	}
+
+	client := f5sdk.NewClient(serverUrl, username, password)
+	if skipTlsVerify {
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return client, nil
+}
@@ -0,0 +1,328 @@
+package f5bigip_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/f5bigip"
+)
+
+var (
+	fInputCertPath string
+	fInputKeyPath  string
+	fServerUrl     string
+	fUsername      string
+	fPassword      string
+	fProfileName   string
+)
+
+func init() {
+	argsPrefix := "CERTIMATE_DEPLOYER_F5BIGIP_"
+
+	flag.StringVar(&fInputCertPath, argsPrefix+"INPUTCERTPATH", "", "")
+	flag.StringVar(&fInputKeyPath, argsPrefix+"INPUTKEYPATH", "", "")
+	flag.StringVar(&fServerUrl, argsPrefix+"SERVERURL", "", "")
+	flag.StringVar(&fUsername, argsPrefix+"USERNAME", "", "")
+	flag.StringVar(&fPassword, argsPrefix+"PASSWORD", "", "")
+	flag.StringVar(&fProfileName, argsPrefix+"PROFILENAME", "", "")
+}
+
+/*
+Shell command to run this test:
+
+	go test -v ./f5bigip_test.go -args \
+	--CERTIMATE_DEPLOYER_F5BIGIP_INPUTCERTPATH="/path/to/your-input-cert.pem" \
+	--CERTIMATE_DEPLOYER_F5BIGIP_INPUTKEYPATH="/path/to/your-input-key.pem" \
+	--CERTIMATE_DEPLOYER_F5BIGIP_SERVERURL="https://127.0.0.1:443" \
+	--CERTIMATE_DEPLOYER_F5BIGIP_USERNAME="admin" \
+	--CERTIMATE_DEPLOYER_F5BIGIP_PASSWORD="your-password" \
+	--CERTIMATE_DEPLOYER_F5BIGIP_PROFILENAME="your-client-ssl-profile-name"
+*/
+func TestDeploy(t *testing.T) {
+	flag.Parse()
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("SERVERURL: %v", fServerUrl),
+			fmt.Sprintf("USERNAME: %v", fUsername),
+			fmt.Sprintf("PASSWORD: %v", fPassword),
+			fmt.Sprintf("PROFILENAME: %v", fProfileName),
+		}, "\n"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			ServerUrl:                fServerUrl,
+			Username:                 fUsername,
+			Password:                 fPassword,
+			AllowInsecureConnections: true,
+			ProfileName:              fProfileName,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+}
+
+// 模拟 BIG-IP iControl REST API，按顺序记录各写操作。
+type fakeBigIPServer struct {
+	*httptest.Server
+
+	mutex   sync.Mutex
+	events  []string
+	profile []map[string]string
+
+	failProfileUpdate bool
+	failConfigSync    bool
+}
+
+func newFakeBigIPServer(t *testing.T) *fakeBigIPServer {
+	s := &fakeBigIPServer{
+		profile: []map[string]string{
+			{
+				"name":  "default",
+				"cert":  "/Common/certimate_example.com_20200101000000.crt",
+				"key":   "/Common/certimate_example.com_20200101000000.key",
+				"chain": "/Common/certimate_example.com_20200101000000_chain.crt",
+			},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /mgmt/shared/authn/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token":{"token":"test-token","timeout":1200}}`))
+	})
+	mux.HandleFunc("POST /mgmt/shared/file-transfer/uploads/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.record("upload " + r.PathValue("name"))
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("POST /mgmt/tm/util/unix-rm", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		s.record("rm " + req["utilCmdArgs"])
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("POST /mgmt/tm/sys/crypto/{kind}", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		s.record("install " + req["name"])
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("DELETE /mgmt/tm/sys/file/{kind}/{fullPath}", func(w http.ResponseWriter, r *http.Request) {
+		s.record("delete " + strings.ReplaceAll(r.PathValue("fullPath"), "~", "/"))
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("GET /mgmt/tm/ltm/profile/client-ssl/~Common~test", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"name": "test", "certKeyChain": s.profile})
+	})
+	mux.HandleFunc("PATCH /mgmt/tm/ltm/profile/client-ssl/~Common~test", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			CertKeyChain []map[string]string `json:"certKeyChain"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if s.failProfileUpdate {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"invalid profile"}`))
+			return
+		}
+		s.events = append(s.events, "update "+req.CertKeyChain[0]["cert"])
+		s.profile = req.CertKeyChain
+		json.NewEncoder(w).Encode(map[string]any{"name": "test", "certKeyChain": s.profile})
+	})
+	mux.HandleFunc("POST /mgmt/tm/cm", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.events = append(s.events, "sync")
+		if s.failConfigSync {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"sync failed"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeBigIPServer) record(event string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *fakeBigIPServer) eventsWithPrefix(prefix string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.DeleteFunc(slices.Clone(s.events), func(e string) bool { return !strings.HasPrefix(e, prefix) })
+}
+
+func generateCertificateChain(t *testing.T) (string, string, string) {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Intermediate CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	serverCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	caCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return serverCertPEM, caCertPEM, keyPEM
+}
+
+func TestDeployWithFakeServer(t *testing.T) {
+	serverCertPEM, caCertPEM, privkeyPEM := generateCertificateChain(t)
+	certPEM := serverCertPEM + caCertPEM
+
+	newProvider := func(server *fakeBigIPServer) *provider.DeployerProvider {
+		deployer, _ := provider.NewDeployer(&provider.DeployerConfig{
+			ServerUrl:        server.URL,
+			Username:         "admin",
+			Password:         "password",
+			ProfileName:      "test",
+			ObjectNamePrefix: "certimate_example.com",
+			DeviceGroup:      "sync-failover",
+		})
+		deployer.WithLogger(slog.New(slog.DiscardHandler))
+		return deployer
+	}
+
+	t.Run("Deploy_CleanupAfterSync", func(t *testing.T) {
+		server := newFakeBigIPServer(t)
+		if _, err := newProvider(server).Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		// 已上传的文件应在安装后全部删除
+		uploads := server.eventsWithPrefix("upload ")
+		removes := server.eventsWithPrefix("rm ")
+		if len(uploads) != 3 || len(removes) != len(uploads) {
+			t.Errorf("expected uploaded files to be removed, uploads: %v, removes: %v", uploads, removes)
+		}
+		for _, remove := range removes {
+			if !strings.HasPrefix(remove, "rm /var/config/rest/downloads/certimate_example.com_") {
+				t.Errorf("unexpected remove: %s", remove)
+			}
+		}
+
+		// 旧对象应在首次同步成功后才清理，清理后再次同步
+		firstSync := slices.Index(server.events, "sync")
+		deletes := server.eventsWithPrefix("delete ")
+		if len(deletes) != 3 {
+			t.Fatalf("expected 3 old objects to be deleted, got: %v", deletes)
+		}
+		for _, deleted := range deletes {
+			if slices.Index(server.events, deleted) < firstSync || !strings.Contains(deleted, "_20200101000000") {
+				t.Errorf("unexpected delete: %s, events: %v", deleted, server.events)
+			}
+		}
+		if syncs := server.eventsWithPrefix("sync"); len(syncs) != 2 || server.events[len(server.events)-1] != "sync" {
+			t.Errorf("expected to sync again after cleanup, events: %v", server.events)
+		}
+	})
+
+	t.Run("Deploy_RollbackOnProfileUpdateFailure", func(t *testing.T) {
+		server := newFakeBigIPServer(t)
+		server.failProfileUpdate = true
+		if _, err := newProvider(server).Deploy(context.Background(), certPEM, privkeyPEM); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		// 应回滚本次安装的对象，且不得删除旧对象
+		installs := server.eventsWithPrefix("install ")
+		deletes := server.eventsWithPrefix("delete ")
+		if len(installs) != 3 || len(deletes) != 3 {
+			t.Fatalf("expected installed objects to be rolled back, installs: %v, deletes: %v", installs, deletes)
+		}
+		for _, deleted := range deletes {
+			if strings.Contains(deleted, "_20200101000000") {
+				t.Errorf("old object should not be deleted: %s", deleted)
+			}
+		}
+		if syncs := server.eventsWithPrefix("sync"); len(syncs) != 0 {
+			t.Errorf("expected no config sync, got: %v", syncs)
+		}
+	})
+
+	t.Run("Deploy_RollbackOnSyncFailure", func(t *testing.T) {
+		server := newFakeBigIPServer(t)
+		server.failConfigSync = true
+		if _, err := newProvider(server).Deploy(context.Background(), certPEM, privkeyPEM); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		// 应先还原配置文件，再回滚本次安装的对象
+		updates := server.eventsWithPrefix("update ")
+		if len(updates) != 2 || updates[1] != "update /Common/certimate_example.com_20200101000000.crt" {
+			t.Fatalf("expected client ssl profile to be restored, updates: %v", updates)
+		}
+		deletes := server.eventsWithPrefix("delete ")
+		if len(deletes) != 3 {
+			t.Fatalf("expected installed objects to be rolled back, deletes: %v", deletes)
+		}
+		for _, deleted := range deletes {
+			if strings.Contains(deleted, "_20200101000000") || slices.Index(server.events, deleted) < slices.Index(server.events, updates[1]) {
+				t.Errorf("unexpected delete: %s, events: %v", deleted, server.events)
+			}
+		}
+	})
+}
@@ -0,0 +1,295 @@
+package fastly
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	fastlysdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/fastly"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// Fastly API Token。
+	// 需具有 "TLS management" 权限。
+	ApiToken string `json:"apiToken"`
+	// 域名（支持泛域名）。
+	Domain string `json:"domain"`
+	// TLS 配置 ID。
+	// 选填。仅首次激活域名时生效，零值时使用账户的默认 TLS 配置。
+	TlsConfigurationId string `json:"tlsConfigurationId,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *fastlysdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ApiToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.Domain == "" {
+		return nil, errors.New("config `domain` is required")
+	}
+
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 提取服务器证书，Fastly 会自动补全证书链
+	serverCertPEM, _, err := certutil.ExtractCertificatesFromPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract certs: %w", err)
+	}
+
+	// 上传私钥，Fastly 要求先上传私钥才能上传证书
+	if err := d.uploadPrivateKey(ctx, certX509, privkeyPEM); err != nil {
+		return nil, err
+	}
+
+	// 获取域名当前的 TLS 激活记录
+	// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/activations/#list-tls-activations
+	listTlsActivationsReq := &fastlysdk.ListTlsActivationsRequest{
+		FilterTlsDomainId: d.config.Domain,
+	}
+	listTlsActivationsResp, err := d.sdkClient.ListTlsActivations(listTlsActivationsReq)
+	d.logger.Debug("sdk request 'fastly.ListTlsActivations'", slog.Any("request", listTlsActivationsReq), slog.Any("response", listTlsActivationsResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'fastly.ListTlsActivations': %w", err)
+	}
+
+	var activation *fastlysdk.TlsActivation
+	activatedCertId := ""
+	if len(listTlsActivationsResp.Data) > 0 {
+		activation = listTlsActivationsResp.Data[0]
+		if activation.Relationships != nil && activation.Relationships.TlsCertificate != nil && activation.Relationships.TlsCertificate.Data != nil {
+			activatedCertId = activation.Relationships.TlsCertificate.Data.Id
+		}
+	}
+
+	// 获取域名关联的证书列表
+	// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/certificates/#list-tls-certs
+	listTlsCertificatesReq := &fastlysdk.ListTlsCertificatesRequest{
+		FilterTlsDomainsId: d.config.Domain,
+		PageSize:           100,
+	}
+	listTlsCertificatesResp, err := d.sdkClient.ListTlsCertificates(listTlsCertificatesReq)
+	d.logger.Debug("sdk request 'fastly.ListTlsCertificates'", slog.Any("request", listTlsCertificatesReq), slog.Any("response", listTlsCertificatesResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'fastly.ListTlsCertificates': %w", err)
+	}
+
+	certId := ""
+	for _, tlsCert := range listTlsCertificatesResp.Data {
+		if tlsCert.Attributes != nil && isSameSerialNumber(tlsCert.Attributes.SerialNumber, certX509) {
+			certId = tlsCert.Id
+			d.logger.Info("ssl certificate already exists", slog.String("certificateId", certId))
+			break
+		}
+	}
+
+	if certId == "" {
+		// 若当前激活的证书所覆盖的域名均包含在新证书中，则原地更新证书，否则新建证书
+		var activatedCert *fastlysdk.TlsCertificate
+		for _, tlsCert := range listTlsCertificatesResp.Data {
+			if tlsCert.Id == activatedCertId {
+				activatedCert = tlsCert
+				break
+			}
+		}
+
+		if activatedCert != nil && isCertificateDomainsCovered(activatedCert, certX509) {
+			// 更新证书
+			// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/certificates/#update-tls-cert
+			updateTlsCertificateReq := &fastlysdk.UpdateTlsCertificateRequest{
+				CertBlob: serverCertPEM,
+			}
+			updateTlsCertificateResp, err := d.sdkClient.UpdateTlsCertificate(activatedCert.Id, updateTlsCertificateReq)
+			d.logger.Debug("sdk request 'fastly.UpdateTlsCertificate'", slog.String("request.certificateId", activatedCert.Id), slog.Any("response", updateTlsCertificateResp))
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute sdk request 'fastly.UpdateTlsCertificate': %w", err)
+			}
+
+			certId = activatedCert.Id
+		} else {
+			// 上传证书
+			// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/certificates/#create-tls-cert
+			createTlsCertificateReq := &fastlysdk.CreateTlsCertificateRequest{
+				CertBlob: serverCertPEM,
+				Name:     fmt.Sprintf("certimate-%d", time.Now().UnixMilli()),
+			}
+			createTlsCertificateResp, err := d.sdkClient.CreateTlsCertificate(createTlsCertificateReq)
+			d.logger.Debug("sdk request 'fastly.CreateTlsCertificate'", slog.String("request.name", createTlsCertificateReq.Name), slog.Any("response", createTlsCertificateResp))
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute sdk request 'fastly.CreateTlsCertificate': %w", err)
+			}
+
+			certId = createTlsCertificateResp.Data.Id
+		}
+	}
+
+	if activation == nil {
+		// 创建 TLS 激活记录
+		// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/activations/#create-tls-activation
+		createTlsActivationReq := &fastlysdk.CreateTlsActivationRequest{
+			TlsCertificateId:   certId,
+			TlsConfigurationId: d.config.TlsConfigurationId,
+			TlsDomainId:        d.config.Domain,
+		}
+		createTlsActivationResp, err := d.sdkClient.CreateTlsActivation(createTlsActivationReq)
+		d.logger.Debug("sdk request 'fastly.CreateTlsActivation'", slog.Any("request", createTlsActivationReq), slog.Any("response", createTlsActivationResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'fastly.CreateTlsActivation': %w", err)
+		}
+	} else if activatedCertId != certId {
+		// 更新 TLS 激活记录
+		// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/activations/#update-tls-activation
+		updateTlsActivationReq := &fastlysdk.UpdateTlsActivationRequest{
+			TlsCertificateId: certId,
+		}
+		updateTlsActivationResp, err := d.sdkClient.UpdateTlsActivation(activation.Id, updateTlsActivationReq)
+		d.logger.Debug("sdk request 'fastly.UpdateTlsActivation'", slog.String("request.activationId", activation.Id), slog.Any("request", updateTlsActivationReq), slog.Any("response", updateTlsActivationResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'fastly.UpdateTlsActivation': %w", err)
+		}
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"certificateId": certId,
+		},
+	}, nil
+}
+
+func (d *DeployerProvider) uploadPrivateKey(ctx context.Context, certX509 *x509.Certificate, privkeyPEM string) error {
+	// 获取私钥列表，通过公钥摘要避免重复上传
+	// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/private-keys/#list-tls-keys
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(certX509.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	publicKeySha1 := sha1.Sum(publicKeyDer)
+
+	listTlsPrivateKeysPage := int32(1)
+	listTlsPrivateKeysLimit := int32(100)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		listTlsPrivateKeysReq := &fastlysdk.ListTlsPrivateKeysRequest{
+			PageNumber: listTlsPrivateKeysPage,
+			PageSize:   listTlsPrivateKeysLimit,
+		}
+		listTlsPrivateKeysResp, err := d.sdkClient.ListTlsPrivateKeys(listTlsPrivateKeysReq)
+		d.logger.Debug("sdk request 'fastly.ListTlsPrivateKeys'", slog.Any("request", listTlsPrivateKeysReq), slog.Any("response", listTlsPrivateKeysResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'fastly.ListTlsPrivateKeys': %w", err)
+		}
+
+		for _, tlsKey := range listTlsPrivateKeysResp.Data {
+			if tlsKey.Attributes != nil && strings.EqualFold(tlsKey.Attributes.PublicKeySha1, hex.EncodeToString(publicKeySha1[:])) {
+				d.logger.Info("ssl private key already exists", slog.String("privateKeyId", tlsKey.Id))
+				return nil
+			}
+		}
+
+		if len(listTlsPrivateKeysResp.Data) < int(listTlsPrivateKeysLimit) || listTlsPrivateKeysResp.Links == nil || listTlsPrivateKeysResp.Links.Next == "" {
+			break
+		} else {
+			listTlsPrivateKeysPage++
+		}
+	}
+
+	// 上传私钥
+	// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/private-keys/#create-tls-key
+	createTlsPrivateKeyReq := &fastlysdk.CreateTlsPrivateKeyRequest{
+		Key:  privkeyPEM,
+		Name: fmt.Sprintf("certimate-%d", time.Now().UnixMilli()),
+	}
+	createTlsPrivateKeyResp, err := d.sdkClient.CreateTlsPrivateKey(createTlsPrivateKeyReq)
+	d.logger.Debug("sdk request 'fastly.CreateTlsPrivateKey'", slog.String("request.name", createTlsPrivateKeyReq.Name), slog.Any("response", createTlsPrivateKeyResp))
+	if err != nil {
+		// 公钥摘要的计算方式可能与 Fastly 不一致，此时私钥已存在也视为成功
+		if strings.Contains(strings.ToLower(err.Error()), "already exists") {
+			d.logger.Info("ssl private key already exists")
+			return nil
+		}
+
+		return fmt.Errorf("failed to execute sdk request 'fastly.CreateTlsPrivateKey': %w", err)
+	}
+
+	return nil
+}
+
+func isSameSerialNumber(serialNumber string, certX509 *x509.Certificate) bool {
+	// 序列号可能以十进制或十六进制表示
+	serialNumber = strings.TrimLeft(strings.ReplaceAll(serialNumber, ":", ""), "0")
+	if serialNumber == "" {
+		return false
+	}
+
+	return serialNumber == certX509.SerialNumber.String() || strings.EqualFold(serialNumber, certX509.SerialNumber.Text(16))
+}
+
+func isCertificateDomainsCovered(tlsCert *fastlysdk.TlsCertificate, certX509 *x509.Certificate) bool {
+	if tlsCert.Relationships == nil || tlsCert.Relationships.TlsDomains == nil || len(tlsCert.Relationships.TlsDomains.Data) == 0 {
+		return false
+	}
+
+	for _, domain := range tlsCert.Relationships.TlsDomains.Data {
+		if !slices.ContainsFunc(certX509.DNSNames, func(s string) bool { return strings.EqualFold(s, domain.Id) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func createSdkClient(apiToken string) (*fastlysdk.Client, error) {
+	if apiToken == "" {
+		return nil, errors.New("invalid fastly api token")
+	}
+
+	client := fastlysdk.NewClient(apiToken)
+	return client, nil
+}
@@ -0,0 +1,12 @@
+package ftp
+
+type TlsModeType string
+
+const (
+	// TLS 模式：不加密。
+	TLS_MODE_NONE = TlsModeType("")
+	// TLS 模式：显式 FTPS（AUTH TLS）。
+	TLS_MODE_EXPLICIT = TlsModeType("explicit")
+	// TLS 模式：隐式 FTPS。
+	TLS_MODE_IMPLICIT = TlsModeType("implicit")
+)
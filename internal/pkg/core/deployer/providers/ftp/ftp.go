@@ -0,0 +1,290 @@
+package ftp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+	ftpsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/ftp"
+	fileutil "github.com/usual2970/certimate/internal/pkg/utils/file"
+)
+
+type DeployerConfig struct {
+	// FTP 主机。
+	FtpHost string `json:"ftpHost"`
+	// FTP 端口。
+	// 零值时根据 TLS 模式决定，隐式 FTPS 默认值 990，其他默认值 21。
+	FtpPort int32 `json:"ftpPort,omitempty"`
+	// FTP 登录用户名。
+	// 零值时默认值 "anonymous"。
+	FtpUsername string `json:"ftpUsername,omitempty"`
+	// FTP 登录密码。
+	FtpPassword string `json:"ftpPassword,omitempty"`
+	// TLS 模式。
+	// 零值时默认值 [TLS_MODE_NONE]。
+	TlsMode TlsModeType `json:"tlsMode,omitempty"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 是否禁用 EPSV 命令，仅使用 PASV 命令建立被动模式数据连接。
+	DisableEPSV bool `json:"disableEPSV,omitempty"`
+	// 输出证书格式。
+	OutputFormat local.OutputFormatType `json:"outputFormat,omitempty"`
+	// 输出证书文件路径。
+	OutputCertPath string `json:"outputCertPath"`
+	// 输出服务器证书文件路径。
+	// 选填。
+	OutputServerCertPath string `json:"outputServerCertPath,omitempty"`
+	// 输出中间证书文件路径。
+	// 选填。
+	OutputIntermediaCertPath string `json:"outputIntermediaCertPath,omitempty"`
+	// 输出私钥文件路径。
+	OutputKeyPath string `json:"outputKeyPath,omitempty"`
+	// 是否在覆盖前备份已存在的输出文件。
+	// 备份文件路径为原文件路径追加时间戳后缀，如 "/path/to/cert.pem.20060102150405.bak"。
+	OutputBackup bool `json:"outputBackup,omitempty"`
+	// 输出根证书文件路径。
+	// 选填。证书格式为 PEM 或 PEM_COMBINED 时有效。
+	OutputRootCertPath string `json:"outputRootCertPath,omitempty"`
+	// 证书链与系统信任的根证书中均未找到根证书时，是否根据 AIA 扩展联网下载颁发者证书。
+	// 选填。输出根证书文件路径不为空时有效。
+	OutputRootCertFetchIssuer bool `json:"outputRootCertFetchIssuer,omitempty"`
+	// 是否逆序输出证书链，即中间证书在前、服务器证书在后。
+	// 证书格式为 PEM 或 PEM_COMBINED 时有效。
+	PemChainReversed bool `json:"pemChainReversed,omitempty"`
+	// 私钥格式。
+	// 证书格式为 PEM、PEM_COMBINED 或 P7B 时有效。零值时保持原有格式。
+	PemKeyFormat local.PrivateKeyFormatType `json:"pemKeyFormat,omitempty"`
+	// 私钥加密密码。
+	// 私钥格式为 PKCS8_ENCRYPTED 时必填。
+	PemKeyPassword string `json:"pemKeyPassword,omitempty"`
+	// PFX 导出密码。
+	// 证书格式为 PFX 时必填。
+	PfxPassword string `json:"pfxPassword,omitempty"`
+	// JKS 别名。
+	// 证书格式为 JKS 时必填。
+	JksAlias string `json:"jksAlias,omitempty"`
+	// JKS 密钥密码。
+	// 证书格式为 JKS 时必填。
+	JksKeypass string `json:"jksKeypass,omitempty"`
+	// JKS 存储密码。
+	// 证书格式为 JKS 时必填。
+	JksStorepass string `json:"jksStorepass,omitempty"`
+}
+
+type DeployerProvider struct {
+	config *DeployerConfig
+	logger *slog.Logger
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	return &DeployerProvider{
+		config: config,
+		logger: slog.Default(),
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.FtpHost == "" {
+		return nil, errors.New("config `ftpHost` is required")
+	}
+	if d.config.OutputCertPath == "" {
+		return nil, errors.New("config `outputCertPath` is required")
+	}
+
+	// 生成输出文件
+	files, err := d.buildOutputFiles(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 连接 FTP 服务器
+	client, err := d.createFtpClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ftp client: %w", err)
+	}
+	defer client.Quit()
+
+	d.logger.Info("ftp connected")
+
+	// 上传证书和私钥文件
+	for _, file := range files {
+		if err := d.writeFile(ctx, client, file.Path, file.Data); err != nil {
+			return nil, fmt.Errorf("failed to upload file '%s': %w", file.Path, err)
+		}
+
+		d.logger.Info("ssl certificate file uploaded", slog.String("path", file.Path))
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func (d *DeployerProvider) buildOutputFiles(ctx context.Context, certPEM string, privkeyPEM string) ([]local.OutputFile, error) {
+	files, err := local.BuildOutputFiles(ctx, certPEM, privkeyPEM, &local.OutputOptions{
+		Format:              d.config.OutputFormat,
+		CertPath:            d.config.OutputCertPath,
+		ServerCertPath:      d.config.OutputServerCertPath,
+		IntermediaCertPath:  d.config.OutputIntermediaCertPath,
+		RootCertPath:        d.config.OutputRootCertPath,
+		RootCertFetchIssuer: d.config.OutputRootCertFetchIssuer,
+		KeyPath:             d.config.OutputKeyPath,
+		PemChainReversed:    d.config.PemChainReversed,
+		PemKeyFormat:        d.config.PemKeyFormat,
+		PemKeyPassword:      d.config.PemKeyPassword,
+		PfxPassword:         d.config.PfxPassword,
+		JksAlias:            d.config.JksAlias,
+		JksKeypass:          d.config.JksKeypass,
+		JksStorepass:        d.config.JksStorepass,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.Info(fmt.Sprintf("ssl certificate transformed to %s", strings.ToLower(string(d.config.OutputFormat))))
+
+	return files, nil
+}
+
+func (d *DeployerProvider) createFtpClient(ctx context.Context) (*ftpsdk.Client, error) {
+	port := d.config.FtpPort
+	if port == 0 {
+		if d.config.TlsMode == TLS_MODE_IMPLICIT {
+			port = 990
+		} else {
+			port = 21
+		}
+	}
+
+	options := &ftpsdk.ClientOptions{
+		DisableEPSV: d.config.DisableEPSV,
+	}
+	switch d.config.TlsMode {
+	case TLS_MODE_NONE:
+		options.TLSMode = ftpsdk.TLSModeNone
+	case TLS_MODE_EXPLICIT:
+		options.TLSMode = ftpsdk.TLSModeExplicit
+	case TLS_MODE_IMPLICIT:
+		options.TLSMode = ftpsdk.TLSModeImplicit
+	default:
+		return nil, fmt.Errorf("unsupported tls mode '%s'", d.config.TlsMode)
+	}
+	if d.config.AllowInsecureConnections {
+		options.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	client, err := ftpsdk.Dial(ctx, net.JoinHostPort(d.config.FtpHost, strconv.Itoa(int(port))), options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Login(d.config.FtpUsername, d.config.FtpPassword); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (d *DeployerProvider) writeFile(ctx context.Context, client *ftpsdk.Client, filePath string, data []byte) error {
+	dir := path.Dir(filePath)
+	if err := client.MakeDirAll(dir); err != nil {
+		return fmt.Errorf("failed to create remote directory: %w", err)
+	}
+
+	// 先写入同目录下的临时文件，再重命名替换目标文件
+	tempPath := path.Join(dir, fmt.Sprintf(".%s.tmp-%d", path.Base(filePath), time.Now().UnixNano()))
+	if err := client.Store(ctx, tempPath, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to write to remote file: %w", err)
+	}
+
+	replaced := false
+	defer func() {
+		if !replaced {
+			client.Delete(tempPath)
+		}
+	}()
+
+	if d.config.OutputBackup {
+		// 以复制而非重命名的方式备份，确保替换前目标文件始终存在
+		if err := d.backupFile(ctx, client, filePath); err != nil {
+			return fmt.Errorf("failed to backup remote file: %w", err)
+		}
+	}
+
+	if err := client.Rename(tempPath, filePath); err != nil {
+		// 部分服务端不允许重命名覆盖已存在的文件，退化为先将原文件移开再重命名，失败时恢复原文件
+		d.logger.Warn("failed to replace remote file by renaming, falling back to non-atomic replacement", slog.String("path", filePath), slog.Any("error", err))
+
+		asidePath := path.Join(dir, fmt.Sprintf(".%s.old-%d", path.Base(filePath), time.Now().UnixNano()))
+		movedAside := client.Rename(filePath, asidePath) == nil
+		if err := client.Rename(tempPath, filePath); err != nil {
+			if movedAside {
+				if err := client.Rename(asidePath, filePath); err != nil {
+					d.logger.Error("failed to restore remote file", slog.String("path", filePath), slog.String("backupPath", asidePath), slog.Any("error", err))
+				}
+			}
+			return fmt.Errorf("failed to replace remote file: %w", err)
+		}
+
+		if movedAside {
+			client.Delete(asidePath)
+		}
+	}
+
+	replaced = true
+	return nil
+}
+
+func (d *DeployerProvider) backupFile(ctx context.Context, client *ftpsdk.Client, filePath string) error {
+	buf := bytes.NewBuffer(nil)
+	if err := client.Retrieve(ctx, filePath, buf); err != nil {
+		// 原文件不存在时无需备份
+		var respErr *ftpsdk.ResponseError
+		if errors.As(err, &respErr) && respErr.Code == 550 {
+			return nil
+		}
+		return err
+	}
+
+	if err := client.Store(ctx, fileutil.BackupPath(filePath, time.Now()), buf); err != nil {
+		return err
+	}
+
+	// 清理超出数量上限的旧备份
+	names, err := client.NameList(ctx, path.Dir(filePath))
+	if err != nil {
+		return fmt.Errorf("failed to list remote backup files: %w", err)
+	}
+
+	for _, stale := range fileutil.StaleBackups(filePath, names, fileutil.DefaultBackupLimit) {
+		if err := client.Delete(stale); err != nil {
+			return fmt.Errorf("failed to prune remote backup files: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,529 @@
+package ftp_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/ftp"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+)
+
+// 模拟 FTP 服务端，仅实现被动模式下的上传、下载、列目录、创建目录、重命名及删除。
+type fakeFtpServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	implicit  bool
+
+	// 是否拒绝重命名覆盖已存在的文件。
+	noOverwrite bool
+	// 是否拒绝重命名临时文件。
+	failTempRename bool
+
+	mutex    sync.Mutex
+	files    map[string][]byte
+	dirs     map[string]bool
+	commands []string
+}
+
+func newFakeFtpServer(t *testing.T, tlsConfig *tls.Config, implicit bool) *fakeFtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeFtpServer{
+		listener:  listener,
+		tlsConfig: tlsConfig,
+		implicit:  implicit,
+		files:     make(map[string][]byte),
+		dirs:      map[string]bool{"/": true},
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeFtpServer) Port() int32 {
+	return int32(s.listener.Addr().(*net.TCPAddr).Port)
+}
+
+func (s *fakeFtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	if s.implicit {
+		conn = tls.Server(conn, s.tlsConfig)
+	}
+
+	reader := bufio.NewReader(conn)
+	reply := func(format string, args ...any) {
+		fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+
+	var (
+		authed      bool
+		protData    bool
+		renameFrom  string
+		dataListen  net.Listener
+		resolvePath = func(p string) string { return path.Clean("/" + p) }
+	)
+
+	reply("220 fake ftp ready")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		cmd, arg, _ := strings.Cut(line, " ")
+		cmd = strings.ToUpper(cmd)
+
+		s.mutex.Lock()
+		s.commands = append(s.commands, cmd)
+		s.mutex.Unlock()
+
+		switch cmd {
+		case "AUTH":
+			reply("234 AUTH TLS ok")
+			conn = tls.Server(conn, s.tlsConfig)
+			reader = bufio.NewReader(conn)
+
+		case "USER":
+			reply("331 password required")
+
+		case "PASS":
+			if arg != "secret" {
+				reply("530 login incorrect")
+				continue
+			}
+			authed = true
+			reply("230 logged in")
+
+		case "PBSZ":
+			reply("200 PBSZ=0")
+
+		case "PROT":
+			protData = arg == "P"
+			reply("200 PROT ok")
+
+		case "TYPE":
+			reply("200 type set")
+
+		case "EPSV", "PASV":
+			dataListen, err = net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				reply("425 cannot open data connection")
+				continue
+			}
+
+			port := dataListen.Addr().(*net.TCPAddr).Port
+			if cmd == "EPSV" {
+				reply("229 Entering Extended Passive Mode (|||%d|)", port)
+			} else {
+				reply("227 Entering Passive Mode (10,0,0,1,%d,%d)", port/256, port%256)
+			}
+
+		case "MKD":
+			if !authed {
+				reply("530 not logged in")
+				continue
+			}
+
+			p := resolvePath(arg)
+			s.mutex.Lock()
+			exists := s.dirs[p]
+			s.dirs[p] = true
+			s.mutex.Unlock()
+			if exists {
+				reply("550 directory exists")
+			} else {
+				reply("257 \"%s\" created", p)
+			}
+
+		case "STOR":
+			if !authed || dataListen == nil {
+				reply("503 bad sequence")
+				continue
+			}
+
+			dataConn, err := dataListen.Accept()
+			dataListen.Close()
+			dataListen = nil
+			if err != nil {
+				reply("425 cannot open data connection")
+				continue
+			}
+			if protData {
+				dataConn = tls.Server(dataConn, s.tlsConfig)
+			}
+
+			reply("150 ok to send data")
+			data, _ := io.ReadAll(dataConn)
+			dataConn.Close()
+
+			p := resolvePath(arg)
+			s.mutex.Lock()
+			if !s.dirs[path.Dir(p)] {
+				s.mutex.Unlock()
+				reply("553 directory does not exist")
+				continue
+			}
+			s.files[p] = data
+			s.mutex.Unlock()
+			reply("226 transfer complete")
+
+		case "RETR", "NLST":
+			if !authed || dataListen == nil {
+				reply("503 bad sequence")
+				continue
+			}
+
+			p := resolvePath(arg)
+			var data []byte
+			s.mutex.Lock()
+			if cmd == "RETR" {
+				content, exists := s.files[p]
+				if !exists {
+					s.mutex.Unlock()
+					dataListen.Close()
+					dataListen = nil
+					reply("550 file not found")
+					continue
+				}
+				data = content
+			} else {
+				for name := range s.files {
+					if path.Dir(name) == p {
+						data = append(data, []byte(name+"\r\n")...)
+					}
+				}
+			}
+			s.mutex.Unlock()
+
+			dataConn, err := dataListen.Accept()
+			dataListen.Close()
+			dataListen = nil
+			if err != nil {
+				reply("425 cannot open data connection")
+				continue
+			}
+			if protData {
+				dataConn = tls.Server(dataConn, s.tlsConfig)
+			}
+
+			reply("150 ok to send data")
+			dataConn.Write(data)
+			dataConn.Close()
+			reply("226 transfer complete")
+
+		case "RNFR":
+			renameFrom = resolvePath(arg)
+			s.mutex.Lock()
+			_, exists := s.files[renameFrom]
+			s.mutex.Unlock()
+			if exists {
+				reply("350 ready for RNTO")
+			} else {
+				reply("550 file not found")
+			}
+
+		case "RNTO":
+			s.mutex.Lock()
+			_, exists := s.files[resolvePath(arg)]
+			if (s.noOverwrite && exists) || (s.failTempRename && strings.Contains(renameFrom, ".tmp-")) {
+				s.mutex.Unlock()
+				reply("553 rename failed")
+				continue
+			}
+			s.files[resolvePath(arg)] = s.files[renameFrom]
+			delete(s.files, renameFrom)
+			s.mutex.Unlock()
+			reply("250 rename successful")
+
+		case "DELE":
+			s.mutex.Lock()
+			delete(s.files, resolvePath(arg))
+			s.mutex.Unlock()
+			reply("250 deleted")
+
+		case "QUIT":
+			reply("221 bye")
+			return
+
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+func generateTLSConfig(t *testing.T) *tls.Config {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{certDER}, PrivateKey: key}},
+	}
+}
+
+func generateCertificate(t *testing.T) (string, string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestDeploy(t *testing.T) {
+	certPEM, privkeyPEM := generateCertificate(t)
+
+	testCases := []struct {
+		name        string
+		tlsMode     provider.TlsModeType
+		disableEPSV bool
+	}{
+		{name: "Deploy_Plain", tlsMode: provider.TLS_MODE_NONE},
+		{name: "Deploy_PlainPASV", tlsMode: provider.TLS_MODE_NONE, disableEPSV: true},
+		{name: "Deploy_ExplicitTLS", tlsMode: provider.TLS_MODE_EXPLICIT},
+		{name: "Deploy_ImplicitTLS", tlsMode: provider.TLS_MODE_IMPLICIT},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newFakeFtpServer(t, generateTLSConfig(t), tc.tlsMode == provider.TLS_MODE_IMPLICIT)
+			server.files["/ssl/example.com/cert.pem"] = []byte("old")
+
+			deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+				FtpHost:                  "127.0.0.1",
+				FtpPort:                  server.Port(),
+				FtpUsername:              "user",
+				FtpPassword:              "secret",
+				TlsMode:                  tc.tlsMode,
+				AllowInsecureConnections: true,
+				DisableEPSV:              tc.disableEPSV,
+				OutputFormat:             local.OUTPUT_FORMAT_PEM,
+				OutputCertPath:           "/ssl/example.com/cert.pem",
+				OutputKeyPath:            "/ssl/example.com/key.pem",
+				OutputBackup:             true,
+			})
+			if err != nil {
+				t.Fatalf("err: %+v", err)
+			}
+
+			if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+				t.Fatalf("err: %+v", err)
+			}
+
+			server.mutex.Lock()
+			defer server.mutex.Unlock()
+
+			if string(server.files["/ssl/example.com/cert.pem"]) != certPEM {
+				t.Errorf("unexpected certificate content: %s", server.files["/ssl/example.com/cert.pem"])
+			}
+			if string(server.files["/ssl/example.com/key.pem"]) != privkeyPEM {
+				t.Errorf("unexpected private key content: %s", server.files["/ssl/example.com/key.pem"])
+			}
+
+			backups := 0
+			for p, data := range server.files {
+				if strings.HasPrefix(p, "/ssl/example.com/cert.pem.") && strings.HasSuffix(p, ".bak") && string(data) == "old" {
+					backups++
+				}
+				if strings.Contains(p, ".tmp-") {
+					t.Errorf("temp file left behind: %s", p)
+				}
+			}
+			if backups != 1 {
+				t.Errorf("expected 1 backup file, got %d", backups)
+			}
+
+			commands := strings.Join(server.commands, ",")
+			if tc.tlsMode == provider.TLS_MODE_EXPLICIT && !strings.HasPrefix(commands, "AUTH,") {
+				t.Errorf("expected AUTH TLS first, got: %s", commands)
+			}
+			if tc.tlsMode != provider.TLS_MODE_NONE && !strings.Contains(commands, "PROT") {
+				t.Errorf("expected PROT P, got: %s", commands)
+			}
+			if tc.disableEPSV && strings.Contains(commands, "EPSV") {
+				t.Errorf("unexpected EPSV, got: %s", commands)
+			}
+		})
+	}
+
+	t.Run("Deploy_PruneBackups", func(t *testing.T) {
+		server := newFakeFtpServer(t, nil, false)
+		server.dirs["/ssl"] = true
+		server.files["/ssl/cert.pem"] = []byte("old")
+		for i := 0; i < 6; i++ {
+			server.files[fmt.Sprintf("/ssl/cert.pem.2024010100000%d.bak", i)] = []byte("older")
+		}
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			FtpHost:        "127.0.0.1",
+			FtpPort:        server.Port(),
+			FtpUsername:    "user",
+			FtpPassword:    "secret",
+			OutputFormat:   local.OUTPUT_FORMAT_PEM_COMBINED,
+			OutputCertPath: "/ssl/cert.pem",
+			OutputBackup:   true,
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		backups := make([]string, 0)
+		for p := range server.files {
+			if strings.HasSuffix(p, ".bak") {
+				backups = append(backups, p)
+			}
+		}
+		if len(backups) != 5 {
+			t.Errorf("expected 5 backup files, got %d: %v", len(backups), backups)
+		}
+		for _, p := range []string{"/ssl/cert.pem.20240101000000.bak", "/ssl/cert.pem.20240101000001.bak"} {
+			if _, ok := server.files[p]; ok {
+				t.Errorf("stale backup file should be removed: %s", p)
+			}
+		}
+	})
+
+	t.Run("Deploy_NoOverwrite", func(t *testing.T) {
+		server := newFakeFtpServer(t, nil, false)
+		server.noOverwrite = true
+		server.dirs["/ssl"] = true
+		server.files["/ssl/cert.pem"] = []byte("old")
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			FtpHost:        "127.0.0.1",
+			FtpPort:        server.Port(),
+			FtpUsername:    "user",
+			FtpPassword:    "secret",
+			OutputFormat:   local.OUTPUT_FORMAT_PEM_COMBINED,
+			OutputCertPath: "/ssl/cert.pem",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		if string(server.files["/ssl/cert.pem"]) != certPEM+privkeyPEM {
+			t.Errorf("unexpected certificate content: %s", server.files["/ssl/cert.pem"])
+		}
+		if len(server.files) != 1 {
+			t.Errorf("unexpected files left behind: %d", len(server.files))
+		}
+	})
+
+	t.Run("Deploy_RestoreOnFailure", func(t *testing.T) {
+		server := newFakeFtpServer(t, nil, false)
+		server.noOverwrite = true
+		server.failTempRename = true
+		server.dirs["/ssl"] = true
+		server.files["/ssl/cert.pem"] = []byte("old")
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			FtpHost:        "127.0.0.1",
+			FtpPort:        server.Port(),
+			FtpUsername:    "user",
+			FtpPassword:    "secret",
+			OutputFormat:   local.OUTPUT_FORMAT_PEM_COMBINED,
+			OutputCertPath: "/ssl/cert.pem",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		if string(server.files["/ssl/cert.pem"]) != "old" {
+			t.Errorf("original file should be restored, got: %s", server.files["/ssl/cert.pem"])
+		}
+		if len(server.files) != 1 {
+			t.Errorf("unexpected files left behind: %d", len(server.files))
+		}
+	})
+
+	t.Run("Deploy_LoginFailed", func(t *testing.T) {
+		server := newFakeFtpServer(t, nil, false)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			FtpHost:        "127.0.0.1",
+			FtpPort:        server.Port(),
+			FtpUsername:    "user",
+			FtpPassword:    "wrong",
+			OutputFormat:   local.OUTPUT_FORMAT_PEM_COMBINED,
+			OutputCertPath: "/ssl/combined.pem",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err == nil || !strings.Contains(err.Error(), "530") {
+			t.Fatalf("expected login error, got: %v", err)
+		}
+	})
+}
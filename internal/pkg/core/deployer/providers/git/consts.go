@@ -0,0 +1,12 @@
+package git
+
+type EncryptionType string
+
+const (
+	// 加密方式：不加密。
+	ENCRYPTION_NONE = EncryptionType("")
+	// 加密方式：使用 age 加密（ASCII 封装格式）。
+	ENCRYPTION_AGE = EncryptionType("age")
+	// 加密方式：使用 SOPS 以 age 为密钥后端加密。
+	ENCRYPTION_SOPS = EncryptionType("sops")
+)
@@ -0,0 +1,510 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// 仓库地址。
+	// 支持 HTTPS（如 "https://github.com/owner/repo.git"）、SSH（如 "git@github.com:owner/repo.git"）及本地路径。
+	RepositoryUrl string `json:"repositoryUrl"`
+	// HTTPS 认证用户名。
+	// 选填。
+	Username string `json:"username,omitempty"`
+	// HTTPS 认证密码或访问令牌。
+	// 选填。
+	Password string `json:"password,omitempty"`
+	// SSH 私钥。
+	// 选填。
+	SshKey string `json:"sshKey,omitempty"`
+	// SSH 私钥密码。
+	// 选填。
+	SshKeyPassphrase string `json:"sshKeyPassphrase,omitempty"`
+	// SSH 已知主机列表，格式同 known_hosts 文件。
+	// 选填。零值时首次连接信任并记录主机密钥（即 "StrictHostKeyChecking=accept-new"），可通过 OnKnownHostsUpdated 持久化。
+	SshKnownHosts string `json:"sshKnownHosts,omitempty"`
+	// 首次信任主机密钥后的回调，入参为记录后的 known_hosts 文件内容，可用于持久化。
+	// 选填。已知主机列表为空时有效。
+	OnKnownHostsUpdated func(knownHosts string) error `json:"-"`
+	// 分支名。
+	// 零值时使用仓库默认分支。
+	Branch string `json:"branch,omitempty"`
+	// 输出证书格式。
+	OutputFormat local.OutputFormatType `json:"outputFormat,omitempty"`
+	// 输出证书文件路径，为相对于仓库根目录的路径。
+	OutputCertPath string `json:"outputCertPath"`
+	// 输出服务器证书文件路径。
+	// 选填。
+	OutputServerCertPath string `json:"outputServerCertPath,omitempty"`
+	// 输出中间证书文件路径。
+	// 选填。
+	OutputIntermediaCertPath string `json:"outputIntermediaCertPath,omitempty"`
+	// 输出私钥文件路径。
+	OutputKeyPath string `json:"outputKeyPath,omitempty"`
+	// 输出根证书文件路径。
+	// 选填。证书格式为 PEM 或 PEM_COMBINED 时有效。
+	OutputRootCertPath string `json:"outputRootCertPath,omitempty"`
+	// 证书链与系统信任的根证书中均未找到根证书时，是否根据 AIA 扩展联网下载颁发者证书。
+	// 选填。输出根证书文件路径不为空时有效。
+	OutputRootCertFetchIssuer bool `json:"outputRootCertFetchIssuer,omitempty"`
+	// 是否逆序输出证书链，即中间证书在前、服务器证书在后。
+	// 证书格式为 PEM 或 PEM_COMBINED 时有效。
+	PemChainReversed bool `json:"pemChainReversed,omitempty"`
+	// 私钥格式。
+	// 证书格式为 PEM、PEM_COMBINED 或 P7B 时有效。零值时保持原有格式。
+	PemKeyFormat local.PrivateKeyFormatType `json:"pemKeyFormat,omitempty"`
+	// 私钥加密密码。
+	// 私钥格式为 PKCS8_ENCRYPTED 时必填。
+	PemKeyPassword string `json:"pemKeyPassword,omitempty"`
+	// PFX 导出密码。
+	// 证书格式为 PFX 时必填。
+	PfxPassword string `json:"pfxPassword,omitempty"`
+	// JKS 别名。
+	// 证书格式为 JKS 时必填。
+	JksAlias string `json:"jksAlias,omitempty"`
+	// JKS 密钥密码。
+	// 证书格式为 JKS 时必填。
+	JksKeypass string `json:"jksKeypass,omitempty"`
+	// JKS 存储密码。
+	// 证书格式为 JKS 时必填。
+	JksStorepass string `json:"jksStorepass,omitempty"`
+	// 私钥加密方式。
+	// 作用于包含私钥的文件。零值时默认值 [ENCRYPTION_NONE]。
+	Encryption EncryptionType `json:"encryption,omitempty"`
+	// age 接收者公钥列表。
+	// 私钥加密方式不为 [ENCRYPTION_NONE] 时必填。
+	AgeRecipients []string `json:"ageRecipients,omitempty"`
+	// 提交信息模板。
+	// 支持模板变量："${DOMAIN}" 为证书主域名；"${DOMAINS}" 为全部域名，以 "," 分隔；"${SERIAL}" 为证书序列号。
+	// 零值时默认值 "chore(certs): renew certificate for ${DOMAIN}"。
+	CommitMessage string `json:"commitMessage,omitempty"`
+	// 提交者名称。
+	// 零值时默认值 "certimate"。
+	CommitAuthorName string `json:"commitAuthorName,omitempty"`
+	// 提交者邮箱。
+	// 零值时默认值 "certimate@localhost"。
+	CommitAuthorEmail string `json:"commitAuthorEmail,omitempty"`
+}
+
+type DeployerProvider struct {
+	config *DeployerConfig
+	logger *slog.Logger
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	return &DeployerProvider{
+		config: config,
+		logger: slog.Default(),
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.RepositoryUrl == "" {
+		return nil, errors.New("config `repositoryUrl` is required")
+	}
+	if d.config.OutputCertPath == "" {
+		return nil, errors.New("config `outputCertPath` is required")
+	}
+	if d.config.Encryption != ENCRYPTION_NONE && len(d.config.AgeRecipients) == 0 {
+		return nil, errors.New("config `ageRecipients` is required")
+	}
+	if err := d.checkExecutables(); err != nil {
+		return nil, err
+	}
+
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 生成输出文件
+	files, err := d.buildOutputFiles(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 加密包含私钥的文件
+	for i, file := range files {
+		if !file.Sensitive || d.config.Encryption == ENCRYPTION_NONE {
+			continue
+		}
+
+		data, err := encryptData(ctx, d.config.Encryption, d.config.AgeRecipients, file.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt file '%s': %w", file.Path, err)
+		}
+
+		files[i].Data = data
+		d.logger.Info("ssl private key encrypted", slog.String("path", file.Path), slog.String("encryption", string(d.config.Encryption)))
+	}
+
+	// 准备临时工作目录
+	tempDir, err := os.MkdirTemp("", "certimate-git-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := d.createGitRunner(tempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// 克隆仓库
+	repoDir := filepath.Join(tempDir, "repo")
+	cloneArgs := []string{"clone", "--depth", "1"}
+	if d.config.Branch != "" {
+		cloneArgs = append(cloneArgs, "--branch", d.config.Branch)
+	}
+	cloneArgs = append(cloneArgs, "--", d.config.RepositoryUrl, repoDir)
+	if _, err := runner.run(ctx, tempDir, cloneArgs...); err != nil {
+		return nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+	d.logger.Info("git repository cloned", slog.String("url", redactRepositoryUrl(d.config.RepositoryUrl)))
+
+	// 持久化首次连接时信任的主机密钥
+	if knownHosts := runner.recordedKnownHosts(); knownHosts != "" {
+		d.logger.Info("ssh host key trusted on first use", slog.String("knownHosts", knownHosts))
+		if d.config.OnKnownHostsUpdated != nil {
+			if err := d.config.OnKnownHostsUpdated(knownHosts + "\n"); err != nil {
+				d.logger.Warn("failed to persist ssh known hosts", slog.String("error", err.Error()))
+			}
+		}
+	}
+
+	// 写入文件
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		relPath, err := sanitizeRepoPath(file.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		absPath := filepath.Join(repoDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for '%s': %w", relPath, err)
+		}
+		if err := os.WriteFile(absPath, file.Data, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write file '%s': %w", relPath, err)
+		}
+
+		paths = append(paths, relPath)
+		d.logger.Info("ssl certificate file written", slog.String("path", relPath))
+	}
+
+	// 暂存并检查是否有变更
+	if _, err := runner.run(ctx, repoDir, append([]string{"add", "--"}, paths...)...); err != nil {
+		return nil, fmt.Errorf("failed to stage files: %w", err)
+	}
+	if _, err := runner.run(ctx, repoDir, "diff", "--cached", "--quiet"); err == nil {
+		d.logger.Info("no changes to commit, skipping")
+
+		commitSha, _ := runner.run(ctx, repoDir, "rev-parse", "HEAD")
+		return &deployer.DeployResult{
+			ExtendedData: map[string]any{
+				"commit": strings.TrimSpace(commitSha),
+			},
+		}, nil
+	}
+
+	// 提交变更
+	commitMessage := resolveTemplate(d.config.CommitMessage, certX509)
+	if _, err := runner.run(ctx, repoDir, "commit", "--no-verify", "-m", commitMessage); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	commitSha, err := runner.run(ctx, repoDir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve commit: %w", err)
+	}
+	commitSha = strings.TrimSpace(commitSha)
+	d.logger.Info("git changes committed", slog.String("commit", commitSha), slog.String("message", commitMessage))
+
+	// 推送变更
+	pushRef := "HEAD"
+	if d.config.Branch != "" {
+		pushRef = "HEAD:refs/heads/" + d.config.Branch
+	}
+	if _, err := runner.run(ctx, repoDir, "push", "origin", pushRef); err != nil {
+		return nil, fmt.Errorf("failed to push: %w", err)
+	}
+	d.logger.Info("git changes pushed", slog.String("ref", pushRef))
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"commit": commitSha,
+		},
+	}, nil
+}
+
+// 检查部署所依赖的外部命令是否可用，以便在执行任何操作前给出明确的错误信息。
+func (d *DeployerProvider) checkExecutables() error {
+	executables := []string{"git"}
+	if d.config.SshKey != "" {
+		executables = append(executables, "ssh")
+	}
+	switch d.config.Encryption {
+	case ENCRYPTION_AGE:
+		executables = append(executables, "age")
+	case ENCRYPTION_SOPS:
+		executables = append(executables, "sops")
+	}
+
+	for _, executable := range executables {
+		if _, err := exec.LookPath(executable); err != nil {
+			return fmt.Errorf("executable '%s' is required but not found in PATH, please install it first: %w", executable, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) buildOutputFiles(ctx context.Context, certPEM string, privkeyPEM string) ([]local.OutputFile, error) {
+	files, err := local.BuildOutputFiles(ctx, certPEM, privkeyPEM, &local.OutputOptions{
+		Format:              d.config.OutputFormat,
+		CertPath:            d.config.OutputCertPath,
+		ServerCertPath:      d.config.OutputServerCertPath,
+		IntermediaCertPath:  d.config.OutputIntermediaCertPath,
+		RootCertPath:        d.config.OutputRootCertPath,
+		RootCertFetchIssuer: d.config.OutputRootCertFetchIssuer,
+		KeyPath:             d.config.OutputKeyPath,
+		PemChainReversed:    d.config.PemChainReversed,
+		PemKeyFormat:        d.config.PemKeyFormat,
+		PemKeyPassword:      d.config.PemKeyPassword,
+		PfxPassword:         d.config.PfxPassword,
+		JksAlias:            d.config.JksAlias,
+		JksKeypass:          d.config.JksKeypass,
+		JksStorepass:        d.config.JksStorepass,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.Info(fmt.Sprintf("ssl certificate transformed to %s", strings.ToLower(string(d.config.OutputFormat))))
+
+	return files, nil
+}
+
+type gitRunner struct {
+	env     []string
+	configs [][2]string
+
+	knownHostsPath string
+}
+
+func (d *DeployerProvider) createGitRunner(tempDir string) (*gitRunner, error) {
+	authorName := d.config.CommitAuthorName
+	if authorName == "" {
+		authorName = "certimate"
+	}
+	authorEmail := d.config.CommitAuthorEmail
+	if authorEmail == "" {
+		authorEmail = "certimate@localhost"
+	}
+
+	runner := &gitRunner{
+		env: append(os.Environ(),
+			"GIT_TERMINAL_PROMPT=0",
+			"GIT_CONFIG_NOSYSTEM=1",
+			"GIT_AUTHOR_NAME="+authorName,
+			"GIT_AUTHOR_EMAIL="+authorEmail,
+			"GIT_COMMITTER_NAME="+authorName,
+			"GIT_COMMITTER_EMAIL="+authorEmail,
+		),
+		configs: [][2]string{{"commit.gpgsign", "false"}},
+	}
+
+	// HTTPS 认证：通过请求头传递凭据，避免写入仓库配置
+	// 经由环境变量而非命令行参数传递，以免凭据出现在进程列表中
+	if d.config.Username != "" || d.config.Password != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(d.config.Username + ":" + d.config.Password))
+		runner.configs = append(runner.configs, [2]string{"http.extraHeader", "Authorization: Basic " + credentials})
+	}
+
+	// SSH 认证：写入临时私钥文件
+	if d.config.SshKey != "" {
+		keyPEM := d.config.SshKey
+		if d.config.SshKeyPassphrase != "" {
+			key, err := ssh.ParseRawPrivateKeyWithPassphrase([]byte(d.config.SshKey), []byte(d.config.SshKeyPassphrase))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt ssh key: %w", err)
+			}
+
+			block, err := ssh.MarshalPrivateKey(key, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal ssh key: %w", err)
+			}
+			keyPEM = string(pem.EncodeToMemory(block))
+		}
+		if !strings.HasSuffix(keyPEM, "\n") {
+			keyPEM += "\n"
+		}
+
+		keyPath := filepath.Join(tempDir, "id_ssh")
+		if err := os.WriteFile(keyPath, []byte(keyPEM), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write ssh key: %w", err)
+		}
+
+		knownHostsPath := filepath.Join(tempDir, "known_hosts")
+		if err := os.WriteFile(knownHostsPath, []byte(d.config.SshKnownHosts), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write ssh known hosts: %w", err)
+		}
+
+		sshCommand := fmt.Sprintf("ssh -i %s -o IdentitiesOnly=yes -o BatchMode=yes -o UserKnownHostsFile=%s", shellQuote(keyPath), shellQuote(knownHostsPath))
+		if d.config.SshKnownHosts != "" {
+			sshCommand += " -o StrictHostKeyChecking=yes"
+		} else {
+			// 未提供已知主机列表时，首次连接信任并记录主机密钥，后续主机密钥变更时将拒绝连接
+			sshCommand += " -o StrictHostKeyChecking=accept-new"
+			runner.knownHostsPath = knownHostsPath
+		}
+
+		runner.env = append(runner.env, "GIT_SSH_COMMAND="+sshCommand)
+	}
+
+	return runner, nil
+}
+
+func (r *gitRunner) run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append([]string{}, r.env...)
+	cmd.Env = append(cmd.Env, fmt.Sprintf("GIT_CONFIG_COUNT=%d", len(r.configs)))
+	for i, config := range r.configs {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, config[0]), fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, config[1]))
+	}
+
+	stdoutBuf := bytes.NewBuffer(nil)
+	cmd.Stdout = stdoutBuf
+	stderrBuf := bytes.NewBuffer(nil)
+	cmd.Stderr = stderrBuf
+	if err := cmd.Run(); err != nil {
+		return stdoutBuf.String(), fmt.Errorf("failed to execute 'git %s' (stderr: %s): %w", args[0], strings.TrimSpace(stderrBuf.String()), err)
+	}
+
+	return stdoutBuf.String(), nil
+}
+
+// 读取首次连接时记录的主机密钥。
+// 未启用首次信任或未记录任何主机密钥时返回空字符串。
+func (r *gitRunner) recordedKnownHosts() string {
+	if r.knownHostsPath == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(r.knownHostsPath)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+func encryptData(ctx context.Context, encryption EncryptionType, recipients []string, data []byte) ([]byte, error) {
+	var cmd *exec.Cmd
+
+	switch encryption {
+	case ENCRYPTION_AGE:
+		args := []string{"--encrypt", "--armor"}
+		for _, recipient := range recipients {
+			args = append(args, "--recipient", recipient)
+		}
+		cmd = exec.CommandContext(ctx, "age", args...)
+		cmd.Stdin = bytes.NewReader(data)
+
+	case ENCRYPTION_SOPS:
+		// 明文经由标准输入传递，避免私钥写入临时文件；输入统一按二进制处理
+		cmd = exec.CommandContext(ctx, "sops", "--encrypt", "--age", strings.Join(recipients, ","), "--input-type", "binary", "--output-type", "yaml", "/dev/stdin")
+		cmd.Stdin = bytes.NewReader(data)
+
+	default:
+		return nil, fmt.Errorf("unsupported encryption '%s'", encryption)
+	}
+
+	stdoutBuf := bytes.NewBuffer(nil)
+	cmd.Stdout = stdoutBuf
+	stderrBuf := bytes.NewBuffer(nil)
+	cmd.Stderr = stderrBuf
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to execute %s (stderr: %s): %w", cmd.Args[0], strings.TrimSpace(stderrBuf.String()), err)
+	}
+
+	return stdoutBuf.Bytes(), nil
+}
+
+func resolveTemplate(template string, certX509 *x509.Certificate) string {
+	if template == "" {
+		template = "chore(certs): renew certificate for ${DOMAIN}"
+	}
+
+	domain := certX509.Subject.CommonName
+	if domain == "" && len(certX509.DNSNames) > 0 {
+		domain = certX509.DNSNames[0]
+	}
+
+	s := template
+	s = strings.ReplaceAll(s, "${DOMAIN}", domain)
+	s = strings.ReplaceAll(s, "${DOMAINS}", strings.Join(certX509.DNSNames, ","))
+	s = strings.ReplaceAll(s, "${SERIAL}", strings.ToUpper(certX509.SerialNumber.Text(16)))
+	return s
+}
+
+func sanitizeRepoPath(path string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(strings.TrimLeft(path, "/\\")))
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid path '%s': must be inside the repository", path)
+	}
+	if cleaned == ".git" || strings.HasPrefix(cleaned, ".git"+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid path '%s': must not be inside the .git directory", path)
+	}
+
+	return cleaned, nil
+}
+
+func redactRepositoryUrl(repositoryUrl string) string {
+	u, err := url.Parse(repositoryUrl)
+	if err != nil || u.User == nil {
+		return repositoryUrl
+	}
+
+	u.User = url.User(u.User.Username())
+	return u.String()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
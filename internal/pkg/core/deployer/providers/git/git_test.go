@@ -0,0 +1,300 @@
+package git_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/git"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@localhost",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@localhost",
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v, output: %s", strings.Join(args, " "), err, output)
+	}
+	return string(output)
+}
+
+// 创建一个包含初始提交的本地裸仓库，返回其路径。
+func newBareRepository(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	rootDir := t.TempDir()
+	bareDir := filepath.Join(rootDir, "remote.git")
+	workDir := filepath.Join(rootDir, "seed")
+
+	runGit(t, rootDir, "init", "--bare", "--initial-branch=main", bareDir)
+	runGit(t, rootDir, "init", "--initial-branch=main", workDir)
+	os.WriteFile(filepath.Join(workDir, "README.md"), []byte("# infra\n"), 0o644)
+	runGit(t, workDir, "add", "README.md")
+	runGit(t, workDir, "-c", "commit.gpgsign=false", "commit", "-m", "init")
+	runGit(t, workDir, "push", bareDir, "main")
+
+	return bareDir
+}
+
+func generateCertificate(t *testing.T) (string, string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(0xABCDEF),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "www.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestDeploy(t *testing.T) {
+	certPEM, privkeyPEM := generateCertificate(t)
+
+	t.Run("Deploy_PEM", func(t *testing.T) {
+		bareDir := newBareRepository(t)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			RepositoryUrl:  bareDir,
+			Branch:         "main",
+			OutputFormat:   local.OUTPUT_FORMAT_PEM,
+			OutputCertPath: "/clusters/prod/tls/tls.crt",
+			OutputKeyPath:  "clusters/prod/tls/tls.key",
+			CommitMessage:  "renew ${DOMAINS} (${SERIAL})",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		res, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		head := strings.TrimSpace(runGit(t, bareDir, "rev-parse", "main"))
+		if res.ExtendedData["commit"] != head {
+			t.Errorf("expected commit %s, got %v", head, res.ExtendedData["commit"])
+		}
+		if message := strings.TrimSpace(runGit(t, bareDir, "log", "-1", "--format=%s", "main")); message != "renew example.com,www.example.com (ABCDEF)" {
+			t.Errorf("unexpected commit message: %s", message)
+		}
+		if author := strings.TrimSpace(runGit(t, bareDir, "log", "-1", "--format=%an <%ae>", "main")); author != "certimate <certimate@localhost>" {
+			t.Errorf("unexpected commit author: %s", author)
+		}
+		if content := runGit(t, bareDir, "show", "main:clusters/prod/tls/tls.crt"); content != certPEM {
+			t.Errorf("unexpected certificate content: %s", content)
+		}
+		if content := runGit(t, bareDir, "show", "main:clusters/prod/tls/tls.key"); content != privkeyPEM {
+			t.Errorf("unexpected private key content: %s", content)
+		}
+
+		// 内容未变化时不应产生新提交
+		res, err = deployer.Deploy(context.Background(), certPEM, privkeyPEM)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if count := strings.TrimSpace(runGit(t, bareDir, "rev-list", "--count", "main")); count != "2" {
+			t.Errorf("expected 2 commits, got %s", count)
+		}
+		if res.ExtendedData["commit"] != head {
+			t.Errorf("expected commit %s, got %v", head, res.ExtendedData["commit"])
+		}
+	})
+
+	t.Run("Deploy_AgeEncrypted", func(t *testing.T) {
+		bareDir := newBareRepository(t)
+
+		// 以脚本模拟 age 命令，记录参数并输出固定密文
+		binDir := t.TempDir()
+		script := "#!/bin/sh\necho \"$@\" > \"" + filepath.Join(binDir, "age.args") + "\"\ncat > /dev/null\necho '-----BEGIN AGE ENCRYPTED FILE-----'\necho 'ciphertext'\necho '-----END AGE ENCRYPTED FILE-----'\n"
+		if err := os.WriteFile(filepath.Join(binDir, "age"), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			RepositoryUrl:  bareDir,
+			OutputFormat:   local.OUTPUT_FORMAT_PEM,
+			OutputCertPath: "tls/tls.crt",
+			OutputKeyPath:  "tls/tls.key.age",
+			Encryption:     provider.ENCRYPTION_AGE,
+			AgeRecipients:  []string{"age1aaa", "age1bbb"},
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if content := runGit(t, bareDir, "show", "main:tls/tls.crt"); content != certPEM {
+			t.Errorf("certificate should not be encrypted, got: %s", content)
+		}
+		if content := runGit(t, bareDir, "show", "main:tls/tls.key.age"); !strings.Contains(content, "BEGIN AGE ENCRYPTED FILE") {
+			t.Errorf("private key should be encrypted, got: %s", content)
+		}
+		args, _ := os.ReadFile(filepath.Join(binDir, "age.args"))
+		if strings.TrimSpace(string(args)) != "--encrypt --armor --recipient age1aaa --recipient age1bbb" {
+			t.Errorf("unexpected age args: %s", args)
+		}
+	})
+
+	t.Run("Deploy_SopsEncrypted", func(t *testing.T) {
+		bareDir := newBareRepository(t)
+
+		// 以脚本模拟 sops 命令，记录参数与标准输入并输出固定密文
+		binDir := t.TempDir()
+		script := "#!/bin/sh\necho \"$@\" > \"" + filepath.Join(binDir, "sops.args") + "\"\ncat > \"" + filepath.Join(binDir, "sops.stdin") + "\"\necho 'data: ENC[ciphertext]'\n"
+		if err := os.WriteFile(filepath.Join(binDir, "sops"), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			RepositoryUrl:  bareDir,
+			OutputFormat:   local.OUTPUT_FORMAT_PEM,
+			OutputCertPath: "tls/tls.crt",
+			OutputKeyPath:  "tls/tls.key.sops.yaml",
+			Encryption:     provider.ENCRYPTION_SOPS,
+			AgeRecipients:  []string{"age1aaa"},
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if content := runGit(t, bareDir, "show", "main:tls/tls.key.sops.yaml"); !strings.Contains(content, "ENC[ciphertext]") {
+			t.Errorf("private key should be encrypted, got: %s", content)
+		}
+		// 明文应经由标准输入传递，而非临时文件
+		args, _ := os.ReadFile(filepath.Join(binDir, "sops.args"))
+		if !strings.HasSuffix(strings.TrimSpace(string(args)), "/dev/stdin") {
+			t.Errorf("unexpected sops args: %s", args)
+		}
+		if stdin, _ := os.ReadFile(filepath.Join(binDir, "sops.stdin")); string(stdin) != privkeyPEM {
+			t.Errorf("unexpected sops stdin: %s", stdin)
+		}
+	})
+
+	t.Run("Deploy_MissingExecutable", func(t *testing.T) {
+		gitPath, err := exec.LookPath("git")
+		if err != nil {
+			t.Skip("git is not installed")
+		}
+
+		// 仅保留 git 命令，模拟未安装 age 的运行环境
+		binDir := t.TempDir()
+		if err := os.Symlink(gitPath, filepath.Join(binDir, "git")); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("PATH", binDir)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			RepositoryUrl:  "https://example.com/infra.git",
+			OutputFormat:   local.OUTPUT_FORMAT_PEM,
+			OutputCertPath: "tls/tls.crt",
+			OutputKeyPath:  "tls/tls.key.age",
+			Encryption:     provider.ENCRYPTION_AGE,
+			AgeRecipients:  []string{"age1aaa"},
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		_, err = deployer.Deploy(context.Background(), certPEM, privkeyPEM)
+		if err == nil || !strings.Contains(err.Error(), "'age' is required") {
+			t.Errorf("expected missing executable error, got: %v", err)
+		}
+	})
+
+	t.Run("Deploy_InvalidPath", func(t *testing.T) {
+		bareDir := newBareRepository(t)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			RepositoryUrl:  bareDir,
+			OutputFormat:   local.OUTPUT_FORMAT_PEM_COMBINED,
+			OutputCertPath: "../outside.pem",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+	t.Run("Deploy_HTTPCredentials", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git is not installed")
+		}
+
+		var mtx sync.Mutex
+		authorizations := make([]string, 0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mtx.Lock()
+			authorizations = append(authorizations, r.Header.Get("Authorization"))
+			mtx.Unlock()
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			RepositoryUrl:  server.URL + "/infra.git",
+			Username:       "deploy",
+			Password:       "s3cr3t",
+			OutputFormat:   local.OUTPUT_FORMAT_PEM_COMBINED,
+			OutputCertPath: "certs/example.com.pem",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		expect := "Basic " + base64.StdEncoding.EncodeToString([]byte("deploy:s3cr3t"))
+		mtx.Lock()
+		defer mtx.Unlock()
+		if len(authorizations) == 0 || authorizations[0] != expect {
+			t.Errorf("expected authorization header %q, got %v", expect, authorizations)
+		}
+	})
+}
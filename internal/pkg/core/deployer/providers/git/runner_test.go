@@ -0,0 +1,90 @@
+package git
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCreateGitRunner(t *testing.T) {
+	t.Run("SshKnownHosts", func(t *testing.T) {
+		testCases := []struct {
+			name         string
+			knownHosts   string
+			expectOption string
+		}{
+			{"Empty", "", "StrictHostKeyChecking=accept-new"},
+			{"Provided", "example.com ssh-ed25519 AAAA", "StrictHostKeyChecking=yes"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				tempDir := t.TempDir()
+				d := &DeployerProvider{config: &DeployerConfig{SshKey: generateSshKey(t), SshKnownHosts: tc.knownHosts}}
+				runner, err := d.createGitRunner(tempDir)
+				if err != nil {
+					t.Fatalf("err: %+v", err)
+				}
+
+				sshCommand := ""
+				for _, env := range runner.env {
+					if strings.HasPrefix(env, "GIT_SSH_COMMAND=") {
+						sshCommand = env
+					}
+				}
+				if !strings.Contains(sshCommand, tc.expectOption) || strings.Contains(sshCommand, "StrictHostKeyChecking=no") {
+					t.Errorf("unexpected ssh command: %s", sshCommand)
+				}
+
+				// 首次信任的主机密钥应可被读取以便持久化
+				os.WriteFile(filepath.Join(tempDir, "known_hosts"), []byte("example.com ssh-ed25519 BBBB\n"), 0o600)
+				recorded := runner.recordedKnownHosts()
+				if tc.knownHosts == "" && recorded != "example.com ssh-ed25519 BBBB" {
+					t.Errorf("unexpected recorded known hosts: %q", recorded)
+				} else if tc.knownHosts != "" && recorded != "" {
+					t.Errorf("known hosts should not be recorded when provided, got: %q", recorded)
+				}
+			})
+		}
+	})
+
+	t.Run("HttpCredentials", func(t *testing.T) {
+		d := &DeployerProvider{config: &DeployerConfig{Username: "deploy", Password: "s3cr3t"}}
+		runner, err := d.createGitRunner(t.TempDir())
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		found := false
+		for _, config := range runner.configs {
+			if config[0] == "http.extraHeader" && strings.HasPrefix(config[1], "Authorization: Basic ") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected credentials to be passed via git config, got: %v", runner.configs)
+		}
+	})
+}
+
+func generateSshKey(t *testing.T) string {
+	t.Helper()
+
+	_, privkey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(privkey, "")
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	return string(pem.EncodeToMemory(block))
+}
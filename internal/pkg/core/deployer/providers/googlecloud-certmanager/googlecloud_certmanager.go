@@ -0,0 +1,128 @@
+package googlecloudcertmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	uploadersp "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/googlecloud-certmanager"
+	gcloudsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/googlecloud"
+)
+
+type DeployerConfig struct {
+	// Google Cloud 服务账号密钥（JSON 格式）。
+	ServiceAccountKey string `json:"serviceAccountKey"`
+	// Google Cloud 项目 ID。
+	// 选填。零值时使用服务账号密钥中的项目 ID。
+	ProjectId string `json:"projectId,omitempty"`
+	// Certificate Manager 位置。
+	// 零值时默认值 "global"。
+	Location string `json:"location,omitempty"`
+	// Certificate Manager 证书名称。
+	// 选填。零值时表示新建证书；否则表示更新证书。
+	CertificateName string `json:"certificateName,omitempty"`
+}
+
+type DeployerProvider struct {
+	config      *DeployerConfig
+	logger      *slog.Logger
+	sdkClient   *gcloudsdk.Client
+	sslUploader uploader.Uploader
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServiceAccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	uploader, err := uploadersp.NewUploader(&uploadersp.UploaderConfig{
+		ServiceAccountKey: config.ServiceAccountKey,
+		ProjectId:         config.ProjectId,
+		Location:          config.Location,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:      config,
+		logger:      slog.Default(),
+		sdkClient:   client,
+		sslUploader: uploader,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	d.sslUploader.WithLogger(logger)
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.CertificateName == "" {
+		// 上传证书到 Certificate Manager
+		upres, err := d.sslUploader.Upload(ctx, certPEM, privkeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+		} else {
+			d.logger.Info("ssl certificate uploaded", slog.Any("result", upres))
+		}
+	} else {
+		projectId := d.config.ProjectId
+		if projectId == "" {
+			projectId = d.sdkClient.ProjectId()
+		}
+		if projectId == "" {
+			return nil, errors.New("config `projectId` is required")
+		}
+
+		location := d.config.Location
+		if location == "" {
+			location = "global"
+		}
+
+		// 更新证书
+		// REF: https://cloud.google.com/certificate-manager/docs/reference/certificate-manager/rest/v1/projects.locations.certificates/patch
+		certificateName := fmt.Sprintf("projects/%s/locations/%s/certificates/%s", projectId, location, d.config.CertificateName)
+		patchCertificateReq := &gcloudsdk.Certificate{
+			SelfManaged: &gcloudsdk.CertificateSelfManaged{
+				PemCertificate: certPEM,
+				PemPrivateKey:  privkeyPEM,
+			},
+		}
+		patchCertificateResp, err := d.sdkClient.PatchCertificate(certificateName, "selfManaged", patchCertificateReq)
+		d.logger.Debug("sdk request 'certificatemanager.PatchCertificate'", slog.String("name", certificateName), slog.Any("response", patchCertificateResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'certificatemanager.PatchCertificate': %w", err)
+		}
+
+		if _, err := d.sdkClient.WaitOperation(ctx, patchCertificateResp, 2*time.Second); err != nil {
+			return nil, fmt.Errorf("failed to wait for certificate update: %w", err)
+		}
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func createSdkClient(serviceAccountKey string) (*gcloudsdk.Client, error) {
+	if serviceAccountKey == "" {
+		return nil, errors.New("invalid googlecloud service account key")
+	}
+
+	return gcloudsdk.NewClient(serviceAccountKey)
+}
@@ -0,0 +1,12 @@
+package googlecloudlb
+
+type ResourceType string
+
+const (
+	// 资源类型：替换指定目标 HTTPS 代理的证书。
+	RESOURCE_TYPE_TARGET_HTTPS_PROXY = ResourceType("target-https-proxy")
+	// 资源类型：替换指定目标 SSL 代理的证书。
+	RESOURCE_TYPE_TARGET_SSL_PROXY = ResourceType("target-ssl-proxy")
+	// 资源类型：替换指定证书映射条目的证书。
+	RESOURCE_TYPE_CERTIFICATE_MAP_ENTRY = ResourceType("certificate-map-entry")
+)
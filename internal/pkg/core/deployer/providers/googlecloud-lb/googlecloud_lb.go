@@ -0,0 +1,307 @@
+package googlecloudlb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	uploaderspcm "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/googlecloud-certmanager"
+	uploaderspcompute "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/googlecloud-compute"
+	gcloudsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/googlecloud"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// Google Cloud 服务账号密钥（JSON 格式）。
+	ServiceAccountKey string `json:"serviceAccountKey"`
+	// Google Cloud 项目 ID。
+	// 选填。零值时使用服务账号密钥中的项目 ID。
+	ProjectId string `json:"projectId,omitempty"`
+	// Google Cloud 区域。
+	// 选填。零值或 "global" 时表示全局负载均衡。
+	Region string `json:"region,omitempty"`
+	// 部署资源类型。
+	ResourceType ResourceType `json:"resourceType"`
+	// 目标代理名称。
+	// 部署资源类型为 [RESOURCE_TYPE_TARGET_HTTPS_PROXY]、[RESOURCE_TYPE_TARGET_SSL_PROXY] 时必填。
+	TargetProxyName string `json:"targetProxyName,omitempty"`
+	// 证书映射名称。
+	// 部署资源类型为 [RESOURCE_TYPE_CERTIFICATE_MAP_ENTRY] 时必填。
+	CertificateMapName string `json:"certificateMapName,omitempty"`
+	// 证书映射条目名称。
+	// 部署资源类型为 [RESOURCE_TYPE_CERTIFICATE_MAP_ENTRY] 时必填。
+	CertificateMapEntryName string `json:"certificateMapEntryName,omitempty"`
+}
+
+type DeployerProvider struct {
+	config      *DeployerConfig
+	logger      *slog.Logger
+	sdkClient   *gcloudsdk.Client
+	sslUploader uploader.Uploader
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServiceAccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	// 目标代理使用 Compute Engine SSL 证书，证书映射使用 Certificate Manager 证书
+	var uploader uploader.Uploader
+	switch config.ResourceType {
+	case RESOURCE_TYPE_TARGET_HTTPS_PROXY, RESOURCE_TYPE_TARGET_SSL_PROXY:
+		uploader, err = uploaderspcompute.NewUploader(&uploaderspcompute.UploaderConfig{
+			ServiceAccountKey: config.ServiceAccountKey,
+			ProjectId:         config.ProjectId,
+			Region:            config.Region,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+		}
+
+	case RESOURCE_TYPE_CERTIFICATE_MAP_ENTRY:
+		uploader, err = uploaderspcm.NewUploader(&uploaderspcm.UploaderConfig{
+			ServiceAccountKey: config.ServiceAccountKey,
+			ProjectId:         config.ProjectId,
+			Location:          "global",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported resource type '%s'", config.ResourceType)
+	}
+
+	return &DeployerProvider{
+		config:      config,
+		logger:      slog.Default(),
+		sdkClient:   client,
+		sslUploader: uploader,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	d.sslUploader.WithLogger(logger)
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	// 上传证书到 Compute Engine/Certificate Manager
+	upres, err := d.sslUploader.Upload(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+	} else {
+		d.logger.Info("ssl certificate uploaded", slog.Any("result", upres))
+	}
+
+	projectId := d.config.ProjectId
+	if projectId == "" {
+		projectId = d.sdkClient.ProjectId()
+	}
+
+	// 根据部署资源类型决定部署方式
+	switch d.config.ResourceType {
+	case RESOURCE_TYPE_TARGET_HTTPS_PROXY:
+		if err := d.deployToTargetHttpsProxy(ctx, projectId, certPEM, upres.CertId); err != nil {
+			return nil, err
+		}
+
+	case RESOURCE_TYPE_TARGET_SSL_PROXY:
+		if err := d.deployToTargetSslProxy(ctx, projectId, certPEM, upres.CertId); err != nil {
+			return nil, err
+		}
+
+	case RESOURCE_TYPE_CERTIFICATE_MAP_ENTRY:
+		if err := d.deployToCertificateMapEntry(ctx, projectId, upres.CertId); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported resource type '%s'", d.config.ResourceType)
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func (d *DeployerProvider) deployToTargetHttpsProxy(ctx context.Context, projectId string, certPEM string, cloudCertUrl string) error {
+	if d.config.TargetProxyName == "" {
+		return errors.New("config `targetProxyName` is required")
+	}
+
+	// 获取目标 HTTPS 代理
+	// REF: https://cloud.google.com/compute/docs/reference/rest/v1/targetHttpsProxies/get
+	getTargetHttpsProxyResp, err := d.sdkClient.GetTargetHttpsProxy(projectId, d.config.Region, d.config.TargetProxyName)
+	d.logger.Debug("sdk request 'compute.GetTargetHttpsProxy'", slog.String("name", d.config.TargetProxyName), slog.Any("response", getTargetHttpsProxyResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'compute.GetTargetHttpsProxy': %w", err)
+	} else if getTargetHttpsProxyResp.CertificateMap != "" {
+		return fmt.Errorf("target https proxy '%s' uses certificate map '%s', please deploy to the certificate map entry instead", d.config.TargetProxyName, getTargetHttpsProxyResp.CertificateMap)
+	}
+
+	sslCertificates, err := d.resolveSslCertificates(projectId, getTargetHttpsProxyResp.SslCertificates, certPEM, cloudCertUrl)
+	if err != nil {
+		return err
+	}
+
+	// 设置目标 HTTPS 代理的证书
+	// REF: https://cloud.google.com/compute/docs/reference/rest/v1/targetHttpsProxies/setSslCertificates
+	setSslCertificatesResp, err := d.sdkClient.SetTargetHttpsProxySslCertificates(projectId, d.config.Region, d.config.TargetProxyName, sslCertificates)
+	d.logger.Debug("sdk request 'compute.SetTargetHttpsProxySslCertificates'", slog.Any("sslCertificates", sslCertificates), slog.Any("response", setSslCertificatesResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'compute.SetTargetHttpsProxySslCertificates': %w", err)
+	}
+
+	if _, err := d.sdkClient.WaitComputeOperation(ctx, setSslCertificatesResp, 2*time.Second); err != nil {
+		return fmt.Errorf("failed to wait for target https proxy update: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) deployToTargetSslProxy(ctx context.Context, projectId string, certPEM string, cloudCertUrl string) error {
+	if d.config.TargetProxyName == "" {
+		return errors.New("config `targetProxyName` is required")
+	}
+	if d.config.Region != "" && d.config.Region != "global" {
+		return errors.New("target ssl proxies are global resources, config `region` must be empty")
+	}
+
+	// 获取目标 SSL 代理
+	// REF: https://cloud.google.com/compute/docs/reference/rest/v1/targetSslProxies/get
+	getTargetSslProxyResp, err := d.sdkClient.GetTargetSslProxy(projectId, d.config.TargetProxyName)
+	d.logger.Debug("sdk request 'compute.GetTargetSslProxy'", slog.String("name", d.config.TargetProxyName), slog.Any("response", getTargetSslProxyResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'compute.GetTargetSslProxy': %w", err)
+	} else if getTargetSslProxyResp.CertificateMap != "" {
+		return fmt.Errorf("target ssl proxy '%s' uses certificate map '%s', please deploy to the certificate map entry instead", d.config.TargetProxyName, getTargetSslProxyResp.CertificateMap)
+	}
+
+	sslCertificates, err := d.resolveSslCertificates(projectId, getTargetSslProxyResp.SslCertificates, certPEM, cloudCertUrl)
+	if err != nil {
+		return err
+	}
+
+	// 设置目标 SSL 代理的证书
+	// REF: https://cloud.google.com/compute/docs/reference/rest/v1/targetSslProxies/setSslCertificates
+	setSslCertificatesResp, err := d.sdkClient.SetTargetSslProxySslCertificates(projectId, d.config.TargetProxyName, sslCertificates)
+	d.logger.Debug("sdk request 'compute.SetTargetSslProxySslCertificates'", slog.Any("sslCertificates", sslCertificates), slog.Any("response", setSslCertificatesResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'compute.SetTargetSslProxySslCertificates': %w", err)
+	}
+
+	if _, err := d.sdkClient.WaitComputeOperation(ctx, setSslCertificatesResp, 2*time.Second); err != nil {
+		return fmt.Errorf("failed to wait for target ssl proxy update: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) deployToCertificateMapEntry(ctx context.Context, projectId string, cloudCertName string) error {
+	if d.config.CertificateMapName == "" {
+		return errors.New("config `certificateMapName` is required")
+	}
+	if d.config.CertificateMapEntryName == "" {
+		return errors.New("config `certificateMapEntryName` is required")
+	}
+
+	// 更新证书映射条目
+	// REF: https://cloud.google.com/certificate-manager/docs/reference/certificate-manager/rest/v1/projects.locations.certificateMaps.certificateMapEntries/patch
+	entryName := fmt.Sprintf("projects/%s/locations/global/certificateMaps/%s/certificateMapEntries/%s", projectId, d.config.CertificateMapName, d.config.CertificateMapEntryName)
+	patchCertificateMapEntryReq := &gcloudsdk.CertificateMapEntry{
+		Certificates: []string{cloudCertName},
+	}
+	patchCertificateMapEntryResp, err := d.sdkClient.PatchCertificateMapEntry(entryName, "certificates", patchCertificateMapEntryReq)
+	d.logger.Debug("sdk request 'certificatemanager.PatchCertificateMapEntry'", slog.String("name", entryName), slog.Any("request", patchCertificateMapEntryReq), slog.Any("response", patchCertificateMapEntryResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'certificatemanager.PatchCertificateMapEntry': %w", err)
+	}
+
+	if _, err := d.sdkClient.WaitOperation(ctx, patchCertificateMapEntryResp, 2*time.Second); err != nil {
+		return fmt.Errorf("failed to wait for certificate map entry update: %w", err)
+	}
+
+	return nil
+}
+
+// 计算目标代理的新证书列表：替换域名相同的旧证书，其余证书保持不变；若无可替换的证书则追加。
+func (d *DeployerProvider) resolveSslCertificates(projectId string, current []string, certPEM string, cloudCertUrl string) ([]string, error) {
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	listSslCertificatesResp, err := d.sdkClient.ListSslCertificates(projectId, d.config.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'compute.ListSslCertificates': %w", err)
+	}
+
+	newDomains := normalizeDomains(certX509.DNSNames)
+	sameDomainCerts := make(map[string]bool)
+	for _, sslCertificate := range listSslCertificatesResp {
+		if slices.Equal(normalizeDomains(sslCertificate.SubjectAlternativeNames), newDomains) {
+			sameDomainCerts[resourceKey(sslCertificate.SelfLink)] = true
+		}
+	}
+
+	replaced := false
+	sslCertificates := make([]string, 0, len(current)+1)
+	for _, certUrl := range current {
+		if resourceKey(certUrl) == resourceKey(cloudCertUrl) || sameDomainCerts[resourceKey(certUrl)] {
+			if !replaced {
+				sslCertificates = append(sslCertificates, cloudCertUrl)
+				replaced = true
+			}
+			continue
+		}
+
+		sslCertificates = append(sslCertificates, certUrl)
+	}
+	if !replaced {
+		sslCertificates = append(sslCertificates, cloudCertUrl)
+	}
+
+	return sslCertificates, nil
+}
+
+func normalizeDomains(domains []string) []string {
+	result := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		result = append(result, strings.ToLower(domain))
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// 资源 URL 可能带有不同的主机名前缀（如 "www.googleapis.com" 或 "compute.googleapis.com"），仅比较 "projects/" 之后的部分。
+func resourceKey(resourceUrl string) string {
+	if i := strings.Index(resourceUrl, "projects/"); i >= 0 {
+		return resourceUrl[i:]
+	}
+	return resourceUrl
+}
+
+func createSdkClient(serviceAccountKey string) (*gcloudsdk.Client, error) {
+	if serviceAccountKey == "" {
+		return nil, errors.New("invalid googlecloud service account key")
+	}
+
+	return gcloudsdk.NewClient(serviceAccountKey)
+}
@@ -0,0 +1,77 @@
+package googlecloudlb_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/googlecloud-lb"
+)
+
+var (
+	fInputCertPath         string
+	fInputKeyPath          string
+	fServiceAccountKeyPath string
+	fRegion                string
+	fTargetProxyName       string
+)
+
+func init() {
+	argsPrefix := "CERTIMATE_DEPLOYER_GOOGLECLOUDLB_"
+
+	flag.StringVar(&fInputCertPath, argsPrefix+"INPUTCERTPATH", "", "")
+	flag.StringVar(&fInputKeyPath, argsPrefix+"INPUTKEYPATH", "", "")
+	flag.StringVar(&fServiceAccountKeyPath, argsPrefix+"SERVICEACCOUNTKEYPATH", "", "")
+	flag.StringVar(&fRegion, argsPrefix+"REGION", "", "")
+	flag.StringVar(&fTargetProxyName, argsPrefix+"TARGETPROXYNAME", "", "")
+}
+
+/*
+Shell command to run this test:
+
+	go test -v ./googlecloud_lb_test.go -args \
+	--CERTIMATE_DEPLOYER_GOOGLECLOUDLB_INPUTCERTPATH="/path/to/your-input-cert.pem" \
+	--CERTIMATE_DEPLOYER_GOOGLECLOUDLB_INPUTKEYPATH="/path/to/your-input-key.pem" \
+	--CERTIMATE_DEPLOYER_GOOGLECLOUDLB_SERVICEACCOUNTKEYPATH="/path/to/your-service-account-key.json" \
+	--CERTIMATE_DEPLOYER_GOOGLECLOUDLB_REGION="global" \
+	--CERTIMATE_DEPLOYER_GOOGLECLOUDLB_TARGETPROXYNAME="your-target-https-proxy-name"
+*/
+func TestDeploy(t *testing.T) {
+	flag.Parse()
+
+	t.Run("Deploy_ToTargetHttpsProxy", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("SERVICEACCOUNTKEYPATH: %v", fServiceAccountKeyPath),
+			fmt.Sprintf("REGION: %v", fRegion),
+			fmt.Sprintf("TARGETPROXYNAME: %v", fTargetProxyName),
+		}, "\n"))
+
+		fServiceAccountKeyData, _ := os.ReadFile(fServiceAccountKeyPath)
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			ServiceAccountKey: string(fServiceAccountKeyData),
+			Region:            fRegion,
+			ResourceType:      provider.RESOURCE_TYPE_TARGET_HTTPS_PROXY,
+			TargetProxyName:   fTargetProxyName,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+}
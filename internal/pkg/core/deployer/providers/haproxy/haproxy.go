@@ -0,0 +1,140 @@
+package haproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	haproxysdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/haproxy"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// HAProxy Runtime API 网络类型。
+	// 可取值 "unix"、"tcp"。零值时根据地址推断。
+	RuntimeApiNetwork string `json:"runtimeApiNetwork,omitempty"`
+	// HAProxy Runtime API 地址。
+	// 网络类型为 "unix" 时为套接字文件路径，为 "tcp" 时为 "host:port"。
+	RuntimeApiAddress string `json:"runtimeApiAddress"`
+	// 证书文件路径，即 HAProxy 配置中 crt 指令所引用的路径。
+	CertPath string `json:"certPath"`
+	// 持久化证书文件的部署器，在内存中的证书更新成功后调用。
+	// 选填。零值时不持久化，HAProxy 重启后将恢复为原有证书文件。
+	Persister deployer.Deployer `json:"-"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *haproxysdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.RuntimeApiNetwork, config.RuntimeApiAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	if d.config.Persister != nil {
+		d.config.Persister.WithLogger(d.logger)
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.CertPath == "" {
+		return nil, errors.New("config `certPath` is required")
+	} else if strings.IndexFunc(d.config.CertPath, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		// Runtime API 以空白分隔参数、以换行分隔命令，不允许路径中出现此类字符，以免注入其他命令
+		return nil, errors.New("config `certPath` must not contain whitespace or control characters")
+	}
+
+	// 合并证书链与私钥
+	combinedPEM, err := certutil.TransformCertificateFromPEMToCombinedPEM(certPEM, privkeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to combine certificate and private key: %w", err)
+	}
+
+	// 创建证书更新事务
+	// REF: https://docs.haproxy.org/3.0/management.html#9.3-set%20ssl%20cert
+	setSslCertResp, err := d.sdkClient.SetSslCert(ctx, d.config.CertPath, combinedPEM)
+	d.logger.Debug("sdk request 'haproxy.SetSslCert'", slog.String("certName", d.config.CertPath), slog.String("response", setSslCertResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'haproxy.SetSslCert': %w", err)
+	}
+
+	// 提交证书更新事务
+	// REF: https://docs.haproxy.org/3.0/management.html#9.3-commit%20ssl%20cert
+	commitSslCertResp, err := d.sdkClient.CommitSslCert(ctx, d.config.CertPath)
+	d.logger.Debug("sdk request 'haproxy.CommitSslCert'", slog.String("certName", d.config.CertPath), slog.String("response", commitSslCertResp))
+	if err != nil {
+		// 提交失败时放弃事务，避免残留的事务阻塞后续更新
+		abortSslCertResp, abortErr := d.sdkClient.AbortSslCert(ctx, d.config.CertPath)
+		d.logger.Debug("sdk request 'haproxy.AbortSslCert'", slog.String("certName", d.config.CertPath), slog.String("response", abortSslCertResp))
+		if abortErr != nil {
+			d.logger.Warn("failed to abort ssl cert transaction", slog.String("error", abortErr.Error()))
+		}
+
+		return nil, fmt.Errorf("failed to execute sdk request 'haproxy.CommitSslCert': %w", err)
+	}
+
+	d.logger.Info("ssl certificate updated in memory", slog.String("certName", d.config.CertPath))
+
+	// 持久化证书文件
+	if d.config.Persister != nil {
+		if _, err := d.config.Persister.Deploy(ctx, certPEM, privkeyPEM); err != nil {
+			return nil, fmt.Errorf("failed to persist certificate file: %w", err)
+		}
+
+		d.logger.Info("ssl certificate file persisted", slog.String("path", d.config.CertPath))
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func createSdkClient(network, address string) (*haproxysdk.Client, error) {
+	if address == "" {
+		return nil, errors.New("invalid haproxy runtime api address")
+	}
+
+	if network == "" {
+		if strings.HasPrefix(address, "unix://") || strings.HasPrefix(address, "/") {
+			network = "unix"
+		} else {
+			network = "tcp"
+		}
+	}
+	address = strings.TrimPrefix(strings.TrimPrefix(address, "unix://"), "tcp://")
+
+	switch network {
+	case "unix", "tcp":
+	default:
+		return nil, fmt.Errorf("invalid haproxy runtime api network '%s'", network)
+	}
+
+	client := haproxysdk.NewClient(network, address)
+	return client, nil
+}
@@ -0,0 +1,22 @@
+package k8ssecret
+
+type AuthMethodType string
+
+const (
+	// 认证方式：根据有无 kubeconfig 决定，无 kubeconfig 时使用集群内 ServiceAccount。
+	AUTH_METHOD_AUTO = AuthMethodType("")
+	// 认证方式：kubeconfig 文件。
+	AUTH_METHOD_KUBECONFIG = AuthMethodType("kubeconfig")
+	// 认证方式：集群内 ServiceAccount，即挂载于 Pod 内的令牌。
+	AUTH_METHOD_INCLUSTER = AuthMethodType("incluster")
+	// 认证方式：API Server 地址与 ServiceAccount 令牌。
+	AUTH_METHOD_TOKEN = AuthMethodType("token")
+)
+
+const (
+	// 声明 Deployment 为 Secret 使用方的注解键，值为以半角逗号分隔的 Secret 名称。
+	// Secret 内容变更后，将滚动重启声明了该 Secret 的 Deployment。
+	ANNOTATION_RELOAD_SECRETS = "certimate/reload-secrets"
+	// 滚动重启时写入 Pod 模板的注解键，与 `kubectl rollout restart` 一致。
+	ANNOTATION_RESTARTED_AT = "kubectl.kubernetes.io/restartedAt"
+)
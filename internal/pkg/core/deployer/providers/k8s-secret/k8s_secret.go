@@ -1,14 +1,23 @@
 package k8ssecret
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	k8score "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -18,27 +27,61 @@ import (
 )
 
 type DeployerConfig struct {
+	// 认证方式。
+	// 零值时默认值 [AUTH_METHOD_AUTO]。
+	AuthMethod AuthMethodType `json:"authMethod,omitempty"`
 	// kubeconfig 文件内容。
 	KubeConfig string `json:"kubeConfig,omitempty"`
+	// Kubernetes API Server 地址。
+	// 认证方式为 [AUTH_METHOD_TOKEN] 时必填。
+	ApiServer string `json:"apiServer,omitempty"`
+	// Kubernetes ServiceAccount 令牌。
+	// 认证方式为 [AUTH_METHOD_TOKEN] 时必填。
+	Token string `json:"token,omitempty"`
+	// Kubernetes API Server 的 CA 证书 PEM 内容。
+	// 认证方式为 [AUTH_METHOD_TOKEN] 时有效。
+	CaCertificate string `json:"caCertificate,omitempty"`
+	// 是否允许不安全的连接。
+	// 认证方式为 [AUTH_METHOD_TOKEN] 时有效。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
 	// Kubernetes 命名空间。
 	Namespace string `json:"namespace,omitempty"`
 	// Kubernetes Secret 名称。
 	SecretName string `json:"secretName"`
+	// Kubernetes Secret 标签选择器。
+	// 选填。非空时将忽略 Namespace 和 SecretName，更新所有匹配的已存在的 Secret。
+	SecretLabelSelector string `json:"secretLabelSelector,omitempty"`
+	// 标签选择器匹配的命名空间数组。
+	// 选填。零值时匹配所有命名空间。
+	SecretNamespaces []string `json:"secretNamespaces,omitempty"`
 	// Kubernetes Secret 类型。
 	SecretType string `json:"secretType"`
 	// Kubernetes Secret 中用于存放证书的 Key。
 	SecretDataKeyForCrt string `json:"secretDataKeyForCrt,omitempty"`
 	// Kubernetes Secret 中用于存放私钥的 Key。
 	SecretDataKeyForKey string `json:"secretDataKeyForKey,omitempty"`
+	// 是否修改同命名空间下 Ingress 的 TLS 配置，使与证书域名匹配的条目引用该 Secret。
+	// 使用标签选择器时，同一命名空间下须至多匹配一个 Secret。
+	PatchIngresses bool `json:"patchIngresses,omitempty"`
+	// 是否修改同命名空间下 Gateway API Gateway 的 TLS 配置，使与证书域名匹配的监听器引用该 Secret。
+	// 使用标签选择器时，同一命名空间下须至多匹配一个 Secret。
+	PatchGateways bool `json:"patchGateways,omitempty"`
+	// 是否在 Secret 内容变更后滚动重启声明为使用方的 Deployment。
+	// 使用方须声明注解 [ANNOTATION_RELOAD_SECRETS]。
+	RolloutRestart bool `json:"rolloutRestart,omitempty"`
 }
 
 type DeployerProvider struct {
-	config *DeployerConfig
-	logger *slog.Logger
+	config        *DeployerConfig
+	logger        *slog.Logger
+	k8sClient     kubernetes.Interface
+	dynamicClient dynamic.Interface
 }
 
 var _ deployer.Deployer = (*DeployerProvider)(nil)
 
+var gatewayGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}
+
 func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
 	if config == nil {
 		panic("config is nil")
@@ -60,11 +103,13 @@ func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
 }
 
 func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
-	if d.config.Namespace == "" {
-		return nil, errors.New("config `namespace` is required")
-	}
-	if d.config.SecretName == "" {
-		return nil, errors.New("config `secretName` is required")
+	if d.config.SecretLabelSelector == "" {
+		if d.config.Namespace == "" {
+			return nil, errors.New("config `namespace` is required")
+		}
+		if d.config.SecretName == "" {
+			return nil, errors.New("config `secretName` is required")
+		}
 	}
 	if d.config.SecretType == "" {
 		return nil, errors.New("config `secretType` is required")
@@ -82,31 +127,121 @@ func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPE
 	}
 
 	// 连接
-	client, err := createK8sClient(d.config.KubeConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create k8s client: %w", err)
+	if d.k8sClient == nil {
+		client, dynamicClient, err := createK8sClients(d.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create k8s client: %w", err)
+		}
+
+		d.k8sClient = client
+		d.dynamicClient = dynamicClient
 	}
 
-	var secretPayload *k8score.Secret
-	secretAnnotations := map[string]string{
-		"certimate/common-name":       certX509.Subject.CommonName,
-		"certimate/subject-sn":        certX509.Subject.SerialNumber,
-		"certimate/subject-alt-names": strings.Join(certX509.DNSNames, ","),
-		"certimate/issuer-sn":         certX509.Issuer.SerialNumber,
-		"certimate/issuer-org":        strings.Join(certX509.Issuer.Organization, ","),
+	// 创建或更新 Secret
+	var secrets []k8stypes.NamespacedName
+	var changedSecrets []k8stypes.NamespacedName
+	if d.config.SecretLabelSelector == "" {
+		changed, err := d.applySecret(ctx, d.config.Namespace, d.config.SecretName, certX509, certPEM, privkeyPEM)
+		if err != nil {
+			return nil, err
+		}
+
+		secrets = append(secrets, k8stypes.NamespacedName{Namespace: d.config.Namespace, Name: d.config.SecretName})
+		if changed {
+			changedSecrets = append(changedSecrets, secrets[0])
+		}
+	} else {
+		namespaces := d.config.SecretNamespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{k8smeta.NamespaceAll}
+		}
+
+		matchedSecrets := make([]k8score.Secret, 0)
+		for _, namespace := range namespaces {
+			secretList, err := d.k8sClient.CoreV1().Secrets(namespace).List(ctx, k8smeta.ListOptions{LabelSelector: d.config.SecretLabelSelector})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list k8s secrets: %w", err)
+			}
+
+			matchedSecrets = append(matchedSecrets, secretList.Items...)
+		}
+
+		if len(matchedSecrets) == 0 {
+			return nil, fmt.Errorf("no k8s secrets matched the label selector '%s'", d.config.SecretLabelSelector)
+		}
+
+		// 同一命名空间下匹配到多个 Secret 时，无法确定 Ingress 或 Gateway 应引用哪一个，拒绝部署以免相互覆盖
+		if d.config.PatchIngresses || d.config.PatchGateways {
+			secretNamesByNamespace := make(map[string][]string)
+			for _, secret := range matchedSecrets {
+				secretNamesByNamespace[secret.Namespace] = append(secretNamesByNamespace[secret.Namespace], secret.Name)
+			}
+
+			for namespace, secretNames := range secretNamesByNamespace {
+				if len(secretNames) > 1 {
+					return nil, fmt.Errorf("the label selector '%s' matched multiple k8s secrets in namespace '%s' (%s), cannot decide which one ingresses or gateways should reference", d.config.SecretLabelSelector, namespace, strings.Join(secretNames, ", "))
+				}
+			}
+		}
+
+		for _, secret := range matchedSecrets {
+			changed, err := d.updateSecret(ctx, &secret, certX509, certPEM, privkeyPEM)
+			if err != nil {
+				return nil, err
+			}
+
+			secretKey := k8stypes.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+			secrets = append(secrets, secretKey)
+			if changed {
+				changedSecrets = append(changedSecrets, secretKey)
+			}
+		}
+	}
+
+	// 修改 Ingress 和 Gateway 的 TLS 配置
+	for _, secret := range secrets {
+		if d.config.PatchIngresses {
+			if err := d.patchIngresses(ctx, secret, certX509); err != nil {
+				return nil, err
+			}
+		}
+
+		if d.config.PatchGateways {
+			if err := d.patchGateways(ctx, secret, certX509); err != nil {
+				return nil, err
+			}
+		}
 	}
 
+	// Secret 内容变更后，滚动重启使用方 Deployment
+	if d.config.RolloutRestart {
+		restarted := make(map[k8stypes.NamespacedName]bool)
+		for _, secret := range changedSecrets {
+			if err := d.restartDeployments(ctx, secret, restarted); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func (d *DeployerProvider) applySecret(ctx context.Context, namespace, secretName string, certX509 *x509.Certificate, certPEM, privkeyPEM string) (_changed bool, _err error) {
 	// 获取 Secret 实例，如果不存在则创建
-	secretPayload, err = client.CoreV1().Secrets(d.config.Namespace).Get(context.TODO(), d.config.SecretName, k8smeta.GetOptions{})
+	secretPayload, err := d.k8sClient.CoreV1().Secrets(namespace).Get(ctx, secretName, k8smeta.GetOptions{})
 	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get k8s secret: %w", err)
+		}
+
 		secretPayload = &k8score.Secret{
 			TypeMeta: k8smeta.TypeMeta{
 				Kind:       "Secret",
 				APIVersion: "v1",
 			},
 			ObjectMeta: k8smeta.ObjectMeta{
-				Name:        d.config.SecretName,
-				Annotations: secretAnnotations,
+				Name:        secretName,
+				Annotations: d.buildSecretAnnotations(certX509),
 			},
 			Type: k8score.SecretType(d.config.SecretType),
 		}
@@ -114,58 +249,275 @@ func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPE
 		secretPayload.Data[d.config.SecretDataKeyForCrt] = []byte(certPEM)
 		secretPayload.Data[d.config.SecretDataKeyForKey] = []byte(privkeyPEM)
 
-		secretPayload, err = client.CoreV1().Secrets(d.config.Namespace).Create(context.TODO(), secretPayload, k8smeta.CreateOptions{})
-		d.logger.Debug("k8s operate 'Secrets.Create'", slog.String("namespace", d.config.Namespace), slog.Any("secret", secretPayload))
+		secretPayload, err = d.k8sClient.CoreV1().Secrets(namespace).Create(ctx, secretPayload, k8smeta.CreateOptions{})
+		d.logger.Debug("k8s operate 'Secrets.Create'", slog.String("namespace", namespace), slog.Any("secret", secretPayload))
 		if err != nil {
-			return nil, fmt.Errorf("failed to create k8s secret: %w", err)
-		} else {
-			return &deployer.DeployResult{}, nil
+			return false, fmt.Errorf("failed to create k8s secret: %w", err)
 		}
+
+		return true, nil
+	}
+
+	return d.updateSecret(ctx, secretPayload, certX509, certPEM, privkeyPEM)
+}
+
+func (d *DeployerProvider) updateSecret(ctx context.Context, secretPayload *k8score.Secret, certX509 *x509.Certificate, certPEM, privkeyPEM string) (_changed bool, _err error) {
+	changed := secretPayload.Type != k8score.SecretType(d.config.SecretType) ||
+		!bytes.Equal(secretPayload.Data[d.config.SecretDataKeyForCrt], []byte(certPEM)) ||
+		!bytes.Equal(secretPayload.Data[d.config.SecretDataKeyForKey], []byte(privkeyPEM))
+	if !changed {
+		d.logger.Info("k8s secret is up to date", slog.String("namespace", secretPayload.Namespace), slog.String("secret", secretPayload.Name))
+		return false, nil
 	}
 
 	// 更新 Secret 实例
 	secretPayload.Type = k8score.SecretType(d.config.SecretType)
 	if secretPayload.ObjectMeta.Annotations == nil {
-		secretPayload.ObjectMeta.Annotations = secretAnnotations
-	} else {
-		for k, v := range secretAnnotations {
-			secretPayload.ObjectMeta.Annotations[k] = v
-		}
+		secretPayload.ObjectMeta.Annotations = make(map[string]string)
+	}
+	for k, v := range d.buildSecretAnnotations(certX509) {
+		secretPayload.ObjectMeta.Annotations[k] = v
 	}
 	if secretPayload.Data == nil {
 		secretPayload.Data = make(map[string][]byte)
 	}
 	secretPayload.Data[d.config.SecretDataKeyForCrt] = []byte(certPEM)
 	secretPayload.Data[d.config.SecretDataKeyForKey] = []byte(privkeyPEM)
-	secretPayload, err = client.CoreV1().Secrets(d.config.Namespace).Update(context.TODO(), secretPayload, k8smeta.UpdateOptions{})
-	d.logger.Debug("k8s operate 'Secrets.Update'", slog.String("namespace", d.config.Namespace), slog.Any("secret", secretPayload))
+	secretPayload, err := d.k8sClient.CoreV1().Secrets(secretPayload.Namespace).Update(ctx, secretPayload, k8smeta.UpdateOptions{})
+	d.logger.Debug("k8s operate 'Secrets.Update'", slog.String("namespace", secretPayload.Namespace), slog.Any("secret", secretPayload))
 	if err != nil {
-		return nil, fmt.Errorf("failed to update k8s secret: %w", err)
+		return false, fmt.Errorf("failed to update k8s secret: %w", err)
 	}
 
-	return &deployer.DeployResult{}, nil
+	d.logger.Info("k8s secret updated", slog.String("namespace", secretPayload.Namespace), slog.String("secret", secretPayload.Name))
+	return true, nil
 }
 
-func createK8sClient(kubeConfig string) (*kubernetes.Clientset, error) {
-	var config *rest.Config
-	var err error
-	if kubeConfig == "" {
-		config, err = rest.InClusterConfig()
-	} else {
-		kubeConfig, err := clientcmd.NewClientConfigFromBytes([]byte(kubeConfig))
+func (d *DeployerProvider) buildSecretAnnotations(certX509 *x509.Certificate) map[string]string {
+	return map[string]string{
+		"certimate/common-name":       certX509.Subject.CommonName,
+		"certimate/subject-sn":        certX509.Subject.SerialNumber,
+		"certimate/subject-alt-names": strings.Join(certX509.DNSNames, ","),
+		"certimate/issuer-sn":         certX509.Issuer.SerialNumber,
+		"certimate/issuer-org":        strings.Join(certX509.Issuer.Organization, ","),
+	}
+}
+
+func (d *DeployerProvider) patchIngresses(ctx context.Context, secret k8stypes.NamespacedName, certX509 *x509.Certificate) error {
+	ingressList, err := d.k8sClient.NetworkingV1().Ingresses(secret.Namespace).List(ctx, k8smeta.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list k8s ingresses: %w", err)
+	}
+
+	for _, ingress := range ingressList.Items {
+		modified := false
+		for i, tls := range ingress.Spec.TLS {
+			// 仅当条目中的所有主机均被证书覆盖时才修改
+			if len(tls.Hosts) == 0 || tls.SecretName == secret.Name {
+				continue
+			}
+			if !slices.ContainsFunc(tls.Hosts, func(host string) bool { return !matchCertificateHost(certX509, host) }) {
+				ingress.Spec.TLS[i].SecretName = secret.Name
+				modified = true
+			}
+		}
+		if !modified {
+			continue
+		}
+
+		ingressPayload, err := d.k8sClient.NetworkingV1().Ingresses(secret.Namespace).Update(ctx, &ingress, k8smeta.UpdateOptions{})
+		d.logger.Debug("k8s operate 'Ingresses.Update'", slog.String("namespace", secret.Namespace), slog.Any("ingress", ingressPayload))
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("failed to update k8s ingress: %w", err)
 		}
-		config, err = kubeConfig.ClientConfig()
+
+		d.logger.Info("k8s ingress patched", slog.String("namespace", secret.Namespace), slog.String("ingress", ingress.Name))
 	}
+
+	return nil
+}
+
+func (d *DeployerProvider) patchGateways(ctx context.Context, secret k8stypes.NamespacedName, certX509 *x509.Certificate) error {
+	if d.dynamicClient == nil {
+		return errors.New("k8s dynamic client is not initialized")
+	}
+
+	gatewayList, err := d.dynamicClient.Resource(gatewayGVR).Namespace(secret.Namespace).List(ctx, k8smeta.ListOptions{})
 	if err != nil {
-		return nil, err
+		if k8serrors.IsNotFound(err) {
+			d.logger.Warn("gateway api is not installed, skip patching gateways", slog.String("namespace", secret.Namespace))
+			return nil
+		}
+		return fmt.Errorf("failed to list k8s gateways: %w", err)
 	}
 
-	client, err := kubernetes.NewForConfig(config)
+	for _, gateway := range gatewayList.Items {
+		listeners, found, err := k8sunstructured.NestedSlice(gateway.Object, "spec", "listeners")
+		if err != nil || !found {
+			continue
+		}
+
+		modified := false
+		for i, item := range listeners {
+			listener, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			// 仅修改终止 TLS 且主机名被证书覆盖的监听器
+			hostname, _ := listener["hostname"].(string)
+			tls, _ := listener["tls"].(map[string]any)
+			if hostname == "" || tls == nil || !matchCertificateHost(certX509, hostname) {
+				continue
+			}
+			if mode, _ := tls["mode"].(string); mode != "" && mode != "Terminate" {
+				continue
+			}
+
+			certificateRefs := []any{map[string]any{"group": "", "kind": "Secret", "name": secret.Name}}
+			if refs, _ := tls["certificateRefs"].([]any); len(refs) == 1 {
+				if ref, _ := refs[0].(map[string]any); ref != nil && ref["name"] == secret.Name && (ref["namespace"] == nil || ref["namespace"] == secret.Namespace) {
+					continue
+				}
+			}
+
+			tls["certificateRefs"] = certificateRefs
+			listener["tls"] = tls
+			listeners[i] = listener
+			modified = true
+		}
+		if !modified {
+			continue
+		}
+
+		if err := k8sunstructured.SetNestedSlice(gateway.Object, listeners, "spec", "listeners"); err != nil {
+			return fmt.Errorf("failed to patch k8s gateway: %w", err)
+		}
+
+		gatewayPayload, err := d.dynamicClient.Resource(gatewayGVR).Namespace(secret.Namespace).Update(ctx, &gateway, k8smeta.UpdateOptions{})
+		d.logger.Debug("k8s operate 'Gateways.Update'", slog.String("namespace", secret.Namespace), slog.Any("gateway", gatewayPayload))
+		if err != nil {
+			return fmt.Errorf("failed to update k8s gateway: %w", err)
+		}
+
+		d.logger.Info("k8s gateway patched", slog.String("namespace", secret.Namespace), slog.String("gateway", gateway.GetName()))
+	}
+
+	return nil
+}
+
+func (d *DeployerProvider) restartDeployments(ctx context.Context, secret k8stypes.NamespacedName, restarted map[k8stypes.NamespacedName]bool) error {
+	deploymentList, err := d.k8sClient.AppsV1().Deployments(secret.Namespace).List(ctx, k8smeta.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list k8s deployments: %w", err)
+	}
+
+	for _, deployment := range deploymentList.Items {
+		deploymentKey := k8stypes.NamespacedName{Namespace: deployment.Namespace, Name: deployment.Name}
+		if restarted[deploymentKey] {
+			continue
+		}
+
+		consumes := false
+		for _, name := range strings.Split(deployment.Annotations[ANNOTATION_RELOAD_SECRETS], ",") {
+			if strings.TrimSpace(name) == secret.Name {
+				consumes = true
+				break
+			}
+		}
+		if !consumes {
+			continue
+		}
+
+		// 与 `kubectl rollout restart` 相同，通过修改 Pod 模板注解触发滚动更新
+		patchData := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, ANNOTATION_RESTARTED_AT, time.Now().Format(time.RFC3339))
+		deploymentPayload, err := d.k8sClient.AppsV1().Deployments(deployment.Namespace).Patch(ctx, deployment.Name, k8stypes.StrategicMergePatchType, []byte(patchData), k8smeta.PatchOptions{})
+		d.logger.Debug("k8s operate 'Deployments.Patch'", slog.String("namespace", deployment.Namespace), slog.Any("deployment", deploymentPayload))
+		if err != nil {
+			return fmt.Errorf("failed to restart k8s deployment: %w", err)
+		}
+
+		restarted[deploymentKey] = true
+		d.logger.Info("k8s deployment restarted", slog.String("namespace", deployment.Namespace), slog.String("deployment", deployment.Name))
+	}
+
+	return nil
+}
+
+func matchCertificateHost(certX509 *x509.Certificate, host string) bool {
+	if slices.Contains(certX509.DNSNames, host) {
+		return true
+	}
+
+	return certX509.VerifyHostname(host) == nil
+}
+
+func createK8sClients(config *DeployerConfig) (kubernetes.Interface, dynamic.Interface, error) {
+	restConfig, err := createK8sRestConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, dynamicClient, nil
+}
+
+func createK8sRestConfig(config *DeployerConfig) (*rest.Config, error) {
+	switch config.AuthMethod {
+	case AUTH_METHOD_AUTO:
+		if config.KubeConfig == "" {
+			return rest.InClusterConfig()
+		}
+		return createK8sRestConfigFromKubeConfig(config.KubeConfig)
+
+	case AUTH_METHOD_KUBECONFIG:
+		if config.KubeConfig == "" {
+			return nil, errors.New("kubeconfig is required")
+		}
+		return createK8sRestConfigFromKubeConfig(config.KubeConfig)
+
+	case AUTH_METHOD_INCLUSTER:
+		return rest.InClusterConfig()
+
+	case AUTH_METHOD_TOKEN:
+		if config.ApiServer == "" {
+			return nil, errors.New("api server is required")
+		}
+		if config.Token == "" {
+			return nil, errors.New("token is required")
+		}
+
+		restConfig := &rest.Config{
+			Host:        config.ApiServer,
+			BearerToken: config.Token,
+			TLSClientConfig: rest.TLSClientConfig{
+				Insecure: config.AllowInsecureConnections,
+			},
+		}
+		if config.CaCertificate != "" && !config.AllowInsecureConnections {
+			restConfig.TLSClientConfig.CAData = []byte(config.CaCertificate)
+		}
+		return restConfig, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported auth method '%s'", config.AuthMethod)
+	}
+}
+
+func createK8sRestConfigFromKubeConfig(kubeConfig string) (*rest.Config, error) {
+	clientConfig, err := clientcmd.NewClientConfigFromBytes([]byte(kubeConfig))
 	if err != nil {
 		return nil, err
 	}
 
-	return client, nil
+	return clientConfig.ClientConfig()
 }
@@ -0,0 +1,298 @@
+package k8ssecret
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	k8sapps "k8s.io/api/apps/v1"
+	k8score "k8s.io/api/core/v1"
+	k8snetworking "k8s.io/api/networking/v1"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func generateCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "*.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func newFakeDeployer(t *testing.T, config *DeployerConfig, objects []runtime.Object, gateways []*k8sunstructured.Unstructured) *DeployerProvider {
+	d, _ := NewDeployer(config)
+	d.k8sClient = fake.NewSimpleClientset(objects...)
+	d.dynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{gatewayGVR: "GatewayList"})
+
+	// 未注册到 Scheme 的资源无法通过初始对象追踪，需逐个创建
+	for _, gateway := range gateways {
+		if _, err := d.dynamicClient.Resource(gatewayGVR).Namespace(gateway.GetNamespace()).Create(context.Background(), gateway, k8smeta.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return d
+}
+
+func newDeployment(namespace, name string, annotations map[string]string) *k8sapps.Deployment {
+	return &k8sapps.Deployment{
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations},
+	}
+}
+
+func getRestartedAt(t *testing.T, d *DeployerProvider, namespace, name string) string {
+	deployment, err := d.k8sClient.AppsV1().Deployments(namespace).Get(context.Background(), name, k8smeta.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return deployment.Spec.Template.Annotations[ANNOTATION_RESTARTED_AT]
+}
+
+func TestDeployWithFakeClient(t *testing.T) {
+	certPEM, privkeyPEM := generateCertificate(t)
+
+	baseConfig := DeployerConfig{
+		SecretType:          "kubernetes.io/tls",
+		SecretDataKeyForCrt: "tls.crt",
+		SecretDataKeyForKey: "tls.key",
+	}
+
+	t.Run("CreateSecretAndRestart", func(t *testing.T) {
+		config := baseConfig
+		config.Namespace = "default"
+		config.SecretName = "example-tls"
+		config.RolloutRestart = true
+
+		d := newFakeDeployer(t, &config, []runtime.Object{
+			newDeployment("default", "web", map[string]string{ANNOTATION_RELOAD_SECRETS: "other, example-tls"}),
+			newDeployment("default", "api", nil),
+		}, nil)
+
+		if _, err := d.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		secret, err := d.k8sClient.CoreV1().Secrets("default").Get(context.Background(), "example-tls", k8smeta.GetOptions{})
+		if err != nil {
+			t.Fatalf("secret not created: %v", err)
+		}
+		if string(secret.Data["tls.crt"]) != certPEM || string(secret.Data["tls.key"]) != privkeyPEM {
+			t.Errorf("unexpected secret data")
+		}
+
+		if getRestartedAt(t, d, "default", "web") == "" {
+			t.Errorf("annotated deployment was not restarted")
+		}
+		if getRestartedAt(t, d, "default", "api") != "" {
+			t.Errorf("unannotated deployment should not be restarted")
+		}
+
+		// 证书未变更时不应再次重启
+		if _, err := d.k8sClient.AppsV1().Deployments("default").Update(context.Background(), newDeployment("default", "web", map[string]string{ANNOTATION_RELOAD_SECRETS: "example-tls"}), k8smeta.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := d.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if getRestartedAt(t, d, "default", "web") != "" {
+			t.Errorf("deployment should not be restarted when the secret is unchanged")
+		}
+	})
+
+	t.Run("UpdateSecretsByLabelSelector", func(t *testing.T) {
+		config := baseConfig
+		config.SecretLabelSelector = "certimate/managed=true"
+		config.RolloutRestart = true
+
+		newSecret := func(namespace, name string, labels map[string]string) *k8score.Secret {
+			return &k8score.Secret{
+				ObjectMeta: k8smeta.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+				Type:       k8score.SecretTypeTLS,
+				Data:       map[string][]byte{"tls.crt": []byte("old"), "tls.key": []byte("old")},
+			}
+		}
+
+		d := newFakeDeployer(t, &config, []runtime.Object{
+			newSecret("ns1", "tls-a", map[string]string{"certimate/managed": "true"}),
+			newSecret("ns2", "tls-b", map[string]string{"certimate/managed": "true"}),
+			newSecret("ns2", "tls-c", nil),
+			newDeployment("ns2", "web", map[string]string{ANNOTATION_RELOAD_SECRETS: "tls-b"}),
+		}, nil)
+
+		if _, err := d.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		for _, item := range []struct {
+			namespace string
+			name      string
+			updated   bool
+		}{{"ns1", "tls-a", true}, {"ns2", "tls-b", true}, {"ns2", "tls-c", false}} {
+			secret, err := d.k8sClient.CoreV1().Secrets(item.namespace).Get(context.Background(), item.name, k8smeta.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated := string(secret.Data["tls.crt"]) == certPEM; updated != item.updated {
+				t.Errorf("secret %s/%s: expected updated=%v, got %v", item.namespace, item.name, item.updated, updated)
+			}
+		}
+
+		if getRestartedAt(t, d, "ns2", "web") == "" {
+			t.Errorf("annotated deployment was not restarted")
+		}
+	})
+
+	t.Run("PatchIngressesAndGateways", func(t *testing.T) {
+		config := baseConfig
+		config.Namespace = "default"
+		config.SecretName = "example-tls"
+		config.PatchIngresses = true
+		config.PatchGateways = true
+
+		ingress := &k8snetworking.Ingress{
+			ObjectMeta: k8smeta.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec: k8snetworking.IngressSpec{
+				TLS: []k8snetworking.IngressTLS{
+					{Hosts: []string{"www.example.com", "example.com"}, SecretName: "old-tls"},
+					{Hosts: []string{"www.example.org"}, SecretName: "org-tls"},
+				},
+			},
+		}
+
+		gateway := &k8sunstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "Gateway",
+			"metadata":   map[string]any{"namespace": "default", "name": "gw"},
+			"spec": map[string]any{
+				"listeners": []any{
+					map[string]any{
+						"name":     "https",
+						"hostname": "api.example.com",
+						"protocol": "HTTPS",
+						"tls": map[string]any{
+							"mode":            "Terminate",
+							"certificateRefs": []any{map[string]any{"kind": "Secret", "name": "old-tls"}},
+						},
+					},
+					map[string]any{
+						"name":     "https-org",
+						"hostname": "api.example.org",
+						"protocol": "HTTPS",
+						"tls": map[string]any{
+							"certificateRefs": []any{map[string]any{"kind": "Secret", "name": "org-tls"}},
+						},
+					},
+				},
+			},
+		}}
+
+		d := newFakeDeployer(t, &config, []runtime.Object{ingress}, []*k8sunstructured.Unstructured{gateway})
+
+		if _, err := d.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		ingressResp, err := d.k8sClient.NetworkingV1().Ingresses("default").Get(context.Background(), "web", k8smeta.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ingressResp.Spec.TLS[0].SecretName != "example-tls" {
+			t.Errorf("matched ingress tls was not patched: %s", ingressResp.Spec.TLS[0].SecretName)
+		}
+		if ingressResp.Spec.TLS[1].SecretName != "org-tls" {
+			t.Errorf("unmatched ingress tls should not be patched: %s", ingressResp.Spec.TLS[1].SecretName)
+		}
+
+		gatewayResp, err := d.dynamicClient.Resource(gatewayGVR).Namespace("default").Get(context.Background(), "gw", k8smeta.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		listeners, _, _ := k8sunstructured.NestedSlice(gatewayResp.Object, "spec", "listeners")
+		getRefName := func(listener any) string {
+			refs, _, _ := k8sunstructured.NestedSlice(listener.(map[string]any), "tls", "certificateRefs")
+			return refs[0].(map[string]any)["name"].(string)
+		}
+		if name := getRefName(listeners[0]); name != "example-tls" {
+			t.Errorf("matched gateway listener was not patched: %s", name)
+		}
+		if name := getRefName(listeners[1]); name != "org-tls" {
+			t.Errorf("unmatched gateway listener should not be patched: %s", name)
+		}
+	})
+	t.Run("PatchIngressesWithAmbiguousSelector", func(t *testing.T) {
+		config := baseConfig
+		config.SecretLabelSelector = "certimate/managed=true"
+		config.PatchIngresses = true
+
+		newSecret := func(name string) *k8score.Secret {
+			return &k8score.Secret{
+				ObjectMeta: k8smeta.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"certimate/managed": "true"}},
+				Type:       k8score.SecretTypeTLS,
+				Data:       map[string][]byte{"tls.crt": []byte("old"), "tls.key": []byte("old")},
+			}
+		}
+
+		ingress := &k8snetworking.Ingress{
+			ObjectMeta: k8smeta.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec: k8snetworking.IngressSpec{
+				TLS: []k8snetworking.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "tls-a"}},
+			},
+		}
+
+		d := newFakeDeployer(t, &config, []runtime.Object{newSecret("tls-a"), newSecret("tls-b"), ingress}, nil)
+
+		if _, err := d.Deploy(context.Background(), certPEM, privkeyPEM); err == nil {
+			t.Fatal("expected error when the label selector matched multiple secrets in one namespace, got nil")
+		}
+
+		// 拒绝部署时不应修改任何资源
+		for _, name := range []string{"tls-a", "tls-b"} {
+			secret, err := d.k8sClient.CoreV1().Secrets("default").Get(context.Background(), name, k8smeta.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(secret.Data["tls.crt"]) != "old" {
+				t.Errorf("secret %s should not be updated", name)
+			}
+		}
+		ingressResp, err := d.k8sClient.NetworkingV1().Ingresses("default").Get(context.Background(), "web", k8smeta.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ingressResp.Spec.TLS[0].SecretName != "tls-a" {
+			t.Errorf("ingress should not be patched: %s", ingressResp.Spec.TLS[0].SecretName)
+		}
+	})
+}
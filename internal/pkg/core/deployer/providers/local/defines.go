@@ -6,6 +6,33 @@ const (
 	OUTPUT_FORMAT_PEM = OutputFormatType("PEM")
 	OUTPUT_FORMAT_PFX = OutputFormatType("PFX")
 	OUTPUT_FORMAT_JKS = OutputFormatType("JKS")
+	// DER 格式，仅包含服务器证书。
+	OUTPUT_FORMAT_DER = OutputFormatType("DER")
+	// PKCS#7 格式的证书链包（.p7b）。
+	OUTPUT_FORMAT_P7B = OutputFormatType("P7B")
+	// 证书链与私钥合并的单文件 PEM 格式（如 HAProxy 所需格式）。
+	OUTPUT_FORMAT_PEM_COMBINED = OutputFormatType("PEM_COMBINED")
+)
+
+type PrivateKeyFormatType string
+
+const (
+	// 保持原有格式。
+	PRIVATE_KEY_FORMAT_ORIGINAL = PrivateKeyFormatType("")
+	// PKCS#8 格式（即 "PRIVATE KEY"）。
+	PRIVATE_KEY_FORMAT_PKCS8 = PrivateKeyFormatType("PKCS8")
+	// 加密的 PKCS#8 格式（即 "ENCRYPTED PRIVATE KEY"）。
+	PRIVATE_KEY_FORMAT_PKCS8_ENCRYPTED = PrivateKeyFormatType("PKCS8_ENCRYPTED")
+)
+
+type OutputFileKind string
+
+const (
+	OUTPUT_FILE_KIND_CERT            = OutputFileKind("cert")
+	OUTPUT_FILE_KIND_SERVER_CERT     = OutputFileKind("serverCert")
+	OUTPUT_FILE_KIND_INTERMEDIA_CERT = OutputFileKind("intermediaCert")
+	OUTPUT_FILE_KIND_ROOT_CERT       = OutputFileKind("rootCert")
+	OUTPUT_FILE_KIND_KEY             = OutputFileKind("key")
 )
 
 type ShellEnvType string
@@ -15,3 +42,15 @@ const (
 	SHELL_ENV_CMD        = ShellEnvType("cmd")
 	SHELL_ENV_POWERSHELL = ShellEnvType("powershell")
 )
+
+type FileAttrs struct {
+	// 文件权限，八进制表示，如 "0600"。
+	// 零值时若文件已存在则保持原有权限，否则默认值 "0644"。
+	Mode string `json:"mode,omitempty"`
+	// 文件所有者的用户名或 UID。
+	// 零值时若文件已存在则尽量保持原有所有者。
+	Owner string `json:"owner,omitempty"`
+	// 文件所属组的组名或 GID。
+	// 零值时若文件已存在则尽量保持原有所属组。
+	Group string `json:"group,omitempty"`
+}
@@ -7,9 +7,9 @@ import (
 	"log/slog"
 	"os/exec"
 	"runtime"
+	"strings"
 
 	"github.com/usual2970/certimate/internal/pkg/core/deployer"
-	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
 	fileutil "github.com/usual2970/certimate/internal/pkg/utils/file"
 )
 
@@ -33,6 +33,39 @@ type DeployerConfig struct {
 	OutputIntermediaCertPath string `json:"outputIntermediaCertPath,omitempty"`
 	// 输出私钥文件路径。
 	OutputKeyPath string `json:"outputKeyPath,omitempty"`
+	// 输出证书文件属性。
+	// 选填。证书格式为 PFX 或 JKS 时作用于证书库文件。
+	OutputCertFileAttrs FileAttrs `json:"outputCertFileAttrs,omitempty"`
+	// 输出服务器证书文件属性。
+	// 选填。
+	OutputServerCertFileAttrs FileAttrs `json:"outputServerCertFileAttrs,omitempty"`
+	// 输出中间证书文件属性。
+	// 选填。
+	OutputIntermediaCertFileAttrs FileAttrs `json:"outputIntermediaCertFileAttrs,omitempty"`
+	// 输出私钥文件属性。
+	// 选填。
+	OutputKeyFileAttrs FileAttrs `json:"outputKeyFileAttrs,omitempty"`
+	// 输出根证书文件路径。
+	// 选填。证书格式为 PEM 或 PEM_COMBINED 时有效。
+	OutputRootCertPath string `json:"outputRootCertPath,omitempty"`
+	// 输出根证书文件属性。
+	// 选填。
+	OutputRootCertFileAttrs FileAttrs `json:"outputRootCertFileAttrs,omitempty"`
+	// 证书链与系统信任的根证书中均未找到根证书时，是否根据 AIA 扩展联网下载颁发者证书。
+	// 选填。输出根证书文件路径不为空时有效。
+	OutputRootCertFetchIssuer bool `json:"outputRootCertFetchIssuer,omitempty"`
+	// 是否在覆盖前备份已存在的输出文件。
+	// 备份文件路径为原文件路径追加时间戳后缀，如 "/path/to/cert.pem.20060102150405.bak"。
+	OutputBackup bool `json:"outputBackup,omitempty"`
+	// 是否逆序输出证书链，即中间证书在前、服务器证书在后。
+	// 证书格式为 PEM 或 PEM_COMBINED 时有效。
+	PemChainReversed bool `json:"pemChainReversed,omitempty"`
+	// 私钥格式。
+	// 证书格式为 PEM、PEM_COMBINED 或 P7B 时有效。零值时保持原有格式。
+	PemKeyFormat PrivateKeyFormatType `json:"pemKeyFormat,omitempty"`
+	// 私钥加密密码。
+	// 私钥格式为 PKCS8_ENCRYPTED 时必填。
+	PemKeyPassword string `json:"pemKeyPassword,omitempty"`
 	// PFX 导出密码。
 	// 证书格式为 PFX 时必填。
 	PfxPassword string `json:"pfxPassword,omitempty"`
@@ -75,12 +108,29 @@ func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
 }
 
 func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
-	// 提取服务器证书和中间证书
-	serverCertPEM, intermediaCertPEM, err := certutil.ExtractCertificatesFromPEM(certPEM)
+	// 生成输出文件
+	files, err := BuildOutputFiles(ctx, certPEM, privkeyPEM, &OutputOptions{
+		Format:              d.config.OutputFormat,
+		CertPath:            d.config.OutputCertPath,
+		ServerCertPath:      d.config.OutputServerCertPath,
+		IntermediaCertPath:  d.config.OutputIntermediaCertPath,
+		RootCertPath:        d.config.OutputRootCertPath,
+		RootCertFetchIssuer: d.config.OutputRootCertFetchIssuer,
+		KeyPath:             d.config.OutputKeyPath,
+		PemChainReversed:    d.config.PemChainReversed,
+		PemKeyFormat:        d.config.PemKeyFormat,
+		PemKeyPassword:      d.config.PemKeyPassword,
+		PfxPassword:         d.config.PfxPassword,
+		JksAlias:            d.config.JksAlias,
+		JksKeypass:          d.config.JksKeypass,
+		JksStorepass:        d.config.JksStorepass,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract certs: %w", err)
+		return nil, err
 	}
 
+	d.logger.Info(fmt.Sprintf("ssl certificate transformed to %s", strings.ToLower(string(d.config.OutputFormat))))
+
 	// 执行前置命令
 	if d.config.PreCommand != "" {
 		stdout, stderr, err := execCommand(d.config.ShellEnv, d.config.PreCommand)
@@ -91,58 +141,11 @@ func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPE
 	}
 
 	// 写入证书和私钥文件
-	switch d.config.OutputFormat {
-	case OUTPUT_FORMAT_PEM:
-		if err := fileutil.WriteString(d.config.OutputCertPath, certPEM); err != nil {
-			return nil, fmt.Errorf("failed to save certificate file: %w", err)
-		}
-		d.logger.Info("ssl certificate file saved", slog.String("path", d.config.OutputCertPath))
-
-		if d.config.OutputServerCertPath != "" {
-			if err := fileutil.WriteString(d.config.OutputServerCertPath, serverCertPEM); err != nil {
-				return nil, fmt.Errorf("failed to save server certificate file: %w", err)
-			}
-			d.logger.Info("ssl server certificate file saved", slog.String("path", d.config.OutputServerCertPath))
-		}
-
-		if d.config.OutputIntermediaCertPath != "" {
-			if err := fileutil.WriteString(d.config.OutputIntermediaCertPath, intermediaCertPEM); err != nil {
-				return nil, fmt.Errorf("failed to save intermedia certificate file: %w", err)
-			}
-			d.logger.Info("ssl intermedia certificate file saved", slog.String("path", d.config.OutputIntermediaCertPath))
-		}
-
-		if err := fileutil.WriteString(d.config.OutputKeyPath, privkeyPEM); err != nil {
-			return nil, fmt.Errorf("failed to save private key file: %w", err)
-		}
-		d.logger.Info("ssl private key file saved", slog.String("path", d.config.OutputKeyPath))
-
-	case OUTPUT_FORMAT_PFX:
-		pfxData, err := certutil.TransformCertificateFromPEMToPFX(certPEM, privkeyPEM, d.config.PfxPassword)
-		if err != nil {
-			return nil, fmt.Errorf("failed to transform certificate to PFX: %w", err)
-		}
-		d.logger.Info("ssl certificate transformed to pfx")
-
-		if err := fileutil.Write(d.config.OutputCertPath, pfxData); err != nil {
-			return nil, fmt.Errorf("failed to save certificate file: %w", err)
+	for _, file := range files {
+		if err := d.writeFile(file.Path, file.Data, d.fileAttrsOf(file.Kind)); err != nil {
+			return nil, fmt.Errorf("failed to save %s file '%s': %w", file.Kind, file.Path, err)
 		}
-		d.logger.Info("ssl certificate file saved", slog.String("path", d.config.OutputCertPath))
-
-	case OUTPUT_FORMAT_JKS:
-		jksData, err := certutil.TransformCertificateFromPEMToJKS(certPEM, privkeyPEM, d.config.JksAlias, d.config.JksKeypass, d.config.JksStorepass)
-		if err != nil {
-			return nil, fmt.Errorf("failed to transform certificate to JKS: %w", err)
-		}
-		d.logger.Info("ssl certificate transformed to jks")
-
-		if err := fileutil.Write(d.config.OutputCertPath, jksData); err != nil {
-			return nil, fmt.Errorf("failed to save certificate file: %w", err)
-		}
-		d.logger.Info("ssl certificate file saved", slog.String("path", d.config.OutputCertPath))
-
-	default:
-		return nil, fmt.Errorf("unsupported output format '%s'", d.config.OutputFormat)
+		d.logger.Info("ssl certificate file saved", slog.String("kind", string(file.Kind)), slog.String("path", file.Path))
 	}
 
 	// 执行后置命令
@@ -157,6 +160,35 @@ func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPE
 	return &deployer.DeployResult{}, nil
 }
 
+func (d *DeployerProvider) fileAttrsOf(kind OutputFileKind) FileAttrs {
+	switch kind {
+	case OUTPUT_FILE_KIND_SERVER_CERT:
+		return d.config.OutputServerCertFileAttrs
+	case OUTPUT_FILE_KIND_INTERMEDIA_CERT:
+		return d.config.OutputIntermediaCertFileAttrs
+	case OUTPUT_FILE_KIND_ROOT_CERT:
+		return d.config.OutputRootCertFileAttrs
+	case OUTPUT_FILE_KIND_KEY:
+		return d.config.OutputKeyFileAttrs
+	default:
+		return d.config.OutputCertFileAttrs
+	}
+}
+
+func (d *DeployerProvider) writeFile(path string, data []byte, attrs FileAttrs) error {
+	mode, err := fileutil.ParseFileMode(attrs.Mode)
+	if err != nil {
+		return err
+	}
+
+	return fileutil.WriteAtomic(path, data, &fileutil.WriteOptions{
+		Backup: d.config.OutputBackup,
+		Mode:   mode,
+		Owner:  attrs.Owner,
+		Group:  attrs.Group,
+	})
+}
+
 func execCommand(shellEnv ShellEnvType, command string) (string, string, error) {
 	var cmd *exec.Cmd
 
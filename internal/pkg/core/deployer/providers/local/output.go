@@ -0,0 +1,225 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type OutputOptions struct {
+	// 输出证书格式。
+	Format OutputFormatType
+	// 输出证书文件路径。
+	CertPath string
+	// 输出服务器证书文件路径。
+	// 选填。证书格式为 PEM 或 PEM_COMBINED 时有效。
+	ServerCertPath string
+	// 输出中间证书文件路径。
+	// 选填。证书格式为 PEM 或 PEM_COMBINED 时有效。
+	IntermediaCertPath string
+	// 输出根证书文件路径。
+	// 选填。证书格式为 PEM 或 PEM_COMBINED 时有效。
+	RootCertPath string
+	// 证书链与系统信任的根证书中均未找到根证书时，是否根据 AIA 扩展联网下载颁发者证书。
+	// 选填。输出根证书文件路径不为空时有效。
+	RootCertFetchIssuer bool
+	// 输出私钥文件路径。
+	// 证书格式为 PEM 时必填。
+	KeyPath string
+	// 是否逆序输出证书链，即中间证书在前、服务器证书在后。
+	// 证书格式为 PEM 或 PEM_COMBINED 时有效。
+	PemChainReversed bool
+	// 私钥格式。
+	// 证书格式为 PEM、PEM_COMBINED 或 P7B 时有效。零值时保持原有格式。
+	PemKeyFormat PrivateKeyFormatType
+	// 私钥加密密码。
+	// 私钥格式为 PKCS8_ENCRYPTED 时必填。
+	PemKeyPassword string
+	// PFX 导出密码。
+	// 证书格式为 PFX 时必填。
+	PfxPassword string
+	// JKS 别名。
+	// 证书格式为 JKS 时必填。
+	JksAlias string
+	// JKS 密钥密码。
+	// 证书格式为 JKS 时必填。
+	JksKeypass string
+	// JKS 存储密码。
+	// 证书格式为 JKS 时必填。
+	JksStorepass string
+}
+
+type OutputFile struct {
+	// 文件种类。
+	Kind OutputFileKind
+	// 文件路径。
+	Path string
+	// 文件内容。
+	Data []byte
+	// 文件内容的 MIME 类型。
+	ContentType string
+	// 文件内容是否包含私钥。
+	Sensitive bool
+}
+
+// 按输出选项将证书和私钥转换为待输出的文件列表。
+// 本地、SSH、FTP、Git、Docker、S3 等以文件形式输出证书的部署器共用此方法，以保证各格式选项的行为一致。
+//
+// 入参:
+//   - ctx: 上下文。
+//   - certPEM: 证书 PEM 内容。
+//   - privkeyPEM: 私钥 PEM 内容。
+//   - options: 输出选项。
+//
+// 出参:
+//   - files: 待输出的文件列表。
+//   - err: 错误。
+func BuildOutputFiles(ctx context.Context, certPEM string, privkeyPEM string, options *OutputOptions) (_files []OutputFile, _err error) {
+	if options == nil {
+		return nil, errors.New("output options is nil")
+	}
+	if options.CertPath == "" {
+		return nil, errors.New("output certificate path is required")
+	}
+
+	const pemContentType = "application/x-pem-file"
+
+	files := make([]OutputFile, 0)
+	switch options.Format {
+	case OUTPUT_FORMAT_PEM, OUTPUT_FORMAT_PEM_COMBINED:
+		serverCertPEM, intermediaCertPEM, err := certutil.ExtractCertificatesFromPEM(certPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract certs: %w", err)
+		}
+
+		fullchainPEM := certPEM
+		if options.PemChainReversed {
+			fullchainPEM, err = certutil.TransformCertificateFromPEMToReversedPEM(certPEM)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reverse certificate chain: %w", err)
+			}
+
+			if intermediaCertPEM != "" {
+				intermediaCertPEM, err = certutil.TransformCertificateFromPEMToReversedPEM(intermediaCertPEM)
+				if err != nil {
+					return nil, fmt.Errorf("failed to reverse intermedia certificate chain: %w", err)
+				}
+			}
+		}
+
+		keyPEM, err := transformPrivateKeyPEM(privkeyPEM, options.PemKeyFormat, options.PemKeyPassword)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform private key: %w", err)
+		}
+
+		if options.Format == OUTPUT_FORMAT_PEM_COMBINED {
+			combinedPEM, err := certutil.TransformCertificateFromPEMToCombinedPEM(fullchainPEM, keyPEM)
+			if err != nil {
+				return nil, fmt.Errorf("failed to combine certificate and private key: %w", err)
+			}
+
+			files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_CERT, Path: options.CertPath, Data: []byte(combinedPEM), ContentType: pemContentType, Sensitive: true})
+		} else {
+			files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_CERT, Path: options.CertPath, Data: []byte(fullchainPEM), ContentType: pemContentType})
+		}
+
+		if options.ServerCertPath != "" {
+			files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_SERVER_CERT, Path: options.ServerCertPath, Data: []byte(serverCertPEM), ContentType: pemContentType})
+		}
+
+		if options.IntermediaCertPath != "" {
+			files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_INTERMEDIA_CERT, Path: options.IntermediaCertPath, Data: []byte(intermediaCertPEM), ContentType: pemContentType})
+		}
+
+		if options.RootCertPath != "" {
+			rootCertPEM, err := certutil.ResolveRootCertificateFromPEM(ctx, certPEM, &certutil.ResolveRootOptions{FetchIssuer: options.RootCertFetchIssuer})
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve root certificate: %w", err)
+			}
+
+			files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_ROOT_CERT, Path: options.RootCertPath, Data: []byte(rootCertPEM), ContentType: pemContentType})
+		}
+
+		// 合并格式下证书文件中已包含私钥，私钥文件路径为选填
+		if options.Format == OUTPUT_FORMAT_PEM || options.KeyPath != "" {
+			if options.KeyPath == "" {
+				return nil, errors.New("output private key path is required")
+			}
+
+			files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_KEY, Path: options.KeyPath, Data: []byte(keyPEM), ContentType: pemContentType, Sensitive: true})
+		}
+
+	case OUTPUT_FORMAT_DER:
+		derData, err := certutil.TransformCertificateFromPEMToDER(certPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform certificate to DER: %w", err)
+		}
+
+		files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_CERT, Path: options.CertPath, Data: derData, ContentType: "application/pkix-cert"})
+
+		if options.KeyPath != "" {
+			keyData, err := certutil.TransformPrivateKeyFromPEMToPKCS8(privkeyPEM)
+			if err != nil {
+				return nil, fmt.Errorf("failed to transform private key to DER: %w", err)
+			}
+
+			files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_KEY, Path: options.KeyPath, Data: keyData, ContentType: "application/pkcs8", Sensitive: true})
+		}
+
+	case OUTPUT_FORMAT_P7B:
+		p7bData, err := certutil.TransformCertificateFromPEMToPKCS7(certPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform certificate to PKCS#7: %w", err)
+		}
+
+		files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_CERT, Path: options.CertPath, Data: p7bData, ContentType: "application/x-pkcs7-certificates"})
+
+		if options.KeyPath != "" {
+			keyPEM, err := transformPrivateKeyPEM(privkeyPEM, options.PemKeyFormat, options.PemKeyPassword)
+			if err != nil {
+				return nil, fmt.Errorf("failed to transform private key: %w", err)
+			}
+
+			files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_KEY, Path: options.KeyPath, Data: []byte(keyPEM), ContentType: pemContentType, Sensitive: true})
+		}
+
+	case OUTPUT_FORMAT_PFX:
+		pfxData, err := certutil.TransformCertificateFromPEMToPFX(certPEM, privkeyPEM, options.PfxPassword)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform certificate to PFX: %w", err)
+		}
+
+		files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_CERT, Path: options.CertPath, Data: pfxData, ContentType: "application/x-pkcs12", Sensitive: true})
+
+	case OUTPUT_FORMAT_JKS:
+		jksData, err := certutil.TransformCertificateFromPEMToJKS(certPEM, privkeyPEM, options.JksAlias, options.JksKeypass, options.JksStorepass)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transform certificate to JKS: %w", err)
+		}
+
+		files = append(files, OutputFile{Kind: OUTPUT_FILE_KIND_CERT, Path: options.CertPath, Data: jksData, ContentType: "application/octet-stream", Sensitive: true})
+
+	default:
+		return nil, fmt.Errorf("unsupported output format '%s'", options.Format)
+	}
+
+	return files, nil
+}
+
+func transformPrivateKeyPEM(privkeyPEM string, format PrivateKeyFormatType, password string) (string, error) {
+	switch format {
+	case PRIVATE_KEY_FORMAT_ORIGINAL:
+		return privkeyPEM, nil
+
+	case PRIVATE_KEY_FORMAT_PKCS8:
+		return certutil.TransformPrivateKeyFromPEMToPKCS8PEM(privkeyPEM)
+
+	case PRIVATE_KEY_FORMAT_PKCS8_ENCRYPTED:
+		return certutil.TransformPrivateKeyFromPEMToEncryptedPKCS8PEM(privkeyPEM, password)
+
+	default:
+		return "", fmt.Errorf("unsupported private key format '%s'", format)
+	}
+}
@@ -0,0 +1,190 @@
+package mikrotik
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	mikrotiksdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/mikrotik"
+)
+
+// 生成由中间证书签发的服务器证书，返回证书链 PEM、私钥 PEM 与各证书的 SHA-256 指纹。
+func generateCertificateChain(t *testing.T) (string, string, []string) {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Intermediate CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "router.example.com"},
+		DNSNames:     []string{"router.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	fingerprints := make([]string, 0, 2)
+	for _, der := range [][]byte{certDER, caDER} {
+		fingerprint := sha256.Sum256(der)
+		fingerprints = append(fingerprints, hex.EncodeToString(fingerprint[:]))
+	}
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})) +
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM, fingerprints
+}
+
+func TestDeployRemovesOutdatedCertificates(t *testing.T) {
+	certPEM, privkeyPEM, fingerprints := generateCertificateChain(t)
+
+	var mtx sync.Mutex
+	removed := make([]string, 0)
+	certificates := mikrotiksdk.ListCertificatesResponse{
+		// 此前导入的证书链，已不再被引用
+		{Id: "*1", Name: "certimate-1000.crt_0", PrivateKey: "true"},
+		{Id: "*2", Name: "certimate-1000.crt_1"},
+		// 此前导入的证书链，仍被 IPsec 使用
+		{Id: "*3", Name: "certimate-2000.crt_0", PrivateKey: "true"},
+		{Id: "*4", Name: "certimate-2000.crt_1"},
+		// 此前导入的证书链，其中间证书与当前证书链相同
+		{Id: "*5", Name: "certimate-3000.crt_0", PrivateKey: "true"},
+		{Id: "*6", Name: "certimate-3000.crt_1", Fingerprint: fingerprints[1]},
+		// 当前证书链
+		{Id: "*7", Name: "certimate-4000.crt_0", Fingerprint: fingerprints[0], PrivateKey: "true"},
+		{Id: "*8", Name: "certimate-4000.crt_1", Fingerprint: fingerprints[1]},
+		// 非本程序导入的证书
+		{Id: "*9", Name: "manual-cert", PrivateKey: "true"},
+	}
+	services := mikrotiksdk.ListServicesResponse{
+		{Id: "*a", Name: "www-ssl", Certificate: "certimate-1000.crt_0"},
+		{Id: "*b", Name: "api-ssl", Certificate: "certimate-1000.crt_0"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/certificate", func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		json.NewEncoder(w).Encode(certificates)
+	})
+	mux.HandleFunc("GET /rest/ip/service", func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		json.NewEncoder(w).Encode(services)
+	})
+	mux.HandleFunc("GET /rest/ip/ipsec/identity", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{".id":"*1","peer":"road-warrior","certificate":"certimate-2000.crt_0"}]`))
+	})
+	mux.HandleFunc("GET /rest/interface/sstp-server/server", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"enabled":"false","certificate":"none"}`))
+	})
+	mux.HandleFunc("GET /rest/interface/ovpn-server/server", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("GET /rest/ip/hotspot/profile", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{".id":"*1","name":"default","ssl-certificate":"none"}]`))
+	})
+	mux.HandleFunc("POST /rest/ip/service/set", func(w http.ResponseWriter, r *http.Request) {
+		req := &mikrotiksdk.SetServiceRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, service := range services {
+			if service.Name == req.Numbers {
+				service.Certificate = req.Certificate
+			}
+		}
+	})
+	mux.HandleFunc("POST /rest/certificate/remove", func(w http.ResponseWriter, r *http.Request) {
+		req := &mikrotiksdk.RemoveCertificateRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		removed = append(removed, req.Numbers)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider, err := NewDeployer(&DeployerConfig{
+		ServerUrl: server.URL,
+		Username:  "admin",
+	})
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	provider.WithLogger(slog.New(slog.DiscardHandler))
+
+	res, err := provider.Deploy(context.Background(), certPEM, privkeyPEM)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	if res.ExtendedData["certificateName"] != "certimate-4000.crt_0" {
+		t.Errorf("unexpected certificate name: %v", res.ExtendedData["certificateName"])
+	}
+
+	// 仅应删除已不再被引用的旧证书链，且保留与当前证书链共享的证书
+	slices.Sort(removed)
+	if !slices.Equal(removed, []string{"*1", "*2", "*5"}) {
+		t.Errorf("unexpected removed certificates: %v", removed)
+	}
+
+	t.Run("ConsumerUnavailable", func(t *testing.T) {
+		removed = removed[:0]
+
+		// 无法确认证书是否仍被引用时，不应删除任何证书
+		brokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/rest/ip/hotspot/profile" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		}))
+		defer brokenServer.Close()
+
+		provider, _ := NewDeployer(&DeployerConfig{
+			ServerUrl: brokenServer.URL,
+			Username:  "admin",
+		})
+		provider.WithLogger(slog.New(slog.DiscardHandler))
+
+		if _, err := provider.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if len(removed) != 0 {
+			t.Errorf("expected no certificates to be removed, got: %v", removed)
+		}
+	})
+}
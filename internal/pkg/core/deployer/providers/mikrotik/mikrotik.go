@@ -0,0 +1,323 @@
+package mikrotik
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	mikrotiksdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/mikrotik"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// RouterOS 服务地址。
+	ServerUrl string `json:"serverUrl"`
+	// RouterOS 用户名。
+	Username string `json:"username"`
+	// RouterOS 密码。
+	Password string `json:"password"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 需要绑定证书的 IP 服务名称数组。
+	// 零值时默认值 ["www-ssl", "api-ssl"]。
+	Services []string `json:"services,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *mikrotiksdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServerUrl, config.Username, config.Password, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// RouterOS 以 SHA-256 指纹标识证书，据此在导入后定位证书
+	fingerprint := sha256.Sum256(certX509.Raw)
+	certFingerprint := hex.EncodeToString(fingerprint[:])
+
+	certificate, err := d.findCertificate(certFingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	if certificate != nil {
+		d.logger.Info("ssl certificate already exists, skip importing", slog.String("certificateName", certificate.Name))
+	} else {
+		certificate, err = d.importCertificate(certPEM, privkeyPEM, certFingerprint)
+		if err != nil {
+			return nil, err
+		}
+
+		d.logger.Info("ssl certificate imported", slog.String("certificateName", certificate.Name))
+	}
+
+	// 绑定证书到 IP 服务
+	services := d.config.Services
+	if len(services) == 0 {
+		services = []string{"www-ssl", "api-ssl"}
+	}
+
+	for _, service := range services {
+		setServiceReq := &mikrotiksdk.SetServiceRequest{
+			Numbers:     service,
+			Certificate: certificate.Name,
+		}
+		err := d.sdkClient.SetService(setServiceReq)
+		d.logger.Debug("sdk request 'mikrotik.SetService'", slog.Any("request", setServiceReq))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'mikrotik.SetService': %w", err)
+		}
+	}
+
+	// 清理此前导入的旧证书，失败时不影响部署结果
+	if err := d.removeOutdatedCertificates(certificate.Name, certPEM); err != nil {
+		d.logger.Warn("failed to remove outdated certificates", slog.Any("error", err))
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"certificateName": certificate.Name,
+			"services":        services,
+		},
+	}, nil
+}
+
+func (d *DeployerProvider) findCertificate(certFingerprint string) (*mikrotiksdk.CertificateRecord, error) {
+	// 获取证书列表
+	// REF: https://help.mikrotik.com/docs/spaces/ROS/pages/2555969/Certificates
+	listCertificatesResp, err := d.sdkClient.ListCertificates()
+	d.logger.Debug("sdk request 'mikrotik.ListCertificates'", slog.Any("response", listCertificatesResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'mikrotik.ListCertificates': %w", err)
+	}
+
+	for _, certificate := range listCertificatesResp {
+		if !strings.EqualFold(strings.ReplaceAll(certificate.Fingerprint, ":", ""), certFingerprint) {
+			continue
+		}
+		if certificate.PrivateKey != "true" {
+			continue
+		}
+
+		return certificate, nil
+	}
+
+	return nil, nil
+}
+
+func (d *DeployerProvider) importCertificate(certPEM string, privkeyPEM string, certFingerprint string) (*mikrotiksdk.CertificateRecord, error) {
+	fileName := fmt.Sprintf("certimate-%d", time.Now().UnixMilli())
+	certFileName := fileName + ".crt"
+	keyFileName := fileName + ".key"
+
+	// 上传证书文件
+	// RouterOS 仅支持从文件导入证书，导入完成后删除临时文件
+	for _, file := range []*mikrotiksdk.AddFileRequest{
+		{Name: certFileName, Contents: certPEM},
+		{Name: keyFileName, Contents: privkeyPEM},
+	} {
+		if err := d.sdkClient.AddFile(file); err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'mikrotik.AddFile': %w", err)
+		}
+		d.logger.Debug("sdk request 'mikrotik.AddFile'", slog.String("name", file.Name))
+
+		defer func(name string) {
+			if err := d.sdkClient.RemoveFile(&mikrotiksdk.RemoveFileRequest{Numbers: name}); err != nil {
+				d.logger.Warn("failed to remove temporary file", slog.String("name", name), slog.Any("error", err))
+			}
+		}(file.Name)
+	}
+
+	// 导入证书，再导入私钥
+	// 私钥会自动关联到已导入的对应证书
+	for _, name := range []string{certFileName, keyFileName} {
+		importCertificateReq := &mikrotiksdk.ImportCertificateRequest{
+			FileName:   name,
+			Passphrase: "",
+		}
+		importCertificateResp, err := d.sdkClient.ImportCertificate(importCertificateReq)
+		d.logger.Debug("sdk request 'mikrotik.ImportCertificate'", slog.Any("request", importCertificateReq), slog.Any("response", importCertificateResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'mikrotik.ImportCertificate': %w", err)
+		}
+	}
+
+	certificate, err := d.findCertificate(certFingerprint)
+	if err != nil {
+		return nil, err
+	} else if certificate == nil {
+		return nil, errors.New("could not find the imported certificate with private key")
+	}
+
+	return certificate, nil
+}
+
+func (d *DeployerProvider) removeOutdatedCertificates(currentCertName string, certPEM string) error {
+	// 仅清理由本程序导入的证书，按导入时的文件名前缀分组
+	// 导入包含证书链的文件时，RouterOS 会为每张证书创建一条记录，名称形如 "certimate-<时间戳>.crt_<序号>"
+	currentGroup, ok := parseImportedCertificateGroup(currentCertName)
+	if !ok {
+		return nil
+	}
+
+	// 当前证书链中的证书（如与此前导入共享的中间证书）不可删除
+	protectedFingerprints := make(map[string]struct{})
+	if certs, err := certutil.ParseCertificatesFromPEM(certPEM); err == nil {
+		for _, cert := range certs {
+			fingerprint := sha256.Sum256(cert.Raw)
+			protectedFingerprints[hex.EncodeToString(fingerprint[:])] = struct{}{}
+		}
+	}
+
+	// 获取仍被引用的证书，任一来源获取失败时放弃清理
+	referencedCertNames := make(map[string]struct{})
+	for _, consumer := range certificateConsumers {
+		listMenuItemsResp, err := d.sdkClient.ListMenuItems(consumer.path)
+		d.logger.Debug("sdk request 'mikrotik.ListMenuItems'", slog.String("path", consumer.path), slog.Any("response", listMenuItemsResp))
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'mikrotik.ListMenuItems': %w", err)
+		}
+
+		for _, item := range listMenuItemsResp {
+			for _, field := range consumer.fields {
+				value, _ := item[field].(string)
+				for _, name := range strings.Split(value, ",") {
+					if name = strings.TrimSpace(name); name != "" {
+						referencedCertNames[name] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	listCertificatesResp, err := d.sdkClient.ListCertificates()
+	d.logger.Debug("sdk request 'mikrotik.ListCertificates'", slog.Any("response", listCertificatesResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'mikrotik.ListCertificates': %w", err)
+	}
+
+	groups := make(map[string][]*mikrotiksdk.CertificateRecord)
+	for _, certificate := range listCertificatesResp {
+		group, ok := parseImportedCertificateGroup(certificate.Name)
+		if !ok || group == currentGroup {
+			continue
+		}
+
+		groups[group] = append(groups[group], certificate)
+	}
+
+	var errs []error
+	for group, certificates := range groups {
+		// 同一次导入的证书中任意一张仍被引用时，保留整组证书
+		if slices.ContainsFunc(certificates, func(certificate *mikrotiksdk.CertificateRecord) bool {
+			_, ok := referencedCertNames[certificate.Name]
+			return ok
+		}) {
+			d.logger.Info("outdated ssl certificates are still in use, skip removing", slog.String("group", group))
+			continue
+		}
+
+		for _, certificate := range certificates {
+			if _, ok := protectedFingerprints[strings.ToLower(strings.ReplaceAll(certificate.Fingerprint, ":", ""))]; ok {
+				continue
+			}
+
+			removeCertificateReq := &mikrotiksdk.RemoveCertificateRequest{
+				Numbers: certificate.Id,
+			}
+			err := d.sdkClient.RemoveCertificate(removeCertificateReq)
+			d.logger.Debug("sdk request 'mikrotik.RemoveCertificate'", slog.Any("request", removeCertificateReq))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to execute sdk request 'mikrotik.RemoveCertificate': %w", err))
+				continue
+			}
+
+			d.logger.Info("outdated ssl certificate removed", slog.String("certificateName", certificate.Name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// 可能引用证书的菜单及其字段。
+var certificateConsumers = []struct {
+	path   string
+	fields []string
+}{
+	{"/ip/service", []string{"certificate"}},
+	{"/ip/ipsec/identity", []string{"certificate", "remote-certificate"}},
+	{"/interface/sstp-server/server", []string{"certificate"}},
+	{"/interface/ovpn-server/server", []string{"certificate"}},
+	{"/ip/hotspot/profile", []string{"ssl-certificate"}},
+}
+
+var importedCertificateNameRegexp = regexp.MustCompile(`^(certimate-\d+)\.(crt|key)_\d+$`)
+
+func parseImportedCertificateGroup(name string) (string, bool) {
+	matches := importedCertificateNameRegexp.FindStringSubmatch(name)
+	if matches == nil {
+		return "", false
+	}
+
+	return matches[1], true
+}
+
+func createSdkClient(serverUrl, username, password string, skipTlsVerify bool) (*mikrotiksdk.Client, error) {
+	if _, err := url.Parse(serverUrl); err != nil {
+		return nil, errors.New("invalid mikrotik server url")
+	}
+
+	if username == "" {
+		return nil, errors.New("invalid mikrotik username")
+	}
+
+	client := mikrotiksdk.NewClient(serverUrl, username, password)
+	if skipTlsVerify {
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return client, nil
+}
@@ -0,0 +1,225 @@
+package nginxproxymanager
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	npmsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/nginxproxymanager"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// Nginx Proxy Manager 服务地址。
+	ServerUrl string `json:"serverUrl"`
+	// Nginx Proxy Manager 登录邮箱。
+	Email string `json:"email"`
+	// Nginx Proxy Manager 登录密码。
+	Password string `json:"password"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 证书 ID。
+	// 选填。非零值时替换指定证书；零值时按证书名称查找自定义证书并替换，未找到时新建。
+	CertificateId int64 `json:"certificateId,omitempty"`
+	// 证书名称。
+	// 选填。零值时默认值为证书主域名。
+	CertificateName string `json:"certificateName,omitempty"`
+	// 是否将证书绑定到域名与之匹配的代理主机。
+	// 仅当代理主机的全部域名均被证书覆盖时才会绑定。
+	AttachToProxyHosts bool `json:"attachToProxyHosts,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *npmsdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServerUrl, config.Email, config.Password, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 提取服务器证书和中间证书
+	serverCertPEM, intermediaCertPEM, err := certutil.ExtractCertificatesFromPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract certs: %w", err)
+	}
+
+	// 确定待替换的证书
+	certificateId := d.config.CertificateId
+	if certificateId == 0 {
+		certificateId, err = d.findOrCreateCertificate(certX509)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 上传证书
+	uploadCertificateReq := &npmsdk.UploadCertificateRequest{
+		Certificate:             serverCertPEM,
+		CertificateKey:          privkeyPEM,
+		IntermediateCertificate: intermediaCertPEM,
+	}
+	_, err = d.sdkClient.UploadCertificate(certificateId, uploadCertificateReq)
+	d.logger.Debug("sdk request 'nginxproxymanager.UploadCertificate'", slog.Int64("certificateId", certificateId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'nginxproxymanager.UploadCertificate': %w", err)
+	}
+
+	d.logger.Info("ssl certificate uploaded", slog.Int64("certificateId", certificateId))
+
+	// 绑定到代理主机
+	attachedProxyHostIds := make([]int64, 0)
+	if d.config.AttachToProxyHosts {
+		attachedProxyHostIds, err = d.attachToProxyHosts(certX509, certificateId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"certificateId": certificateId,
+			"proxyHostIds":  attachedProxyHostIds,
+		},
+	}, nil
+}
+
+func (d *DeployerProvider) findOrCreateCertificate(certX509 *x509.Certificate) (int64, error) {
+	certificateName := d.config.CertificateName
+	if certificateName == "" {
+		certificateName = certX509.Subject.CommonName
+		if certificateName == "" && len(certX509.DNSNames) > 0 {
+			certificateName = certX509.DNSNames[0]
+		}
+	}
+
+	// 查找同名的自定义证书
+	listCertificatesResp, err := d.sdkClient.ListCertificates()
+	d.logger.Debug("sdk request 'nginxproxymanager.ListCertificates'", slog.Any("response", listCertificatesResp))
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute sdk request 'nginxproxymanager.ListCertificates': %w", err)
+	}
+
+	for _, certificate := range listCertificatesResp {
+		if certificate.Provider == "other" && certificate.NiceName == certificateName {
+			d.logger.Info("found existing custom certificate", slog.Int64("certificateId", certificate.Id), slog.String("name", certificateName))
+			return certificate.Id, nil
+		}
+	}
+
+	// 新建自定义证书
+	createCertificateReq := &npmsdk.CreateCertificateRequest{
+		Provider: "other",
+		NiceName: certificateName,
+	}
+	createCertificateResp, err := d.sdkClient.CreateCertificate(createCertificateReq)
+	d.logger.Debug("sdk request 'nginxproxymanager.CreateCertificate'", slog.Any("request", createCertificateReq), slog.Any("response", createCertificateResp))
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute sdk request 'nginxproxymanager.CreateCertificate': %w", err)
+	}
+
+	d.logger.Info("custom certificate created", slog.Int64("certificateId", createCertificateResp.Id), slog.String("name", certificateName))
+	return createCertificateResp.Id, nil
+}
+
+func (d *DeployerProvider) attachToProxyHosts(certX509 *x509.Certificate, certificateId int64) ([]int64, error) {
+	listProxyHostsResp, err := d.sdkClient.ListProxyHosts()
+	d.logger.Debug("sdk request 'nginxproxymanager.ListProxyHosts'", slog.Any("response", listProxyHostsResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'nginxproxymanager.ListProxyHosts': %w", err)
+	}
+
+	proxyHostIds := make([]int64, 0)
+	for _, proxyHost := range listProxyHostsResp {
+		if len(proxyHost.DomainNames) == 0 || !matchAllHosts(certX509, proxyHost.DomainNames) {
+			continue
+		}
+
+		if proxyHost.GetCertificateId() == certificateId {
+			d.logger.Info("proxy host already uses the certificate, skipping", slog.Int64("proxyHostId", proxyHost.Id))
+			continue
+		}
+
+		updateProxyHostReq := &npmsdk.UpdateProxyHostRequest{
+			CertificateId: certificateId,
+		}
+		updateProxyHostResp, err := d.sdkClient.UpdateProxyHost(proxyHost.Id, updateProxyHostReq)
+		d.logger.Debug("sdk request 'nginxproxymanager.UpdateProxyHost'", slog.Int64("proxyHostId", proxyHost.Id), slog.Any("request", updateProxyHostReq), slog.Any("response", updateProxyHostResp))
+		if err != nil {
+			return proxyHostIds, fmt.Errorf("failed to execute sdk request 'nginxproxymanager.UpdateProxyHost': %w", err)
+		}
+
+		proxyHostIds = append(proxyHostIds, proxyHost.Id)
+		d.logger.Info("ssl certificate attached to proxy host", slog.Int64("proxyHostId", proxyHost.Id), slog.Any("domains", proxyHost.DomainNames))
+	}
+
+	return proxyHostIds, nil
+}
+
+func matchAllHosts(certX509 *x509.Certificate, hosts []string) bool {
+	for _, host := range hosts {
+		if certX509.VerifyHostname(host) != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func createSdkClient(serverUrl, email, password string, skipTlsVerify bool) (*npmsdk.Client, error) {
+	if _, err := url.Parse(serverUrl); err != nil {
+		return nil, errors.New("invalid nginxproxymanager server url")
+	}
+
+	if email == "" {
+		return nil, errors.New("invalid nginxproxymanager email")
+	}
+
+	if password == "" {
+		return nil, errors.New("invalid nginxproxymanager password")
+	}
+
+	client := npmsdk.NewClient(serverUrl, email, password)
+	if skipTlsVerify {
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return client, nil
+}
@@ -0,0 +1,82 @@
+package nginxproxymanager_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/nginxproxymanager"
+)
+
+var (
+	fInputCertPath string
+	fInputKeyPath  string
+	fServerUrl     string
+	fEmail         string
+	fPassword      string
+	fCertificateId int64
+)
+
+func init() {
+	argsPrefix := "CERTIMATE_DEPLOYER_NGINXPROXYMANAGER_"
+
+	flag.StringVar(&fInputCertPath, argsPrefix+"INPUTCERTPATH", "", "")
+	flag.StringVar(&fInputKeyPath, argsPrefix+"INPUTKEYPATH", "", "")
+	flag.StringVar(&fServerUrl, argsPrefix+"SERVERURL", "", "")
+	flag.StringVar(&fEmail, argsPrefix+"EMAIL", "", "")
+	flag.StringVar(&fPassword, argsPrefix+"PASSWORD", "", "")
+	flag.Int64Var(&fCertificateId, argsPrefix+"CERTIFICATEID", 0, "")
+}
+
+/*
+Shell command to run this test:
+
+	go test -v ./nginxproxymanager_test.go -args \
+	--CERTIMATE_DEPLOYER_NGINXPROXYMANAGER_INPUTCERTPATH="/path/to/your-input-cert.pem" \
+	--CERTIMATE_DEPLOYER_NGINXPROXYMANAGER_INPUTKEYPATH="/path/to/your-input-key.pem" \
+	--CERTIMATE_DEPLOYER_NGINXPROXYMANAGER_SERVERURL="http://127.0.0.1:81" \
+	--CERTIMATE_DEPLOYER_NGINXPROXYMANAGER_EMAIL="admin@example.com" \
+	--CERTIMATE_DEPLOYER_NGINXPROXYMANAGER_PASSWORD="your-password" \
+	--CERTIMATE_DEPLOYER_NGINXPROXYMANAGER_CERTIFICATEID=0
+*/
+func TestDeploy(t *testing.T) {
+	flag.Parse()
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Log(strings.Join([]string{
+			"args:",
+			fmt.Sprintf("INPUTCERTPATH: %v", fInputCertPath),
+			fmt.Sprintf("INPUTKEYPATH: %v", fInputKeyPath),
+			fmt.Sprintf("SERVERURL: %v", fServerUrl),
+			fmt.Sprintf("EMAIL: %v", fEmail),
+			fmt.Sprintf("PASSWORD: %v", fPassword),
+			fmt.Sprintf("CERTIFICATEID: %v", fCertificateId),
+		}, "\n"))
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			ServerUrl:                fServerUrl,
+			Email:                    fEmail,
+			Password:                 fPassword,
+			AllowInsecureConnections: true,
+			CertificateId:            fCertificateId,
+			AttachToProxyHosts:       true,
+		})
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		fInputCertData, _ := os.ReadFile(fInputCertPath)
+		fInputKeyData, _ := os.ReadFile(fInputKeyPath)
+		res, err := deployer.Deploy(context.Background(), string(fInputCertData), string(fInputKeyData))
+		if err != nil {
+			t.Errorf("err: %+v", err)
+			return
+		}
+
+		t.Logf("ok: %v", res)
+	})
+}
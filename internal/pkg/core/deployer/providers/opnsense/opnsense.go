@@ -0,0 +1,161 @@
+package opnsense
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	opnsensesdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/opnsense"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// OPNsense 服务地址。
+	ServerUrl string `json:"serverUrl"`
+	// OPNsense API Key。
+	ApiKey string `json:"apiKey"`
+	// OPNsense API Secret。
+	ApiSecret string `json:"apiSecret"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 证书 UUID。
+	// 选填。零值时将根据证书描述查找证书，未找到时新建证书。
+	CertificateUuid string `json:"certificateUuid,omitempty"`
+	// 证书描述。
+	// 选填。零值时默认值 "certimate-{CommonName}"。
+	CertificateDescription string `json:"certificateDescription,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *opnsensesdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServerUrl, config.ApiKey, config.ApiSecret, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	certificateDesc := d.config.CertificateDescription
+	if certificateDesc == "" {
+		certificateDesc = "certimate-" + certX509.Subject.CommonName
+	}
+
+	// 确定待替换的证书
+	certificateUuid := d.config.CertificateUuid
+	if certificateUuid == "" {
+		// 查询证书列表
+		// REF: https://docs.opnsense.org/development/api/core/trust.html
+		searchCertificatesResp, err := d.sdkClient.SearchCertificates(certificateDesc)
+		d.logger.Debug("sdk request 'opnsense.SearchCertificates'", slog.String("searchPhrase", certificateDesc), slog.Any("response", searchCertificatesResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'opnsense.SearchCertificates': %w", err)
+		}
+
+		for _, certificate := range searchCertificatesResp.Rows {
+			if certificate.Descr == certificateDesc {
+				certificateUuid = certificate.Uuid
+				d.logger.Info("found existing certificate", slog.String("certificateUuid", certificateUuid), slog.String("descr", certificateDesc))
+				break
+			}
+		}
+	}
+
+	payload := &opnsensesdk.CertificatePayload{
+		Action:     "import",
+		Descr:      certificateDesc,
+		CrtPayload: certPEM,
+		PrvPayload: privkeyPEM,
+	}
+	if certificateUuid == "" {
+		// 导入新证书到信任库
+		// REF: https://docs.opnsense.org/development/api/core/trust.html
+		addCertificateResp, err := d.sdkClient.AddCertificate(&opnsensesdk.AddCertificateRequest{Cert: payload})
+		d.logger.Debug("sdk request 'opnsense.AddCertificate'", slog.String("descr", certificateDesc), slog.Any("response", addCertificateResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'opnsense.AddCertificate': %w", err)
+		}
+
+		certificateUuid = addCertificateResp.Uuid
+		d.logger.Info("ssl certificate imported", slog.String("certificateUuid", certificateUuid))
+	} else {
+		// 原地替换证书内容
+		// 证书的引用标识保持不变，因此已绑定到 Web 界面等服务的证书无需重新绑定
+		err := d.sdkClient.SetCertificate(certificateUuid, &opnsensesdk.SetCertificateRequest{Cert: payload})
+		d.logger.Debug("sdk request 'opnsense.SetCertificate'", slog.String("certificateUuid", certificateUuid), slog.String("descr", certificateDesc))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'opnsense.SetCertificate': %w", err)
+		}
+
+		d.logger.Info("ssl certificate replaced", slog.String("certificateUuid", certificateUuid))
+	}
+
+	// 重启 Web 界面使证书生效
+	// OPNsense 未提供修改 Web 界面证书的 API，首次导入后需在「System > Settings > Administration」中手动选择该证书
+	// REF: https://docs.opnsense.org/development/api/core/core.html
+	if err := d.sdkClient.RestartService("webgui"); err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'opnsense.RestartService': %w", err)
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"certificateUuid": certificateUuid,
+		},
+	}, nil
+}
+
+func createSdkClient(serverUrl, apiKey, apiSecret string, skipTlsVerify bool) (*opnsensesdk.Client, error) {
+	if _, err := url.Parse(serverUrl); err != nil {
+		return nil, errors.New("invalid opnsense server url")
+	}
+
+	if apiKey == "" {
+		return nil, errors.New("invalid opnsense api key")
+	}
+
+	if apiSecret == "" {
+		return nil, errors.New("invalid opnsense api secret")
+	}
+
+	client := opnsensesdk.NewClient(serverUrl, apiKey, apiSecret)
+	if skipTlsVerify {
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return client, nil
+}
@@ -0,0 +1,173 @@
+package pfsense
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	pfsensesdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/pfsense"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// pfSense 服务地址。
+	ServerUrl string `json:"serverUrl"`
+	// pfSense REST API Key。
+	ApiKey string `json:"apiKey"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 证书描述。
+	// 选填。零值时默认值 "certimate-{CommonName}"。同描述的证书将被原地替换，未找到时新建证书。
+	CertificateDescription string `json:"certificateDescription,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *pfsensesdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServerUrl, config.ApiKey, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	certificateDesc := d.config.CertificateDescription
+	if certificateDesc == "" {
+		certificateDesc = "certimate-" + certX509.Subject.CommonName
+	}
+
+	// 获取证书列表
+	// REF: https://pfrest.org/api-docs/#/SYSTEM/getSystemCertificatesEndpoint
+	listCertificatesResp, err := d.sdkClient.ListCertificates()
+	d.logger.Debug("sdk request 'pfsense.ListCertificates'", slog.Any("response", listCertificatesResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'pfsense.ListCertificates': %w", err)
+	}
+
+	// 确定待替换的证书
+	// 注意 pfSense 的证书 ID 为数组下标，可能随增删而变化，因此以描述查找证书
+	var certificate *pfsensesdk.CertificateRecord
+	for _, record := range listCertificatesResp.Data {
+		if record.Descr == certificateDesc {
+			certificate = record
+			break
+		}
+	}
+
+	if certificate == nil {
+		// 导入新证书
+		// REF: https://pfrest.org/api-docs/#/SYSTEM/postSystemCertificateEndpoint
+		createCertificateReq := &pfsensesdk.CreateCertificateRequest{
+			Descr: certificateDesc,
+			Type:  "server",
+			Crt:   certPEM,
+			Prv:   privkeyPEM,
+		}
+		createCertificateResp, err := d.sdkClient.CreateCertificate(createCertificateReq)
+		d.logger.Debug("sdk request 'pfsense.CreateCertificate'", slog.String("descr", certificateDesc), slog.Any("response", createCertificateResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'pfsense.CreateCertificate': %w", err)
+		} else if createCertificateResp.Data == nil || createCertificateResp.Data.RefId == "" {
+			return nil, errors.New("pfsense api error: missing certificate refid in response")
+		}
+
+		certificate = createCertificateResp.Data
+		d.logger.Info("ssl certificate imported", slog.String("refid", certificate.RefId))
+	} else {
+		// 原地替换证书内容，保持引用标识不变
+		// REF: https://pfrest.org/api-docs/#/SYSTEM/patchSystemCertificateEndpoint
+		updateCertificateReq := &pfsensesdk.UpdateCertificateRequest{
+			Id:  certificate.Id,
+			Crt: certPEM,
+			Prv: privkeyPEM,
+		}
+		updateCertificateResp, err := d.sdkClient.UpdateCertificate(updateCertificateReq)
+		d.logger.Debug("sdk request 'pfsense.UpdateCertificate'", slog.Int64("id", certificate.Id), slog.Any("response", updateCertificateResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'pfsense.UpdateCertificate': %w", err)
+		}
+
+		d.logger.Info("ssl certificate replaced", slog.String("refid", certificate.RefId))
+	}
+
+	// 设置 Web 界面证书
+	// REF: https://pfrest.org/api-docs/#/SYSTEM/patchSystemWebGUISettingsEndpoint
+	getWebGUISettingsResp, err := d.sdkClient.GetWebGUISettings()
+	d.logger.Debug("sdk request 'pfsense.GetWebGUISettings'", slog.Any("response", getWebGUISettingsResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'pfsense.GetWebGUISettings': %w", err)
+	}
+
+	if getWebGUISettingsResp.Data != nil && getWebGUISettingsResp.Data.SslCertRef == certificate.RefId {
+		d.logger.Info("webgui certificate already up to date")
+	} else {
+		updateWebGUISettingsReq := &pfsensesdk.UpdateWebGUISettingsRequest{
+			SslCertRef: certificate.RefId,
+		}
+		updateWebGUISettingsResp, err := d.sdkClient.UpdateWebGUISettings(updateWebGUISettingsReq)
+		d.logger.Debug("sdk request 'pfsense.UpdateWebGUISettings'", slog.Any("request", updateWebGUISettingsReq), slog.Any("response", updateWebGUISettingsResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'pfsense.UpdateWebGUISettings': %w", err)
+		}
+
+		d.logger.Info("webgui certificate updated", slog.String("refid", certificate.RefId))
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"refid": certificate.RefId,
+		},
+	}, nil
+}
+
+func createSdkClient(serverUrl, apiKey string, skipTlsVerify bool) (*pfsensesdk.Client, error) {
+	if _, err := url.Parse(serverUrl); err != nil {
+		return nil, errors.New("invalid pfsense server url")
+	}
+
+	if apiKey == "" {
+		return nil, errors.New("invalid pfsense api key")
+	}
+
+	client := pfsensesdk.NewClient(serverUrl, apiKey)
+	if skipTlsVerify {
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return client, nil
+}
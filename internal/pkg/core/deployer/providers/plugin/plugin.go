@@ -0,0 +1,129 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	uploadersp "github.com/usual2970/certimate/internal/pkg/core/uploader/providers/plugin"
+	pluginsdk "github.com/usual2970/certimate/pkg/plugin"
+)
+
+type DeployerConfig struct {
+	// 插件名称。
+	PluginName string `json:"pluginName"`
+	// 传递给插件的授权配置。
+	AccessConfig map[string]any `json:"accessConfig,omitempty"`
+	// 传递给插件的部署配置。
+	ServiceConfig map[string]any `json:"serviceConfig,omitempty"`
+	// 插件客户端。
+	Client *pluginsdk.Client `json:"-"`
+}
+
+type DeployerProvider struct {
+	config      *DeployerConfig
+	logger      *slog.Logger
+	sslUploader uploader.Uploader
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	if config.Client == nil {
+		return nil, errors.New("plugin client is not available")
+	}
+
+	info := config.Client.Info()
+	if !info.HasKind(pluginsdk.KindDeployer) && !info.HasKind(pluginsdk.KindUploader) {
+		return nil, fmt.Errorf("plugin '%s' implements neither '%s' nor '%s'", config.PluginName, pluginsdk.KindDeployer, pluginsdk.KindUploader)
+	}
+
+	// 仅实现了上传器的插件，以上传证书作为部署
+	var sslUploader uploader.Uploader
+	if !info.HasKind(pluginsdk.KindDeployer) {
+		uploader, err := uploadersp.NewUploader(&uploadersp.UploaderConfig{
+			PluginName:    config.PluginName,
+			AccessConfig:  config.AccessConfig,
+			ServiceConfig: config.ServiceConfig,
+			Client:        config.Client,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ssl uploader: %w", err)
+		}
+
+		sslUploader = uploader
+	}
+
+	return &DeployerProvider{
+		config:      config,
+		logger:      slog.Default(),
+		sslUploader: sslUploader,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	if d.sslUploader != nil {
+		d.sslUploader.WithLogger(logger)
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.sslUploader != nil {
+		upres, err := d.sslUploader.Upload(ctx, certPEM, privkeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+		} else {
+			d.logger.Info("ssl certificate uploaded", slog.Any("result", upres))
+		}
+
+		extendedData := map[string]any{
+			"certId":   upres.CertId,
+			"certName": upres.CertName,
+		}
+		for k, v := range upres.ExtendedData {
+			extendedData[k] = v
+		}
+		return &deployer.DeployResult{
+			ExtendedData: extendedData,
+		}, nil
+	}
+
+	accessConfig, err := json.Marshal(d.config.AccessConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin access config: %w", err)
+	}
+
+	serviceConfig, err := json.Marshal(d.config.ServiceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin service config: %w", err)
+	}
+
+	deployResp, err := d.config.Client.Deploy(ctx, &pluginsdk.DeployRequest{
+		AccessConfig:  accessConfig,
+		ServiceConfig: serviceConfig,
+		Certificate:   certPEM,
+		PrivateKey:    privkeyPEM,
+	})
+	d.logger.Debug("plugin request 'Deploy'", slog.String("plugin", d.config.PluginName), slog.Any("response", deployResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute plugin request 'Deploy': %w", err)
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: deployResp.ExtendedData,
+	}, nil
+}
@@ -0,0 +1,12 @@
+package s3
+
+type ServerSideEncryptionType string
+
+const (
+	// 服务端加密：不加密（或沿用存储桶默认加密设置）。
+	SSE_NONE = ServerSideEncryptionType("")
+	// 服务端加密：SSE-S3。
+	SSE_AES256 = ServerSideEncryptionType("AES256")
+	// 服务端加密：SSE-KMS。
+	SSE_KMS = ServerSideEncryptionType("aws:kms")
+)
@@ -0,0 +1,228 @@
+package s3
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+	s3sdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/s3"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// S3 服务端点。
+	// 零值时默认使用 AWS S3 端点。
+	Endpoint string `json:"endpoint,omitempty"`
+	// 区域。
+	// 零值时默认值 "us-east-1"。
+	Region string `json:"region,omitempty"`
+	// AccessKeyId。
+	AccessKeyId string `json:"accessKeyId"`
+	// SecretAccessKey。
+	SecretAccessKey string `json:"secretAccessKey"`
+	// 是否使用路径风格访问（即 "https://endpoint/bucket/key"）。
+	// MinIO、Ceph RGW 等自建服务通常需要开启。
+	UsePathStyle bool `json:"usePathStyle,omitempty"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 存储桶名。
+	Bucket string `json:"bucket"`
+	// 输出证书格式。
+	OutputFormat local.OutputFormatType `json:"outputFormat,omitempty"`
+	// 输出证书对象键。
+	// 支持模板变量："${DOMAIN}" 为证书主域名（通配符 "*" 替换为 "_"）；"${DOMAINS}" 为全部域名，以 "," 分隔。
+	OutputCertObjectKey string `json:"outputCertObjectKey"`
+	// 输出服务器证书对象键。
+	// 选填。支持模板变量，同 OutputCertObjectKey。
+	OutputServerCertObjectKey string `json:"outputServerCertObjectKey,omitempty"`
+	// 输出中间证书对象键。
+	// 选填。支持模板变量，同 OutputCertObjectKey。
+	OutputIntermediaCertObjectKey string `json:"outputIntermediaCertObjectKey,omitempty"`
+	// 输出私钥对象键。
+	// 支持模板变量，同 OutputCertObjectKey。
+	OutputKeyObjectKey string `json:"outputKeyObjectKey,omitempty"`
+	// 输出根证书对象键。
+	// 选填。证书格式为 PEM 或 PEM_COMBINED 时有效。支持模板变量，同 OutputCertObjectKey。
+	OutputRootCertObjectKey string `json:"outputRootCertObjectKey,omitempty"`
+	// 证书链与系统信任的根证书中均未找到根证书时，是否根据 AIA 扩展联网下载颁发者证书。
+	// 选填。输出根证书对象键不为空时有效。
+	OutputRootCertFetchIssuer bool `json:"outputRootCertFetchIssuer,omitempty"`
+	// 是否逆序输出证书链，即中间证书在前、服务器证书在后。
+	// 证书格式为 PEM 或 PEM_COMBINED 时有效。
+	PemChainReversed bool `json:"pemChainReversed,omitempty"`
+	// 私钥格式。
+	// 证书格式为 PEM、PEM_COMBINED 或 P7B 时有效。零值时保持原有格式。
+	PemKeyFormat local.PrivateKeyFormatType `json:"pemKeyFormat,omitempty"`
+	// 私钥加密密码。
+	// 私钥格式为 PKCS8_ENCRYPTED 时必填。
+	PemKeyPassword string `json:"pemKeyPassword,omitempty"`
+	// PFX 导出密码。
+	// 证书格式为 PFX 时必填。
+	PfxPassword string `json:"pfxPassword,omitempty"`
+	// JKS 别名。
+	// 证书格式为 JKS 时必填。
+	JksAlias string `json:"jksAlias,omitempty"`
+	// JKS 密钥密码。
+	// 证书格式为 JKS 时必填。
+	JksKeypass string `json:"jksKeypass,omitempty"`
+	// JKS 存储密码。
+	// 证书格式为 JKS 时必填。
+	JksStorepass string `json:"jksStorepass,omitempty"`
+	// 服务端加密方式。
+	// 零值时默认值 [SSE_NONE]。
+	ServerSideEncryption ServerSideEncryptionType `json:"serverSideEncryption,omitempty"`
+	// KMS 密钥 ID。
+	// 选填。服务端加密方式为 [SSE_KMS] 时有效，零值时使用默认的 KMS 密钥。
+	SSEKMSKeyId string `json:"sseKmsKeyId,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *s3sdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.Endpoint, config.Region, config.AccessKeyId, config.SecretAccessKey, config.UsePathStyle, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.Bucket == "" {
+		return nil, errors.New("config `bucket` is required")
+	}
+	if d.config.OutputCertObjectKey == "" {
+		return nil, errors.New("config `outputCertObjectKey` is required")
+	}
+
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 生成输出对象
+	objects, err := d.buildOutputObjects(ctx, certPEM, privkeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 上传对象
+	objectKeys := make([]string, 0, len(objects))
+	for _, object := range objects {
+		objectKey := resolveObjectKey(object.Path, certX509)
+		if objectKey == "" {
+			return nil, fmt.Errorf("object key resolved from '%s' is empty", object.Path)
+		}
+
+		// 上传对象
+		// REF: https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObject.html
+		putObjectReq := &s3sdk.PutObjectRequest{
+			Bucket:               d.config.Bucket,
+			Key:                  objectKey,
+			Body:                 object.Data,
+			ContentType:          object.ContentType,
+			ServerSideEncryption: string(d.config.ServerSideEncryption),
+		}
+		if d.config.ServerSideEncryption == SSE_KMS {
+			putObjectReq.SSEKMSKeyId = d.config.SSEKMSKeyId
+		}
+		putObjectResp, err := d.sdkClient.PutObject(ctx, putObjectReq)
+		d.logger.Debug("sdk request 's3.PutObject'", slog.String("bucket", putObjectReq.Bucket), slog.String("key", putObjectReq.Key), slog.Any("response", putObjectResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 's3.PutObject': %w", err)
+		}
+
+		objectKeys = append(objectKeys, objectKey)
+		d.logger.Info("ssl certificate object uploaded", slog.String("bucket", d.config.Bucket), slog.String("key", objectKey))
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"objectKeys": objectKeys,
+		},
+	}, nil
+}
+
+func (d *DeployerProvider) buildOutputObjects(ctx context.Context, certPEM string, privkeyPEM string) ([]local.OutputFile, error) {
+	// 对象键作为输出文件路径，上传前再解析模板变量
+	objects, err := local.BuildOutputFiles(ctx, certPEM, privkeyPEM, &local.OutputOptions{
+		Format:              d.config.OutputFormat,
+		CertPath:            d.config.OutputCertObjectKey,
+		ServerCertPath:      d.config.OutputServerCertObjectKey,
+		IntermediaCertPath:  d.config.OutputIntermediaCertObjectKey,
+		RootCertPath:        d.config.OutputRootCertObjectKey,
+		RootCertFetchIssuer: d.config.OutputRootCertFetchIssuer,
+		KeyPath:             d.config.OutputKeyObjectKey,
+		PemChainReversed:    d.config.PemChainReversed,
+		PemKeyFormat:        d.config.PemKeyFormat,
+		PemKeyPassword:      d.config.PemKeyPassword,
+		PfxPassword:         d.config.PfxPassword,
+		JksAlias:            d.config.JksAlias,
+		JksKeypass:          d.config.JksKeypass,
+		JksStorepass:        d.config.JksStorepass,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.Info(fmt.Sprintf("ssl certificate transformed to %s", strings.ToLower(string(d.config.OutputFormat))))
+
+	return objects, nil
+}
+
+func resolveObjectKey(template string, certX509 *x509.Certificate) string {
+	domain := certX509.Subject.CommonName
+	if domain == "" && len(certX509.DNSNames) > 0 {
+		domain = certX509.DNSNames[0]
+	}
+
+	key := template
+	key = strings.ReplaceAll(key, "${DOMAIN}", strings.ReplaceAll(domain, "*", "_"))
+	key = strings.ReplaceAll(key, "${DOMAINS}", strings.ReplaceAll(strings.Join(certX509.DNSNames, ","), "*", "_"))
+	return strings.TrimLeft(key, "/")
+}
+
+func createSdkClient(endpoint, region, accessKeyId, secretAccessKey string, usePathStyle, skipTlsVerify bool) (*s3sdk.Client, error) {
+	client, err := s3sdk.NewClient(endpoint, region, accessKeyId, secretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	client.WithPathStyle(usePathStyle)
+
+	if skipTlsVerify {
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return client, nil
+}
@@ -0,0 +1,265 @@
+package s3_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/s3"
+)
+
+const (
+	testAccessKeyId     = "test-access-key"
+	testSecretAccessKey = "test-secret-key"
+	testRegion          = "auto"
+	testBucket          = "certs"
+)
+
+type fakeObject struct {
+	Data        []byte
+	ContentType string
+	Headers     http.Header
+}
+
+// 模拟 S3 兼容服务，仅支持路径风格的 PutObject，并校验 SigV4 签名。
+type fakeS3Server struct {
+	*httptest.Server
+
+	mutex   sync.Mutex
+	objects map[string]fakeObject
+}
+
+func newFakeS3Server(t *testing.T) *fakeS3Server {
+	s := &fakeS3Server{objects: make(map[string]fakeObject)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if err := verifySignature(r, body); err != "" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<Error><Code>SignatureDoesNotMatch</Code><Message>" + err + "</Message></Error>"))
+			return
+		}
+
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if bucket != testBucket {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>"))
+			return
+		}
+
+		s.mutex.Lock()
+		s.objects[key] = fakeObject{Data: body, ContentType: r.Header.Get("Content-Type"), Headers: r.Header.Clone()}
+		s.mutex.Unlock()
+
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func verifySignature(r *http.Request, body []byte) string {
+	payloadHash := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(payloadHash[:]) {
+		return "payload hash mismatch"
+	}
+
+	signingTime, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return "invalid x-amz-date"
+	}
+
+	// 仅保留客户端声明的签名头，与服务端校验逻辑一致
+	signedHeaders := make(map[string]bool)
+	if _, after, ok := strings.Cut(r.Header.Get("Authorization"), "SignedHeaders="); ok {
+		before, _, _ := strings.Cut(after, ",")
+		for _, name := range strings.Split(before, ";") {
+			signedHeaders[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+
+	req := r.Clone(context.Background())
+	for name := range req.Header {
+		if !signedHeaders[name] {
+			req.Header.Del(name)
+		}
+	}
+	req.URL.Host = r.Host
+	signer := v4.NewSigner(func(so *v4.SignerOptions) { so.DisableURIPathEscaping = true })
+	credentials := aws.Credentials{AccessKeyID: testAccessKeyId, SecretAccessKey: testSecretAccessKey}
+	if err := signer.SignHTTP(context.Background(), credentials, req, r.Header.Get("X-Amz-Content-Sha256"), "s3", testRegion, signingTime); err != nil {
+		return err.Error()
+	}
+
+	if req.Header.Get("Authorization") != r.Header.Get("Authorization") {
+		return "signature mismatch"
+	}
+
+	return ""
+}
+
+func generateCertificateChain(t *testing.T) (string, string) {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "*.example.com"},
+		DNSNames:     []string{"*.example.com", "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})) + string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestDeploy(t *testing.T) {
+	certPEM, privkeyPEM := generateCertificateChain(t)
+
+	t.Run("Deploy_PEM", func(t *testing.T) {
+		server := newFakeS3Server(t)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			Endpoint:                  server.URL,
+			Region:                    testRegion,
+			AccessKeyId:               testAccessKeyId,
+			SecretAccessKey:           testSecretAccessKey,
+			UsePathStyle:              true,
+			Bucket:                    testBucket,
+			OutputFormat:              local.OUTPUT_FORMAT_PEM,
+			OutputCertObjectKey:       "/tls/${DOMAIN}/fullchain.pem",
+			OutputServerCertObjectKey: "tls/${DOMAIN}/cert.pem",
+			OutputKeyObjectKey:        "tls/${DOMAIN}/privkey.pem",
+			ServerSideEncryption:      provider.SSE_KMS,
+			SSEKMSKeyId:               "test-kms-key",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		res, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		expectedKeys := []string{"tls/_.example.com/fullchain.pem", "tls/_.example.com/cert.pem", "tls/_.example.com/privkey.pem"}
+		if strings.Join(res.ExtendedData["objectKeys"].([]string), "|") != strings.Join(expectedKeys, "|") {
+			t.Errorf("unexpected object keys: %v", res.ExtendedData["objectKeys"])
+		}
+		for _, key := range expectedKeys {
+			object, ok := server.objects[key]
+			if !ok {
+				t.Fatalf("object '%s' not uploaded", key)
+			}
+			if object.ContentType != "application/x-pem-file" {
+				t.Errorf("unexpected content type of '%s': %s", key, object.ContentType)
+			}
+			if object.Headers.Get("X-Amz-Server-Side-Encryption") != "aws:kms" || object.Headers.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id") != "test-kms-key" {
+				t.Errorf("unexpected sse headers of '%s': %v", key, object.Headers)
+			}
+		}
+		if string(server.objects["tls/_.example.com/fullchain.pem"].Data) != certPEM {
+			t.Errorf("unexpected fullchain content")
+		}
+		if string(server.objects["tls/_.example.com/privkey.pem"].Data) != privkeyPEM {
+			t.Errorf("unexpected private key content")
+		}
+	})
+
+	t.Run("Deploy_PFX", func(t *testing.T) {
+		server := newFakeS3Server(t)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			Endpoint:             server.URL,
+			Region:               testRegion,
+			AccessKeyId:          testAccessKeyId,
+			SecretAccessKey:      testSecretAccessKey,
+			UsePathStyle:         true,
+			Bucket:               testBucket,
+			OutputFormat:         local.OUTPUT_FORMAT_PFX,
+			OutputCertObjectKey:  "${DOMAINS}.pfx",
+			PfxPassword:          "password",
+			ServerSideEncryption: provider.SSE_AES256,
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		object, ok := server.objects["_.example.com,example.com.pfx"]
+		if !ok {
+			t.Fatalf("object not uploaded, got: %v", server.objects)
+		}
+		if object.ContentType != "application/x-pkcs12" || object.Headers.Get("X-Amz-Server-Side-Encryption") != "AES256" {
+			t.Errorf("unexpected object headers: %v", object.Headers)
+		}
+	})
+
+	t.Run("Deploy_InvalidCredentials", func(t *testing.T) {
+		server := newFakeS3Server(t)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			Endpoint:            server.URL,
+			Region:              testRegion,
+			AccessKeyId:         testAccessKeyId,
+			SecretAccessKey:     "wrong-secret-key",
+			UsePathStyle:        true,
+			Bucket:              testBucket,
+			OutputFormat:        local.OUTPUT_FORMAT_PEM_COMBINED,
+			OutputCertObjectKey: "${DOMAIN}.pem",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		_, err = deployer.Deploy(context.Background(), certPEM, privkeyPEM)
+		if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+			t.Fatalf("expected signature error, got: %v", err)
+		}
+	})
+}
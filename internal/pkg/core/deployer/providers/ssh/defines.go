@@ -6,4 +6,53 @@ const (
 	OUTPUT_FORMAT_PEM = OutputFormatType("PEM")
 	OUTPUT_FORMAT_PFX = OutputFormatType("PFX")
 	OUTPUT_FORMAT_JKS = OutputFormatType("JKS")
+	// DER 格式，仅包含服务器证书。
+	OUTPUT_FORMAT_DER = OutputFormatType("DER")
+	// PKCS#7 格式的证书链包（.p7b）。
+	OUTPUT_FORMAT_P7B = OutputFormatType("P7B")
+	// 证书链与私钥合并的单文件 PEM 格式（如 HAProxy 所需格式）。
+	OUTPUT_FORMAT_PEM_COMBINED = OutputFormatType("PEM_COMBINED")
+)
+
+type PrivateKeyFormatType string
+
+const (
+	// 保持原有格式。
+	PRIVATE_KEY_FORMAT_ORIGINAL = PrivateKeyFormatType("")
+	// PKCS#8 格式（即 "PRIVATE KEY"）。
+	PRIVATE_KEY_FORMAT_PKCS8 = PrivateKeyFormatType("PKCS8")
+	// 加密的 PKCS#8 格式（即 "ENCRYPTED PRIVATE KEY"）。
+	PRIVATE_KEY_FORMAT_PKCS8_ENCRYPTED = PrivateKeyFormatType("PKCS8_ENCRYPTED")
+)
+
+type HostKeyVerificationType string
+
+const (
+	// 不校验主机密钥。
+	HOST_KEY_VERIFICATION_NONE = HostKeyVerificationType("none")
+	// 校验主机密钥指纹是否在固定列表中。
+	HOST_KEY_VERIFICATION_FINGERPRINT = HostKeyVerificationType("fingerprint")
+	// 校验主机密钥是否在 known_hosts 中。
+	HOST_KEY_VERIFICATION_KNOWNHOSTS = HostKeyVerificationType("knownhosts")
+	// 首次连接时信任并记录主机密钥，此后按 known_hosts 校验。
+	HOST_KEY_VERIFICATION_TOFU = HostKeyVerificationType("tofu")
+)
+
+type FileAttrs struct {
+	// 文件权限，八进制表示，如 "0600"。
+	// 零值时若文件已存在则保持原有权限，否则默认值 "0644"。
+	Mode string `json:"mode,omitempty"`
+	// 文件所有者的用户名或 UID。
+	// 零值时若文件已存在则尽量保持原有所有者。
+	Owner string `json:"owner,omitempty"`
+	// 文件所属组的组名或 GID。
+	// 零值时若文件已存在则尽量保持原有所属组。
+	Group string `json:"group,omitempty"`
+}
+
+const (
+	// 所有主机均部署成功才视为成功。
+	SUCCESS_THRESHOLD_ALL = "all"
+	// 任意一台主机部署成功即视为成功。
+	SUCCESS_THRESHOLD_ANY = "any"
 )
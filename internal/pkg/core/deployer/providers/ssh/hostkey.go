@@ -0,0 +1,187 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+type hostKeyVerifier struct {
+	mode                HostKeyVerificationType
+	knownHosts          string
+	onKnownHostsUpdated func(knownHosts string) error
+	mutex               sync.Mutex
+}
+
+func newHostKeyVerifier(mode HostKeyVerificationType, knownHosts string, onKnownHostsUpdated func(knownHosts string) error) *hostKeyVerifier {
+	if mode == "" {
+		mode = HOST_KEY_VERIFICATION_TOFU
+	}
+
+	return &hostKeyVerifier{
+		mode:                mode,
+		knownHosts:          knownHosts,
+		onKnownHostsUpdated: onKnownHostsUpdated,
+	}
+}
+
+// 返回指定主机的主机密钥校验回调，以及优先协商的主机密钥算法。
+//
+// 入参：
+//   - addr：主机地址，格式为 "host:port"。
+//   - fingerprints：主机密钥指纹数组，仅在校验方式为 [HOST_KEY_VERIFICATION_FINGERPRINT] 时使用。
+//
+// 出参：
+//   - callback：主机密钥校验回调。
+//   - algorithms：主机密钥算法数组，为空时表示使用默认值。
+//   - err: 错误。
+func (v *hostKeyVerifier) Callback(addr string, fingerprints []string) (_callback ssh.HostKeyCallback, _algorithms []string, _err error) {
+	switch v.mode {
+	case HOST_KEY_VERIFICATION_NONE:
+		return ssh.InsecureIgnoreHostKey(), nil, nil
+
+	case HOST_KEY_VERIFICATION_FINGERPRINT:
+		if len(fingerprints) == 0 {
+			return nil, nil, fmt.Errorf("no host key fingerprints configured for '%s'", addr)
+		}
+
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			for _, fingerprint := range fingerprints {
+				if matchHostKeyFingerprint(fingerprint, key) {
+					return nil
+				}
+			}
+
+			return fmt.Errorf("host key fingerprint mismatch for '%s' (got %s), the remote host may have been impersonated", hostname, ssh.FingerprintSHA256(key))
+		}, nil, nil
+
+	case HOST_KEY_VERIFICATION_KNOWNHOSTS, HOST_KEY_VERIFICATION_TOFU:
+		v.mutex.Lock()
+		knownHostsCallback, err := parseKnownHosts(v.knownHosts)
+		v.mutex.Unlock()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse known_hosts: %w", err)
+		}
+
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			err := knownHostsCallback(hostname, remote, key)
+			if err == nil {
+				return nil
+			}
+
+			var revokedErr *knownhosts.RevokedError
+			if errors.As(err, &revokedErr) {
+				return fmt.Errorf("host key for '%s' has been revoked (got %s)", hostname, ssh.FingerprintSHA256(key))
+			}
+
+			var keyErr *knownhosts.KeyError
+			if errors.As(err, &keyErr) {
+				if len(keyErr.Want) > 0 {
+					wants := make([]string, 0, len(keyErr.Want))
+					for _, want := range keyErr.Want {
+						wants = append(wants, ssh.FingerprintSHA256(want.Key))
+					}
+					return fmt.Errorf("host key for '%s' has changed (got %s, want %s), the remote host may have been impersonated", hostname, ssh.FingerprintSHA256(key), strings.Join(wants, ", "))
+				}
+
+				if v.mode == HOST_KEY_VERIFICATION_TOFU {
+					return v.trust(hostname, key)
+				}
+
+				return fmt.Errorf("host key for '%s' is unknown (got %s)", hostname, ssh.FingerprintSHA256(key))
+			}
+
+			return err
+		}, knownHostKeyAlgorithms(knownHostsCallback, addr), nil
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported host key verification '%s'", v.mode)
+	}
+}
+
+func (v *hostKeyVerifier) trust(hostname string, key ssh.PublicKey) error {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	line := knownhosts.Line([]string{hostname}, key)
+	if v.knownHosts != "" && !strings.HasSuffix(v.knownHosts, "\n") {
+		v.knownHosts += "\n"
+	}
+	v.knownHosts += line + "\n"
+
+	if v.onKnownHostsUpdated != nil {
+		if err := v.onKnownHostsUpdated(v.knownHosts); err != nil {
+			return fmt.Errorf("failed to persist known_hosts: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func parseKnownHosts(knownHosts string) (ssh.HostKeyCallback, error) {
+	// knownhosts 仅支持从文件读取，因此需要借助临时文件
+	tempFile, err := os.CreateTemp("", "certimate-known-hosts-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.WriteString(knownHosts); err != nil {
+		tempFile.Close()
+		return nil, err
+	}
+	if err := tempFile.Close(); err != nil {
+		return nil, err
+	}
+
+	return knownhosts.New(tempFile.Name())
+}
+
+func knownHostKeyAlgorithms(knownHostsCallback ssh.HostKeyCallback, addr string) []string {
+	// 使用一个不可能匹配的公钥进行探测，以获取 known_hosts 中该主机已记录的密钥类型
+	probeKey, err := ssh.NewPublicKey(ed25519.PublicKey(make([]byte, ed25519.PublicKeySize)))
+	if err != nil {
+		return nil
+	}
+
+	var keyErr *knownhosts.KeyError
+	if err := knownHostsCallback(addr, &net.TCPAddr{IP: net.IPv4zero}, probeKey); !errors.As(err, &keyErr) {
+		return nil
+	}
+
+	// 注意：该主机尚无已记录的密钥时必须返回 nil 而非空切片，否则将不会协商任何主机密钥算法
+	var algorithms []string
+	for _, want := range keyErr.Want {
+		switch want.Key.Type() {
+		case ssh.KeyAlgoRSA:
+			algorithms = append(algorithms, ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA)
+		default:
+			algorithms = append(algorithms, want.Key.Type())
+		}
+	}
+	return algorithms
+}
+
+func matchHostKeyFingerprint(fingerprint string, key ssh.PublicKey) bool {
+	fingerprint = strings.TrimSpace(fingerprint)
+	if fingerprint == "" {
+		return false
+	}
+
+	if strings.HasPrefix(fingerprint, "MD5:") {
+		return strings.EqualFold(strings.TrimPrefix(fingerprint, "MD5:"), ssh.FingerprintLegacyMD5(key))
+	}
+
+	// 兼容省略前缀、带填充字符的 Base64 编码
+	if !strings.HasPrefix(fingerprint, "SHA256:") {
+		fingerprint = "SHA256:" + fingerprint
+	}
+	return strings.TrimRight(fingerprint, "=") == ssh.FingerprintSHA256(key)
+}
@@ -0,0 +1,229 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func generateHostKey(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, privkey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(privkey)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	return signer
+}
+
+// 在本地回环地址上完成一次 SSH 握手，返回客户端侧的错误。
+func handshake(t *testing.T, hostKey ssh.Signer, callback ssh.HostKeyCallback, algorithms []string) error {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	defer listener.Close()
+
+	serverConfig := &ssh.ServerConfig{NoClientAuth: true}
+	serverConfig.AddHostKey(hostKey)
+	go func() {
+		serverConn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer serverConn.Close()
+
+		conn, chans, reqs, err := ssh.NewServerConn(serverConn, serverConfig)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		go ssh.DiscardRequests(reqs)
+		for ch := range chans {
+			ch.Reject(ssh.Prohibited, "")
+		}
+	}()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	defer clientConn.Close()
+
+	conn, chans, reqs, err := ssh.NewClientConn(clientConn, "example.com:2222", &ssh.ClientConfig{
+		User:              "root",
+		HostKeyCallback:   callback,
+		HostKeyAlgorithms: algorithms,
+	})
+	if err != nil {
+		return err
+	}
+
+	ssh.NewClient(conn, chans, reqs).Close()
+	return nil
+}
+
+func TestHostKeyVerifierFingerprint(t *testing.T) {
+	hostKey := generateHostKey(t)
+	otherKey := generateHostKey(t)
+	verifier := newHostKeyVerifier(HOST_KEY_VERIFICATION_FINGERPRINT, "", nil)
+
+	t.Run("Match", func(t *testing.T) {
+		fingerprints := []string{
+			ssh.FingerprintSHA256(otherKey.PublicKey()),
+			strings.TrimPrefix(ssh.FingerprintSHA256(hostKey.PublicKey()), "SHA256:") + "=",
+		}
+		callback, algorithms, err := verifier.Callback("example.com:2222", fingerprints)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if err := handshake(t, hostKey, callback, algorithms); err != nil {
+			t.Errorf("expected handshake to succeed, got: %v", err)
+		}
+	})
+
+	t.Run("MatchMD5", func(t *testing.T) {
+		callback, _, err := verifier.Callback("example.com:2222", []string{"MD5:" + strings.ToUpper(ssh.FingerprintLegacyMD5(hostKey.PublicKey()))})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if err := callback("example.com:2222", nil, hostKey.PublicKey()); err != nil {
+			t.Errorf("expected fingerprint to match, got: %v", err)
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		callback, algorithms, err := verifier.Callback("example.com:2222", []string{ssh.FingerprintSHA256(otherKey.PublicKey())})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if err := handshake(t, hostKey, callback, algorithms); err == nil || !strings.Contains(err.Error(), "fingerprint mismatch") {
+			t.Errorf("expected fingerprint mismatch, got: %v", err)
+		}
+	})
+
+	t.Run("NoFingerprints", func(t *testing.T) {
+		if _, _, err := verifier.Callback("example.com:2222", nil); err == nil {
+			t.Error("expected error when no fingerprints configured")
+		}
+	})
+}
+
+func TestHostKeyVerifierKnownHosts(t *testing.T) {
+	hostKey := generateHostKey(t)
+	otherKey := generateHostKey(t)
+
+	t.Run("Known", func(t *testing.T) {
+		knownHosts := knownhosts.Line([]string{"example.com:2222"}, hostKey.PublicKey())
+		verifier := newHostKeyVerifier(HOST_KEY_VERIFICATION_KNOWNHOSTS, knownHosts, nil)
+		callback, algorithms, err := verifier.Callback("example.com:2222", nil)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if len(algorithms) != 1 || algorithms[0] != ssh.KeyAlgoED25519 {
+			t.Errorf("unexpected algorithms: %v", algorithms)
+		}
+		if err := handshake(t, hostKey, callback, algorithms); err != nil {
+			t.Errorf("expected handshake to succeed, got: %v", err)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		knownHosts := knownhosts.Line([]string{"other.example.com"}, otherKey.PublicKey())
+		verifier := newHostKeyVerifier(HOST_KEY_VERIFICATION_KNOWNHOSTS, knownHosts, nil)
+		callback, algorithms, err := verifier.Callback("example.com:2222", nil)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if algorithms != nil {
+			t.Errorf("expected nil algorithms for unknown host, got: %v", algorithms)
+		}
+		if err := handshake(t, hostKey, callback, algorithms); err == nil || !strings.Contains(err.Error(), "is unknown") {
+			t.Errorf("expected unknown host error, got: %v", err)
+		}
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		knownHosts := knownhosts.Line([]string{"example.com:2222"}, otherKey.PublicKey())
+		verifier := newHostKeyVerifier(HOST_KEY_VERIFICATION_KNOWNHOSTS, knownHosts, nil)
+		callback, _, err := verifier.Callback("example.com:2222", nil)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if err := callback("example.com:2222", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 2222}, hostKey.PublicKey()); err == nil || !strings.Contains(err.Error(), "has changed") {
+			t.Errorf("expected changed host key error, got: %v", err)
+		}
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		knownHosts := "@revoked * " + strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey.PublicKey())))
+		verifier := newHostKeyVerifier(HOST_KEY_VERIFICATION_KNOWNHOSTS, knownHosts, nil)
+		callback, _, err := verifier.Callback("example.com:2222", nil)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if err := callback("example.com:2222", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 2222}, hostKey.PublicKey()); err == nil || !strings.Contains(err.Error(), "revoked") {
+			t.Errorf("expected revoked host key error, got: %v", err)
+		}
+	})
+}
+
+func TestHostKeyVerifierTOFU(t *testing.T) {
+	hostKey := generateHostKey(t)
+	otherKey := generateHostKey(t)
+
+	persisted := ""
+	verifier := newHostKeyVerifier("", "", func(knownHosts string) error {
+		persisted = knownHosts
+		return nil
+	})
+	if verifier.mode != HOST_KEY_VERIFICATION_TOFU {
+		t.Fatalf("expected default mode to be tofu, got: %s", verifier.mode)
+	}
+
+	t.Run("FirstConnection", func(t *testing.T) {
+		callback, algorithms, err := verifier.Callback("example.com:2222", nil)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if err := handshake(t, hostKey, callback, algorithms); err != nil {
+			t.Fatalf("expected first connection to succeed, got: %v", err)
+		}
+		if !strings.Contains(persisted, strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey.PublicKey())))) {
+			t.Errorf("expected host key to be persisted, got: %q", persisted)
+		}
+	})
+
+	t.Run("SubsequentConnection", func(t *testing.T) {
+		callback, algorithms, err := verifier.Callback("example.com:2222", nil)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if err := handshake(t, hostKey, callback, algorithms); err != nil {
+			t.Errorf("expected trusted host to succeed, got: %v", err)
+		}
+	})
+
+	t.Run("ChangedHostKey", func(t *testing.T) {
+		callback, _, err := verifier.Callback("example.com:2222", nil)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if err := callback("example.com:2222", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 2222}, otherKey.PublicKey()); err == nil || !strings.Contains(err.Error(), "has changed") {
+			t.Errorf("expected changed host key error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,301 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+)
+
+func TestCheckSuccessThreshold(t *testing.T) {
+	testCases := []struct {
+		name      string
+		threshold string
+		succeeded int
+		total     int
+		expect    bool
+		valid     bool
+	}{
+		{"Default_All", "", 3, 3, true, true},
+		{"Default_Partial", "", 2, 3, false, true},
+		{"All", "ALL", 3, 3, true, true},
+		{"All_Partial", "all", 2, 3, false, true},
+		{"Any", "any", 1, 3, true, true},
+		{"Any_None", "any", 0, 3, false, true},
+		{"Percent_Met", "50%", 2, 4, true, true},
+		{"Percent_NotMet", "50%", 1, 4, false, true},
+		{"Percent_Fraction", "66.7%", 2, 3, false, true},
+		{"Percent_Spaces", " 60 % ", 3, 5, true, true},
+		{"Percent_Zero", "0%", 0, 3, true, true},
+		{"Count_Met", "2", 2, 3, true, true},
+		{"Count_NotMet", "2", 1, 3, false, true},
+		{"Count_Total", "3", 3, 3, true, true},
+		{"Invalid_Percent", "120%", 3, 3, false, false},
+		{"Invalid_NegativePercent", "-1%", 3, 3, false, false},
+		{"Invalid_Count", "0", 3, 3, false, false},
+		{"Invalid_CountExceeded", "4", 3, 3, false, false},
+		{"Invalid_Text", "most", 3, 3, false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := checkSuccessThreshold(tc.threshold, tc.succeeded, tc.total)
+			if tc.valid && err != nil {
+				t.Fatalf("err: %+v", err)
+			} else if !tc.valid && err == nil {
+				t.Fatalf("expected error, got %v", ok)
+			}
+			if ok != tc.expect {
+				t.Errorf("expected %v, got %v", tc.expect, ok)
+			}
+		})
+	}
+}
+
+func TestParseHostEntry(t *testing.T) {
+	testCases := []struct {
+		entry      string
+		expectHost string
+		expectPort int32
+		valid      bool
+	}{
+		{"10.0.0.1", "10.0.0.1", 2222, true},
+		{"10.0.0.1:22", "10.0.0.1", 22, true},
+		{"[::1]:2200", "::1", 2200, true},
+		{"[::1]", "::1", 2222, true},
+		{"example.com:0", "", 0, false},
+		{"  ", "", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.entry, func(t *testing.T) {
+			host, port, err := parseHostEntry(tc.entry, 2222)
+			if tc.valid && err != nil {
+				t.Fatalf("err: %+v", err)
+			} else if !tc.valid && err == nil {
+				t.Fatalf("expected error, got %s:%d", host, port)
+			}
+			if host != tc.expectHost || port != tc.expectPort {
+				t.Errorf("expected %s:%d, got %s:%d", tc.expectHost, tc.expectPort, host, port)
+			}
+		})
+	}
+}
+
+func TestDeployToHosts(t *testing.T) {
+	newProvider := func(config *DeployerConfig) *DeployerProvider {
+		provider, _ := NewDeployer(config)
+		provider.WithLogger(slog.New(slog.DiscardHandler))
+		return provider
+	}
+
+	hostsOf := func(t *testing.T, res *deployer.DeployResult) []deployer.DeployHostResult {
+		t.Helper()
+
+		if res == nil {
+			t.Fatal("expected result, got nil")
+		}
+		hosts, ok := res.ExtendedData["hosts"].([]deployer.DeployHostResult)
+		if !ok {
+			t.Fatalf("unexpected hosts: %v", res.ExtendedData["hosts"])
+		}
+		return hosts
+	}
+
+	t.Run("AllSucceeded", func(t *testing.T) {
+		var mtx sync.Mutex
+		deployed := make(map[string]int32)
+		provider := newProvider(&DeployerConfig{
+			SshPort:  2222,
+			SshHosts: []string{"10.0.0.1", "10.0.0.2:22", "10.0.0.3"},
+		})
+
+		res, err := provider.deployToHosts(context.Background(), func(ctx context.Context, logger *slog.Logger, host string, port int32) error {
+			mtx.Lock()
+			defer mtx.Unlock()
+			deployed[host] = port
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if len(deployed) != 3 || deployed["10.0.0.1"] != 2222 || deployed["10.0.0.2"] != 22 {
+			t.Errorf("unexpected deployed hosts: %v", deployed)
+		}
+		for _, host := range hostsOf(t, res) {
+			if !host.Succeeded || host.Skipped || host.Error != "" {
+				t.Errorf("unexpected host result: %+v", host)
+			}
+		}
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		deployFn := func(ctx context.Context, logger *slog.Logger, host string, port int32) error {
+			if host == "10.0.0.2" {
+				return errors.New("connection refused")
+			}
+			return nil
+		}
+
+		testCases := []struct {
+			threshold string
+			expectErr bool
+		}{
+			{"", true},
+			{"all", true},
+			{"any", false},
+			{"50%", false},
+			{"80%", true},
+			{"2", false},
+			{"3", true},
+		}
+
+		for _, tc := range testCases {
+			provider := newProvider(&DeployerConfig{
+				SshHosts:         []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+				SuccessThreshold: tc.threshold,
+			})
+
+			res, err := provider.deployToHosts(context.Background(), deployFn)
+			if tc.expectErr && err == nil {
+				t.Errorf("threshold %q: expected error, got nil", tc.threshold)
+			} else if !tc.expectErr && err != nil {
+				t.Errorf("threshold %q: unexpected error: %v", tc.threshold, err)
+			}
+
+			// 无论成功与否都应返回各主机的部署结果
+			hosts := hostsOf(t, res)
+			if len(hosts) != 3 || !hosts[0].Succeeded || hosts[1].Succeeded || !hosts[2].Succeeded {
+				t.Errorf("threshold %q: unexpected host results: %+v", tc.threshold, hosts)
+			}
+			if !strings.Contains(hosts[1].Error, "connection refused") {
+				t.Errorf("threshold %q: expected host error to be recorded, got: %q", tc.threshold, hosts[1].Error)
+			}
+		}
+	})
+
+	t.Run("SkipSucceededHosts", func(t *testing.T) {
+		var calls atomic.Int32
+		provider := newProvider(&DeployerConfig{
+			SshHosts:       []string{"10.0.0.1", "10.0.0.2"},
+			SucceededHosts: []string{"10.0.0.1"},
+		})
+
+		res, err := provider.deployToHosts(context.Background(), func(ctx context.Context, logger *slog.Logger, host string, port int32) error {
+			calls.Add(1)
+			if host == "10.0.0.1" {
+				t.Errorf("host %s should have been skipped", host)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		hosts := hostsOf(t, res)
+		if calls.Load() != 1 {
+			t.Errorf("expected 1 deployment, got %d", calls.Load())
+		}
+		if !hosts[0].Succeeded || !hosts[0].Skipped || !hosts[1].Succeeded || hosts[1].Skipped {
+			t.Errorf("unexpected host results: %+v", hosts)
+		}
+	})
+
+	t.Run("InvalidHostEntry", func(t *testing.T) {
+		provider := newProvider(&DeployerConfig{
+			SshHosts:         []string{"10.0.0.1", "10.0.0.2:99999"},
+			SuccessThreshold: "any",
+		})
+
+		res, err := provider.deployToHosts(context.Background(), func(ctx context.Context, logger *slog.Logger, host string, port int32) error {
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		hosts := hostsOf(t, res)
+		if hosts[1].Succeeded || !strings.Contains(hosts[1].Error, "invalid port") {
+			t.Errorf("unexpected host result: %+v", hosts[1])
+		}
+	})
+
+	t.Run("InvalidThreshold", func(t *testing.T) {
+		provider := newProvider(&DeployerConfig{
+			SshHosts:         []string{"10.0.0.1"},
+			SuccessThreshold: "most",
+		})
+
+		_, err := provider.deployToHosts(context.Background(), func(ctx context.Context, logger *slog.Logger, host string, port int32) error {
+			t.Error("should not deploy when the threshold is invalid")
+			return nil
+		})
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("Parallelism", func(t *testing.T) {
+		var running, peak atomic.Int32
+		provider := newProvider(&DeployerConfig{
+			SshHosts:    []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"},
+			Parallelism: 2,
+		})
+
+		_, err := provider.deployToHosts(context.Background(), func(ctx context.Context, logger *slog.Logger, host string, port int32) error {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				prev := peak.Load()
+				if current <= prev || peak.CompareAndSwap(prev, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if peak.Load() != 2 {
+			t.Errorf("expected peak parallelism 2, got %d", peak.Load())
+		}
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		provider := newProvider(&DeployerConfig{
+			SshHosts:         []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+			SuccessThreshold: "any",
+		})
+
+		res, err := provider.deployToHosts(ctx, func(ctx context.Context, logger *slog.Logger, host string, port int32) error {
+			// 首台主机部署完成后取消，剩余主机不应再部署
+			cancel()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		succeeded := 0
+		for _, host := range hostsOf(t, res) {
+			if host.Succeeded {
+				succeeded++
+			} else if !strings.Contains(host.Error, context.Canceled.Error()) {
+				t.Errorf("expected canceled error, got: %q", host.Error)
+			}
+		}
+		if succeeded != 1 {
+			t.Errorf("expected 1 host to be deployed, got %d", succeeded)
+		}
+	})
+}
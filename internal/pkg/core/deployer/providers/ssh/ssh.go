@@ -3,20 +3,27 @@ package ssh
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/sftp"
 	"github.com/povsister/scp"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 
 	"github.com/usual2970/certimate/internal/pkg/core/deployer"
-	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+	"github.com/usual2970/certimate/internal/pkg/core/deployer/providers/local"
+	fileutil "github.com/usual2970/certimate/internal/pkg/utils/file"
 )
 
 type JumpServerConfig struct {
@@ -27,7 +34,7 @@ type JumpServerConfig struct {
 	// 零值时默认值 22。
 	SshPort int32 `json:"sshPort,omitempty"`
 	// SSH 认证方式。
-	// 可取值 "none"、"password"、"key"。
+	// 可取值 "none"、"password"、"key"、"agent"。
 	// 零值时根据有无密码或私钥字段决定。
 	SshAuthMethod string `json:"sshAuthMethod,omitempty"`
 	// SSH 登录用户名。
@@ -39,6 +46,15 @@ type JumpServerConfig struct {
 	SshKey string `json:"sshKey,omitempty"`
 	// SSH 登录私钥口令。
 	SshKeyPassphrase string `json:"sshKeyPassphrase,omitempty"`
+	// SSH 登录私钥对应的 OpenSSH 证书。
+	// 选填。
+	SshKeyCertificate string `json:"sshKeyCertificate,omitempty"`
+	// SSH Agent 套接字路径。
+	// 零值时默认值为环境变量 SSH_AUTH_SOCK 的值。
+	SshAgentSocket string `json:"sshAgentSocket,omitempty"`
+	// SSH 主机密钥指纹数组。
+	// 主机密钥校验方式为 [HOST_KEY_VERIFICATION_FINGERPRINT] 时必填。
+	SshHostKeyFingerprints []string `json:"sshHostKeyFingerprints,omitempty"`
 }
 
 type DeployerConfig struct {
@@ -48,8 +64,20 @@ type DeployerConfig struct {
 	// SSH 端口。
 	// 零值时默认值 22。
 	SshPort int32 `json:"sshPort,omitempty"`
+	// SSH 主机数组，每项格式为 "host" 或 "host:port"，未指定端口时使用 SshPort。
+	// 非空时将忽略 SshHost，并行部署到所有主机。
+	SshHosts []string `json:"sshHosts,omitempty"`
+	// 部署到多台主机时的最大并行数。
+	// 零值时默认值 1。
+	Parallelism int `json:"parallelism,omitempty"`
+	// 部署到多台主机时的成功阈值。
+	// 可取值 "all"、"any"、百分比（如 "80%"）或主机数量（如 "3"）。零值时默认值 "all"。
+	SuccessThreshold string `json:"successThreshold,omitempty"`
+	// 先前已部署成功的主机数组，部署时将跳过这些主机，但计入成功数。
+	// 选填。
+	SucceededHosts []string `json:"succeededHosts,omitempty"`
 	// SSH 认证方式。
-	// 可取值 "none"、"password" 或 "key"。
+	// 可取值 "none"、"password"、"key" 或 "agent"。
 	// 零值时根据有无密码或私钥字段决定。
 	SshAuthMethod string `json:"sshAuthMethod,omitempty"`
 	// SSH 登录用户名。
@@ -61,10 +89,31 @@ type DeployerConfig struct {
 	SshKey string `json:"sshKey,omitempty"`
 	// SSH 登录私钥口令。
 	SshKeyPassphrase string `json:"sshKeyPassphrase,omitempty"`
+	// SSH 登录私钥对应的 OpenSSH 证书。
+	// 选填。
+	SshKeyCertificate string `json:"sshKeyCertificate,omitempty"`
+	// SSH Agent 套接字路径。
+	// 零值时默认值为环境变量 SSH_AUTH_SOCK 的值。
+	SshAgentSocket string `json:"sshAgentSocket,omitempty"`
+	// SSH 主机密钥指纹数组。
+	// 主机密钥校验方式为 [HOST_KEY_VERIFICATION_FINGERPRINT] 时必填。
+	SshHostKeyFingerprints []string `json:"sshHostKeyFingerprints,omitempty"`
+	// SSH 主机密钥校验方式，对目标服务器和所有跳板机生效。
+	// 零值时默认值 [HOST_KEY_VERIFICATION_TOFU]。
+	HostKeyVerification HostKeyVerificationType `json:"hostKeyVerification,omitempty"`
+	// known_hosts 文件内容。
+	// 主机密钥校验方式为 [HOST_KEY_VERIFICATION_KNOWNHOSTS] 或 [HOST_KEY_VERIFICATION_TOFU] 时有效。
+	KnownHosts string `json:"knownHosts,omitempty"`
+	// 首次信任主机密钥后的回调，入参为更新后的 known_hosts 文件内容，可用于持久化。
+	// 主机密钥校验方式为 [HOST_KEY_VERIFICATION_TOFU] 时有效。
+	OnKnownHostsUpdated func(knownHosts string) error `json:"-"`
 	// 跳板机配置数组。
 	JumpServers []JumpServerConfig `json:"jumpServers,omitempty"`
 	// 是否回退使用 SCP。
 	UseSCP bool `json:"useSCP,omitempty"`
+	// 是否为仅 SFTP 模式，适用于无 Shell 权限的 SFTP 服务端（如 chroot 环境）。
+	// 开启后不可设置前置命令、后置命令，不可回退使用 SCP，且文件所有者和所属组须以数字 ID 指定。
+	SftpOnly bool `json:"sftpOnly,omitempty"`
 	// 前置命令。
 	PreCommand string `json:"preCommand,omitempty"`
 	// 后置命令。
@@ -81,6 +130,39 @@ type DeployerConfig struct {
 	OutputIntermediaCertPath string `json:"outputIntermediaCertPath,omitempty"`
 	// 输出私钥文件路径。
 	OutputKeyPath string `json:"outputKeyPath,omitempty"`
+	// 输出证书文件属性。
+	// 选填。证书格式为 PFX 或 JKS 时作用于证书库文件。
+	OutputCertFileAttrs FileAttrs `json:"outputCertFileAttrs,omitempty"`
+	// 输出服务器证书文件属性。
+	// 选填。
+	OutputServerCertFileAttrs FileAttrs `json:"outputServerCertFileAttrs,omitempty"`
+	// 输出中间证书文件属性。
+	// 选填。
+	OutputIntermediaCertFileAttrs FileAttrs `json:"outputIntermediaCertFileAttrs,omitempty"`
+	// 输出私钥文件属性。
+	// 选填。
+	OutputKeyFileAttrs FileAttrs `json:"outputKeyFileAttrs,omitempty"`
+	// 输出根证书文件路径。
+	// 选填。证书格式为 PEM 或 PEM_COMBINED 时有效。
+	OutputRootCertPath string `json:"outputRootCertPath,omitempty"`
+	// 输出根证书文件属性。
+	// 选填。
+	OutputRootCertFileAttrs FileAttrs `json:"outputRootCertFileAttrs,omitempty"`
+	// 证书链与系统信任的根证书中均未找到根证书时，是否根据 AIA 扩展联网下载颁发者证书。
+	// 选填。输出根证书文件路径不为空时有效。
+	OutputRootCertFetchIssuer bool `json:"outputRootCertFetchIssuer,omitempty"`
+	// 是否在覆盖前备份已存在的输出文件。
+	// 备份文件路径为原文件路径追加时间戳后缀，如 "/path/to/cert.pem.20060102150405.bak"。
+	OutputBackup bool `json:"outputBackup,omitempty"`
+	// 是否逆序输出证书链，即中间证书在前、服务器证书在后。
+	// 证书格式为 PEM 或 PEM_COMBINED 时有效。
+	PemChainReversed bool `json:"pemChainReversed,omitempty"`
+	// 私钥格式。
+	// 证书格式为 PEM、PEM_COMBINED 或 P7B 时有效。零值时保持原有格式。
+	PemKeyFormat PrivateKeyFormatType `json:"pemKeyFormat,omitempty"`
+	// 私钥加密密码。
+	// 私钥格式为 PKCS8_ENCRYPTED 时必填。
+	PemKeyPassword string `json:"pemKeyPassword,omitempty"`
 	// PFX 导出密码。
 	// 证书格式为 PFX 时必填。
 	PfxPassword string `json:"pfxPassword,omitempty"`
@@ -123,19 +205,142 @@ func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
 }
 
 func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
-	// 提取服务器证书和中间证书
-	serverCertPEM, intermediaCertPEM, err := certutil.ExtractCertificatesFromPEM(certPEM)
+	if d.config.SftpOnly {
+		if d.config.UseSCP {
+			return nil, errors.New("config `useSCP` is not supported in sftp-only mode")
+		}
+		if d.config.PreCommand != "" || d.config.PostCommand != "" {
+			return nil, errors.New("config `preCommand` and `postCommand` are not supported in sftp-only mode")
+		}
+	}
+
+	// 生成输出文件，各主机共用
+	files, err := local.BuildOutputFiles(ctx, certPEM, privkeyPEM, &local.OutputOptions{
+		Format:              local.OutputFormatType(d.config.OutputFormat),
+		CertPath:            d.config.OutputCertPath,
+		ServerCertPath:      d.config.OutputServerCertPath,
+		IntermediaCertPath:  d.config.OutputIntermediaCertPath,
+		RootCertPath:        d.config.OutputRootCertPath,
+		RootCertFetchIssuer: d.config.OutputRootCertFetchIssuer,
+		KeyPath:             d.config.OutputKeyPath,
+		PemChainReversed:    d.config.PemChainReversed,
+		PemKeyFormat:        local.PrivateKeyFormatType(d.config.PemKeyFormat),
+		PemKeyPassword:      d.config.PemKeyPassword,
+		PfxPassword:         d.config.PfxPassword,
+		JksAlias:            d.config.JksAlias,
+		JksKeypass:          d.config.JksKeypass,
+		JksStorepass:        d.config.JksStorepass,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract certs: %w", err)
+		return nil, err
 	}
 
+	d.logger.Info(fmt.Sprintf("ssl certificate transformed to %s", strings.ToLower(string(d.config.OutputFormat))))
+
+	// 初始化主机密钥校验器，对目标服务器和所有跳板机生效
+	hostKeyVerifier := newHostKeyVerifier(d.config.HostKeyVerification, d.config.KnownHosts, d.config.OnKnownHostsUpdated)
+
+	// 批量部署到多台主机
+	if len(d.config.SshHosts) > 0 {
+		return d.deployToHosts(ctx, func(ctx context.Context, logger *slog.Logger, host string, port int32) error {
+			return d.deployToHost(ctx, logger, hostKeyVerifier, host, port, files)
+		})
+	}
+
+	if err := d.deployToHost(ctx, d.logger, hostKeyVerifier, d.config.SshHost, d.config.SshPort, files); err != nil {
+		return nil, err
+	}
+
+	return &deployer.DeployResult{}, nil
+}
+
+func (d *DeployerProvider) deployToHosts(ctx context.Context, deployFn func(ctx context.Context, logger *slog.Logger, host string, port int32) error) (*deployer.DeployResult, error) {
+	if _, err := checkSuccessThreshold(d.config.SuccessThreshold, 0, len(d.config.SshHosts)); err != nil {
+		return nil, err
+	}
+
+	parallelism := d.config.Parallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	results := make([]deployer.DeployHostResult, len(d.config.SshHosts))
+	semaphore := make(chan struct{}, parallelism)
+	wg := sync.WaitGroup{}
+	for i, hostEntry := range d.config.SshHosts {
+		results[i] = deployer.DeployHostResult{Host: hostEntry}
+
+		// 先前已部署成功的主机直接跳过，但计入成功数
+		if slices.Contains(d.config.SucceededHosts, hostEntry) {
+			results[i].Succeeded = true
+			results[i].Skipped = true
+			d.logger.Info("skip the host, because it has already been deployed", slog.String("host", hostEntry))
+			continue
+		}
+
+		host, port, err := parseHostEntry(hostEntry, d.config.SshPort)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, host string, port int32) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			logger := d.logger.With(slog.String("host", results[i].Host))
+			if err := ctx.Err(); err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+
+			if err := deployFn(ctx, logger, host, port); err != nil {
+				results[i].Error = err.Error()
+				logger.Warn("failed to deploy to the host", slog.String("error", err.Error()))
+				return
+			}
+
+			results[i].Succeeded = true
+			logger.Info("deployed to the host")
+		}(i, host, port)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, result := range results {
+		if result.Succeeded {
+			succeeded++
+		}
+	}
+
+	d.logger.Info(fmt.Sprintf("deployed to %d of %d hosts", succeeded, len(results)))
+
+	res := &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"hosts": results,
+		},
+	}
+
+	if ok, _ := checkSuccessThreshold(d.config.SuccessThreshold, succeeded, len(results)); !ok {
+		return res, fmt.Errorf("only %d of %d hosts deployed successfully, which does not meet the success threshold '%s'", succeeded, len(results), d.config.SuccessThreshold)
+	}
+
+	return res, nil
+}
+
+func (d *DeployerProvider) deployToHost(ctx context.Context, logger *slog.Logger, hostKeyVerifier *hostKeyVerifier, host string, port int32, files []local.OutputFile) error {
+	var err error
+
 	var targetConn net.Conn
 
 	// 连接到跳板机
 	if len(d.config.JumpServers) > 0 {
 		var jumpClient *ssh.Client
 		for i, jumpServerConf := range d.config.JumpServers {
-			d.logger.Info(fmt.Sprintf("connecting to jump server [%d]", i+1), slog.String("host", jumpServerConf.SshHost))
+			logger.Info(fmt.Sprintf("connecting to jump server [%d]", i+1), slog.String("host", jumpServerConf.SshHost))
 
 			var jumpConn net.Conn
 			// 第一个连接是主机发起，后续通过跳板机发起
@@ -145,7 +350,7 @@ func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPE
 				jumpConn, err = jumpClient.DialContext(ctx, "tcp", net.JoinHostPort(jumpServerConf.SshHost, strconv.Itoa(int(jumpServerConf.SshPort))))
 			}
 			if err != nil {
-				return nil, fmt.Errorf("failed to connect to jump server [%d]: %w", i+1, err)
+				return fmt.Errorf("failed to connect to jump server [%d]: %w", i+1, err)
 			}
 			defer jumpConn.Close()
 
@@ -158,26 +363,30 @@ func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPE
 				jumpServerConf.SshPassword,
 				jumpServerConf.SshKey,
 				jumpServerConf.SshKeyPassphrase,
+				jumpServerConf.SshKeyCertificate,
+				jumpServerConf.SshAgentSocket,
+				jumpServerConf.SshHostKeyFingerprints,
+				hostKeyVerifier,
 			)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create jump server ssh client[%d]: %w", i+1, err)
+				return fmt.Errorf("failed to create jump server ssh client[%d]: %w", i+1, err)
 			}
 			defer newClient.Close()
 
 			jumpClient = newClient
-			d.logger.Info(fmt.Sprintf("jump server connected [%d]", i+1), slog.String("host", jumpServerConf.SshHost))
+			logger.Info(fmt.Sprintf("jump server connected [%d]", i+1), slog.String("host", jumpServerConf.SshHost))
 		}
 
 		// 通过跳板机发起 TCP 连接到目标服务器
-		targetConn, err = jumpClient.DialContext(ctx, "tcp", net.JoinHostPort(d.config.SshHost, strconv.Itoa(int(d.config.SshPort))))
+		targetConn, err = jumpClient.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(int(port))))
 		if err != nil {
-			return nil, fmt.Errorf("failed to connect to target server: %w", err)
+			return fmt.Errorf("failed to connect to target server: %w", err)
 		}
 	} else {
 		// 直接发起 TCP 连接到目标服务器
-		targetConn, err = net.Dial("tcp", net.JoinHostPort(d.config.SshHost, strconv.Itoa(int(d.config.SshPort))))
+		targetConn, err = net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(port))))
 		if err != nil {
-			return nil, fmt.Errorf("failed to connect to target server: %w", err)
+			return fmt.Errorf("failed to connect to target server: %w", err)
 		}
 	}
 	defer targetConn.Close()
@@ -185,98 +394,106 @@ func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPE
 	// 通过已有的连接创建目标服务器 SSH 客户端
 	client, err := createSshClient(
 		targetConn,
-		d.config.SshHost,
-		d.config.SshPort,
+		host,
+		port,
 		d.config.SshAuthMethod,
 		d.config.SshUsername,
 		d.config.SshPassword,
 		d.config.SshKey,
 		d.config.SshKeyPassphrase,
+		d.config.SshKeyCertificate,
+		d.config.SshAgentSocket,
+		d.config.SshHostKeyFingerprints,
+		hostKeyVerifier,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create ssh client: %w", err)
+		return fmt.Errorf("failed to create ssh client: %w", err)
 	}
 	defer client.Close()
 
-	d.logger.Info("ssh connected")
+	logger.Info("ssh connected")
 
 	// 执行前置命令
 	if d.config.PreCommand != "" {
 		stdout, stderr, err := execSshCommand(client, d.config.PreCommand)
-		d.logger.Debug("run pre-command", slog.String("stdout", stdout), slog.String("stderr", stderr))
+		logger.Debug("run pre-command", slog.String("stdout", stdout), slog.String("stderr", stderr))
 		if err != nil {
-			return nil, fmt.Errorf("failed to execute pre-command (stdout: %s, stderr: %s): %w ", stdout, stderr, err)
+			return fmt.Errorf("failed to execute pre-command (stdout: %s, stderr: %s): %w ", stdout, stderr, err)
 		}
 	}
 
 	// 上传证书和私钥文件
-	switch d.config.OutputFormat {
-	case OUTPUT_FORMAT_PEM:
-		if err := writeFileString(client, d.config.UseSCP, d.config.OutputCertPath, certPEM); err != nil {
-			return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+	for _, file := range files {
+		if err := d.writeFile(client, file.Path, file.Data, d.fileAttrsOf(file.Kind)); err != nil {
+			return fmt.Errorf("failed to upload %s file '%s': %w", file.Kind, file.Path, err)
 		}
-		d.logger.Info("ssl certificate file uploaded", slog.String("path", d.config.OutputCertPath))
+		logger.Info("ssl certificate file uploaded", slog.String("kind", string(file.Kind)), slog.String("path", file.Path))
+	}
 
-		if d.config.OutputServerCertPath != "" {
-			if err := writeFileString(client, d.config.UseSCP, d.config.OutputServerCertPath, serverCertPEM); err != nil {
-				return nil, fmt.Errorf("failed to save server certificate file: %w", err)
-			}
-			d.logger.Info("ssl server certificate file uploaded", slog.String("path", d.config.OutputServerCertPath))
+	// 执行后置命令
+	if d.config.PostCommand != "" {
+		stdout, stderr, err := execSshCommand(client, d.config.PostCommand)
+		logger.Debug("run post-command", slog.String("stdout", stdout), slog.String("stderr", stderr))
+		if err != nil {
+			return fmt.Errorf("failed to execute post-command (stdout: %s, stderr: %s): %w ", stdout, stderr, err)
 		}
+	}
 
-		if d.config.OutputIntermediaCertPath != "" {
-			if err := writeFileString(client, d.config.UseSCP, d.config.OutputIntermediaCertPath, intermediaCertPEM); err != nil {
-				return nil, fmt.Errorf("failed to save intermedia certificate file: %w", err)
-			}
-			d.logger.Info("ssl intermedia certificate file uploaded", slog.String("path", d.config.OutputIntermediaCertPath))
-		}
+	return nil
+}
 
-		if err := writeFileString(client, d.config.UseSCP, d.config.OutputKeyPath, privkeyPEM); err != nil {
-			return nil, fmt.Errorf("failed to upload private key file: %w", err)
-		}
-		d.logger.Info("ssl private key file uploaded", slog.String("path", d.config.OutputKeyPath))
+func parseHostEntry(entry string, defaultPort int32) (string, int32, error) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return "", 0, errors.New("host is empty")
+	}
 
-	case OUTPUT_FORMAT_PFX:
-		pfxData, err := certutil.TransformCertificateFromPEMToPFX(certPEM, privkeyPEM, d.config.PfxPassword)
-		if err != nil {
-			return nil, fmt.Errorf("failed to transform certificate to PFX: %w", err)
+	host, portStr, err := net.SplitHostPort(entry)
+	if err != nil {
+		// 未指定端口
+		port := defaultPort
+		if port == 0 {
+			port = 22
 		}
-		d.logger.Info("ssl certificate transformed to pfx")
+		return strings.Trim(entry, "[]"), port, nil
+	}
 
-		if err := writeFile(client, d.config.UseSCP, d.config.OutputCertPath, pfxData); err != nil {
-			return nil, fmt.Errorf("failed to upload certificate file: %w", err)
-		}
-		d.logger.Info("ssl certificate file uploaded", slog.String("path", d.config.OutputCertPath))
+	port, err := strconv.ParseInt(portStr, 10, 32)
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port in host '%s'", entry)
+	}
 
-	case OUTPUT_FORMAT_JKS:
-		jksData, err := certutil.TransformCertificateFromPEMToJKS(certPEM, privkeyPEM, d.config.JksAlias, d.config.JksKeypass, d.config.JksStorepass)
-		if err != nil {
-			return nil, fmt.Errorf("failed to transform certificate to JKS: %w", err)
-		}
-		d.logger.Info("ssl certificate transformed to jks")
+	return host, int32(port), nil
+}
+
+func checkSuccessThreshold(threshold string, succeeded, total int) (bool, error) {
+	threshold = strings.ToLower(strings.TrimSpace(threshold))
+	switch {
+	case threshold == "" || threshold == SUCCESS_THRESHOLD_ALL:
+		return succeeded >= total, nil
+
+	case threshold == SUCCESS_THRESHOLD_ANY:
+		return succeeded > 0, nil
 
-		if err := writeFile(client, d.config.UseSCP, d.config.OutputCertPath, jksData); err != nil {
-			return nil, fmt.Errorf("failed to upload certificate file: %w", err)
+	case strings.HasSuffix(threshold, "%"):
+		percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(threshold, "%")), 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return false, fmt.Errorf("invalid success threshold '%s'", threshold)
 		}
-		d.logger.Info("ssl certificate file uploaded", slog.String("path", d.config.OutputCertPath))
+		return float64(succeeded)*100 >= percent*float64(total), nil
 
 	default:
-		return nil, fmt.Errorf("unsupported output format '%s'", d.config.OutputFormat)
-	}
-
-	// 执行后置命令
-	if d.config.PostCommand != "" {
-		stdout, stderr, err := execSshCommand(client, d.config.PostCommand)
-		d.logger.Debug("run post-command", slog.String("stdout", stdout), slog.String("stderr", stderr))
-		if err != nil {
-			return nil, fmt.Errorf("failed to execute post-command (stdout: %s, stderr: %s): %w ", stdout, stderr, err)
+		count, err := strconv.Atoi(threshold)
+		if err != nil || count <= 0 {
+			return false, fmt.Errorf("invalid success threshold '%s'", threshold)
+		} else if count > total {
+			return false, fmt.Errorf("success threshold '%s' exceeds the number of hosts %d", threshold, total)
 		}
+		return succeeded >= count, nil
 	}
-
-	return &deployer.DeployResult{}, nil
 }
 
-func createSshClient(conn net.Conn, host string, port int32, authMethod string, username, password, key, keyPassphrase string) (*ssh.Client, error) {
+func createSshClient(conn net.Conn, host string, port int32, authMethod string, username, password, key, keyPassphrase, keyCertificate, agentSocket string, hostKeyFingerprints []string, hostKeyVerifier *hostKeyVerifier) (*ssh.Client, error) {
 	if host == "" {
 		host = "localhost"
 	}
@@ -292,6 +509,7 @@ func createSshClient(conn net.Conn, host string, port int32, authMethod string,
 	const AUTH_METHOD_NONE = "none"
 	const AUTH_METHOD_PASSWORD = "password"
 	const AUTH_METHOD_KEY = "key"
+	const AUTH_METHOD_AGENT = "agent"
 	if authMethod == "" {
 		if key != "" {
 			authMethod = AUTH_METHOD_KEY
@@ -334,18 +552,60 @@ func createSshClient(conn net.Conn, host string, port int32, authMethod string,
 				return nil, err
 			}
 
+			if keyCertificate != "" {
+				pubkey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(keyCertificate))
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse ssh key certificate: %w", err)
+				}
+
+				cert, ok := pubkey.(*ssh.Certificate)
+				if !ok {
+					return nil, fmt.Errorf("ssh key certificate is not an openssh certificate")
+				}
+
+				signer, err = ssh.NewCertSigner(cert, signer)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create ssh certificate signer: %w", err)
+				}
+			}
+
 			authentications = append(authentications, ssh.PublicKeys(signer))
 		}
 
+	case AUTH_METHOD_AGENT:
+		{
+			if agentSocket == "" {
+				agentSocket = os.Getenv("SSH_AUTH_SOCK")
+			}
+			if agentSocket == "" {
+				return nil, fmt.Errorf("ssh agent socket is not set")
+			}
+
+			// 握手完成后即可关闭与 SSH Agent 的连接
+			agentConn, err := net.Dial("unix", agentSocket)
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect to ssh agent: %w", err)
+			}
+			defer agentConn.Close()
+
+			authentications = append(authentications, ssh.PublicKeysCallback(agent.NewClient(agentConn).Signers))
+		}
+
 	default:
 		return nil, fmt.Errorf("unsupported auth method '%s'", authMethod)
 	}
 
 	addr := net.JoinHostPort(host, strconv.Itoa(int(port)))
+	hostKeyCallback, hostKeyAlgorithms, err := hostKeyVerifier.Callback(addr, hostKeyFingerprints)
+	if err != nil {
+		return nil, err
+	}
+
 	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, &ssh.ClientConfig{
-		User:            username,
-		Auth:            authentications,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		User:              username,
+		Auth:              authentications,
+		HostKeyCallback:   hostKeyCallback,
+		HostKeyAlgorithms: hostKeyAlgorithms,
 	})
 	if err != nil {
 		return nil, err
@@ -373,66 +633,344 @@ func execSshCommand(sshCli *ssh.Client, command string) (string, string, error)
 	return stdoutBuf.String(), stderrBuf.String(), nil
 }
 
-func writeFileString(sshCli *ssh.Client, useSCP bool, path string, content string) error {
-	if useSCP {
-		return writeFileStringWithSCP(sshCli, path, content)
+func (d *DeployerProvider) fileAttrsOf(kind local.OutputFileKind) FileAttrs {
+	switch kind {
+	case local.OUTPUT_FILE_KIND_SERVER_CERT:
+		return d.config.OutputServerCertFileAttrs
+	case local.OUTPUT_FILE_KIND_INTERMEDIA_CERT:
+		return d.config.OutputIntermediaCertFileAttrs
+	case local.OUTPUT_FILE_KIND_ROOT_CERT:
+		return d.config.OutputRootCertFileAttrs
+	case local.OUTPUT_FILE_KIND_KEY:
+		return d.config.OutputKeyFileAttrs
+	default:
+		return d.config.OutputCertFileAttrs
 	}
-
-	return writeFileStringWithSFTP(sshCli, path, content)
 }
 
-func writeFile(sshCli *ssh.Client, useSCP bool, path string, data []byte) error {
-	if useSCP {
-		return writeFileWithSCP(sshCli, path, data)
+func (d *DeployerProvider) writeFile(sshCli *ssh.Client, path string, data []byte, attrs FileAttrs) error {
+	mode, err := fileutil.ParseFileMode(attrs.Mode)
+	if err != nil {
+		return err
+	}
+
+	options := &fileutil.WriteOptions{
+		Backup: d.config.OutputBackup,
+		Mode:   mode,
+		Owner:  attrs.Owner,
+		Group:  attrs.Group,
+	}
+	if d.config.UseSCP {
+		return writeFileWithSCP(sshCli, path, data, options)
 	}
 
-	return writeFileWithSFTP(sshCli, path, data)
-}
+	// 仅 SFTP 模式下无法通过 Shell 命令查询用户或组 ID
+	if d.config.SftpOnly {
+		if _, err := strconv.Atoi(options.Owner); options.Owner != "" && err != nil {
+			return fmt.Errorf("file owner must be a numeric uid in sftp-only mode, got '%s'", options.Owner)
+		}
+		if _, err := strconv.Atoi(options.Group); options.Group != "" && err != nil {
+			return fmt.Errorf("file group must be a numeric gid in sftp-only mode, got '%s'", options.Group)
+		}
+	}
 
-func writeFileStringWithSCP(sshCli *ssh.Client, path string, content string) error {
-	return writeFileWithSCP(sshCli, path, []byte(content))
+	return writeFileWithSFTP(d.logger, sshCli, path, data, options)
 }
 
-func writeFileWithSCP(sshCli *ssh.Client, path string, data []byte) error {
+func writeFileWithSCP(sshCli *ssh.Client, path string, data []byte, options *fileutil.WriteOptions) error {
 	scpCli, err := scp.NewClientFromExistingSSH(sshCli, &scp.ClientOption{})
 	if err != nil {
 		return fmt.Errorf("failed to create scp client: %w", err)
 	}
 
+	mode := options.Mode
+	if mode == 0 {
+		mode = 0o644
+	}
+
+	// 目标文件是符号链接时，写入其最终指向的文件，而不是替换符号链接本身
+	if stdout, _, err := execSshCommand(sshCli, fmt.Sprintf("if [ -L %[1]s ]; then readlink -f %[1]s; fi", shellQuote(path))); err == nil {
+		if target := strings.TrimSpace(stdout); target != "" {
+			path = target
+		}
+	}
+
+	// 先写入同目录下的临时文件
+	tempPath := filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.tmp-%d", filepath.Base(path), time.Now().UnixNano()))
 	reader := bytes.NewReader(data)
-	err = scpCli.CopyToRemote(reader, path, &scp.FileTransferOption{})
+	err = scpCli.CopyToRemote(reader, tempPath, &scp.FileTransferOption{Perm: mode})
 	if err != nil {
 		return fmt.Errorf("failed to write to remote file: %w", err)
 	}
 
-	return nil
-}
+	// SCP 协议不支持文件操作，需借助 Shell 命令完成备份、权限设置和原子替换
+	commands := make([]string, 0)
+	if options.Backup {
+		commands = append(commands, fmt.Sprintf("if [ -f %[1]s ]; then cp -p %[1]s %[2]s; fi", shellQuote(path), shellQuote(fileutil.BackupPath(path, time.Now()))))
+	}
+	if options.Mode == 0 {
+		commands = append(commands, fmt.Sprintf("if [ -f %[1]s ]; then chmod --reference=%[1]s %[2]s 2>/dev/null || true; fi", shellQuote(path), shellQuote(tempPath)))
+	}
+	if options.Owner != "" || options.Group != "" {
+		owner := options.Owner
+		if options.Group != "" {
+			owner += ":" + options.Group
+		}
+		commands = append(commands, fmt.Sprintf("chown %s %s", shellQuote(owner), shellQuote(tempPath)))
+	} else {
+		commands = append(commands, fmt.Sprintf("if [ -f %[1]s ]; then chown --reference=%[1]s %[2]s 2>/dev/null || true; fi", shellQuote(path), shellQuote(tempPath)))
+	}
+	commands = append(commands, fmt.Sprintf("mv -f %s %s", shellQuote(tempPath), shellQuote(path)))
+
+	stdout, stderr, err := execSshCommand(sshCli, strings.Join(commands, " && "))
+	if err != nil {
+		execSshCommand(sshCli, fmt.Sprintf("rm -f %s", shellQuote(tempPath)))
+		return fmt.Errorf("failed to replace remote file (stdout: %s, stderr: %s): %w", stdout, stderr, err)
+	}
 
-func writeFileStringWithSFTP(sshCli *ssh.Client, path string, content string) error {
-	return writeFileWithSFTP(sshCli, path, []byte(content))
+	if options.Backup {
+		stdout, stderr, err := execSshCommand(sshCli, fmt.Sprintf("ls -1a %s", shellQuote(filepath.Dir(path))))
+		if err != nil {
+			return fmt.Errorf("failed to list remote backup files (stdout: %s, stderr: %s): %w", stdout, stderr, err)
+		}
+
+		stales := fileutil.StaleBackups(path, strings.Split(stdout, "\n"), options.BackupLimit)
+		if len(stales) > 0 {
+			quoted := make([]string, 0, len(stales))
+			for _, stale := range stales {
+				quoted = append(quoted, shellQuote(stale))
+			}
+			if stdout, stderr, err := execSshCommand(sshCli, fmt.Sprintf("rm -f %s", strings.Join(quoted, " "))); err != nil {
+				return fmt.Errorf("failed to prune remote backup files (stdout: %s, stderr: %s): %w", stdout, stderr, err)
+			}
+		}
+	}
+
+	return nil
 }
 
-func writeFileWithSFTP(sshCli *ssh.Client, path string, data []byte) error {
+func writeFileWithSFTP(logger *slog.Logger, sshCli *ssh.Client, path string, data []byte, options *fileutil.WriteOptions) error {
 	sftpCli, err := sftp.NewClient(sshCli)
 	if err != nil {
 		return fmt.Errorf("failed to create sftp client: %w", err)
 	}
 	defer sftpCli.Close()
 
+	// 目标文件是符号链接时，写入其最终指向的文件，而不是替换符号链接本身
+	path, err = resolveSymlinkWithSFTP(sftpCli, path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve remote symlink: %w", err)
+	}
+
 	if err := sftpCli.MkdirAll(filepath.Dir(path)); err != nil {
 		return fmt.Errorf("failed to create remote directory: %w", err)
 	}
 
-	file, err := sftpCli.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	mode := options.Mode
+	uid, gid := -1, -1
+
+	fi, err := sftpCli.Stat(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to stat remote file: %w", err)
+	} else if err == nil {
+		if mode == 0 {
+			mode = fi.Mode().Perm()
+		}
+
+		if stat, ok := fi.Sys().(*sftp.FileStat); ok {
+			uid, gid = int(stat.UID), int(stat.GID)
+		}
+
+		if options.Backup {
+			if err := copyFileWithSFTP(sftpCli, path, fileutil.BackupPath(path, time.Now()), fi.Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to backup remote file: %w", err)
+			}
+
+			if err := pruneBackupsWithSFTP(sftpCli, path, options.BackupLimit); err != nil {
+				return fmt.Errorf("failed to prune remote backup files: %w", err)
+			}
+		}
+	}
+	if mode == 0 {
+		mode = 0o644
+	}
+
+	if options.Owner != "" {
+		uid, err = lookupRemoteId(sshCli, "user", options.Owner)
+		if err != nil {
+			return err
+		}
+	}
+	if options.Group != "" {
+		gid, err = lookupRemoteId(sshCli, "group", options.Group)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 先写入同目录下的临时文件，再重命名替换目标文件
+	tempPath := filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.tmp-%d", filepath.Base(path), time.Now().UnixNano()))
+	file, err := sftpCli.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL)
 	if err != nil {
 		return fmt.Errorf("failed to open remote file: %w", err)
 	}
-	defer file.Close()
 
-	_, err = file.Write(data)
-	if err != nil {
+	replaced := false
+	defer func() {
+		if !replaced {
+			sftpCli.Remove(tempPath)
+		}
+	}()
+
+	// 临时文件以服务端默认权限创建，写入内容前先收紧权限，避免私钥在变更所有者前被其他用户读取
+	if err := file.Chmod(0o600); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to change remote file mode: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
 		return fmt.Errorf("failed to write to remote file: %w", err)
 	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close remote file: %w", err)
+	}
+
+	if uid != -1 || gid != -1 {
+		if uid == -1 || gid == -1 {
+			if tfi, err := sftpCli.Stat(tempPath); err == nil {
+				if stat, ok := tfi.Sys().(*sftp.FileStat); ok {
+					if uid == -1 {
+						uid = int(stat.UID)
+					}
+					if gid == -1 {
+						gid = int(stat.GID)
+					}
+				}
+			}
+		}
+
+		// 未显式指定所有者时，保持原有所有者仅为尽力而为，失败时不中断
+		if err := sftpCli.Chown(tempPath, uid, gid); err != nil && (options.Owner != "" || options.Group != "") {
+			return fmt.Errorf("failed to change remote file owner: %w", err)
+		}
+	}
+
+	// 变更所有者后再设置最终权限
+	if err := sftpCli.Chmod(tempPath, mode); err != nil {
+		return fmt.Errorf("failed to change remote file mode: %w", err)
+	}
+
+	if err := sftpCli.PosixRename(tempPath, path); err != nil {
+		// 部分 SFTP 服务端不支持 "posix-rename@openssh.com" 扩展，退化为先删除再重命名
+		// 此时替换不再是原子的，重命名失败将导致目标文件缺失
+		logger.Warn("posix-rename is not supported by the sftp server, falling back to non-atomic replacement", slog.String("path", path), slog.String("error", err.Error()))
+		if err := sftpCli.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to replace remote file: %w", err)
+		}
+		if err := sftpCli.Rename(tempPath, path); err != nil {
+			return fmt.Errorf("failed to replace remote file: %w", err)
+		}
+	}
+
+	replaced = true
+	return nil
+}
+
+func resolveSymlinkWithSFTP(sftpCli *sftp.Client, path string) (string, error) {
+	for i := 0; i < 255; i++ {
+		fi, err := sftpCli.Lstat(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return path, nil
+			}
+			return "", err
+		}
+
+		if fi.Mode()&os.ModeSymlink == 0 {
+			return path, nil
+		}
+
+		target, err := sftpCli.ReadLink(path)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		path = target
+	}
+
+	return "", fmt.Errorf("too many levels of symbolic links: '%s'", path)
+}
+
+func pruneBackupsWithSFTP(sftpCli *sftp.Client, path string, limit int) error {
+	entries, err := sftpCli.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	for _, stale := range fileutil.StaleBackups(path, names, limit) {
+		if err := sftpCli.Remove(stale); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
 
 	return nil
 }
+
+func copyFileWithSFTP(sftpCli *sftp.Client, src string, dst string, perm os.FileMode) error {
+	srcFile, err := sftpCli.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := sftpCli.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	if _, err := io.Copy(dstFile, srcFile); err != nil {
+		return err
+	}
+
+	return sftpCli.Chmod(dst, perm)
+}
+
+func lookupRemoteId(sshCli *ssh.Client, kind string, name string) (int, error) {
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+
+	var command string
+	switch kind {
+	case "user":
+		command = fmt.Sprintf("id -u %s", shellQuote(name))
+	case "group":
+		command = fmt.Sprintf("getent group %s | cut -d: -f3", shellQuote(name))
+	default:
+		return -1, fmt.Errorf("unsupported id kind '%s'", kind)
+	}
+
+	stdout, stderr, err := execSshCommand(sshCli, command)
+	if err != nil {
+		return -1, fmt.Errorf("failed to lookup remote %s '%s' (stderr: %s): %w", kind, name, stderr, err)
+	}
+
+	id, err := strconv.Atoi(strings.TrimSpace(stdout))
+	if err != nil {
+		return -1, fmt.Errorf("failed to lookup remote %s '%s': not found", kind, name)
+	}
+
+	return id, nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,231 @@
+package synologydsm
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	synologysdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/synology"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// Synology DSM 服务地址。
+	ServerUrl string `json:"serverUrl"`
+	// Synology DSM 用户名。
+	// 须为管理员组成员，且未启用两步验证。
+	Username string `json:"username"`
+	// Synology DSM 密码。
+	Password string `json:"password"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 证书 ID。
+	// 选填。非空时替换指定证书；为空时按证书描述查找并替换，未找到时新建。
+	CertificateId string `json:"certificateId,omitempty"`
+	// 证书描述。
+	// 选填。零值时默认值为证书主域名。
+	CertificateDescription string `json:"certificateDescription,omitempty"`
+	// 是否设为默认证书。
+	SetAsDefault bool `json:"setAsDefault,omitempty"`
+	// 需要绑定证书的服务数组，每项为服务的显示名称（如 "DSM Desktop Service"）或服务标识（如 "default"）。
+	// 选填。值为 "*" 时绑定所有服务。
+	BindServices []string `json:"bindServices,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *synologysdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServerUrl, config.Username, config.Password, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 提取服务器证书和中间证书
+	serverCertPEM, intermediaCertPEM, err := certutil.ExtractCertificatesFromPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract certs: %w", err)
+	}
+
+	defer d.sdkClient.Logout()
+
+	certificateDesc := d.config.CertificateDescription
+	if certificateDesc == "" {
+		certificateDesc = certX509.Subject.CommonName
+	}
+
+	// 获取证书列表
+	// REF: SYNO.Core.Certificate.CRT/list
+	listCertificatesResp, err := d.sdkClient.ListCertificates()
+	d.logger.Debug("sdk request 'synology.ListCertificates'", slog.Any("response", listCertificatesResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'synology.ListCertificates': %w", err)
+	}
+
+	// 确定待替换的证书
+	certificateId := d.config.CertificateId
+	if certificateId == "" {
+		for _, certificate := range listCertificatesResp.Certificates {
+			if certificate.Desc == certificateDesc {
+				certificateId = certificate.Id
+				d.logger.Info("found existing certificate", slog.String("certificateId", certificateId), slog.String("desc", certificateDesc))
+				break
+			}
+		}
+	} else {
+		found := false
+		for _, certificate := range listCertificatesResp.Certificates {
+			if certificate.Id == certificateId {
+				certificateDesc = certificate.Desc
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("could not find certificate '%s'", certificateId)
+		}
+	}
+
+	// 导入证书
+	// REF: SYNO.Core.Certificate/import
+	importCertificateReq := &synologysdk.ImportCertificateRequest{
+		Id:          certificateId,
+		Desc:        certificateDesc,
+		AsDefault:   d.config.SetAsDefault,
+		Certificate: serverCertPEM,
+		PrivateKey:  privkeyPEM,
+		InterCert:   intermediaCertPEM,
+	}
+	importCertificateResp, err := d.sdkClient.ImportCertificate(importCertificateReq)
+	d.logger.Debug("sdk request 'synology.ImportCertificate'", slog.String("id", importCertificateReq.Id), slog.String("desc", importCertificateReq.Desc), slog.Any("response", importCertificateResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'synology.ImportCertificate': %w", err)
+	}
+
+	certificateId = importCertificateResp.Id
+	d.logger.Info("ssl certificate imported", slog.String("certificateId", certificateId))
+
+	// 绑定到服务
+	boundServices := make([]string, 0)
+	if len(d.config.BindServices) > 0 {
+		boundServices, err = d.bindServices(certificateId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"certificateId": certificateId,
+			"services":      boundServices,
+		},
+	}, nil
+}
+
+func (d *DeployerProvider) bindServices(certificateId string) ([]string, error) {
+	// 重新获取证书列表，以得到各服务当前所绑定的证书
+	listCertificatesResp, err := d.sdkClient.ListCertificates()
+	d.logger.Debug("sdk request 'synology.ListCertificates'", slog.Any("response", listCertificatesResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'synology.ListCertificates': %w", err)
+	}
+
+	bindAll := slices.Contains(d.config.BindServices, "*")
+	settings := make([]*synologysdk.SetCertificateServiceSetting, 0)
+	boundServices := make([]string, 0)
+	for _, certificate := range listCertificatesResp.Certificates {
+		if certificate.Id == certificateId {
+			continue
+		}
+
+		for _, service := range certificate.Services {
+			if !bindAll && !slices.Contains(d.config.BindServices, service.DisplayName) && !slices.Contains(d.config.BindServices, service.Service) {
+				continue
+			}
+
+			settings = append(settings, &synologysdk.SetCertificateServiceSetting{
+				Service: service,
+				OldId:   certificate.Id,
+				Id:      certificateId,
+			})
+			boundServices = append(boundServices, service.DisplayName)
+		}
+	}
+
+	if len(settings) == 0 {
+		d.logger.Info("no services need to be rebound")
+		return boundServices, nil
+	}
+
+	// 设置服务证书
+	// REF: SYNO.Core.Certificate.Service/set
+	setCertificateServicesReq := &synologysdk.SetCertificateServicesRequest{
+		Settings: settings,
+	}
+	setCertificateServicesResp, err := d.sdkClient.SetCertificateServices(setCertificateServicesReq)
+	d.logger.Debug("sdk request 'synology.SetCertificateServices'", slog.Any("request", setCertificateServicesReq), slog.Any("response", setCertificateServicesResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'synology.SetCertificateServices': %w", err)
+	}
+
+	d.logger.Info("ssl certificate bound to services", slog.Any("services", boundServices))
+	return boundServices, nil
+}
+
+func createSdkClient(serverUrl, username, password string, skipTlsVerify bool) (*synologysdk.Client, error) {
+	if _, err := url.Parse(serverUrl); err != nil {
+		return nil, errors.New("invalid synology server url")
+	}
+
+	if username == "" {
+		return nil, errors.New("invalid synology username")
+	}
+
+	if password == "" {
+		return nil, errors.New("invalid synology password")
+	}
+
+	client := synologysdk.NewClient(serverUrl, username, password)
+	if skipTlsVerify {
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return client, nil
+}
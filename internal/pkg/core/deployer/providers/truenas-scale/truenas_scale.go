@@ -0,0 +1,373 @@
+package truenasscale
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	truenassdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/truenas"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// TrueNAS 服务地址。
+	ServerUrl string `json:"serverUrl"`
+	// TrueNAS API Key。
+	ApiKey string `json:"apiKey"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 证书名称前缀。
+	// 导入的证书名称为前缀追加时间戳，同前缀的证书将被视为旧证书而被替换，且在不再被引用后删除。零值时默认值 "certimate"。
+	CertificateNamePrefix string `json:"certificateNamePrefix,omitempty"`
+	// 是否替换 Web 界面证书。
+	UpdateUiCertificate bool `json:"updateUiCertificate,omitempty"`
+	// 是否替换应用中引用的证书。
+	UpdateApps bool `json:"updateApps,omitempty"`
+	// 需要替换证书的应用名称数组。
+	// 选填。零值时作用于所有引用了旧证书的应用；非零值时替换指定应用中引用的任意证书。
+	AppNames []string `json:"appNames,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *truenassdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+const jobPollInterval = 2 * time.Second
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServerUrl, config.ApiKey, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	namePrefix := d.config.CertificateNamePrefix
+	if namePrefix == "" {
+		namePrefix = "certimate"
+	}
+	if !regexp.MustCompile(`^[A-Za-z0-9_-]+$`).MatchString(namePrefix) {
+		return nil, errors.New("config `certificateNamePrefix` may only contain letters, digits, '-' and '_'")
+	}
+
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取已有证书，同前缀的证书视为旧证书
+	listCertificatesResp, err := d.sdkClient.ListCertificates()
+	d.logger.Debug("sdk request 'truenas.ListCertificates'", slog.Any("response", listCertificatesResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'truenas.ListCertificates': %w", err)
+	}
+
+	var certificate *truenassdk.CertificateRecord
+	oldCertificateIds := make([]int64, 0)
+	for _, record := range listCertificatesResp {
+		if !strings.HasPrefix(record.Name, namePrefix+"-") {
+			continue
+		}
+
+		// 同前缀且内容相同的证书视为此前已导入过
+		if certificate == nil {
+			if recordX509, err := certutil.ParseCertificateFromPEM(record.Certificate); err == nil && recordX509.Equal(certX509) {
+				certificate = record
+				continue
+			}
+		}
+
+		oldCertificateIds = append(oldCertificateIds, record.Id)
+	}
+
+	if certificate != nil {
+		d.logger.Info("ssl certificate already exists, skip importing", slog.Int64("certificateId", certificate.Id), slog.String("name", certificate.Name))
+	} else {
+		certificate, err = d.importCertificate(ctx, namePrefix, certPEM, privkeyPEM)
+		if err != nil {
+			return nil, err
+		}
+
+		d.logger.Info("ssl certificate imported", slog.Int64("certificateId", certificate.Id), slog.String("name", certificate.Name))
+	}
+
+	// 替换 Web 界面证书
+	if d.config.UpdateUiCertificate {
+		if err := d.updateUiCertificate(certificate.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	// 替换应用中引用的证书
+	updatedApps := make([]string, 0)
+	if d.config.UpdateApps {
+		updatedApps, err = d.updateApps(ctx, certificate.Id, oldCertificateIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 清理不再被引用的旧证书，失败时不影响部署结果
+	if err := d.removeOutdatedCertificates(ctx, oldCertificateIds); err != nil {
+		d.logger.Warn("failed to remove outdated certificates", slog.Any("error", err))
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"certificateId": certificate.Id,
+			"apps":          updatedApps,
+		},
+	}, nil
+}
+
+func (d *DeployerProvider) importCertificate(ctx context.Context, namePrefix string, certPEM string, privkeyPEM string) (*truenassdk.CertificateRecord, error) {
+	// 导入证书
+	// REF: https://www.truenas.com/docs/api/scale_rest_api.html#/certificate/post_certificate
+	createCertificateReq := &truenassdk.CreateCertificateRequest{
+		CreateType:  "CERTIFICATE_CREATE_IMPORTED",
+		Name:        fmt.Sprintf("%s-%d", namePrefix, time.Now().UnixMilli()),
+		Certificate: certPEM,
+		Privatekey:  privkeyPEM,
+	}
+	createCertificateJobId, err := d.sdkClient.CreateCertificate(createCertificateReq)
+	d.logger.Debug("sdk request 'truenas.CreateCertificate'", slog.String("name", createCertificateReq.Name), slog.Int64("jobId", createCertificateJobId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'truenas.CreateCertificate': %w", err)
+	}
+
+	createCertificateJob, err := d.sdkClient.WaitJob(ctx, createCertificateJobId, jobPollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for certificate creation: %w", err)
+	}
+
+	return d.sdkClient.GetCreatedCertificate(createCertificateJob)
+}
+
+func (d *DeployerProvider) removeOutdatedCertificates(ctx context.Context, oldCertificateIds []int64) error {
+	if len(oldCertificateIds) == 0 {
+		return nil
+	}
+
+	// 获取仍被 Web 界面或任意应用引用的证书，任一来源获取失败时放弃清理
+	referencedCertificateIds := make(map[int64]struct{})
+
+	getSystemGeneralResp, err := d.sdkClient.GetSystemGeneral()
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'truenas.GetSystemGeneral': %w", err)
+	}
+	referencedCertificateIds[getSystemGeneralResp.GetUiCertificateId()] = struct{}{}
+
+	listAppsResp, err := d.sdkClient.ListApps()
+	d.logger.Debug("sdk request 'truenas.ListApps'", slog.Any("response", listAppsResp))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'truenas.ListApps': %w", err)
+	}
+
+	for _, app := range listAppsResp {
+		values, err := d.sdkClient.GetAppConfig(app.Name)
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'truenas.GetAppConfig': %w", err)
+		}
+
+		// 仅收集引用，不做替换
+		replaceCertificateReferences(values, 0, func(value int64) bool {
+			referencedCertificateIds[value] = struct{}{}
+			return false
+		})
+	}
+
+	var errs []error
+	for _, certificateId := range oldCertificateIds {
+		if _, ok := referencedCertificateIds[certificateId]; ok {
+			d.logger.Info("outdated ssl certificate is still in use, skip removing", slog.Int64("certificateId", certificateId))
+			continue
+		}
+
+		// 删除证书
+		// 其他服务（如 FTP、S3）仍在引用时 TrueNAS 会拒绝删除
+		// REF: https://www.truenas.com/docs/api/scale_rest_api.html#/certificate/delete_certificate_id_id_
+		deleteCertificateJobId, err := d.sdkClient.DeleteCertificate(certificateId)
+		d.logger.Debug("sdk request 'truenas.DeleteCertificate'", slog.Int64("certificateId", certificateId), slog.Int64("jobId", deleteCertificateJobId))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to execute sdk request 'truenas.DeleteCertificate': %w", err))
+			continue
+		}
+
+		if _, err := d.sdkClient.WaitJob(ctx, deleteCertificateJobId, jobPollInterval); err != nil {
+			errs = append(errs, fmt.Errorf("failed to wait for certificate #%d deletion: %w", certificateId, err))
+			continue
+		}
+
+		d.logger.Info("outdated ssl certificate removed", slog.Int64("certificateId", certificateId))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *DeployerProvider) updateUiCertificate(certificateId int64) error {
+	getSystemGeneralResp, err := d.sdkClient.GetSystemGeneral()
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'truenas.GetSystemGeneral': %w", err)
+	}
+
+	if getSystemGeneralResp.GetUiCertificateId() == certificateId {
+		d.logger.Info("ui certificate already up to date")
+		return nil
+	}
+
+	// 修改界面证书
+	// REF: https://www.truenas.com/docs/api/scale_rest_api.html#/system%2Fgeneral/put_system_general
+	updateSystemGeneralReq := &truenassdk.UpdateSystemGeneralRequest{
+		UiCertificate: certificateId,
+	}
+	err = d.sdkClient.UpdateSystemGeneral(updateSystemGeneralReq)
+	d.logger.Debug("sdk request 'truenas.UpdateSystemGeneral'", slog.Any("request", updateSystemGeneralReq))
+	if err != nil {
+		return fmt.Errorf("failed to execute sdk request 'truenas.UpdateSystemGeneral': %w", err)
+	}
+
+	// 重启 Web 界面使证书生效
+	// REF: https://www.truenas.com/docs/api/scale_rest_api.html#/system%2Fgeneral/post_system_general_ui_restart
+	if err := d.sdkClient.RestartUI(); err != nil {
+		return fmt.Errorf("failed to execute sdk request 'truenas.RestartUI': %w", err)
+	}
+
+	d.logger.Info("ui certificate updated", slog.Int64("certificateId", certificateId))
+	return nil
+}
+
+func (d *DeployerProvider) updateApps(ctx context.Context, certificateId int64, oldCertificateIds []int64) ([]string, error) {
+	appNames := d.config.AppNames
+	if len(appNames) == 0 {
+		listAppsResp, err := d.sdkClient.ListApps()
+		d.logger.Debug("sdk request 'truenas.ListApps'", slog.Any("response", listAppsResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'truenas.ListApps': %w", err)
+		}
+
+		for _, app := range listAppsResp {
+			appNames = append(appNames, app.Name)
+		}
+	}
+
+	// 显式指定应用时替换其引用的任意证书，否则仅替换引用了旧证书的配置项
+	shouldReplace := func(value int64) bool {
+		if value == certificateId {
+			return false
+		}
+		if len(d.config.AppNames) > 0 {
+			return true
+		}
+		return slices.Contains(oldCertificateIds, value)
+	}
+
+	updatedApps := make([]string, 0)
+	for _, appName := range appNames {
+		values, err := d.sdkClient.GetAppConfig(appName)
+		if err != nil {
+			return updatedApps, fmt.Errorf("failed to execute sdk request 'truenas.GetAppConfig': %w", err)
+		}
+
+		if !replaceCertificateReferences(values, certificateId, shouldReplace) {
+			continue
+		}
+
+		// 修改应用配置
+		// REF: https://www.truenas.com/docs/api/scale_rest_api.html#/app/put_app_id_id_
+		updateAppJobId, err := d.sdkClient.UpdateApp(appName, &truenassdk.UpdateAppRequest{Values: values})
+		d.logger.Debug("sdk request 'truenas.UpdateApp'", slog.String("app", appName), slog.Int64("jobId", updateAppJobId))
+		if err != nil {
+			return updatedApps, fmt.Errorf("failed to execute sdk request 'truenas.UpdateApp': %w", err)
+		}
+
+		if _, err := d.sdkClient.WaitJob(ctx, updateAppJobId, jobPollInterval); err != nil {
+			return updatedApps, fmt.Errorf("failed to wait for app '%s' update: %w", appName, err)
+		}
+
+		updatedApps = append(updatedApps, appName)
+		d.logger.Info("app certificate updated", slog.String("app", appName), slog.Int64("certificateId", certificateId))
+	}
+
+	return updatedApps, nil
+}
+
+// 递归替换应用配置中的证书引用，返回是否有变更。
+// 新版应用使用 "certificate_id" 字段，旧版 Helm Chart 应用使用 "scaleCert" 字段。
+func replaceCertificateReferences(node any, certificateId int64, shouldReplace func(int64) bool) bool {
+	changed := false
+
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if key == "certificate_id" || key == "scaleCert" {
+				if id, ok := value.(float64); ok && shouldReplace(int64(id)) {
+					v[key] = certificateId
+					changed = true
+				}
+				continue
+			}
+
+			if replaceCertificateReferences(value, certificateId, shouldReplace) {
+				changed = true
+			}
+		}
+
+	case []any:
+		for _, item := range v {
+			if replaceCertificateReferences(item, certificateId, shouldReplace) {
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
+
+func createSdkClient(serverUrl, apiKey string, skipTlsVerify bool) (*truenassdk.Client, error) {
+	if _, err := url.Parse(serverUrl); err != nil {
+		return nil, errors.New("invalid truenas server url")
+	}
+
+	if apiKey == "" {
+		return nil, errors.New("invalid truenas api key")
+	}
+
+	client := truenassdk.NewClient(serverUrl, apiKey)
+	if skipTlsVerify {
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return client, nil
+}
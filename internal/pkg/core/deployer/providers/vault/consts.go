@@ -0,0 +1,22 @@
+package vault
+
+type AuthMethodType string
+
+const (
+	// 认证方式：令牌。
+	AUTH_METHOD_TOKEN = AuthMethodType("token")
+	// 认证方式：AppRole。
+	AUTH_METHOD_APPROLE = AuthMethodType("approle")
+	// 认证方式：Kubernetes ServiceAccount。
+	AUTH_METHOD_KUBERNETES = AuthMethodType("kubernetes")
+)
+
+type ResourceType string
+
+const (
+	// 资源类型：写入 KV v2 密钥引擎。
+	RESOURCE_TYPE_KV = ResourceType("kv")
+	// 资源类型：将 CA 证书包导入 PKI 密钥引擎作为颁发者。
+	// 注意 Vault 仅接受 CA 证书作为颁发者，ACME 签发的普通服务器证书无法使用此类型，仅适用于部署自行管理的中间 CA 证书。
+	RESOURCE_TYPE_PKI_CA_BUNDLE = ResourceType("pki-ca-bundle")
+)
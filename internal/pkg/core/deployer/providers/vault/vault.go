@@ -0,0 +1,365 @@
+package vault
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/usual2970/certimate/internal/pkg/core/deployer"
+	vaultsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/vault"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type DeployerConfig struct {
+	// Vault 服务地址。
+	Address string `json:"address"`
+	// Vault 企业版命名空间。
+	// 选填。
+	Namespace string `json:"namespace,omitempty"`
+	// 是否允许不安全的连接。
+	AllowInsecureConnections bool `json:"allowInsecureConnections,omitempty"`
+	// 认证方式。
+	// 零值时默认值 [AUTH_METHOD_TOKEN]。
+	AuthMethod AuthMethodType `json:"authMethod,omitempty"`
+	// Vault 令牌。
+	// 认证方式为 [AUTH_METHOD_TOKEN] 时必填。
+	Token string `json:"token,omitempty"`
+	// AppRole 认证挂载路径。
+	// 认证方式为 [AUTH_METHOD_APPROLE] 时有效。零值时默认值 "approle"。
+	AppRoleMountPath string `json:"appRoleMountPath,omitempty"`
+	// AppRole RoleID。
+	// 认证方式为 [AUTH_METHOD_APPROLE] 时必填。
+	AppRoleRoleId string `json:"appRoleRoleId,omitempty"`
+	// AppRole SecretID。
+	// 认证方式为 [AUTH_METHOD_APPROLE] 时有效。
+	AppRoleSecretId string `json:"appRoleSecretId,omitempty"`
+	// Kubernetes 认证挂载路径。
+	// 认证方式为 [AUTH_METHOD_KUBERNETES] 时有效。零值时默认值 "kubernetes"。
+	KubernetesMountPath string `json:"kubernetesMountPath,omitempty"`
+	// Kubernetes 认证角色。
+	// 认证方式为 [AUTH_METHOD_KUBERNETES] 时必填。
+	KubernetesRole string `json:"kubernetesRole,omitempty"`
+	// Kubernetes ServiceAccount 令牌。
+	// 认证方式为 [AUTH_METHOD_KUBERNETES] 时有效。零值时读取 Pod 内挂载的令牌文件。
+	KubernetesJwt string `json:"kubernetesJwt,omitempty"`
+	// 部署资源类型。
+	// 零值时默认值 [RESOURCE_TYPE_KV]。
+	ResourceType ResourceType `json:"resourceType,omitempty"`
+	// KV v2 引擎挂载路径。
+	// 部署资源类型为 [RESOURCE_TYPE_KV] 时必填。
+	KVMountPath string `json:"kvMountPath,omitempty"`
+	// KV v2 密钥路径。
+	// 部署资源类型为 [RESOURCE_TYPE_KV] 时必填。
+	SecretPath string `json:"secretPath,omitempty"`
+	// 密钥中用于存放服务器证书的字段名。
+	// 部署资源类型为 [RESOURCE_TYPE_KV] 时必填。
+	FieldNameForCert string `json:"fieldNameForCert,omitempty"`
+	// 密钥中用于存放中间证书链的字段名。
+	// 部署资源类型为 [RESOURCE_TYPE_KV] 时选填。零值时不写入。
+	FieldNameForChain string `json:"fieldNameForChain,omitempty"`
+	// 密钥中用于存放完整证书链（服务器证书与中间证书）的字段名。
+	// 部署资源类型为 [RESOURCE_TYPE_KV] 时选填。零值时不写入。
+	FieldNameForFullchain string `json:"fieldNameForFullchain,omitempty"`
+	// 密钥中用于存放私钥的字段名。
+	// 部署资源类型为 [RESOURCE_TYPE_KV] 时必填。
+	FieldNameForKey string `json:"fieldNameForKey,omitempty"`
+	// 保留的最大版本数。
+	// 部署资源类型为 [RESOURCE_TYPE_KV] 时选填。零值时保持 Vault 中的原有设置。
+	MaxVersions int64 `json:"maxVersions,omitempty"`
+	// 自定义元数据。
+	// 部署资源类型为 [RESOURCE_TYPE_KV] 时选填。
+	CustomMetadata map[string]string `json:"customMetadata,omitempty"`
+	// PKI 引擎挂载路径。
+	// 部署资源类型为 [RESOURCE_TYPE_PKI_CA_BUNDLE] 时必填。
+	PKIMountPath string `json:"pkiMountPath,omitempty"`
+	// 导入后的颁发者名称。
+	// 部署资源类型为 [RESOURCE_TYPE_PKI_CA_BUNDLE] 时选填。零值时不设置。
+	PKIIssuerName string `json:"pkiIssuerName,omitempty"`
+	// 是否将导入的颁发者设为默认颁发者。
+	// 部署资源类型为 [RESOURCE_TYPE_PKI_CA_BUNDLE] 时有效。
+	PKISetDefault bool `json:"pkiSetDefault,omitempty"`
+}
+
+type DeployerProvider struct {
+	config    *DeployerConfig
+	logger    *slog.Logger
+	sdkClient *vaultsdk.Client
+}
+
+var _ deployer.Deployer = (*DeployerProvider)(nil)
+
+const kubernetesServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+func NewDeployer(config *DeployerConfig) (*DeployerProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.Address, config.Namespace, config.AllowInsecureConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &DeployerProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (d *DeployerProvider) WithLogger(logger *slog.Logger) deployer.Deployer {
+	if logger == nil {
+		d.logger = slog.New(slog.DiscardHandler)
+	} else {
+		d.logger = logger
+	}
+	return d
+}
+
+func (d *DeployerProvider) Deploy(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	switch d.config.ResourceType {
+	case "", RESOURCE_TYPE_KV:
+		return d.deployToKV(ctx, certPEM, privkeyPEM)
+
+	case RESOURCE_TYPE_PKI_CA_BUNDLE:
+		return d.deployToPKI(ctx, certPEM, privkeyPEM)
+
+	default:
+		return nil, fmt.Errorf("unsupported resource type '%s'", d.config.ResourceType)
+	}
+}
+
+func (d *DeployerProvider) deployToKV(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.KVMountPath == "" {
+		return nil, errors.New("config `kvMountPath` is required")
+	}
+	if d.config.SecretPath == "" {
+		return nil, errors.New("config `secretPath` is required")
+	}
+	if d.config.FieldNameForCert == "" {
+		return nil, errors.New("config `fieldNameForCert` is required")
+	}
+	if d.config.FieldNameForKey == "" {
+		return nil, errors.New("config `fieldNameForKey` is required")
+	}
+
+	// 提取服务器证书和中间证书
+	serverCertPEM, intermediaCertPEM, err := certutil.ExtractCertificatesFromPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract certs: %w", err)
+	}
+
+	// 登录认证
+	if err := d.login(); err != nil {
+		return nil, err
+	}
+
+	// 写入密钥，KV v2 引擎每次写入都会生成新版本
+	// REF: https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v2#create-update-secret
+	writeSecretReq := &vaultsdk.KVv2WriteSecretRequest{
+		MountPath: d.config.KVMountPath,
+		Path:      d.config.SecretPath,
+		Data: map[string]string{
+			d.config.FieldNameForCert: serverCertPEM,
+			d.config.FieldNameForKey:  privkeyPEM,
+		},
+	}
+	if d.config.FieldNameForChain != "" {
+		writeSecretReq.Data[d.config.FieldNameForChain] = intermediaCertPEM
+	}
+	if d.config.FieldNameForFullchain != "" {
+		writeSecretReq.Data[d.config.FieldNameForFullchain] = certPEM
+	}
+	writeSecretResp, err := d.sdkClient.KVv2WriteSecret(writeSecretReq)
+	d.logger.Debug("sdk request 'vault.KVv2WriteSecret'", slog.String("mountPath", writeSecretReq.MountPath), slog.String("path", writeSecretReq.Path), slog.Any("response", writeSecretResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'vault.KVv2WriteSecret': %w", err)
+	}
+
+	var version int64
+	if writeSecretResp.Data != nil {
+		version = writeSecretResp.Data.Version
+	}
+	d.logger.Info("ssl certificate written to vault", slog.String("path", d.config.SecretPath), slog.Int64("version", version))
+
+	// 更新元数据
+	// REF: https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v2#create-update-metadata
+	if d.config.MaxVersions > 0 || len(d.config.CustomMetadata) > 0 {
+		updateMetadataReq := &vaultsdk.KVv2UpdateMetadataRequest{
+			MountPath:      d.config.KVMountPath,
+			Path:           d.config.SecretPath,
+			CustomMetadata: d.config.CustomMetadata,
+		}
+		if d.config.MaxVersions > 0 {
+			updateMetadataReq.MaxVersions = &d.config.MaxVersions
+		}
+		updateMetadataResp, err := d.sdkClient.KVv2UpdateMetadata(updateMetadataReq)
+		d.logger.Debug("sdk request 'vault.KVv2UpdateMetadata'", slog.Any("request", updateMetadataReq), slog.Any("response", updateMetadataResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'vault.KVv2UpdateMetadata': %w", err)
+		}
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"version": version,
+		},
+	}, nil
+}
+
+func (d *DeployerProvider) deployToPKI(ctx context.Context, certPEM string, privkeyPEM string) (*deployer.DeployResult, error) {
+	if d.config.PKIMountPath == "" {
+		return nil, errors.New("config `pkiMountPath` is required")
+	}
+
+	// Vault 拒绝导入非 CA 证书，提前校验以给出明确的错误信息
+	cert, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	} else if !cert.BasicConstraintsValid || !cert.IsCA {
+		return nil, errors.New("the certificate is not a CA certificate, vault pki engine only accepts CA certificates as issuers, please use resource type 'kv' for ordinary certificates")
+	}
+
+	// 登录认证
+	if err := d.login(); err != nil {
+		return nil, err
+	}
+
+	// 导入证书链与私钥，已存在的颁发者与密钥不会重复导入
+	// REF: https://developer.hashicorp.com/vault/api-docs/secret/pki#import-ca-certificates-and-keys
+	importBundleReq := &vaultsdk.PKIImportIssuerBundleRequest{
+		MountPath: d.config.PKIMountPath,
+		PemBundle: strings.TrimRight(certPEM, "\r\n") + "\n" + privkeyPEM,
+	}
+	importBundleResp, err := d.sdkClient.PKIImportIssuerBundle(importBundleReq)
+	d.logger.Debug("sdk request 'vault.PKIImportIssuerBundle'", slog.String("mountPath", importBundleReq.MountPath), slog.Any("response", importBundleResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'vault.PKIImportIssuerBundle': %w", err)
+	}
+
+	// 证书链中仅服务器证书附带了私钥，据此找出其对应的颁发者
+	var issuerId string
+	if importBundleResp.Data != nil {
+		for id, keyId := range importBundleResp.Data.Mapping {
+			if keyId != "" {
+				issuerId = id
+				break
+			}
+		}
+	}
+	if issuerId == "" {
+		return nil, errors.New("could not find the imported issuer in vault")
+	}
+
+	d.logger.Info("ssl certificate imported to vault pki", slog.String("mountPath", d.config.PKIMountPath), slog.String("issuerId", issuerId))
+
+	// 设置颁发者名称
+	// REF: https://developer.hashicorp.com/vault/api-docs/secret/pki#update-issuer
+	if d.config.PKIIssuerName != "" {
+		updateIssuerReq := &vaultsdk.PKIUpdateIssuerRequest{
+			MountPath:  d.config.PKIMountPath,
+			IssuerRef:  issuerId,
+			IssuerName: d.config.PKIIssuerName,
+		}
+		updateIssuerResp, err := d.sdkClient.PKIUpdateIssuer(updateIssuerReq)
+		d.logger.Debug("sdk request 'vault.PKIUpdateIssuer'", slog.Any("request", updateIssuerReq), slog.Any("response", updateIssuerResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'vault.PKIUpdateIssuer': %w", err)
+		}
+	}
+
+	// 设为默认颁发者
+	// REF: https://developer.hashicorp.com/vault/api-docs/secret/pki#set-issuers-configuration
+	if d.config.PKISetDefault {
+		updateIssuersConfigReq := &vaultsdk.PKIUpdateIssuersConfigRequest{
+			MountPath: d.config.PKIMountPath,
+			Default:   issuerId,
+		}
+		updateIssuersConfigResp, err := d.sdkClient.PKIUpdateIssuersConfig(updateIssuersConfigReq)
+		d.logger.Debug("sdk request 'vault.PKIUpdateIssuersConfig'", slog.Any("request", updateIssuersConfigReq), slog.Any("response", updateIssuersConfigResp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute sdk request 'vault.PKIUpdateIssuersConfig': %w", err)
+		}
+	}
+
+	return &deployer.DeployResult{
+		ExtendedData: map[string]any{
+			"issuerId": issuerId,
+		},
+	}, nil
+}
+
+func (d *DeployerProvider) login() error {
+	switch d.config.AuthMethod {
+	case "", AUTH_METHOD_TOKEN:
+		if d.config.Token == "" {
+			return errors.New("config `token` is required")
+		}
+
+		d.sdkClient.WithToken(d.config.Token)
+
+	case AUTH_METHOD_APPROLE:
+		// REF: https://developer.hashicorp.com/vault/api-docs/auth/approle#login-with-approle
+		loginReq := &vaultsdk.LoginWithAppRoleRequest{
+			MountPath: d.config.AppRoleMountPath,
+			RoleId:    d.config.AppRoleRoleId,
+			SecretId:  d.config.AppRoleSecretId,
+		}
+		loginResp, err := d.sdkClient.LoginWithAppRole(loginReq)
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'vault.LoginWithAppRole': %w", err)
+		} else if loginResp.Auth == nil || loginResp.Auth.ClientToken == "" {
+			return errors.New("failed to execute sdk request 'vault.LoginWithAppRole': no client token returned")
+		}
+
+		d.sdkClient.WithToken(loginResp.Auth.ClientToken)
+
+	case AUTH_METHOD_KUBERNETES:
+		jwt := d.config.KubernetesJwt
+		if jwt == "" {
+			data, err := os.ReadFile(kubernetesServiceAccountTokenPath)
+			if err != nil {
+				return fmt.Errorf("failed to read kubernetes service account token: %w", err)
+			}
+			jwt = strings.TrimSpace(string(data))
+		}
+
+		// REF: https://developer.hashicorp.com/vault/api-docs/auth/kubernetes#login
+		loginReq := &vaultsdk.LoginWithKubernetesRequest{
+			MountPath: d.config.KubernetesMountPath,
+			Role:      d.config.KubernetesRole,
+			Jwt:       jwt,
+		}
+		loginResp, err := d.sdkClient.LoginWithKubernetes(loginReq)
+		if err != nil {
+			return fmt.Errorf("failed to execute sdk request 'vault.LoginWithKubernetes': %w", err)
+		} else if loginResp.Auth == nil || loginResp.Auth.ClientToken == "" {
+			return errors.New("failed to execute sdk request 'vault.LoginWithKubernetes': no client token returned")
+		}
+
+		d.sdkClient.WithToken(loginResp.Auth.ClientToken)
+
+	default:
+		return fmt.Errorf("unsupported auth method '%s'", d.config.AuthMethod)
+	}
+
+	return nil
+}
+
+func createSdkClient(address, namespace string, skipTlsVerify bool) (*vaultsdk.Client, error) {
+	if _, err := url.Parse(address); err != nil || address == "" {
+		return nil, errors.New("invalid vault address")
+	}
+
+	client := vaultsdk.NewClient(address).WithNamespace(namespace)
+	if skipTlsVerify {
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+
+	return client, nil
+}
@@ -0,0 +1,378 @@
+package vault_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	provider "github.com/usual2970/certimate/internal/pkg/core/deployer/providers/vault"
+)
+
+// 模拟 Vault HTTP API，仅实现 AppRole、Kubernetes 登录、KV v2 写入和 PKI 颁发者导入。
+type fakeVaultServer struct {
+	*httptest.Server
+
+	mutex     sync.Mutex
+	namespace string
+	versions  []map[string]string
+	metadata  map[string]any
+
+	pemBundles    []string
+	issuerName    string
+	defaultIssuer string
+}
+
+func newFakeVaultServer(t *testing.T) *fakeVaultServer {
+	s := &fakeVaultServer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/auth/approle/login", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["role_id"] != "test-role-id" || req["secret_id"] != "test-secret-id" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+			return
+		}
+		w.Write([]byte(`{"auth":{"client_token":"approle-token"}}`))
+	})
+	mux.HandleFunc("POST /v1/auth/k8s/login", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["role"] != "certimate" || req["jwt"] != "test-jwt" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		w.Write([]byte(`{"auth":{"client_token":"k8s-token"}}`))
+	})
+	mux.HandleFunc("POST /v1/secret/data/apps/web/tls", func(w http.ResponseWriter, r *http.Request) {
+		if !s.checkToken(w, r) {
+			return
+		}
+
+		var req struct {
+			Data map[string]string `json:"data"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		s.mutex.Lock()
+		s.namespace = r.Header.Get("X-Vault-Namespace")
+		s.versions = append(s.versions, req.Data)
+		version := len(s.versions)
+		s.mutex.Unlock()
+
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"version": version}})
+	})
+	mux.HandleFunc("POST /v1/secret/metadata/apps/web/tls", func(w http.ResponseWriter, r *http.Request) {
+		if !s.checkToken(w, r) {
+			return
+		}
+
+		s.mutex.Lock()
+		json.NewDecoder(r.Body).Decode(&s.metadata)
+		s.mutex.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST /v1/pki_int/issuers/import/bundle", func(w http.ResponseWriter, r *http.Request) {
+		if !s.checkToken(w, r) {
+			return
+		}
+
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+
+		s.mutex.Lock()
+		s.pemBundles = append(s.pemBundles, req["pem_bundle"])
+		s.mutex.Unlock()
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"imported_issuers": []string{"issuer-int", "issuer-root"},
+				"imported_keys":    []string{"key-int"},
+				"mapping":          map[string]string{"issuer-int": "key-int", "issuer-root": ""},
+			},
+		})
+	})
+	mux.HandleFunc("PATCH /v1/pki_int/issuer/issuer-int", func(w http.ResponseWriter, r *http.Request) {
+		if !s.checkToken(w, r) {
+			return
+		}
+		if r.Header.Get("Content-Type") != "application/merge-patch+json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+
+		s.mutex.Lock()
+		s.issuerName = req["issuer_name"]
+		s.mutex.Unlock()
+
+		w.Write([]byte(`{"data":{}}`))
+	})
+	mux.HandleFunc("POST /v1/pki_int/config/issuers", func(w http.ResponseWriter, r *http.Request) {
+		if !s.checkToken(w, r) {
+			return
+		}
+
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+
+		s.mutex.Lock()
+		s.defaultIssuer = req["default"]
+		s.mutex.Unlock()
+
+		w.Write([]byte(`{"data":{}}`))
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeVaultServer) checkToken(w http.ResponseWriter, r *http.Request) bool {
+	switch r.Header.Get("X-Vault-Token") {
+	case "root-token", "approle-token", "k8s-token":
+		return true
+	}
+
+	w.WriteHeader(http.StatusForbidden)
+	w.Write([]byte(`{"errors":["permission denied"]}`))
+	return false
+}
+
+func generateCertificateChain(t *testing.T, isCA bool) (string, string, string) {
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if isCA {
+		// 模拟自行管理的中间 CA 证书
+		template.Subject = pkix.Name{CommonName: "Test Intermediate CA"}
+		template.DNSNames = nil
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	serverCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	caCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return serverCertPEM, caCertPEM, keyPEM
+}
+
+func TestDeploy(t *testing.T) {
+	serverCertPEM, caCertPEM, privkeyPEM := generateCertificateChain(t, false)
+	certPEM := serverCertPEM + caCertPEM
+
+	baseConfig := provider.DeployerConfig{
+		KVMountPath:      "secret",
+		SecretPath:       "apps/web/tls",
+		FieldNameForCert: "certificate",
+		FieldNameForKey:  "private_key",
+	}
+
+	t.Run("Deploy_Token", func(t *testing.T) {
+		server := newFakeVaultServer(t)
+
+		config := baseConfig
+		config.Address = server.URL
+		config.Namespace = "team-a"
+		config.Token = "root-token"
+		config.FieldNameForChain = "ca_chain"
+		config.FieldNameForFullchain = "fullchain"
+		config.MaxVersions = 5
+		config.CustomMetadata = map[string]string{"owner": "platform"}
+
+		deployer, err := provider.NewDeployer(&config)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		for i := 1; i <= 2; i++ {
+			res, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM)
+			if err != nil {
+				t.Fatalf("err: %+v", err)
+			}
+			if res.ExtendedData["version"] != int64(i) {
+				t.Errorf("expected version %d, got %v", i, res.ExtendedData["version"])
+			}
+		}
+
+		if len(server.versions) != 2 {
+			t.Fatalf("expected 2 versions, got %d", len(server.versions))
+		}
+		data := server.versions[1]
+		if data["certificate"] != serverCertPEM || data["ca_chain"] != caCertPEM || data["fullchain"] != certPEM || data["private_key"] != privkeyPEM {
+			t.Errorf("unexpected secret data: %v", data)
+		}
+		if server.namespace != "team-a" {
+			t.Errorf("expected namespace header 'team-a', got '%s'", server.namespace)
+		}
+		if server.metadata["max_versions"] != float64(5) {
+			t.Errorf("unexpected max_versions: %v", server.metadata["max_versions"])
+		}
+		if customMetadata, _ := server.metadata["custom_metadata"].(map[string]any); customMetadata["owner"] != "platform" {
+			t.Errorf("unexpected custom_metadata: %v", server.metadata["custom_metadata"])
+		}
+	})
+
+	t.Run("Deploy_AppRole", func(t *testing.T) {
+		server := newFakeVaultServer(t)
+
+		config := baseConfig
+		config.Address = server.URL
+		config.AuthMethod = provider.AUTH_METHOD_APPROLE
+		config.AppRoleRoleId = "test-role-id"
+		config.AppRoleSecretId = "test-secret-id"
+
+		deployer, err := provider.NewDeployer(&config)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if len(server.versions) != 1 {
+			t.Fatalf("expected 1 version, got %d", len(server.versions))
+		}
+		if _, ok := server.versions[0]["ca_chain"]; ok {
+			t.Errorf("chain field should not be written when not configured")
+		}
+		if server.metadata != nil {
+			t.Errorf("metadata should not be updated when not configured")
+		}
+	})
+
+	t.Run("Deploy_Kubernetes", func(t *testing.T) {
+		server := newFakeVaultServer(t)
+
+		config := baseConfig
+		config.Address = server.URL
+		config.AuthMethod = provider.AUTH_METHOD_KUBERNETES
+		config.KubernetesMountPath = "k8s"
+		config.KubernetesRole = "certimate"
+		config.KubernetesJwt = "test-jwt"
+
+		deployer, err := provider.NewDeployer(&config)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+	})
+
+	t.Run("Deploy_PermissionDenied", func(t *testing.T) {
+		server := newFakeVaultServer(t)
+
+		config := baseConfig
+		config.Address = server.URL
+		config.Token = "invalid-token"
+
+		deployer, err := provider.NewDeployer(&config)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+	t.Run("Deploy_PKICABundle", func(t *testing.T) {
+		server := newFakeVaultServer(t)
+		intermediateCertPEM, rootCertPEM, intermediateKeyPEM := generateCertificateChain(t, true)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			Address:       server.URL,
+			Token:         "root-token",
+			ResourceType:  provider.RESOURCE_TYPE_PKI_CA_BUNDLE,
+			PKIMountPath:  "pki_int",
+			PKIIssuerName: "certimate-int",
+			PKISetDefault: true,
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		res, err := deployer.Deploy(context.Background(), intermediateCertPEM+rootCertPEM, intermediateKeyPEM)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if res.ExtendedData["issuerId"] != "issuer-int" {
+			t.Errorf("unexpected issuer id: %v", res.ExtendedData["issuerId"])
+		}
+		if len(server.pemBundles) != 1 || server.pemBundles[0] != intermediateCertPEM+rootCertPEM+intermediateKeyPEM {
+			t.Errorf("unexpected pem bundle: %v", server.pemBundles)
+		}
+		if server.issuerName != "certimate-int" {
+			t.Errorf("unexpected issuer name: %s", server.issuerName)
+		}
+		if server.defaultIssuer != "issuer-int" {
+			t.Errorf("unexpected default issuer: %s", server.defaultIssuer)
+		}
+	})
+
+	t.Run("Deploy_PKICABundle_NonCA", func(t *testing.T) {
+		server := newFakeVaultServer(t)
+
+		deployer, err := provider.NewDeployer(&provider.DeployerConfig{
+			Address:      server.URL,
+			Token:        "root-token",
+			ResourceType: provider.RESOURCE_TYPE_PKI_CA_BUNDLE,
+			PKIMountPath: "pki_int",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if _, err := deployer.Deploy(context.Background(), certPEM, privkeyPEM); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(server.pemBundles) != 0 {
+			t.Errorf("non-CA certificate should not be imported")
+		}
+	})
+}
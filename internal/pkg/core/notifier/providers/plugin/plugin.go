@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/usual2970/certimate/internal/pkg/core/notifier"
+	pluginsdk "github.com/usual2970/certimate/pkg/plugin"
+)
+
+type NotifierConfig struct {
+	// 插件名称。
+	PluginName string `json:"pluginName"`
+	// 传递给插件的授权配置。
+	AccessConfig map[string]any `json:"accessConfig,omitempty"`
+	// 传递给插件的通知配置。
+	ServiceConfig map[string]any `json:"serviceConfig,omitempty"`
+	// 插件客户端。
+	Client *pluginsdk.Client `json:"-"`
+}
+
+type NotifierProvider struct {
+	config *NotifierConfig
+	logger *slog.Logger
+}
+
+var _ notifier.Notifier = (*NotifierProvider)(nil)
+
+func NewNotifier(config *NotifierConfig) (*NotifierProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	if config.Client == nil {
+		return nil, errors.New("plugin client is not available")
+	}
+
+	if !config.Client.Info().HasKind(pluginsdk.KindNotifier) {
+		return nil, fmt.Errorf("plugin '%s' does not implement '%s'", config.PluginName, pluginsdk.KindNotifier)
+	}
+
+	return &NotifierProvider{
+		config: config,
+		logger: slog.Default(),
+	}, nil
+}
+
+func (n *NotifierProvider) WithLogger(logger *slog.Logger) notifier.Notifier {
+	if logger == nil {
+		n.logger = slog.New(slog.DiscardHandler)
+	} else {
+		n.logger = logger
+	}
+	return n
+}
+
+func (n *NotifierProvider) Notify(ctx context.Context, subject string, message string) (*notifier.NotifyResult, error) {
+	accessConfig, err := json.Marshal(n.config.AccessConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin access config: %w", err)
+	}
+
+	serviceConfig, err := json.Marshal(n.config.ServiceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin service config: %w", err)
+	}
+
+	notifyResp, err := n.config.Client.Notify(ctx, &pluginsdk.NotifyRequest{
+		AccessConfig:  accessConfig,
+		ServiceConfig: serviceConfig,
+		Subject:       subject,
+		Message:       message,
+	})
+	n.logger.Debug("plugin request 'Notify'", slog.String("plugin", n.config.PluginName), slog.Any("response", notifyResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute plugin request 'Notify': %w", err)
+	}
+
+	return &notifier.NotifyResult{
+		ExtendedData: notifyResp.ExtendedData,
+	}, nil
+}
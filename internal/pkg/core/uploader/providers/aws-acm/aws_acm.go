@@ -22,6 +22,9 @@ type UploaderConfig struct {
 	SecretAccessKey string `json:"secretAccessKey"`
 	// AWS 区域。
 	Region string `json:"region"`
+	// AWS 服务地址。
+	// 选填。零值时使用 AWS 官方服务地址，可用于对接 LocalStack 等本地模拟服务。
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 type UploaderProvider struct {
@@ -37,7 +40,7 @@ func NewUploader(config *UploaderConfig) (*UploaderProvider, error) {
 		panic("config is nil")
 	}
 
-	client, err := createSdkClient(config.AccessKeyId, config.SecretAccessKey, config.Region)
+	client, err := createSdkClient(config.AccessKeyId, config.SecretAccessKey, config.Region, config.Endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sdk client: %w", err)
 	}
@@ -158,7 +161,7 @@ func (u *UploaderProvider) Upload(ctx context.Context, certPEM string, privkeyPE
 	}, nil
 }
 
-func createSdkClient(accessKeyId, secretAccessKey, region string) (*awsacm.Client, error) {
+func createSdkClient(accessKeyId, secretAccessKey, region, endpoint string) (*awsacm.Client, error) {
 	cfg, err := awscfg.LoadDefaultConfig(context.TODO())
 	if err != nil {
 		return nil, err
@@ -167,6 +170,9 @@ func createSdkClient(accessKeyId, secretAccessKey, region string) (*awsacm.Clien
 	client := awsacm.NewFromConfig(cfg, func(o *awsacm.Options) {
 		o.Region = region
 		o.Credentials = aws.NewCredentialsCache(awscred.NewStaticCredentialsProvider(accessKeyId, secretAccessKey, ""))
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
 	})
 	return client, nil
 }
@@ -0,0 +1,120 @@
+package cloudflaressl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	cfsdk "github.com/cloudflare/cloudflare-go"
+
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	cfcommon "github.com/usual2970/certimate/internal/pkg/sdk3rd/cloudflare/common"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type UploaderConfig struct {
+	// Cloudflare API Token。
+	// 需具有区域的 "SSL and Certificates: Edit" 权限。
+	ApiToken string `json:"apiToken"`
+	// Cloudflare 区域 ID。
+	ZoneId string `json:"zoneId"`
+	// 证书链捆绑方式。
+	// 选填。零值时默认值 [BUNDLE_METHOD_UBIQUITOUS]。
+	BundleMethod string `json:"bundleMethod,omitempty"`
+}
+
+const (
+	BUNDLE_METHOD_UBIQUITOUS = "ubiquitous"
+	BUNDLE_METHOD_OPTIMAL    = "optimal"
+	BUNDLE_METHOD_FORCE      = "force"
+)
+
+type UploaderProvider struct {
+	config    *UploaderConfig
+	logger    *slog.Logger
+	sdkClient *cfsdk.API
+}
+
+var _ uploader.Uploader = (*UploaderProvider)(nil)
+
+func NewUploader(config *UploaderConfig) (*UploaderProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ApiToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &UploaderProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (u *UploaderProvider) WithLogger(logger *slog.Logger) uploader.Uploader {
+	if logger == nil {
+		u.logger = slog.New(slog.DiscardHandler)
+	} else {
+		u.logger = logger
+	}
+	return u
+}
+
+func (u *UploaderProvider) Upload(ctx context.Context, certPEM string, privkeyPEM string) (*uploader.UploadResult, error) {
+	if u.config.ZoneId == "" {
+		return nil, errors.New("config `zoneId` is required")
+	}
+
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取自定义证书列表，避免重复上传
+	// Cloudflare 不返回证书内容，只能通过域名、签发者及有效期判断是否为同一证书
+	// REF: https://developers.cloudflare.com/api/resources/custom_certificates/methods/list/
+	listSSLResp, err := u.sdkClient.ListSSL(ctx, u.config.ZoneId)
+	u.logger.Debug("sdk request 'cloudflare.ListSSL'", slog.String("request.zoneId", u.config.ZoneId), slog.Any("response", listSSLResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'cloudflare.ListSSL': %w", err)
+	} else {
+		for _, customSSL := range listSSLResp {
+			if cfcommon.IsSameCertificate(&customSSL, certX509) {
+				u.logger.Info("ssl certificate already exists")
+				return &uploader.UploadResult{
+					CertId: customSSL.ID,
+				}, nil
+			}
+		}
+	}
+
+	// 上传自定义证书
+	// REF: https://developers.cloudflare.com/api/resources/custom_certificates/methods/create/
+	createSSLReq := cfsdk.ZoneCustomSSLOptions{
+		Certificate:  certPEM,
+		PrivateKey:   privkeyPEM,
+		BundleMethod: u.config.BundleMethod,
+		Type:         "sni_custom",
+	}
+	if createSSLReq.BundleMethod == "" {
+		createSSLReq.BundleMethod = BUNDLE_METHOD_UBIQUITOUS
+	}
+	createSSLResp, err := u.sdkClient.CreateSSL(ctx, u.config.ZoneId, createSSLReq)
+	u.logger.Debug("sdk request 'cloudflare.CreateSSL'", slog.String("request.zoneId", u.config.ZoneId), slog.Any("response", createSSLResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'cloudflare.CreateSSL': %w", err)
+	}
+
+	return &uploader.UploadResult{
+		CertId: createSSLResp.ID,
+	}, nil
+}
+
+func createSdkClient(apiToken string) (*cfsdk.API, error) {
+	return cfsdk.NewWithAPIToken(apiToken, cfsdk.UserAgent("certimate"))
+}
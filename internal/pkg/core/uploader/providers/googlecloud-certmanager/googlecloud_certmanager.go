@@ -0,0 +1,145 @@
+package googlecloudcertmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	gcloudsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/googlecloud"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type UploaderConfig struct {
+	// Google Cloud 服务账号密钥（JSON 格式）。
+	ServiceAccountKey string `json:"serviceAccountKey"`
+	// Google Cloud 项目 ID。
+	// 选填。零值时使用服务账号密钥中的项目 ID。
+	ProjectId string `json:"projectId,omitempty"`
+	// Certificate Manager 位置。
+	// 零值时默认值 "global"。
+	Location string `json:"location,omitempty"`
+}
+
+type UploaderProvider struct {
+	config    *UploaderConfig
+	logger    *slog.Logger
+	sdkClient *gcloudsdk.Client
+}
+
+var _ uploader.Uploader = (*UploaderProvider)(nil)
+
+func NewUploader(config *UploaderConfig) (*UploaderProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServiceAccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &UploaderProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (u *UploaderProvider) WithLogger(logger *slog.Logger) uploader.Uploader {
+	if logger == nil {
+		u.logger = slog.New(slog.DiscardHandler)
+	} else {
+		u.logger = logger
+	}
+	return u
+}
+
+func (u *UploaderProvider) Upload(ctx context.Context, certPEM string, privkeyPEM string) (*uploader.UploadResult, error) {
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	projectId := u.config.ProjectId
+	if projectId == "" {
+		projectId = u.sdkClient.ProjectId()
+	}
+	if projectId == "" {
+		return nil, errors.New("config `projectId` is required")
+	}
+
+	location := u.config.Location
+	if location == "" {
+		location = "global"
+	}
+
+	// 获取证书列表，避免重复上传
+	// REF: https://cloud.google.com/certificate-manager/docs/reference/certificate-manager/rest/v1/projects.locations.certificates/list
+	listCertificatesResp, err := u.sdkClient.ListCertificates(projectId, location)
+	u.logger.Debug("sdk request 'certificatemanager.ListCertificates'", slog.String("project", projectId), slog.String("location", location), slog.Int("count", len(listCertificatesResp)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'certificatemanager.ListCertificates': %w", err)
+	}
+
+	for _, certificate := range listCertificatesResp {
+		if certificate.PemCertificate == "" {
+			continue
+		}
+
+		oldCertX509, err := certutil.ParseCertificateFromPEM(certificate.PemCertificate)
+		if err != nil {
+			continue
+		}
+
+		if !certutil.EqualCertificate(certX509, oldCertX509) {
+			continue
+		}
+
+		// 如果已存在相同证书，直接返回
+		u.logger.Info("ssl certificate already exists")
+		return &uploader.UploadResult{
+			CertId:   certificate.Name,
+			CertName: path.Base(certificate.Name),
+		}, nil
+	}
+
+	// 生成新证书名（需符合 Google Cloud 资源命名规则）
+	certId := fmt.Sprintf("certimate-%d", time.Now().UnixMilli())
+
+	// 创建证书
+	// REF: https://cloud.google.com/certificate-manager/docs/reference/certificate-manager/rest/v1/projects.locations.certificates/create
+	createCertificateReq := &gcloudsdk.Certificate{
+		Description: "uploaded by certimate",
+		SelfManaged: &gcloudsdk.CertificateSelfManaged{
+			PemCertificate: certPEM,
+			PemPrivateKey:  privkeyPEM,
+		},
+	}
+	createCertificateResp, err := u.sdkClient.CreateCertificate(projectId, location, certId, createCertificateReq)
+	u.logger.Debug("sdk request 'certificatemanager.CreateCertificate'", slog.String("certificateId", certId), slog.Any("response", createCertificateResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'certificatemanager.CreateCertificate': %w", err)
+	}
+
+	if _, err := u.sdkClient.WaitOperation(ctx, createCertificateResp, 2*time.Second); err != nil {
+		return nil, fmt.Errorf("failed to wait for certificate creation: %w", err)
+	}
+
+	return &uploader.UploadResult{
+		CertId:   fmt.Sprintf("projects/%s/locations/%s/certificates/%s", projectId, location, certId),
+		CertName: certId,
+	}, nil
+}
+
+func createSdkClient(serviceAccountKey string) (*gcloudsdk.Client, error) {
+	if serviceAccountKey == "" {
+		return nil, errors.New("invalid googlecloud service account key")
+	}
+
+	return gcloudsdk.NewClient(serviceAccountKey)
+}
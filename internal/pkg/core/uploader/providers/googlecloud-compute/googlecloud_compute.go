@@ -0,0 +1,142 @@
+package googlecloudcompute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	gcloudsdk "github.com/usual2970/certimate/internal/pkg/sdk3rd/googlecloud"
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type UploaderConfig struct {
+	// Google Cloud 服务账号密钥（JSON 格式）。
+	ServiceAccountKey string `json:"serviceAccountKey"`
+	// Google Cloud 项目 ID。
+	// 选填。零值时使用服务账号密钥中的项目 ID。
+	ProjectId string `json:"projectId,omitempty"`
+	// Google Cloud 区域。
+	// 选填。零值或 "global" 时表示全局 SSL 证书资源。
+	Region string `json:"region,omitempty"`
+}
+
+type UploaderProvider struct {
+	config    *UploaderConfig
+	logger    *slog.Logger
+	sdkClient *gcloudsdk.Client
+}
+
+var _ uploader.Uploader = (*UploaderProvider)(nil)
+
+func NewUploader(config *UploaderConfig) (*UploaderProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	client, err := createSdkClient(config.ServiceAccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sdk client: %w", err)
+	}
+
+	return &UploaderProvider{
+		config:    config,
+		logger:    slog.Default(),
+		sdkClient: client,
+	}, nil
+}
+
+func (u *UploaderProvider) WithLogger(logger *slog.Logger) uploader.Uploader {
+	if logger == nil {
+		u.logger = slog.New(slog.DiscardHandler)
+	} else {
+		u.logger = logger
+	}
+	return u
+}
+
+func (u *UploaderProvider) Upload(ctx context.Context, certPEM string, privkeyPEM string) (*uploader.UploadResult, error) {
+	// 解析证书内容
+	certX509, err := certutil.ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	projectId := u.config.ProjectId
+	if projectId == "" {
+		projectId = u.sdkClient.ProjectId()
+	}
+	if projectId == "" {
+		return nil, errors.New("config `projectId` is required")
+	}
+
+	// 获取证书列表，避免重复上传
+	// REF: https://cloud.google.com/compute/docs/reference/rest/v1/sslCertificates/list
+	listSslCertificatesResp, err := u.sdkClient.ListSslCertificates(projectId, u.config.Region)
+	u.logger.Debug("sdk request 'compute.ListSslCertificates'", slog.String("project", projectId), slog.String("region", u.config.Region), slog.Int("count", len(listSslCertificatesResp)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'compute.ListSslCertificates': %w", err)
+	}
+
+	for _, sslCertificate := range listSslCertificatesResp {
+		if sslCertificate.Type == "MANAGED" || sslCertificate.Certificate == "" {
+			continue
+		}
+
+		oldCertX509, err := certutil.ParseCertificateFromPEM(sslCertificate.Certificate)
+		if err != nil {
+			continue
+		}
+
+		if !certutil.EqualCertificate(certX509, oldCertX509) {
+			continue
+		}
+
+		// 如果已存在相同证书，直接返回
+		u.logger.Info("ssl certificate already exists")
+		return &uploader.UploadResult{
+			CertId:   sslCertificate.SelfLink,
+			CertName: sslCertificate.Name,
+		}, nil
+	}
+
+	// 生成新证书名（需符合 Google Cloud 资源命名规则）
+	certName := fmt.Sprintf("certimate-%d", time.Now().UnixMilli())
+
+	// 创建 SSL 证书
+	// REF: https://cloud.google.com/compute/docs/reference/rest/v1/sslCertificates/insert
+	insertSslCertificateReq := &gcloudsdk.SslCertificate{
+		Name:        certName,
+		Description: "uploaded by certimate",
+		Type:        "SELF_MANAGED",
+		SelfManaged: &gcloudsdk.SslCertificateSelfManaged{
+			Certificate: certPEM,
+			PrivateKey:  privkeyPEM,
+		},
+	}
+	insertSslCertificateResp, err := u.sdkClient.InsertSslCertificate(projectId, u.config.Region, insertSslCertificateReq)
+	u.logger.Debug("sdk request 'compute.InsertSslCertificate'", slog.String("name", certName), slog.Any("response", insertSslCertificateResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute sdk request 'compute.InsertSslCertificate': %w", err)
+	}
+
+	operation, err := u.sdkClient.WaitComputeOperation(ctx, insertSslCertificateResp, 2*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for ssl certificate creation: %w", err)
+	}
+
+	return &uploader.UploadResult{
+		CertId:   operation.TargetLink,
+		CertName: certName,
+	}, nil
+}
+
+func createSdkClient(serviceAccountKey string) (*gcloudsdk.Client, error) {
+	if serviceAccountKey == "" {
+		return nil, errors.New("invalid googlecloud service account key")
+	}
+
+	return gcloudsdk.NewClient(serviceAccountKey)
+}
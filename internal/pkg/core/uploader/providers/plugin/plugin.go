@@ -0,0 +1,87 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/usual2970/certimate/internal/pkg/core/uploader"
+	pluginsdk "github.com/usual2970/certimate/pkg/plugin"
+)
+
+type UploaderConfig struct {
+	// 插件名称。
+	PluginName string `json:"pluginName"`
+	// 传递给插件的授权配置。
+	AccessConfig map[string]any `json:"accessConfig,omitempty"`
+	// 传递给插件的上传配置。
+	ServiceConfig map[string]any `json:"serviceConfig,omitempty"`
+	// 插件客户端。
+	Client *pluginsdk.Client `json:"-"`
+}
+
+type UploaderProvider struct {
+	config *UploaderConfig
+	logger *slog.Logger
+}
+
+var _ uploader.Uploader = (*UploaderProvider)(nil)
+
+func NewUploader(config *UploaderConfig) (*UploaderProvider, error) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	if config.Client == nil {
+		return nil, errors.New("plugin client is not available")
+	}
+
+	if !config.Client.Info().HasKind(pluginsdk.KindUploader) {
+		return nil, fmt.Errorf("plugin '%s' does not implement '%s'", config.PluginName, pluginsdk.KindUploader)
+	}
+
+	return &UploaderProvider{
+		config: config,
+		logger: slog.Default(),
+	}, nil
+}
+
+func (u *UploaderProvider) WithLogger(logger *slog.Logger) uploader.Uploader {
+	if logger == nil {
+		u.logger = slog.New(slog.DiscardHandler)
+	} else {
+		u.logger = logger
+	}
+	return u
+}
+
+func (u *UploaderProvider) Upload(ctx context.Context, certPEM string, privkeyPEM string) (*uploader.UploadResult, error) {
+	accessConfig, err := json.Marshal(u.config.AccessConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin access config: %w", err)
+	}
+
+	serviceConfig, err := json.Marshal(u.config.ServiceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin service config: %w", err)
+	}
+
+	uploadResp, err := u.config.Client.Upload(ctx, &pluginsdk.UploadRequest{
+		AccessConfig:  accessConfig,
+		ServiceConfig: serviceConfig,
+		Certificate:   certPEM,
+		PrivateKey:    privkeyPEM,
+	})
+	u.logger.Debug("plugin request 'Upload'", slog.String("plugin", u.config.PluginName), slog.Any("response", uploadResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute plugin request 'Upload': %w", err)
+	}
+
+	return &uploader.UploadResult{
+		CertId:       uploadResp.CertId,
+		CertName:     uploadResp.CertName,
+		ExtendedData: uploadResp.ExtendedData,
+	}, nil
+}
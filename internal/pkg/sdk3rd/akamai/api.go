@@ -0,0 +1,54 @@
+package akamai
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// REF: https://techdocs.akamai.com/cps/reference/get-enrollment
+func (c *Client) GetEnrollment(enrollmentId int64) (*Enrollment, error) {
+	headers := map[string]string{
+		"Accept": "application/vnd.akamai.cps.enrollment.v12+json",
+	}
+
+	resp := &Enrollment{}
+	err := c.sendRequestWithResult(http.MethodGet, fmt.Sprintf("/cps/v2/enrollments/%d", enrollmentId), headers, nil, resp)
+	return resp, err
+}
+
+// 获取变更状态，changeLocation 形如 "/cps/v2/enrollments/{enrollmentId}/changes/{changeId}"。
+// REF: https://techdocs.akamai.com/cps/reference/get-enrollment-change
+func (c *Client) GetChangeStatus(changeLocation string) (*ChangeStatus, error) {
+	headers := map[string]string{
+		"Accept": "application/vnd.akamai.cps.change.v2+json",
+	}
+
+	resp := &ChangeStatus{}
+	err := c.sendRequestWithResult(http.MethodGet, changeLocation, headers, nil, resp)
+	return resp, err
+}
+
+// 获取第三方证书的 CSR，infoLocation 取自变更状态中的 allowedInput.info。
+// REF: https://techdocs.akamai.com/cps/reference/get-change-third-party-csr
+func (c *Client) GetThirdPartyCsr(infoLocation string) (*ThirdPartyCsr, error) {
+	headers := map[string]string{
+		"Accept": "application/vnd.akamai.cps.csr.v2+json",
+	}
+
+	resp := &ThirdPartyCsr{}
+	err := c.sendRequestWithResult(http.MethodGet, infoLocation, headers, nil, resp)
+	return resp, err
+}
+
+// 上传第三方证书及证书链，updateLocation 取自变更状态中的 allowedInput.update。
+// REF: https://techdocs.akamai.com/cps/reference/post-change-allowed-input
+func (c *Client) UploadThirdPartyCertificate(updateLocation string, req *UploadThirdPartyCertificateRequest) (*UploadThirdPartyCertificateResponse, error) {
+	headers := map[string]string{
+		"Accept":       "application/vnd.akamai.cps.change-id.v1+json",
+		"Content-Type": "application/vnd.akamai.cps.certificate-and-trust-chain.v2+json",
+	}
+
+	resp := &UploadThirdPartyCertificateResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, updateLocation, headers, req, resp)
+	return resp, err
+}
@@ -0,0 +1,143 @@
+package akamai
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Akamai OPEN API 客户端，使用 EdgeGrid 签名认证。
+// REF: https://techdocs.akamai.com/developer/docs/authenticate-with-edgegrid
+type Client struct {
+	clientToken  string
+	clientSecret string
+	accessToken  string
+
+	client *resty.Client
+}
+
+// 参与签名计算的请求体的最大长度。
+const edgegridMaxBody = 131072
+
+func NewClient(host, clientToken, clientSecret, accessToken string) *Client {
+	host = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://"), "/")
+
+	client := &Client{
+		clientToken:  clientToken,
+		clientSecret: clientSecret,
+		accessToken:  accessToken,
+	}
+	client.client = resty.New().
+		SetBaseURL("https://"+host).
+		SetHeader("User-Agent", "certimate").
+		SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
+			return client.signRequest(req, time.Now().UTC(), newNonce())
+		})
+
+	return client
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) signRequest(req *http.Request, timestamp time.Time, nonce string) error {
+	// 仅 POST 请求的请求体参与签名
+	contentHash := ""
+	if req.Method == http.MethodPost && req.Body != nil {
+		raw, err := io.ReadAll(req.Body)
+		if err != nil {
+			return fmt.Errorf("akamai: failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(raw))
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(raw)), nil }
+
+		body := raw
+
+		if len(body) > edgegridMaxBody {
+			body = body[:edgegridMaxBody]
+		}
+		if len(body) > 0 {
+			sum := sha256.Sum256(body)
+			contentHash = base64.StdEncoding.EncodeToString(sum[:])
+		}
+	}
+
+	ts := timestamp.Format("20060102T15:04:05+0000")
+	authHeader := fmt.Sprintf("EG1-HMAC-SHA256 client_token=%s;access_token=%s;timestamp=%s;nonce=%s;", c.clientToken, c.accessToken, ts, nonce)
+
+	pathAndQuery := req.URL.EscapedPath()
+	if req.URL.RawQuery != "" {
+		pathAndQuery += "?" + req.URL.RawQuery
+	}
+	dataToSign := strings.Join([]string{
+		req.Method,
+		"https",
+		req.URL.Host,
+		pathAndQuery,
+		"",
+		contentHash,
+		authHeader,
+	}, "\t")
+
+	signingKey := hmacSha256Base64([]byte(c.clientSecret), ts)
+	signature := hmacSha256Base64([]byte(signingKey), dataToSign)
+	req.Header.Set("Authorization", authHeader+"signature="+signature)
+	return nil
+}
+
+func newNonce() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func hmacSha256Base64(key []byte, data string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (c *Client) sendRequest(method string, path string, headers map[string]string, params interface{}) (*resty.Response, error) {
+	req := c.client.R().SetHeaders(headers)
+	if params != nil {
+		req = req.SetBody(params)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("akamai api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &errorResponse{}
+		if err := json.Unmarshal(resp.Body(), errResp); err == nil && errResp.Title != "" {
+			return resp, fmt.Errorf("akamai api error: unexpected status code: %d, type: %s, title: %s, detail: %s", resp.StatusCode(), errResp.Type, errResp.Title, errResp.Detail)
+		}
+		return resp, fmt.Errorf("akamai api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(method string, path string, headers map[string]string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(method, path, headers, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("akamai api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
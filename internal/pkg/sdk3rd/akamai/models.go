@@ -0,0 +1,86 @@
+package akamai
+
+import (
+	"encoding/json"
+)
+
+type errorResponse struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+type Enrollment struct {
+	Id                   int64            `json:"id"`
+	ValidationType       string           `json:"validationType"`
+	CertificateType      string           `json:"certificateType"`
+	Location             string           `json:"location"`
+	PendingChanges       []*PendingChange `json:"pendingChanges"`
+	ChangeManagement     bool             `json:"changeManagement"`
+	CertificateChainType string           `json:"certificateChainType,omitempty"`
+	Csr                  *struct {
+		Cn   string   `json:"cn"`
+		Sans []string `json:"sans"`
+	} `json:"csr,omitempty"`
+}
+
+// 待处理变更。旧版本接口返回变更地址字符串，新版本接口返回对象。
+type PendingChange struct {
+	Location   string `json:"location"`
+	ChangeType string `json:"changeType,omitempty"`
+}
+
+func (p *PendingChange) UnmarshalJSON(data []byte) error {
+	var location string
+	if err := json.Unmarshal(data, &location); err == nil {
+		p.Location = location
+		return nil
+	}
+
+	type alias PendingChange
+	return json.Unmarshal(data, (*alias)(p))
+}
+
+const (
+	CHANGE_STATUS_WAIT_UPLOAD_THIRD_PARTY  = "wait-upload-third-party"
+	CHANGE_STATUS_WAIT_REVIEW_CERT_WARNING = "wait-review-cert-warning"
+)
+
+const (
+	ALLOWED_INPUT_TYPE_THIRD_PARTY_CERTIFICATE = "third-party-certificate"
+)
+
+type ChangeStatus struct {
+	StatusInfo *struct {
+		Status      string `json:"status"`
+		State       string `json:"state"`
+		Description string `json:"description"`
+	} `json:"statusInfo"`
+	AllowedInput []*struct {
+		Type              string `json:"type"`
+		RequiredToProceed bool   `json:"requiredToProceed"`
+		Info              string `json:"info"`
+		Update            string `json:"update"`
+	} `json:"allowedInput"`
+}
+
+type ThirdPartyCsr struct {
+	Csrs []*struct {
+		Csr          string `json:"csr"`
+		KeyAlgorithm string `json:"keyAlgorithm"`
+	} `json:"csrs"`
+}
+
+type CertificateAndTrustChain struct {
+	Certificate  string `json:"certificate"`
+	TrustChain   string `json:"trustChain,omitempty"`
+	KeyAlgorithm string `json:"keyAlgorithm"`
+}
+
+type UploadThirdPartyCertificateRequest struct {
+	CertificatesAndTrustChains []*CertificateAndTrustChain `json:"certificatesAndTrustChains"`
+}
+
+type UploadThirdPartyCertificateResponse struct {
+	Change string `json:"change"`
+}
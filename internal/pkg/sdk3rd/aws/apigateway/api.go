@@ -0,0 +1,21 @@
+package apigateway
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// REF: https://docs.aws.amazon.com/apigateway/latest/api/API_GetDomainName.html
+func (c *Client) GetDomainName(ctx context.Context, domainName string) (*DomainName, error) {
+	resp := &DomainName{}
+	err := c.sendRequestWithResult(ctx, http.MethodGet, "/domainnames/"+url.PathEscape(domainName), nil, resp)
+	return resp, err
+}
+
+// REF: https://docs.aws.amazon.com/apigateway/latest/api/API_UpdateDomainName.html
+func (c *Client) UpdateDomainName(ctx context.Context, domainName string, req *UpdateDomainNameRequest) (*DomainName, error) {
+	resp := &DomainName{}
+	err := c.sendRequestWithResult(ctx, http.MethodPatch, "/domainnames/"+url.PathEscape(domainName), req, resp)
+	return resp, err
+}
@@ -0,0 +1,118 @@
+package apigateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-resty/resty/v2"
+)
+
+// API Gateway（REST API）的最小化客户端，仅实现自定义域名证书替换所需的接口。
+// REF: https://docs.aws.amazon.com/apigateway/latest/api/API_Operations.html
+type Client struct {
+	client *resty.Client
+
+	region      string
+	credentials aws.Credentials
+}
+
+const (
+	signingName   = "apigateway"
+	headerPayload = "X-Amz-Content-Sha256"
+)
+
+// 入参 endpoint 选填，零值时使用 AWS 官方服务地址，可用于对接 LocalStack 等本地模拟服务。
+func NewClient(endpoint, region, accessKeyId, secretAccessKey string) (*Client, error) {
+	if region == "" {
+		return nil, fmt.Errorf("apigateway api error: invalid region")
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://apigateway.%s.amazonaws.com", region)
+	}
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, fmt.Errorf("apigateway api error: invalid endpoint: %w", err)
+	}
+
+	client := &Client{
+		region: region,
+		credentials: aws.Credentials{
+			AccessKeyID:     accessKeyId,
+			SecretAccessKey: secretAccessKey,
+		},
+	}
+	client.client = resty.New().
+		SetBaseURL(strings.TrimRight(endpoint, "/")).
+		SetHeader("Accept", "application/json").
+		SetHeader("User-Agent", "certimate").
+		SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
+			payloadHash := req.Header.Get(headerPayload)
+			return v4.NewSigner().SignHTTP(req.Context(), client.credentials, req, payloadHash, signingName, client.region, time.Now().UTC())
+		})
+
+	return client, nil
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+func (c *Client) sendRequest(ctx context.Context, method string, path string, params interface{}) (*resty.Response, error) {
+	body := []byte{}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("apigateway api error: failed to marshal request: %w", err)
+		}
+		body = data
+	}
+	payloadHash := sha256.Sum256(body)
+
+	req := c.client.R().
+		SetContext(ctx).
+		SetHeader(headerPayload, hex.EncodeToString(payloadHash[:]))
+	if params != nil {
+		req = req.SetHeader("Content-Type", "application/json").SetBody(body)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("apigateway api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &errorResponse{}
+		if err := json.Unmarshal(resp.Body(), errResp); err == nil && errResp.GetMessage() != "" {
+			return resp, fmt.Errorf("apigateway api error: unexpected status code: %d, type: %s, message: %s", resp.StatusCode(), resp.Header().Get("X-Amzn-ErrorType"), errResp.GetMessage())
+		}
+		return resp, fmt.Errorf("apigateway api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(ctx context.Context, method string, path string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(ctx, method, path, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp.Body(), result); err != nil {
+		return fmt.Errorf("apigateway api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package apigateway
+
+type errorResponse struct {
+	Message      string `json:"message"`
+	MessageUpper string `json:"Message"`
+}
+
+func (r *errorResponse) GetMessage() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	return r.MessageUpper
+}
+
+const (
+	ENDPOINT_TYPE_EDGE     = "EDGE"
+	ENDPOINT_TYPE_REGIONAL = "REGIONAL"
+	ENDPOINT_TYPE_PRIVATE  = "PRIVATE"
+)
+
+type DomainName struct {
+	DomainName             string `json:"domainName"`
+	CertificateArn         string `json:"certificateArn,omitempty"`
+	RegionalCertificateArn string `json:"regionalCertificateArn,omitempty"`
+	EndpointConfiguration  *struct {
+		Types []string `json:"types"`
+	} `json:"endpointConfiguration,omitempty"`
+	DomainNameStatus string `json:"domainNameStatus,omitempty"`
+}
+
+// 返回自定义域名的终端节点类型，未返回时视为边缘优化类型。
+func (d *DomainName) GetEndpointType() string {
+	if d.EndpointConfiguration != nil && len(d.EndpointConfiguration.Types) > 0 {
+		return d.EndpointConfiguration.Types[0]
+	}
+	return ENDPOINT_TYPE_EDGE
+}
+
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value,omitempty"`
+}
+
+type UpdateDomainNameRequest struct {
+	PatchOperations []*PatchOperation `json:"patchOperations"`
+}
@@ -0,0 +1,75 @@
+package elbv2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeListeners.html
+func (c *Client) DescribeListeners(ctx context.Context, req *DescribeListenersRequest) (*DescribeListenersResponse, error) {
+	params := url.Values{}
+	if req.LoadBalancerArn != "" {
+		params.Set("LoadBalancerArn", req.LoadBalancerArn)
+	}
+	for i, arn := range req.ListenerArns {
+		params.Set(fmt.Sprintf("ListenerArns.member.%d", i+1), arn)
+	}
+	if req.Marker != "" {
+		params.Set("Marker", req.Marker)
+	}
+
+	resp := &DescribeListenersResponse{}
+	err := c.sendRequestWithResult(ctx, "DescribeListeners", params, resp)
+	return resp, err
+}
+
+// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeListenerCertificates.html
+func (c *Client) DescribeListenerCertificates(ctx context.Context, req *DescribeListenerCertificatesRequest) (*DescribeListenerCertificatesResponse, error) {
+	params := url.Values{}
+	params.Set("ListenerArn", req.ListenerArn)
+	if req.Marker != "" {
+		params.Set("Marker", req.Marker)
+	}
+
+	resp := &DescribeListenerCertificatesResponse{}
+	err := c.sendRequestWithResult(ctx, "DescribeListenerCertificates", params, resp)
+	return resp, err
+}
+
+// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_ModifyListener.html
+func (c *Client) ModifyListener(ctx context.Context, req *ModifyListenerRequest) (*ModifyListenerResponse, error) {
+	params := url.Values{}
+	params.Set("ListenerArn", req.ListenerArn)
+	params.Set("Certificates.member.1.CertificateArn", req.CertificateArn)
+
+	resp := &ModifyListenerResponse{}
+	err := c.sendRequestWithResult(ctx, "ModifyListener", params, resp)
+	return resp, err
+}
+
+// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_AddListenerCertificates.html
+func (c *Client) AddListenerCertificates(ctx context.Context, req *AddListenerCertificatesRequest) (*AddListenerCertificatesResponse, error) {
+	params := url.Values{}
+	params.Set("ListenerArn", req.ListenerArn)
+	for i, arn := range req.CertificateArns {
+		params.Set(fmt.Sprintf("Certificates.member.%d.CertificateArn", i+1), arn)
+	}
+
+	resp := &AddListenerCertificatesResponse{}
+	err := c.sendRequestWithResult(ctx, "AddListenerCertificates", params, resp)
+	return resp, err
+}
+
+// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_RemoveListenerCertificates.html
+func (c *Client) RemoveListenerCertificates(ctx context.Context, req *RemoveListenerCertificatesRequest) (*RemoveListenerCertificatesResponse, error) {
+	params := url.Values{}
+	params.Set("ListenerArn", req.ListenerArn)
+	for i, arn := range req.CertificateArns {
+		params.Set(fmt.Sprintf("Certificates.member.%d.CertificateArn", i+1), arn)
+	}
+
+	resp := &RemoveListenerCertificatesResponse{}
+	err := c.sendRequestWithResult(ctx, "RemoveListenerCertificates", params, resp)
+	return resp, err
+}
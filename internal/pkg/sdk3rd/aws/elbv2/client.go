@@ -0,0 +1,116 @@
+package elbv2
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-resty/resty/v2"
+)
+
+// Elastic Load Balancing v2（ALB/NLB）Query API 的最小化客户端，仅实现证书替换所需的接口。
+// REF: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/Welcome.html
+type Client struct {
+	client *resty.Client
+
+	region      string
+	credentials aws.Credentials
+}
+
+const (
+	apiVersion    = "2015-12-01"
+	signingName   = "elasticloadbalancing"
+	headerPayload = "X-Amz-Content-Sha256"
+)
+
+// 入参 endpoint 选填，零值时使用 AWS 官方服务地址，可用于对接 LocalStack 等本地模拟服务。
+func NewClient(endpoint, region, accessKeyId, secretAccessKey string) (*Client, error) {
+	if region == "" {
+		return nil, fmt.Errorf("elbv2 api error: invalid region")
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://elasticloadbalancing.%s.amazonaws.com", region)
+	}
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, fmt.Errorf("elbv2 api error: invalid endpoint: %w", err)
+	}
+
+	client := &Client{
+		region: region,
+		credentials: aws.Credentials{
+			AccessKeyID:     accessKeyId,
+			SecretAccessKey: secretAccessKey,
+		},
+	}
+	client.client = resty.New().
+		SetBaseURL(strings.TrimRight(endpoint, "/")).
+		SetHeader("Content-Type", "application/x-www-form-urlencoded; charset=utf-8").
+		SetHeader("User-Agent", "certimate").
+		SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
+			payloadHash := req.Header.Get(headerPayload)
+			return v4.NewSigner().SignHTTP(req.Context(), client.credentials, req, payloadHash, signingName, client.region, time.Now().UTC())
+		})
+
+	return client, nil
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+func (c *Client) sendRequest(ctx context.Context, action string, params url.Values) (*resty.Response, error) {
+	form := url.Values{}
+	for k, v := range params {
+		form[k] = v
+	}
+	form.Set("Action", action)
+	form.Set("Version", apiVersion)
+
+	body := []byte(form.Encode())
+	payloadHash := sha256.Sum256(body)
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetHeader(headerPayload, hex.EncodeToString(payloadHash[:])).
+		SetBody(body).
+		Post("/")
+	if err != nil {
+		return resp, fmt.Errorf("elbv2 api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &errorResponse{}
+		if err := xml.Unmarshal(resp.Body(), errResp); err == nil && errResp.Error.Code != "" {
+			return resp, fmt.Errorf("elbv2 api error: unexpected status code: %d, code: %s, message: %s", resp.StatusCode(), errResp.Error.Code, errResp.Error.Message)
+		}
+		return resp, fmt.Errorf("elbv2 api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(ctx context.Context, action string, params url.Values, result interface{}) error {
+	resp, err := c.sendRequest(ctx, action, params)
+	if err != nil {
+		return err
+	}
+
+	if err := xml.Unmarshal(resp.Body(), result); err != nil {
+		return fmt.Errorf("elbv2 api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
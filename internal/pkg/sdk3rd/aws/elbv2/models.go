@@ -0,0 +1,69 @@
+package elbv2
+
+type errorResponse struct {
+	Error struct {
+		Type    string `xml:"Type"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Error"`
+	RequestId string `xml:"RequestId"`
+}
+
+type Certificate struct {
+	CertificateArn string `xml:"CertificateArn"`
+	IsDefault      bool   `xml:"IsDefault"`
+}
+
+type Listener struct {
+	ListenerArn     string         `xml:"ListenerArn"`
+	LoadBalancerArn string         `xml:"LoadBalancerArn"`
+	Port            int32          `xml:"Port"`
+	Protocol        string         `xml:"Protocol"`
+	Certificates    []*Certificate `xml:"Certificates>member"`
+}
+
+type DescribeListenersRequest struct {
+	LoadBalancerArn string
+	ListenerArns    []string
+	Marker          string
+}
+
+type DescribeListenersResponse struct {
+	Listeners  []*Listener `xml:"DescribeListenersResult>Listeners>member"`
+	NextMarker string      `xml:"DescribeListenersResult>NextMarker"`
+}
+
+type DescribeListenerCertificatesRequest struct {
+	ListenerArn string
+	Marker      string
+}
+
+type DescribeListenerCertificatesResponse struct {
+	Certificates []*Certificate `xml:"DescribeListenerCertificatesResult>Certificates>member"`
+	NextMarker   string         `xml:"DescribeListenerCertificatesResult>NextMarker"`
+}
+
+type ModifyListenerRequest struct {
+	ListenerArn    string
+	CertificateArn string
+}
+
+type ModifyListenerResponse struct {
+	Listeners []*Listener `xml:"ModifyListenerResult>Listeners>member"`
+}
+
+type AddListenerCertificatesRequest struct {
+	ListenerArn     string
+	CertificateArns []string
+}
+
+type AddListenerCertificatesResponse struct {
+	Certificates []*Certificate `xml:"AddListenerCertificatesResult>Certificates>member"`
+}
+
+type RemoveListenerCertificatesRequest struct {
+	ListenerArn     string
+	CertificateArns []string
+}
+
+type RemoveListenerCertificatesResponse struct{}
@@ -0,0 +1,144 @@
+package resourcemanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	apiVersionResources = "2021-04-01"
+	apiVersionWeb       = "2023-12-01"
+	apiVersionCdn       = "2024-02-01"
+	apiVersionNetwork   = "2024-05-01"
+)
+
+// REF: https://learn.microsoft.com/en-us/rest/api/resources/resources/list
+func (c *Client) ListResources(ctx context.Context, filter string) ([]*GenericResource, error) {
+	path := fmt.Sprintf("/subscriptions/%s/resources", url.PathEscape(c.subscriptionId))
+	if filter != "" {
+		path += "?$filter=" + url.QueryEscape(filter)
+	}
+
+	resources := make([]*GenericResource, 0)
+	for {
+		resp := &genericResourceListResult{}
+		if err := c.sendRequestWithResult(ctx, http.MethodGet, path, apiVersionResources, nil, resp); err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, resp.Value...)
+		if resp.NextLink == "" {
+			break
+		}
+
+		// nextLink 为完整的 URL，且已包含 api-version 参数
+		nextUrl, err := url.Parse(resp.NextLink)
+		if err != nil {
+			return nil, fmt.Errorf("azure api error: invalid next link: %w", err)
+		}
+		query := nextUrl.Query()
+		query.Del("api-version")
+		path = nextUrl.Path + "?" + query.Encode()
+	}
+
+	return resources, nil
+}
+
+// 在当前订阅中按名称查找 Key Vault 的资源 ID。
+func (c *Client) FindKeyVaultId(ctx context.Context, keyvaultName string) (string, error) {
+	resources, err := c.ListResources(ctx, fmt.Sprintf("resourceType eq 'Microsoft.KeyVault/vaults' and name eq '%s'", keyvaultName))
+	if err != nil {
+		return "", err
+	}
+
+	for _, resource := range resources {
+		if strings.EqualFold(resource.Name, keyvaultName) {
+			return resource.Id, nil
+		}
+	}
+
+	return "", fmt.Errorf("azure: could not find key vault '%s' in subscription '%s'", keyvaultName, c.subscriptionId)
+}
+
+func webSitePath(siteName, slotName string) string {
+	path := fmt.Sprintf("/providers/Microsoft.Web/sites/%s", url.PathEscape(siteName))
+	if slotName != "" {
+		path += fmt.Sprintf("/slots/%s", url.PathEscape(slotName))
+	}
+	return path
+}
+
+// REF: https://learn.microsoft.com/en-us/rest/api/appservice/web-apps/get
+func (c *Client) GetWebApp(ctx context.Context, resourceGroupName, siteName, slotName string) (*WebSite, error) {
+	resp := &WebSite{}
+	path := c.ResourceGroupId(resourceGroupName) + webSitePath(siteName, slotName)
+	err := c.sendRequestWithResult(ctx, http.MethodGet, path, apiVersionWeb, nil, resp)
+	return resp, err
+}
+
+// REF: https://learn.microsoft.com/en-us/rest/api/appservice/certificates/create-or-update
+func (c *Client) CreateOrUpdateWebCertificate(ctx context.Context, resourceGroupName, certificateName string, req *WebCertificate) (*WebCertificate, error) {
+	resp := &WebCertificate{}
+	path := c.ResourceGroupId(resourceGroupName) + fmt.Sprintf("/providers/Microsoft.Web/certificates/%s", url.PathEscape(certificateName))
+	err := c.sendRequestWithResult(ctx, http.MethodPut, path, apiVersionWeb, req, resp)
+	return resp, err
+}
+
+// REF: https://learn.microsoft.com/en-us/rest/api/appservice/web-apps/get-host-name-binding
+func (c *Client) GetWebAppHostNameBinding(ctx context.Context, resourceGroupName, siteName, slotName, hostName string) (*WebHostNameBinding, error) {
+	resp := &WebHostNameBinding{}
+	path := c.ResourceGroupId(resourceGroupName) + webSitePath(siteName, slotName) + fmt.Sprintf("/hostNameBindings/%s", url.PathEscape(hostName))
+	err := c.sendRequestWithResult(ctx, http.MethodGet, path, apiVersionWeb, nil, resp)
+	return resp, err
+}
+
+// REF: https://learn.microsoft.com/en-us/rest/api/appservice/web-apps/create-or-update-host-name-binding
+func (c *Client) CreateOrUpdateWebAppHostNameBinding(ctx context.Context, resourceGroupName, siteName, slotName, hostName string, req *WebHostNameBinding) (*WebHostNameBinding, error) {
+	resp := &WebHostNameBinding{}
+	path := c.ResourceGroupId(resourceGroupName) + webSitePath(siteName, slotName) + fmt.Sprintf("/hostNameBindings/%s", url.PathEscape(hostName))
+	err := c.sendRequestWithResult(ctx, http.MethodPut, path, apiVersionWeb, req, resp)
+	return resp, err
+}
+
+// REF: https://learn.microsoft.com/en-us/rest/api/frontdoor/azurefrontdoorstandardpremium/secrets/create
+func (c *Client) CreateOrUpdateAfdSecret(ctx context.Context, resourceGroupName, profileName, secretName string, req *AfdSecret) (*AfdSecret, error) {
+	resp := &AfdSecret{}
+	path := c.ResourceGroupId(resourceGroupName) + fmt.Sprintf("/providers/Microsoft.Cdn/profiles/%s/secrets/%s", url.PathEscape(profileName), url.PathEscape(secretName))
+	err := c.sendRequestWithResult(ctx, http.MethodPut, path, apiVersionCdn, req, resp)
+	return resp, err
+}
+
+// REF: https://learn.microsoft.com/en-us/rest/api/frontdoor/azurefrontdoorstandardpremium/afd-custom-domains/get
+func (c *Client) GetAfdCustomDomain(ctx context.Context, resourceGroupName, profileName, customDomainName string) (*AfdCustomDomain, error) {
+	resp := &AfdCustomDomain{}
+	path := c.ResourceGroupId(resourceGroupName) + fmt.Sprintf("/providers/Microsoft.Cdn/profiles/%s/customDomains/%s", url.PathEscape(profileName), url.PathEscape(customDomainName))
+	err := c.sendRequestWithResult(ctx, http.MethodGet, path, apiVersionCdn, nil, resp)
+	return resp, err
+}
+
+// REF: https://learn.microsoft.com/en-us/rest/api/frontdoor/azurefrontdoorstandardpremium/afd-custom-domains/update
+func (c *Client) UpdateAfdCustomDomain(ctx context.Context, resourceGroupName, profileName, customDomainName string, req *AfdCustomDomain) (*AfdCustomDomain, error) {
+	resp := &AfdCustomDomain{}
+	path := c.ResourceGroupId(resourceGroupName) + fmt.Sprintf("/providers/Microsoft.Cdn/profiles/%s/customDomains/%s", url.PathEscape(profileName), url.PathEscape(customDomainName))
+	err := c.sendRequestWithResult(ctx, http.MethodPatch, path, apiVersionCdn, req, resp)
+	return resp, err
+}
+
+// REF: https://learn.microsoft.com/en-us/rest/api/application-gateway/application-gateways/get
+func (c *Client) GetApplicationGateway(ctx context.Context, resourceGroupName, applicationGatewayName string) (ApplicationGateway, error) {
+	resp := ApplicationGateway{}
+	path := c.ResourceGroupId(resourceGroupName) + fmt.Sprintf("/providers/Microsoft.Network/applicationGateways/%s", url.PathEscape(applicationGatewayName))
+	err := c.sendRequestWithResult(ctx, http.MethodGet, path, apiVersionNetwork, nil, &resp)
+	return resp, err
+}
+
+// REF: https://learn.microsoft.com/en-us/rest/api/application-gateway/application-gateways/create-or-update
+func (c *Client) CreateOrUpdateApplicationGateway(ctx context.Context, resourceGroupName, applicationGatewayName string, req ApplicationGateway) (ApplicationGateway, error) {
+	resp := ApplicationGateway{}
+	path := c.ResourceGroupId(resourceGroupName) + fmt.Sprintf("/providers/Microsoft.Network/applicationGateways/%s", url.PathEscape(applicationGatewayName))
+	err := c.sendRequestWithResult(ctx, http.MethodPut, path, apiVersionNetwork, req, &resp)
+	return resp, err
+}
@@ -0,0 +1,129 @@
+package resourcemanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	azcommon "github.com/usual2970/certimate/internal/pkg/sdk3rd/azure/common"
+)
+
+// Azure Resource Manager REST API 客户端。
+// 仅封装了部署证书所需的少量接口，认证、重试、长时间运行操作的轮询均复用 azcore 的 ARM 管道。
+// REF: https://learn.microsoft.com/en-us/rest/api/azure/
+type Client struct {
+	subscriptionId string
+
+	client *arm.Client
+}
+
+func NewClient(tenantId, clientId, clientSecret, cloudName, subscriptionId string) (*Client, error) {
+	if subscriptionId == "" {
+		return nil, fmt.Errorf("azure: subscription id is required")
+	}
+
+	env, err := azcommon.GetCloudEnvironmentConfiguration(cloudName)
+	if err != nil {
+		return nil, err
+	}
+	clientOptions := azcore.ClientOptions{Cloud: env}
+
+	credential, err := azidentity.NewClientSecretCredential(tenantId, clientId, clientSecret,
+		&azidentity.ClientSecretCredentialOptions{ClientOptions: clientOptions})
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := arm.NewClient("certimate", "v0.0.0", credential, &arm.ClientOptions{ClientOptions: clientOptions})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		subscriptionId: subscriptionId,
+		client:         client,
+	}, nil
+}
+
+// 返回订阅 ID。
+func (c *Client) SubscriptionId() string {
+	return c.subscriptionId
+}
+
+// 返回指定资源组下资源的 ID 前缀，形如 "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}"。
+func (c *Client) ResourceGroupId(resourceGroupName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", c.subscriptionId, resourceGroupName)
+}
+
+func (c *Client) sendRequest(ctx context.Context, method string, path string, apiVersion string, params interface{}) (*http.Response, error) {
+	url := runtime.JoinPaths(c.client.Endpoint(), path)
+	if strings.Contains(url, "?") {
+		url += "&api-version=" + apiVersion
+	} else {
+		url += "?api-version=" + apiVersion
+	}
+
+	req, err := runtime.NewRequest(ctx, method, url)
+	if err != nil {
+		return nil, fmt.Errorf("azure api error: failed to create request: %w", err)
+	}
+	req.Raw().Header.Set("Accept", "application/json")
+	if params != nil {
+		if err := runtime.MarshalAsJSON(req, params); err != nil {
+			return nil, fmt.Errorf("azure api error: failed to marshal request: %w", err)
+		}
+	}
+
+	resp, err := c.client.Pipeline().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("azure api error: failed to send request: %w", err)
+	} else if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent) {
+		return nil, runtime.NewResponseError(resp)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(ctx context.Context, method string, path string, apiVersion string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(ctx, method, path, apiVersion, params)
+	if err != nil {
+		return err
+	}
+
+	// 对于创建、更新类请求，需等待长时间运行的操作完成后再读取最终结果。
+	// REF: https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/async-operations
+	if method == http.MethodPut || method == http.MethodPatch {
+		poller, err := runtime.NewPoller[json.RawMessage](resp, c.client.Pipeline(), nil)
+		if err != nil {
+			return fmt.Errorf("azure api error: failed to create poller: %w", err)
+		}
+
+		data, err := poller.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{Frequency: 5 * time.Second})
+		if err != nil {
+			return fmt.Errorf("azure api error: long-running operation failed: %w", err)
+		}
+
+		if len(bytes.TrimSpace(data)) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(data, &result); err != nil {
+			return fmt.Errorf("azure api error: failed to unmarshal response: %w", err)
+		}
+		return nil
+	}
+
+	if err := runtime.UnmarshalAsJSON(resp, &result); err != nil {
+		return fmt.Errorf("azure api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package resourcemanager
+
+type GenericResource struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Location string `json:"location"`
+}
+
+type genericResourceListResult struct {
+	Value    []*GenericResource `json:"value"`
+	NextLink string             `json:"nextLink,omitempty"`
+}
+
+type WebSite struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Location   string `json:"location"`
+	Properties *struct {
+		ServerFarmId string   `json:"serverFarmId"`
+		HostNames    []string `json:"hostNames"`
+	} `json:"properties,omitempty"`
+}
+
+type WebCertificate struct {
+	Id         string                    `json:"id,omitempty"`
+	Name       string                    `json:"name,omitempty"`
+	Location   string                    `json:"location"`
+	Properties *WebCertificateProperties `json:"properties"`
+}
+
+type WebCertificateProperties struct {
+	KeyVaultId         string   `json:"keyVaultId,omitempty"`
+	KeyVaultSecretName string   `json:"keyVaultSecretName,omitempty"`
+	ServerFarmId       string   `json:"serverFarmId,omitempty"`
+	Thumbprint         string   `json:"thumbprint,omitempty"`
+	HostNames          []string `json:"hostNames,omitempty"`
+	ExpirationDate     string   `json:"expirationDate,omitempty"`
+}
+
+const (
+	SSL_STATE_DISABLED        = "Disabled"
+	SSL_STATE_SNI_ENABLED     = "SniEnabled"
+	SSL_STATE_IP_BASE_ENABLED = "IpBasedEnabled"
+)
+
+type WebHostNameBinding struct {
+	Id         string                        `json:"id,omitempty"`
+	Name       string                        `json:"name,omitempty"`
+	Properties *WebHostNameBindingProperties `json:"properties"`
+}
+
+type WebHostNameBindingProperties struct {
+	SiteName   string `json:"siteName,omitempty"`
+	SslState   string `json:"sslState,omitempty"`
+	Thumbprint string `json:"thumbprint,omitempty"`
+}
+
+const (
+	AFD_SECRET_TYPE_CUSTOMER_CERTIFICATE = "CustomerCertificate"
+)
+
+type AfdSecret struct {
+	Id         string               `json:"id,omitempty"`
+	Name       string               `json:"name,omitempty"`
+	Properties *AfdSecretProperties `json:"properties"`
+}
+
+type AfdSecretProperties struct {
+	Parameters        *AfdSecretParameters `json:"parameters"`
+	ProvisioningState string               `json:"provisioningState,omitempty"`
+}
+
+type AfdSecretParameters struct {
+	Type             string       `json:"type"`
+	SecretSource     *ResourceRef `json:"secretSource,omitempty"`
+	SecretVersion    string       `json:"secretVersion,omitempty"`
+	UseLatestVersion bool         `json:"useLatestVersion"`
+}
+
+type AfdCustomDomain struct {
+	Id         string                     `json:"id,omitempty"`
+	Name       string                     `json:"name,omitempty"`
+	Properties *AfdCustomDomainProperties `json:"properties"`
+}
+
+type AfdCustomDomainProperties struct {
+	HostName    string                `json:"hostName,omitempty"`
+	TlsSettings *AfdDomainTlsSettings `json:"tlsSettings,omitempty"`
+}
+
+type AfdDomainTlsSettings struct {
+	CertificateType   string       `json:"certificateType"`
+	MinimumTlsVersion string       `json:"minimumTlsVersion,omitempty"`
+	Secret            *ResourceRef `json:"secret,omitempty"`
+}
+
+type ResourceRef struct {
+	Id string `json:"id"`
+}
+
+// 应用程序网关的属性较多，且更新时需提交完整的资源定义，
+// 为避免遗漏未声明的字段，此处以原始 JSON 对象表示。
+type ApplicationGateway = map[string]any
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"crypto/x509"
+	"slices"
+	"strings"
+
+	cfsdk "github.com/cloudflare/cloudflare-go"
+)
+
+// 判断自定义证书与指定证书是否为同一证书。
+// Cloudflare 不返回证书内容，只能通过域名及有效期判断。
+func IsSameCertificate(customSSL *cfsdk.ZoneCustomSSL, certX509 *x509.Certificate) bool {
+	if customSSL == nil || customSSL.ExpiresOn.Unix() != certX509.NotAfter.Unix() {
+		return false
+	}
+
+	return IsSameHosts(customSSL, certX509)
+}
+
+// 判断自定义证书与指定证书的域名是否相同。
+func IsSameHosts(customSSL *cfsdk.ZoneCustomSSL, certX509 *x509.Certificate) bool {
+	if customSSL == nil {
+		return false
+	}
+
+	normalize := func(names []string) []string {
+		result := make([]string, 0, len(names))
+		for _, name := range names {
+			result = append(result, strings.ToLower(name))
+		}
+		slices.Sort(result)
+		return slices.Compact(result)
+	}
+	return slices.Equal(normalize(customSSL.Hosts), normalize(certX509.DNSNames))
+}
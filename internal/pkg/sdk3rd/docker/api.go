@@ -0,0 +1,130 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func (c *Client) PutContainerArchive(ctx context.Context, containerId string, path string, tarData []byte) error {
+	if containerId == "" {
+		return fmt.Errorf("docker api error: invalid parameter: containerId")
+	}
+
+	_, err := c.sendRequest(ctx, http.MethodPut, fmt.Sprintf("/containers/%s/archive", url.PathEscape(containerId)), map[string]string{"path": path}, tarData)
+	return err
+}
+
+func (c *Client) CreateContainer(ctx context.Context, req *CreateContainerRequest) (*CreateContainerResponse, error) {
+	var queryParams map[string]string
+	if req.Name != "" {
+		queryParams = map[string]string{"name": req.Name}
+	}
+
+	resp := &CreateContainerResponse{}
+	err := c.sendRequestWithResult(ctx, http.MethodPost, "/containers/create", queryParams, req, resp)
+	return resp, err
+}
+
+func (c *Client) RemoveContainer(ctx context.Context, containerId string, force bool) error {
+	if containerId == "" {
+		return fmt.Errorf("docker api error: invalid parameter: containerId")
+	}
+
+	_, err := c.sendRequest(ctx, http.MethodDelete, fmt.Sprintf("/containers/%s", url.PathEscape(containerId)), map[string]string{"force": strconv.FormatBool(force)}, nil)
+	return err
+}
+
+func (c *Client) KillContainer(ctx context.Context, containerId string, signal string) error {
+	if containerId == "" {
+		return fmt.Errorf("docker api error: invalid parameter: containerId")
+	}
+
+	_, err := c.sendRequest(ctx, http.MethodPost, fmt.Sprintf("/containers/%s/kill", url.PathEscape(containerId)), map[string]string{"signal": signal}, nil)
+	return err
+}
+
+func (c *Client) RestartContainer(ctx context.Context, containerId string) error {
+	if containerId == "" {
+		return fmt.Errorf("docker api error: invalid parameter: containerId")
+	}
+
+	_, err := c.sendRequest(ctx, http.MethodPost, fmt.Sprintf("/containers/%s/restart", url.PathEscape(containerId)), nil, nil)
+	return err
+}
+
+func (c *Client) PullImage(ctx context.Context, image string) error {
+	if image == "" {
+		return fmt.Errorf("docker api error: invalid parameter: image")
+	}
+
+	_, err := c.sendRequest(ctx, http.MethodPost, "/images/create", map[string]string{"fromImage": image}, nil)
+	return err
+}
+
+func (c *Client) CreateExec(ctx context.Context, req *CreateExecRequest) (*CreateExecResponse, error) {
+	if req.ContainerId == "" {
+		return nil, fmt.Errorf("docker api error: invalid parameter: ContainerId")
+	}
+
+	resp := &CreateExecResponse{}
+	err := c.sendRequestWithResult(ctx, http.MethodPost, fmt.Sprintf("/containers/%s/exec", url.PathEscape(req.ContainerId)), nil, req, resp)
+	return resp, err
+}
+
+func (c *Client) StartExec(ctx context.Context, req *StartExecRequest) (*StartExecResponse, error) {
+	if req.ExecId == "" {
+		return nil, fmt.Errorf("docker api error: invalid parameter: ExecId")
+	}
+
+	resp, err := c.sendRequest(ctx, http.MethodPost, fmt.Sprintf("/exec/%s/start", url.PathEscape(req.ExecId)), nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Tty {
+		return &StartExecResponse{Stdout: resp.String()}, nil
+	}
+
+	stdout, stderr := demultiplexStream(resp.Body())
+	return &StartExecResponse{Stdout: stdout, Stderr: stderr}, nil
+}
+
+func (c *Client) InspectExec(ctx context.Context, execId string) (*InspectExecResponse, error) {
+	if execId == "" {
+		return nil, fmt.Errorf("docker api error: invalid parameter: execId")
+	}
+
+	resp := &InspectExecResponse{}
+	err := c.sendRequestWithResult(ctx, http.MethodGet, fmt.Sprintf("/exec/%s/json", url.PathEscape(execId)), nil, nil, resp)
+	return resp, err
+}
+
+// 解析非 TTY 模式下的多路复用输出流。
+// 每帧包含 8 字节头部：第 1 字节为流类型（1 为 stdout，2 为 stderr），第 5-8 字节为大端序的帧长度。
+// REF: https://docs.docker.com/reference/api/engine/version/v1.47/#tag/Container/operation/ContainerAttach
+func demultiplexStream(data []byte) (string, string) {
+	var stdout, stderr bytes.Buffer
+	for len(data) >= 8 {
+		size := int(binary.BigEndian.Uint32(data[4:8]))
+		if len(data) < 8+size {
+			size = len(data) - 8
+		}
+
+		frame := data[8 : 8+size]
+		switch data[0] {
+		case 2:
+			stderr.Write(frame)
+		default:
+			stdout.Write(frame)
+		}
+
+		data = data[8+size:]
+	}
+
+	return stdout.String(), stderr.String()
+}
@@ -0,0 +1,114 @@
+package docker
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Docker Engine API 客户端。
+// REF: https://docs.docker.com/reference/api/engine/
+type Client struct {
+	client *resty.Client
+	scheme string
+	host   string
+}
+
+// 创建 Docker Engine API 客户端。
+// 地址格式同 DOCKER_HOST 环境变量，如 "unix:///var/run/docker.sock"、"tcp://127.0.0.1:2376"。
+func NewClient(dockerHost string) (*Client, error) {
+	if dockerHost == "" {
+		dockerHost = "unix:///var/run/docker.sock"
+	}
+
+	u, err := url.Parse(dockerHost)
+	if err != nil {
+		return nil, fmt.Errorf("docker api error: invalid docker host: %w", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	client := &Client{scheme: "http"}
+	switch u.Scheme {
+	case "unix":
+		socketPath := u.Path
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			dialer := &net.Dialer{}
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}
+		client.host = "docker"
+
+	case "tcp", "http":
+		client.host = u.Host
+
+	case "https":
+		client.scheme = "https"
+		client.host = u.Host
+
+	default:
+		return nil, fmt.Errorf("docker api error: unsupported docker host scheme '%s'", u.Scheme)
+	}
+
+	client.client = resty.New().
+		SetTransport(transport).
+		SetBaseURL(fmt.Sprintf("%s://%s", client.scheme, client.host)).
+		SetHeader("User-Agent", "certimate").
+		SetTimeout(30 * time.Second)
+
+	return client, nil
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.scheme = "https"
+	c.client.SetTLSClientConfig(config)
+	c.client.SetBaseURL(fmt.Sprintf("%s://%s", c.scheme, c.host))
+	return c
+}
+
+func (c *Client) sendRequest(ctx context.Context, method string, path string, queryParams map[string]string, body any) (*resty.Response, error) {
+	req := c.client.R().SetContext(ctx)
+	if queryParams != nil {
+		req = req.SetQueryParams(queryParams)
+	}
+	if body != nil {
+		if data, ok := body.([]byte); ok {
+			req = req.SetHeader("Content-Type", "application/x-tar").SetBody(data)
+		} else {
+			req = req.SetHeader("Content-Type", "application/json").SetBody(body)
+		}
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("docker api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		return resp, fmt.Errorf("docker api error: unexpected status code: %d, resp: %s", resp.StatusCode(), strings.TrimSpace(resp.String()))
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(ctx context.Context, method string, path string, queryParams map[string]string, body any, result any) error {
+	resp, err := c.sendRequest(ctx, method, path, queryParams, body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("docker api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package docker
+
+type CreateContainerRequest struct {
+	Name       string               `json:"-"`
+	Image      string               `json:"Image"`
+	Cmd        []string             `json:"Cmd,omitempty"`
+	HostConfig *ContainerHostConfig `json:"HostConfig,omitempty"`
+}
+
+type ContainerHostConfig struct {
+	Binds []string `json:"Binds,omitempty"`
+}
+
+type CreateContainerResponse struct {
+	Id       string   `json:"Id"`
+	Warnings []string `json:"Warnings"`
+}
+
+type CreateExecRequest struct {
+	ContainerId  string   `json:"-"`
+	Cmd          []string `json:"Cmd"`
+	AttachStdout bool     `json:"AttachStdout"`
+	AttachStderr bool     `json:"AttachStderr"`
+}
+
+type CreateExecResponse struct {
+	Id string `json:"Id"`
+}
+
+type StartExecRequest struct {
+	ExecId string `json:"-"`
+	Detach bool   `json:"Detach"`
+	Tty    bool   `json:"Tty"`
+}
+
+type StartExecResponse struct {
+	Stdout string `json:"-"`
+	Stderr string `json:"-"`
+}
+
+type InspectExecResponse struct {
+	Id       string `json:"ID"`
+	Running  bool   `json:"Running"`
+	ExitCode int    `json:"ExitCode"`
+}
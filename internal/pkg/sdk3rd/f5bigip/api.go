@@ -0,0 +1,118 @@
+package f5bigip
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// 文件上传后在设备上的存放目录。
+const UploadDirectory = "/var/config/rest/downloads"
+
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_shared_file-transfer_uploads.html
+func (c *Client) UploadFile(fileName string, data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("f5bigip api error: empty file content")
+	}
+
+	req := c.client.R().
+		SetHeader("Content-Type", "application/octet-stream").
+		SetHeader("Content-Range", fmt.Sprintf("0-%d/%d", len(data)-1, len(data))).
+		SetBody(data)
+	resp, err := req.Post("/mgmt/shared/file-transfer/uploads/" + url.PathEscape(fileName))
+	if err != nil {
+		return fmt.Errorf("f5bigip api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		return fmt.Errorf("f5bigip api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// 删除已上传的文件。
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_util_unix-rm.html
+func (c *Client) DeleteUploadedFile(fileName string) error {
+	if fileName == "" || strings.Contains(fileName, "/") {
+		return fmt.Errorf("f5bigip api error: invalid file name '%s'", fileName)
+	}
+
+	params := map[string]any{
+		"command":     "run",
+		"utilCmdArgs": UploadDirectory + "/" + fileName,
+	}
+
+	_, err := c.sendRequest(http.MethodPost, "/mgmt/tm/util/unix-rm", params)
+	return err
+}
+
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_sys_crypto_key.html
+func (c *Client) InstallCryptoKey(req *InstallCryptoObjectRequest) error {
+	params := map[string]any{
+		"command":         "install",
+		"name":            req.Name,
+		"partition":       req.Partition,
+		"from-local-file": req.FromLocalFile,
+	}
+
+	_, err := c.sendRequest(http.MethodPost, "/mgmt/tm/sys/crypto/key", params)
+	return err
+}
+
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_sys_crypto_cert.html
+func (c *Client) InstallCryptoCert(req *InstallCryptoObjectRequest) error {
+	params := map[string]any{
+		"command":         "install",
+		"name":            req.Name,
+		"partition":       req.Partition,
+		"from-local-file": req.FromLocalFile,
+	}
+
+	_, err := c.sendRequest(http.MethodPost, "/mgmt/tm/sys/crypto/cert", params)
+	return err
+}
+
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_sys_file_ssl-cert.html
+func (c *Client) GetSslCert(fullPath string) (*SslCert, error) {
+	resp := &SslCert{}
+	err := c.sendRequestWithResult(http.MethodGet, "/mgmt/tm/sys/file/ssl-cert/"+encodeFullPath(fullPath), nil, resp)
+	return resp, err
+}
+
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_sys_file_ssl-cert.html
+func (c *Client) DeleteSslCert(fullPath string) error {
+	_, err := c.sendRequest(http.MethodDelete, "/mgmt/tm/sys/file/ssl-cert/"+encodeFullPath(fullPath), nil)
+	return err
+}
+
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_sys_file_ssl-key.html
+func (c *Client) DeleteSslKey(fullPath string) error {
+	_, err := c.sendRequest(http.MethodDelete, "/mgmt/tm/sys/file/ssl-key/"+encodeFullPath(fullPath), nil)
+	return err
+}
+
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_ltm_profile_client-ssl.html
+func (c *Client) GetClientSslProfile(fullPath string) (*ClientSslProfile, error) {
+	resp := &ClientSslProfile{}
+	err := c.sendRequestWithResult(http.MethodGet, "/mgmt/tm/ltm/profile/client-ssl/"+encodeFullPath(fullPath), nil, resp)
+	return resp, err
+}
+
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_ltm_profile_client-ssl.html
+func (c *Client) UpdateClientSslProfile(fullPath string, req *UpdateClientSslProfileRequest) (*ClientSslProfile, error) {
+	resp := &ClientSslProfile{}
+	err := c.sendRequestWithResult(http.MethodPatch, "/mgmt/tm/ltm/profile/client-ssl/"+encodeFullPath(fullPath), req, resp)
+	return resp, err
+}
+
+// 将配置同步到设备组。
+// REF: https://clouddocs.f5.com/api/icontrol-rest/APIRef_tm_cm.html
+func (c *Client) ConfigSync(deviceGroup string) error {
+	params := map[string]any{
+		"command":     "run",
+		"utilCmdArgs": "config-sync to-group " + deviceGroup,
+	}
+
+	_, err := c.sendRequest(http.MethodPost, "/mgmt/tm/cm", params)
+	return err
+}
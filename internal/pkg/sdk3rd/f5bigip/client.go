@@ -0,0 +1,131 @@
+package f5bigip
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// F5 BIG-IP iControl REST 客户端，使用令牌认证。
+// REF: https://clouddocs.f5.com/api/icontrol-rest/
+type Client struct {
+	username string
+	password string
+
+	token          string
+	tokenExpiresAt time.Time
+	tokenMtx       sync.Mutex
+
+	client *resty.Client
+}
+
+func NewClient(serverUrl, username, password string) *Client {
+	client := &Client{
+		username: username,
+		password: password,
+	}
+	client.client = resty.New().
+		SetBaseURL(strings.TrimRight(serverUrl, "/")).
+		SetHeader("Accept", "application/json").
+		SetHeader("User-Agent", "certimate").
+		OnBeforeRequest(func(c *resty.Client, req *resty.Request) error {
+			if strings.HasSuffix(req.URL, "/mgmt/shared/authn/login") {
+				return nil
+			}
+
+			token, err := client.getToken()
+			if err != nil {
+				return err
+			}
+
+			req.SetHeader("X-F5-Auth-Token", token)
+			return nil
+		})
+
+	return client
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+// 获取认证令牌，令牌过期前一分钟重新登录。
+// REF: https://clouddocs.f5.com/products/extensions/f5-declarative-onboarding/latest/authentication.html
+func (c *Client) getToken() (string, error) {
+	c.tokenMtx.Lock()
+	defer c.tokenMtx.Unlock()
+
+	if c.token != "" && time.Now().Before(c.tokenExpiresAt) {
+		return c.token, nil
+	}
+
+	params := map[string]any{
+		"username":          c.username,
+		"password":          c.password,
+		"loginProviderName": "tmos",
+	}
+	resp := &loginResponse{}
+	if err := c.sendRequestWithResult("POST", "/mgmt/shared/authn/login", params, resp); err != nil {
+		return "", err
+	} else if resp.Token == nil || resp.Token.Token == "" {
+		return "", fmt.Errorf("f5bigip api error: failed to obtain auth token")
+	}
+
+	timeout := resp.Token.Timeout
+	if timeout <= 0 {
+		timeout = 1200
+	}
+	c.token = resp.Token.Token
+	c.tokenExpiresAt = time.Now().Add(time.Duration(timeout)*time.Second - time.Minute)
+	return c.token, nil
+}
+
+func (c *Client) sendRequest(method string, path string, params interface{}) (*resty.Response, error) {
+	req := c.client.R()
+	if params != nil {
+		req = req.SetHeader("Content-Type", "application/json").SetBody(params)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("f5bigip api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &errorResponse{}
+		if err := json.Unmarshal(resp.Body(), errResp); err == nil && errResp.Message != "" {
+			return resp, fmt.Errorf("f5bigip api error: unexpected status code: %d, code: %d, message: %s", resp.StatusCode(), errResp.Code, errResp.Message)
+		}
+		return resp, fmt.Errorf("f5bigip api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(method string, path string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(method, path, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("f5bigip api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// 将形如 "/Common/name" 的完整路径转换为 URL 中使用的 "~Common~name" 形式。
+func encodeFullPath(fullPath string) string {
+	return url.PathEscape(strings.ReplaceAll(fullPath, "/", "~"))
+}
@@ -0,0 +1,45 @@
+package f5bigip
+
+type errorResponse struct {
+	Code    int32  `json:"code"`
+	Message string `json:"message"`
+}
+
+type loginResponse struct {
+	Token *struct {
+		Token   string `json:"token"`
+		Timeout int32  `json:"timeout"`
+	} `json:"token"`
+}
+
+type SslCert struct {
+	Name       string `json:"name"`
+	Partition  string `json:"partition"`
+	FullPath   string `json:"fullPath"`
+	KeyType    string `json:"keyType,omitempty"`
+	Expiration string `json:"expirationString,omitempty"`
+}
+
+type CertKeyChain struct {
+	Name  string `json:"name"`
+	Cert  string `json:"cert"`
+	Key   string `json:"key"`
+	Chain string `json:"chain,omitempty"`
+}
+
+type ClientSslProfile struct {
+	Name         string          `json:"name"`
+	Partition    string          `json:"partition"`
+	FullPath     string          `json:"fullPath"`
+	CertKeyChain []*CertKeyChain `json:"certKeyChain"`
+}
+
+type InstallCryptoObjectRequest struct {
+	Name          string
+	Partition     string
+	FromLocalFile string
+}
+
+type UpdateClientSslProfileRequest struct {
+	CertKeyChain []*CertKeyChain `json:"certKeyChain"`
+}
@@ -0,0 +1,143 @@
+package fastly
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/private-keys/#create-tls-key
+func (c *Client) CreateTlsPrivateKey(req *CreateTlsPrivateKeyRequest) (*CreateTlsPrivateKeyResponse, error) {
+	params := map[string]any{
+		"data": map[string]any{
+			"type": "tls_private_key",
+			"attributes": map[string]any{
+				"key":  req.Key,
+				"name": req.Name,
+			},
+		},
+	}
+
+	resp := &CreateTlsPrivateKeyResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, "/tls/private_keys", nil, params, resp)
+	return resp, err
+}
+
+// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/private-keys/#list-tls-keys
+func (c *Client) ListTlsPrivateKeys(req *ListTlsPrivateKeysRequest) (*ListTlsPrivateKeysResponse, error) {
+	queryParams := map[string]string{}
+	if req.PageNumber > 0 {
+		queryParams["page[number]"] = fmt.Sprintf("%d", req.PageNumber)
+	}
+	if req.PageSize > 0 {
+		queryParams["page[size]"] = fmt.Sprintf("%d", req.PageSize)
+	}
+
+	resp := &ListTlsPrivateKeysResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/tls/private_keys", queryParams, nil, resp)
+	return resp, err
+}
+
+// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/certificates/#create-tls-cert
+func (c *Client) CreateTlsCertificate(req *CreateTlsCertificateRequest) (*CreateTlsCertificateResponse, error) {
+	params := map[string]any{
+		"data": map[string]any{
+			"type": "tls_certificate",
+			"attributes": map[string]any{
+				"cert_blob": req.CertBlob,
+				"name":      req.Name,
+			},
+		},
+	}
+
+	resp := &CreateTlsCertificateResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, "/tls/certificates", nil, params, resp)
+	return resp, err
+}
+
+// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/certificates/#update-tls-cert
+func (c *Client) UpdateTlsCertificate(certificateId string, req *UpdateTlsCertificateRequest) (*UpdateTlsCertificateResponse, error) {
+	attributes := map[string]any{
+		"cert_blob": req.CertBlob,
+	}
+	if req.Name != "" {
+		attributes["name"] = req.Name
+	}
+	params := map[string]any{
+		"data": map[string]any{
+			"type":       "tls_certificate",
+			"attributes": attributes,
+		},
+	}
+
+	resp := &UpdateTlsCertificateResponse{}
+	err := c.sendRequestWithResult(http.MethodPatch, "/tls/certificates/"+url.PathEscape(certificateId), nil, params, resp)
+	return resp, err
+}
+
+// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/certificates/#list-tls-certs
+func (c *Client) ListTlsCertificates(req *ListTlsCertificatesRequest) (*ListTlsCertificatesResponse, error) {
+	queryParams := map[string]string{}
+	if req.FilterTlsDomainsId != "" {
+		queryParams["filter[tls_domains.id]"] = req.FilterTlsDomainsId
+	}
+	if req.PageNumber > 0 {
+		queryParams["page[number]"] = fmt.Sprintf("%d", req.PageNumber)
+	}
+	if req.PageSize > 0 {
+		queryParams["page[size]"] = fmt.Sprintf("%d", req.PageSize)
+	}
+
+	resp := &ListTlsCertificatesResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/tls/certificates", queryParams, nil, resp)
+	return resp, err
+}
+
+// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/activations/#create-tls-activation
+func (c *Client) CreateTlsActivation(req *CreateTlsActivationRequest) (*CreateTlsActivationResponse, error) {
+	relationships := map[string]any{
+		"tls_certificate": map[string]any{"data": map[string]any{"type": "tls_certificate", "id": req.TlsCertificateId}},
+		"tls_domain":      map[string]any{"data": map[string]any{"type": "tls_domain", "id": req.TlsDomainId}},
+	}
+	if req.TlsConfigurationId != "" {
+		relationships["tls_configuration"] = map[string]any{"data": map[string]any{"type": "tls_configuration", "id": req.TlsConfigurationId}}
+	}
+	params := map[string]any{
+		"data": map[string]any{
+			"type":          "tls_activation",
+			"relationships": relationships,
+		},
+	}
+
+	resp := &CreateTlsActivationResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, "/tls/activations", nil, params, resp)
+	return resp, err
+}
+
+// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/activations/#update-tls-activation
+func (c *Client) UpdateTlsActivation(activationId string, req *UpdateTlsActivationRequest) (*UpdateTlsActivationResponse, error) {
+	params := map[string]any{
+		"data": map[string]any{
+			"type": "tls_activation",
+			"relationships": map[string]any{
+				"tls_certificate": map[string]any{"data": map[string]any{"type": "tls_certificate", "id": req.TlsCertificateId}},
+			},
+		},
+	}
+
+	resp := &UpdateTlsActivationResponse{}
+	err := c.sendRequestWithResult(http.MethodPatch, "/tls/activations/"+url.PathEscape(activationId), nil, params, resp)
+	return resp, err
+}
+
+// REF: https://www.fastly.com/documentation/reference/api/tls/custom-certs/activations/#list-tls-activations
+func (c *Client) ListTlsActivations(req *ListTlsActivationsRequest) (*ListTlsActivationsResponse, error) {
+	queryParams := map[string]string{}
+	if req.FilterTlsDomainId != "" {
+		queryParams["filter[tls_domain.id]"] = req.FilterTlsDomainId
+	}
+
+	resp := &ListTlsActivationsResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/tls/activations", queryParams, nil, resp)
+	return resp, err
+}
@@ -0,0 +1,69 @@
+package fastly
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type Client struct {
+	client *resty.Client
+}
+
+func NewClient(apiToken string) *Client {
+	client := resty.New().
+		SetBaseURL("https://api.fastly.com").
+		SetHeader("Accept", "application/vnd.api+json").
+		SetHeader("Content-Type", "application/vnd.api+json").
+		SetHeader("Fastly-Key", apiToken).
+		SetHeader("User-Agent", "certimate")
+
+	return &Client{
+		client: client,
+	}
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithBaseUrl(baseUrl string) *Client {
+	c.client.SetBaseURL(baseUrl)
+	return c
+}
+
+func (c *Client) sendRequest(method string, path string, queryParams map[string]string, params interface{}) (*resty.Response, error) {
+	req := c.client.R().SetQueryParams(queryParams)
+	if params != nil {
+		req = req.SetBody(params)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("fastly api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &errorResponse{}
+		if err := json.Unmarshal(resp.Body(), errResp); err == nil && len(errResp.Errors) > 0 {
+			return resp, fmt.Errorf("fastly api error: unexpected status code: %d, title: %s, detail: %s", resp.StatusCode(), errResp.Errors[0].Title, errResp.Errors[0].Detail)
+		}
+		return resp, fmt.Errorf("fastly api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(method string, path string, queryParams map[string]string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(method, path, queryParams, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("fastly api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package fastly
+
+type errorResponse struct {
+	Errors []struct {
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+}
+
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+type Relationship struct {
+	Data *ResourceIdentifier `json:"data"`
+}
+
+type RelationshipMany struct {
+	Data []*ResourceIdentifier `json:"data"`
+}
+
+type TlsPrivateKey struct {
+	Type       string `json:"type"`
+	Id         string `json:"id,omitempty"`
+	Attributes *struct {
+		Key           string `json:"key,omitempty"`
+		Name          string `json:"name,omitempty"`
+		KeyType       string `json:"key_type,omitempty"`
+		KeyLength     int32  `json:"key_length,omitempty"`
+		PublicKeySha1 string `json:"public_key_sha1,omitempty"`
+		Replace       bool   `json:"replace,omitempty"`
+	} `json:"attributes,omitempty"`
+}
+
+type TlsCertificate struct {
+	Type       string `json:"type"`
+	Id         string `json:"id,omitempty"`
+	Attributes *struct {
+		CertBlob     string `json:"cert_blob,omitempty"`
+		Name         string `json:"name,omitempty"`
+		IssuedTo     string `json:"issued_to,omitempty"`
+		Issuer       string `json:"issuer,omitempty"`
+		SerialNumber string `json:"serial_number,omitempty"`
+		NotBefore    string `json:"not_before,omitempty"`
+		NotAfter     string `json:"not_after,omitempty"`
+		Replace      bool   `json:"replace,omitempty"`
+	} `json:"attributes,omitempty"`
+	Relationships *struct {
+		TlsDomains *RelationshipMany `json:"tls_domains,omitempty"`
+	} `json:"relationships,omitempty"`
+}
+
+type TlsActivation struct {
+	Type          string `json:"type"`
+	Id            string `json:"id,omitempty"`
+	Relationships *struct {
+		TlsCertificate   *Relationship `json:"tls_certificate,omitempty"`
+		TlsConfiguration *Relationship `json:"tls_configuration,omitempty"`
+		TlsDomain        *Relationship `json:"tls_domain,omitempty"`
+	} `json:"relationships,omitempty"`
+}
+
+type CreateTlsPrivateKeyRequest struct {
+	Key  string
+	Name string
+}
+
+type CreateTlsPrivateKeyResponse struct {
+	Data *TlsPrivateKey `json:"data"`
+}
+
+type ListTlsPrivateKeysRequest struct {
+	PageNumber int32
+	PageSize   int32
+}
+
+type ListTlsPrivateKeysResponse struct {
+	Data  []*TlsPrivateKey `json:"data"`
+	Links *paginationLinks `json:"links,omitempty"`
+}
+
+type CreateTlsCertificateRequest struct {
+	CertBlob string
+	Name     string
+}
+
+type CreateTlsCertificateResponse struct {
+	Data *TlsCertificate `json:"data"`
+}
+
+type UpdateTlsCertificateRequest struct {
+	CertBlob string
+	Name     string
+}
+
+type UpdateTlsCertificateResponse struct {
+	Data *TlsCertificate `json:"data"`
+}
+
+type ListTlsCertificatesRequest struct {
+	FilterTlsDomainsId string
+	PageNumber         int32
+	PageSize           int32
+}
+
+type ListTlsCertificatesResponse struct {
+	Data  []*TlsCertificate `json:"data"`
+	Links *paginationLinks  `json:"links,omitempty"`
+}
+
+type CreateTlsActivationRequest struct {
+	TlsCertificateId   string
+	TlsConfigurationId string
+	TlsDomainId        string
+}
+
+type CreateTlsActivationResponse struct {
+	Data *TlsActivation `json:"data"`
+}
+
+type UpdateTlsActivationRequest struct {
+	TlsCertificateId string
+}
+
+type UpdateTlsActivationResponse struct {
+	Data *TlsActivation `json:"data"`
+}
+
+type ListTlsActivationsRequest struct {
+	FilterTlsDomainId string
+}
+
+type ListTlsActivationsResponse struct {
+	Data  []*TlsActivation `json:"data"`
+	Links *paginationLinks `json:"links,omitempty"`
+}
+
+type paginationLinks struct {
+	Next string `json:"next,omitempty"`
+}
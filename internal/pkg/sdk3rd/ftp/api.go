@@ -0,0 +1,195 @@
+package ftp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+func (c *Client) Login(username, password string) error {
+	if username == "" {
+		username = "anonymous"
+	}
+
+	code, _, err := c.cmd(-1, "USER %s", username)
+	if err != nil {
+		return err
+	}
+
+	switch code {
+	case 230:
+	case 331:
+		if _, _, err := c.cmd(230, "PASS %s", password); err != nil {
+			return err
+		}
+	default:
+		return &ResponseError{Code: code, Message: "unexpected response to USER"}
+	}
+
+	// 启用 TLS 时加密数据连接
+	if c.options.TLSMode != TLSModeNone {
+		if _, _, err := c.cmd(200, "PBSZ 0"); err != nil {
+			return err
+		}
+		if _, _, err := c.cmd(200, "PROT P"); err != nil {
+			return err
+		}
+		c.protData = true
+	}
+
+	if _, _, err := c.cmd(200, "TYPE I"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) Quit() error {
+	c.cmd(221, "QUIT")
+	return c.Close()
+}
+
+func (c *Client) MakeDir(dir string) error {
+	_, _, err := c.cmd(257, "MKD %s", dir)
+	return err
+}
+
+// 逐级创建目录，已存在的目录将被忽略。
+func (c *Client) MakeDirAll(dir string) error {
+	dir = path.Clean(dir)
+	if dir == "." || dir == "/" {
+		return nil
+	}
+
+	current := ""
+	if strings.HasPrefix(dir, "/") {
+		current = "/"
+	}
+	for _, segment := range strings.Split(strings.Trim(dir, "/"), "/") {
+		current = path.Join(current, segment)
+
+		if err := c.MakeDir(current); err != nil {
+			// 目录已存在时服务端通常返回 550 或 521
+			var respErr *ResponseError
+			if errors.As(err, &respErr) && (respErr.Code == 550 || respErr.Code == 521) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) Store(ctx context.Context, path string, r io.Reader) error {
+	dataConn, err := c.openDataConn(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 服务端应返回 125 或 150 表示数据传输开始
+	if code, message, err := c.cmd(-1, "STOR %s", path); err != nil {
+		dataConn.Close()
+		return err
+	} else if code/100 != 1 {
+		dataConn.Close()
+		return &ResponseError{Code: code, Message: message}
+	}
+
+	if _, err := io.Copy(dataConn, r); err != nil {
+		dataConn.Close()
+		return fmt.Errorf("ftp error: failed to write data: %w", err)
+	}
+	if err := dataConn.Close(); err != nil {
+		return fmt.Errorf("ftp error: failed to close data connection: %w", err)
+	}
+
+	if _, _, err := c.readResponse(226); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) Retrieve(ctx context.Context, path string, w io.Writer) error {
+	dataConn, err := c.openDataConn(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 服务端应返回 125 或 150 表示数据传输开始
+	if code, message, err := c.cmd(-1, "RETR %s", path); err != nil {
+		dataConn.Close()
+		return err
+	} else if code/100 != 1 {
+		dataConn.Close()
+		return &ResponseError{Code: code, Message: message}
+	}
+
+	if _, err := io.Copy(w, dataConn); err != nil {
+		dataConn.Close()
+		return fmt.Errorf("ftp error: failed to read data: %w", err)
+	}
+	dataConn.Close()
+
+	if _, _, err := c.readResponse(226); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// 列出目录下的文件名。
+// 不同服务端返回的可能是文件名或完整路径，此处统一返回文件名。
+func (c *Client) NameList(ctx context.Context, dir string) ([]string, error) {
+	dataConn, err := c.openDataConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if code, message, err := c.cmd(-1, "NLST %s", dir); err != nil {
+		dataConn.Close()
+		return nil, err
+	} else if code/100 != 1 {
+		dataConn.Close()
+		return nil, &ResponseError{Code: code, Message: message}
+	}
+
+	data, err := io.ReadAll(dataConn)
+	dataConn.Close()
+	if err != nil {
+		return nil, fmt.Errorf("ftp error: failed to read data: %w", err)
+	}
+
+	if _, _, err := c.readResponse(226); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, path.Base(line))
+		}
+	}
+
+	return names, nil
+}
+
+func (c *Client) Rename(from, to string) error {
+	if _, _, err := c.cmd(350, "RNFR %s", from); err != nil {
+		return err
+	}
+	if _, _, err := c.cmd(250, "RNTO %s", to); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) Delete(path string) error {
+	_, _, err := c.cmd(250, "DELE %s", path)
+	return err
+}
@@ -0,0 +1,208 @@
+package ftp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TLSMode string
+
+const (
+	TLSModeNone     = TLSMode("")
+	TLSModeExplicit = TLSMode("explicit")
+	TLSModeImplicit = TLSMode("implicit")
+)
+
+type ClientOptions struct {
+	// TLS 模式。
+	TLSMode TLSMode
+	// TLS 配置。
+	TLSConfig *tls.Config
+	// 是否禁用 EPSV 命令，仅使用 PASV 命令。
+	DisableEPSV bool
+	// 超时时间。
+	Timeout time.Duration
+}
+
+// 极简的 FTP/FTPS 客户端，仅支持被动模式及证书分发所需的命令。
+// REF: https://datatracker.ietf.org/doc/html/rfc959
+// REF: https://datatracker.ietf.org/doc/html/rfc4217
+type Client struct {
+	conn     net.Conn
+	textConn *textproto.Conn
+	host     string
+	options  ClientOptions
+	protData bool
+}
+
+func Dial(ctx context.Context, addr string, options *ClientOptions) (*Client, error) {
+	if options == nil {
+		options = &ClientOptions{}
+	}
+	if options.Timeout == 0 {
+		options.Timeout = 30 * time.Second
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("ftp error: invalid address: %w", err)
+	}
+
+	tlsConfig := options.TLSConfig
+	if options.TLSMode != TLSModeNone {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		} else {
+			tlsConfig = tlsConfig.Clone()
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = host
+		}
+		if tlsConfig.ClientSessionCache == nil {
+			// 多数服务端要求数据连接复用控制连接的 TLS 会话
+			tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(4)
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: options.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("ftp error: failed to connect: %w", err)
+	}
+
+	if options.TLSMode == TLSModeImplicit {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ftp error: failed to complete tls handshake: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	client := &Client{
+		conn:     conn,
+		textConn: textproto.NewConn(conn),
+		host:     host,
+		options:  *options,
+	}
+	client.options.TLSConfig = tlsConfig
+
+	if _, _, err := client.readResponse(220); err != nil {
+		client.conn.Close()
+		return nil, err
+	}
+
+	if options.TLSMode == TLSModeExplicit {
+		if _, _, err := client.cmd(234, "AUTH TLS"); err != nil {
+			client.conn.Close()
+			return nil, err
+		}
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ftp error: failed to complete tls handshake: %w", err)
+		}
+		client.conn = tlsConn
+		client.textConn = textproto.NewConn(tlsConn)
+	}
+
+	return client, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) cmd(expectCode int, format string, args ...any) (int, string, error) {
+	c.conn.SetDeadline(time.Now().Add(c.options.Timeout))
+
+	if _, err := c.textConn.Cmd(format, args...); err != nil {
+		return 0, "", fmt.Errorf("ftp error: failed to send command: %w", err)
+	}
+
+	return c.readResponse(expectCode)
+}
+
+func (c *Client) readResponse(expectCode int) (int, string, error) {
+	c.conn.SetDeadline(time.Now().Add(c.options.Timeout))
+
+	code, message, err := c.textConn.ReadResponse(expectCode)
+	if err != nil {
+		var protoErr *textproto.Error
+		if errors.As(err, &protoErr) {
+			return code, message, &ResponseError{Code: protoErr.Code, Message: protoErr.Msg}
+		}
+		return code, message, fmt.Errorf("ftp error: failed to read response: %w", err)
+	}
+
+	return code, message, nil
+}
+
+func (c *Client) openDataConn(ctx context.Context) (net.Conn, error) {
+	var addr string
+
+	if !c.options.DisableEPSV {
+		if _, message, err := c.cmd(229, "EPSV"); err == nil {
+			// 响应形如 "Entering Extended Passive Mode (|||port|)"
+			start, end := strings.Index(message, "("), strings.LastIndex(message, ")")
+			if start >= 0 && end > start {
+				fields := strings.Split(message[start+1:end], string(message[start+1]))
+				if len(fields) == 5 {
+					addr = net.JoinHostPort(c.host, fields[3])
+				}
+			}
+		}
+	}
+
+	if addr == "" {
+		_, message, err := c.cmd(227, "PASV")
+		if err != nil {
+			return nil, err
+		}
+
+		// 响应形如 "Entering Passive Mode (h1,h2,h3,h4,p1,p2)"，仅使用端口部分以兼容 NAT 后的服务端
+		start, end := strings.Index(message, "("), strings.LastIndex(message, ")")
+		if start < 0 || end <= start {
+			return nil, fmt.Errorf("ftp error: invalid pasv response: %s", message)
+		}
+		fields := strings.Split(message[start+1:end], ",")
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("ftp error: invalid pasv response: %s", message)
+		}
+		p1, err1 := strconv.Atoi(strings.TrimSpace(fields[4]))
+		p2, err2 := strconv.Atoi(strings.TrimSpace(fields[5]))
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("ftp error: invalid pasv response: %s", message)
+		}
+		addr = net.JoinHostPort(c.host, strconv.Itoa(p1*256+p2))
+	}
+
+	dialer := &net.Dialer{Timeout: c.options.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("ftp error: failed to open data connection: %w", err)
+	}
+
+	if c.protData {
+		conn = tls.Client(conn, c.options.TLSConfig)
+	}
+
+	return conn, nil
+}
+
+type ResponseError struct {
+	Code    int
+	Message string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("ftp error: unexpected response: %d %s", e.Code, e.Message)
+}
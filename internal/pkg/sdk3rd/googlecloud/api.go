@@ -0,0 +1,176 @@
+package googlecloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// 区域为空或 "global" 时返回全局资源路径，否则返回区域资源路径。
+func (c *Client) computeScopeUrl(project, region string) string {
+	if region == "" || region == "global" {
+		return fmt.Sprintf("%s/projects/%s/global", c.computeBaseUrl, url.PathEscape(project))
+	}
+	return fmt.Sprintf("%s/projects/%s/regions/%s", c.computeBaseUrl, url.PathEscape(project), url.PathEscape(region))
+}
+
+func (c *Client) ListSslCertificates(project, region string) ([]*SslCertificate, error) {
+	certificates := make([]*SslCertificate, 0)
+
+	pageToken := ""
+	for {
+		reqUrl := c.computeScopeUrl(project, region) + "/sslCertificates?maxResults=500"
+		if pageToken != "" {
+			reqUrl += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		resp := &listSslCertificatesResponse{}
+		if err := c.sendRequestWithResult(http.MethodGet, reqUrl, nil, resp); err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, resp.Items...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	return certificates, nil
+}
+
+func (c *Client) InsertSslCertificate(project, region string, req *SslCertificate) (*ComputeOperation, error) {
+	resp := &ComputeOperation{}
+	err := c.sendRequestWithResult(http.MethodPost, c.computeScopeUrl(project, region)+"/sslCertificates", req, resp)
+	return resp, err
+}
+
+func (c *Client) GetTargetHttpsProxy(project, region, name string) (*TargetProxy, error) {
+	resp := &TargetProxy{}
+	err := c.sendRequestWithResult(http.MethodGet, c.computeScopeUrl(project, region)+"/targetHttpsProxies/"+url.PathEscape(name), nil, resp)
+	return resp, err
+}
+
+func (c *Client) SetTargetHttpsProxySslCertificates(project, region, name string, sslCertificates []string) (*ComputeOperation, error) {
+	// 全局与区域资源的 setSslCertificates 方法路径不同
+	// REF: https://cloud.google.com/compute/docs/reference/rest/v1/targetHttpsProxies/setSslCertificates
+	// REF: https://cloud.google.com/compute/docs/reference/rest/v1/regionTargetHttpsProxies/setSslCertificates
+	reqUrl := c.computeScopeUrl(project, region) + "/targetHttpsProxies/" + url.PathEscape(name) + "/setSslCertificates"
+	if region == "" || region == "global" {
+		reqUrl = fmt.Sprintf("%s/projects/%s/targetHttpsProxies/%s/setSslCertificates", c.computeBaseUrl, url.PathEscape(project), url.PathEscape(name))
+	}
+
+	resp := &ComputeOperation{}
+	err := c.sendRequestWithResult(http.MethodPost, reqUrl, &setSslCertificatesRequest{SslCertificates: sslCertificates}, resp)
+	return resp, err
+}
+
+func (c *Client) GetTargetSslProxy(project, name string) (*TargetProxy, error) {
+	resp := &TargetProxy{}
+	err := c.sendRequestWithResult(http.MethodGet, c.computeScopeUrl(project, "")+"/targetSslProxies/"+url.PathEscape(name), nil, resp)
+	return resp, err
+}
+
+func (c *Client) SetTargetSslProxySslCertificates(project, name string, sslCertificates []string) (*ComputeOperation, error) {
+	reqUrl := c.computeScopeUrl(project, "") + "/targetSslProxies/" + url.PathEscape(name) + "/setSslCertificates"
+
+	resp := &ComputeOperation{}
+	err := c.sendRequestWithResult(http.MethodPost, reqUrl, &setSslCertificatesRequest{SslCertificates: sslCertificates}, resp)
+	return resp, err
+}
+
+func (c *Client) WaitComputeOperation(ctx context.Context, op *ComputeOperation, interval time.Duration) (*ComputeOperation, error) {
+	for op.Status != "DONE" {
+		select {
+		case <-ctx.Done():
+			return op, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		next := &ComputeOperation{}
+		if err := c.sendRequestWithResult(http.MethodGet, op.SelfLink, nil, next); err != nil {
+			return op, err
+		}
+		op = next
+	}
+
+	return op, op.Err()
+}
+
+func (c *Client) certificateManagerLocationUrl(project, location string) string {
+	if location == "" {
+		location = "global"
+	}
+	return fmt.Sprintf("%s/projects/%s/locations/%s", c.certificateManagerBaseUrl, url.PathEscape(project), url.PathEscape(location))
+}
+
+func (c *Client) ListCertificates(project, location string) ([]*Certificate, error) {
+	certificates := make([]*Certificate, 0)
+
+	pageToken := ""
+	for {
+		reqUrl := c.certificateManagerLocationUrl(project, location) + "/certificates?pageSize=500"
+		if pageToken != "" {
+			reqUrl += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		resp := &listCertificatesResponse{}
+		if err := c.sendRequestWithResult(http.MethodGet, reqUrl, nil, resp); err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, resp.Certificates...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	return certificates, nil
+}
+
+func (c *Client) CreateCertificate(project, location, certificateId string, req *Certificate) (*Operation, error) {
+	reqUrl := c.certificateManagerLocationUrl(project, location) + "/certificates?certificateId=" + url.QueryEscape(certificateId)
+
+	resp := &Operation{}
+	err := c.sendRequestWithResult(http.MethodPost, reqUrl, req, resp)
+	return resp, err
+}
+
+// 入参 name 为资源全名，形如 "projects/{project}/locations/{location}/certificates/{certificate}"。
+func (c *Client) PatchCertificate(name, updateMask string, req *Certificate) (*Operation, error) {
+	reqUrl := c.certificateManagerBaseUrl + "/" + name + "?updateMask=" + url.QueryEscape(updateMask)
+
+	resp := &Operation{}
+	err := c.sendRequestWithResult(http.MethodPatch, reqUrl, req, resp)
+	return resp, err
+}
+
+// 入参 name 为资源全名，形如 "projects/{project}/locations/global/certificateMaps/{map}/certificateMapEntries/{entry}"。
+func (c *Client) PatchCertificateMapEntry(name, updateMask string, req *CertificateMapEntry) (*Operation, error) {
+	reqUrl := c.certificateManagerBaseUrl + "/" + name + "?updateMask=" + url.QueryEscape(updateMask)
+
+	resp := &Operation{}
+	err := c.sendRequestWithResult(http.MethodPatch, reqUrl, req, resp)
+	return resp, err
+}
+
+func (c *Client) WaitOperation(ctx context.Context, op *Operation, interval time.Duration) (*Operation, error) {
+	for !op.Done {
+		select {
+		case <-ctx.Done():
+			return op, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		next := &Operation{}
+		if err := c.sendRequestWithResult(http.MethodGet, c.certificateManagerBaseUrl+"/"+op.Name, nil, next); err != nil {
+			return op, err
+		}
+		op = next
+	}
+
+	return op, op.Err()
+}
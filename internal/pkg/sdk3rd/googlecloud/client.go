@@ -0,0 +1,132 @@
+package googlecloud
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	computeBaseUrl            = "https://compute.googleapis.com/compute/v1"
+	certificateManagerBaseUrl = "https://certificatemanager.googleapis.com/v1"
+)
+
+// Google Cloud REST API 客户端，使用服务账号密钥进行 OAuth 2.0 认证。
+// REF: https://developers.google.com/identity/protocols/oauth2/service-account
+type Client struct {
+	projectId   string
+	tokenSource oauth2.TokenSource
+
+	computeBaseUrl            string
+	certificateManagerBaseUrl string
+
+	client *resty.Client
+}
+
+type serviceAccountKey struct {
+	Type         string `json:"type"`
+	ProjectId    string `json:"project_id"`
+	PrivateKeyId string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenUri     string `json:"token_uri"`
+}
+
+func NewClient(serviceAccountKeyJson string) (*Client, error) {
+	key := &serviceAccountKey{}
+	if err := json.Unmarshal([]byte(serviceAccountKeyJson), key); err != nil {
+		return nil, fmt.Errorf("googlecloud: failed to parse service account key: %w", err)
+	} else if key.Type != "service_account" {
+		return nil, fmt.Errorf("googlecloud: unsupported credentials type '%s'", key.Type)
+	} else if key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, errors.New("googlecloud: service account key is missing 'client_email' or 'private_key'")
+	}
+
+	jwtConfig := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyId,
+		Scopes:       []string{"https://www.googleapis.com/auth/cloud-platform"},
+		TokenURL:     key.TokenUri,
+	}
+	if jwtConfig.TokenURL == "" {
+		jwtConfig.TokenURL = "https://oauth2.googleapis.com/token"
+	}
+
+	client := &Client{
+		projectId:                 key.ProjectId,
+		tokenSource:               jwtConfig.TokenSource(context.Background()),
+		computeBaseUrl:            computeBaseUrl,
+		certificateManagerBaseUrl: certificateManagerBaseUrl,
+	}
+	client.client = resty.New().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("User-Agent", "certimate").
+		SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
+			token, err := client.tokenSource.Token()
+			if err != nil {
+				return fmt.Errorf("googlecloud: failed to obtain access token: %w", err)
+			}
+
+			token.SetAuthHeader(req)
+			return nil
+		})
+
+	return client, nil
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+// 返回服务账号密钥中的项目 ID。
+func (c *Client) ProjectId() string {
+	return c.projectId
+}
+
+func (c *Client) sendRequest(method string, url string, params interface{}) (*resty.Response, error) {
+	req := c.client.R()
+	if params != nil {
+		req = req.SetBody(params)
+	}
+
+	resp, err := req.Execute(method, url)
+	if err != nil {
+		return resp, fmt.Errorf("googlecloud api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &errorResponse{}
+		if err := json.Unmarshal(resp.Body(), errResp); err == nil && errResp.Error != nil && errResp.Error.Message != "" {
+			return resp, fmt.Errorf("googlecloud api error: unexpected status code: %d, status: %s, message: %s", resp.StatusCode(), errResp.Error.Status, errResp.Error.Message)
+		}
+		return resp, fmt.Errorf("googlecloud api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(method string, url string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(method, url, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("googlecloud api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
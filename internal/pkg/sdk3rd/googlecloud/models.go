@@ -0,0 +1,119 @@
+package googlecloud
+
+import (
+	"fmt"
+	"strings"
+)
+
+type errorResponse struct {
+	Error *struct {
+		Code    int32  `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error,omitempty"`
+}
+
+// Compute Engine 长时间运行的操作。
+// REF: https://cloud.google.com/compute/docs/reference/rest/v1/globalOperations
+type ComputeOperation struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	SelfLink   string `json:"selfLink"`
+	TargetLink string `json:"targetLink,omitempty"`
+	Error      *struct {
+		Errors []*struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"error,omitempty"`
+}
+
+func (op *ComputeOperation) Err() error {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(op.Error.Errors))
+	for _, e := range op.Error.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", e.Code, e.Message))
+	}
+	return fmt.Errorf("googlecloud api error: operation '%s' failed: %s", op.Name, strings.Join(messages, "; "))
+}
+
+// Certificate Manager 长时间运行的操作。
+// REF: https://cloud.google.com/certificate-manager/docs/reference/certificate-manager/rest/v1/projects.locations.operations
+type Operation struct {
+	Name  string `json:"name"`
+	Done  bool   `json:"done"`
+	Error *struct {
+		Code    int32  `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func (op *Operation) Err() error {
+	if op.Error == nil {
+		return nil
+	}
+
+	return fmt.Errorf("googlecloud api error: operation '%s' failed: code=%d, message='%s'", op.Name, op.Error.Code, op.Error.Message)
+}
+
+type SslCertificateSelfManaged struct {
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"privateKey,omitempty"`
+}
+
+type SslCertificate struct {
+	Name                    string                     `json:"name"`
+	Description             string                     `json:"description,omitempty"`
+	Type                    string                     `json:"type,omitempty"`
+	SelfLink                string                     `json:"selfLink,omitempty"`
+	Certificate             string                     `json:"certificate,omitempty"`
+	SelfManaged             *SslCertificateSelfManaged `json:"selfManaged,omitempty"`
+	SubjectAlternativeNames []string                   `json:"subjectAlternativeNames,omitempty"`
+	ExpireTime              string                     `json:"expireTime,omitempty"`
+}
+
+type listSslCertificatesResponse struct {
+	Items         []*SslCertificate `json:"items"`
+	NextPageToken string            `json:"nextPageToken,omitempty"`
+}
+
+type TargetProxy struct {
+	Name            string   `json:"name"`
+	SelfLink        string   `json:"selfLink,omitempty"`
+	SslCertificates []string `json:"sslCertificates,omitempty"`
+	CertificateMap  string   `json:"certificateMap,omitempty"`
+}
+
+type setSslCertificatesRequest struct {
+	SslCertificates []string `json:"sslCertificates"`
+}
+
+type CertificateSelfManaged struct {
+	PemCertificate string `json:"pemCertificate"`
+	PemPrivateKey  string `json:"pemPrivateKey,omitempty"`
+}
+
+type Certificate struct {
+	Name           string                  `json:"name,omitempty"`
+	Description    string                  `json:"description,omitempty"`
+	Labels         map[string]string       `json:"labels,omitempty"`
+	SelfManaged    *CertificateSelfManaged `json:"selfManaged,omitempty"`
+	PemCertificate string                  `json:"pemCertificate,omitempty"`
+	SanDnsnames    []string                `json:"sanDnsnames,omitempty"`
+	ExpireTime     string                  `json:"expireTime,omitempty"`
+}
+
+type listCertificatesResponse struct {
+	Certificates  []*Certificate `json:"certificates"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
+type CertificateMapEntry struct {
+	Name         string   `json:"name,omitempty"`
+	Hostname     string   `json:"hostname,omitempty"`
+	Matcher      string   `json:"matcher,omitempty"`
+	Certificates []string `json:"certificates"`
+}
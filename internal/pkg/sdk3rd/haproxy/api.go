@@ -0,0 +1,64 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// 创建或更新证书的更新事务。
+// 证书名为 HAProxy 配置中 crt 指令所引用的文件路径。
+// 载荷为 PEM 格式的证书、私钥及证书链，不得包含空行。
+func (c *Client) SetSslCert(ctx context.Context, certName string, payload string) (string, error) {
+	if certName == "" {
+		return "", fmt.Errorf("haproxy api error: invalid parameter: certName")
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(payload, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	resp, err := c.sendCommand(ctx, fmt.Sprintf("set ssl cert %s <<\n%s\n\n", certName, strings.Join(lines, "\n")))
+	if err != nil {
+		return resp, err
+	} else if !strings.Contains(resp, "Transaction created") && !strings.Contains(resp, "Transaction updated") {
+		return resp, fmt.Errorf("haproxy api error: unexpected response: %s", resp)
+	}
+
+	return resp, nil
+}
+
+// 提交证书的更新事务。
+func (c *Client) CommitSslCert(ctx context.Context, certName string) (string, error) {
+	if certName == "" {
+		return "", fmt.Errorf("haproxy api error: invalid parameter: certName")
+	}
+
+	resp, err := c.sendCommand(ctx, fmt.Sprintf("commit ssl cert %s", certName))
+	if err != nil {
+		return resp, err
+	} else if !strings.Contains(resp, "Success!") {
+		return resp, fmt.Errorf("haproxy api error: unexpected response: %s", resp)
+	}
+
+	return resp, nil
+}
+
+// 放弃证书的更新事务。
+func (c *Client) AbortSslCert(ctx context.Context, certName string) (string, error) {
+	if certName == "" {
+		return "", fmt.Errorf("haproxy api error: invalid parameter: certName")
+	}
+
+	resp, err := c.sendCommand(ctx, fmt.Sprintf("abort ssl cert %s", certName))
+	if err != nil {
+		return resp, err
+	} else if !strings.Contains(resp, "Transaction aborted") {
+		return resp, fmt.Errorf("haproxy api error: unexpected response: %s", resp)
+	}
+
+	return resp, nil
+}
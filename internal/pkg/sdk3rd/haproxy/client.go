@@ -0,0 +1,59 @@
+package haproxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// HAProxy Runtime API 客户端。
+// 以非交互模式工作，每条命令使用独立的连接，服务端返回响应后即关闭连接。
+// REF: https://docs.haproxy.org/3.0/management.html#9.3
+type Client struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func NewClient(network, address string) *Client {
+	return &Client{
+		network: network,
+		address: address,
+		timeout: 30 * time.Second,
+	}
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.timeout = timeout
+	return c
+}
+
+func (c *Client) sendCommand(ctx context.Context, command string) (string, error) {
+	dialer := &net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return "", fmt.Errorf("haproxy api error: failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	if c.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(c.timeout))
+	}
+
+	if !strings.HasSuffix(command, "\n") {
+		command += "\n"
+	}
+	if _, err := io.WriteString(conn, command); err != nil {
+		return "", fmt.Errorf("haproxy api error: failed to send command: %w", err)
+	}
+
+	resp, err := io.ReadAll(conn)
+	if err != nil {
+		return string(resp), fmt.Errorf("haproxy api error: failed to read response: %w", err)
+	}
+
+	return strings.TrimSpace(string(resp)), nil
+}
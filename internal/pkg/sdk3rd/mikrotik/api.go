@@ -0,0 +1,68 @@
+package mikrotik
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func (c *Client) AddFile(req *AddFileRequest) error {
+	return c.sendRequestWithResult(http.MethodPut, "/file", req, nil)
+}
+
+func (c *Client) RemoveFile(req *RemoveFileRequest) error {
+	return c.sendRequestWithResult(http.MethodPost, "/file/remove", req, nil)
+}
+
+func (c *Client) ImportCertificate(req *ImportCertificateRequest) (ImportCertificateResponse, error) {
+	resp := ImportCertificateResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, "/certificate/import", req, &resp)
+	return resp, err
+}
+
+func (c *Client) ListCertificates() (ListCertificatesResponse, error) {
+	resp := ListCertificatesResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/certificate", nil, &resp)
+	return resp, err
+}
+
+func (c *Client) RemoveCertificate(req *RemoveCertificateRequest) error {
+	return c.sendRequestWithResult(http.MethodPost, "/certificate/remove", req, nil)
+}
+
+func (c *Client) ListServices() (ListServicesResponse, error) {
+	resp := ListServicesResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/ip/service", nil, &resp)
+	return resp, err
+}
+
+func (c *Client) SetService(req *SetServiceRequest) error {
+	return c.sendRequestWithResult(http.MethodPost, "/ip/service/set", req, nil)
+}
+
+// 获取任意菜单下的记录。
+// 部分菜单（如 "/interface/sstp-server/server"）返回单个对象，此时将其包装为仅含一个元素的数组。
+func (c *Client) ListMenuItems(path string) (ListMenuItemsResponse, error) {
+	raw := json.RawMessage{}
+	if err := c.sendRequestWithResult(http.MethodGet, path, nil, &raw); err != nil {
+		return nil, err
+	}
+
+	resp := ListMenuItemsResponse{}
+	if len(raw) == 0 {
+		return resp, nil
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		item := map[string]any{}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, fmt.Errorf("mikrotik api error: failed to unmarshal response: %w", err)
+		}
+		resp = append(resp, item)
+	} else if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("mikrotik api error: failed to unmarshal response: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,76 @@
+package mikrotik
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RouterOS v7 REST API 客户端。
+// REF: https://help.mikrotik.com/docs/spaces/ROS/pages/47579162/REST+API
+type Client struct {
+	client *resty.Client
+}
+
+func NewClient(serverUrl, username, password string) *Client {
+	client := resty.New().
+		SetBaseURL(strings.TrimRight(serverUrl, "/")+"/rest").
+		SetBasicAuth(username, password).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("User-Agent", "certimate")
+
+	return &Client{
+		client: client,
+	}
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+func (c *Client) sendRequest(method string, path string, params interface{}) (*resty.Response, error) {
+	req := c.client.R()
+	if params != nil {
+		req = req.SetBody(params)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("mikrotik api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &errorResponse{}
+		if err := json.Unmarshal(resp.Body(), errResp); err == nil && errResp.Message != "" {
+			return resp, fmt.Errorf("mikrotik api error: unexpected status code: %d, message: %s, detail: %s", resp.StatusCode(), errResp.Message, errResp.Detail)
+		}
+		return resp, fmt.Errorf("mikrotik api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(method string, path string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(method, path, params)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Body()) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("mikrotik api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
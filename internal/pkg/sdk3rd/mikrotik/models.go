@@ -0,0 +1,56 @@
+package mikrotik
+
+type errorResponse struct {
+	Error   int    `json:"error"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// RouterOS REST API 返回的字段值均为字符串。
+type CertificateRecord struct {
+	Id           string `json:".id"`
+	Name         string `json:"name"`
+	CommonName   string `json:"common-name,omitempty"`
+	Fingerprint  string `json:"fingerprint,omitempty"`
+	PrivateKey   string `json:"private-key,omitempty"`
+	InvalidAfter string `json:"invalid-after,omitempty"`
+}
+
+type ServiceRecord struct {
+	Id          string `json:".id"`
+	Name        string `json:"name"`
+	Port        string `json:"port,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+	Disabled    string `json:"disabled,omitempty"`
+}
+
+type AddFileRequest struct {
+	Name     string `json:"name"`
+	Contents string `json:"contents"`
+}
+
+type RemoveFileRequest struct {
+	Numbers string `json:"numbers"`
+}
+
+type ImportCertificateRequest struct {
+	FileName   string `json:"file-name"`
+	Passphrase string `json:"passphrase"`
+}
+
+type ImportCertificateResponse []map[string]string
+
+type ListCertificatesResponse []*CertificateRecord
+
+type RemoveCertificateRequest struct {
+	Numbers string `json:"numbers"`
+}
+
+type ListServicesResponse []*ServiceRecord
+
+type ListMenuItemsResponse []map[string]any
+
+type SetServiceRequest struct {
+	Numbers     string `json:"numbers"`
+	Certificate string `json:"certificate"`
+}
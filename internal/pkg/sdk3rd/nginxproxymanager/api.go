@@ -0,0 +1,102 @@
+package nginxproxymanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (c *Client) ensureAccessTokenExists() error {
+	c.accessTokenMtx.Lock()
+	defer c.accessTokenMtx.Unlock()
+	if c.accessToken != "" && c.accessTokenExp.After(time.Now()) {
+		return nil
+	}
+
+	req := &requestTokenRequest{
+		Identity: c.identity,
+		Secret:   c.secret,
+	}
+	res, err := c.sendRequest(http.MethodPost, "/tokens", req)
+	if err != nil {
+		return err
+	}
+
+	resp := &requestTokenResponse{}
+	if err := json.Unmarshal(res.Body(), &resp); err != nil {
+		return fmt.Errorf("nginxproxymanager api error: failed to unmarshal response: %w", err)
+	} else if resp.Token == "" {
+		return fmt.Errorf("nginxproxymanager request token failed: empty token")
+	}
+
+	c.accessToken = resp.Token
+	c.accessTokenExp = time.Now().Add(time.Hour)
+	if exp, err := time.Parse(time.RFC3339, resp.Expires); err == nil {
+		c.accessTokenExp = exp.Add(-time.Minute)
+	}
+
+	return nil
+}
+
+func (c *Client) ListCertificates() (ListCertificatesResponse, error) {
+	if err := c.ensureAccessTokenExists(); err != nil {
+		return nil, err
+	}
+
+	resp := ListCertificatesResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/nginx/certificates", nil, &resp)
+	return resp, err
+}
+
+func (c *Client) CreateCertificate(req *CreateCertificateRequest) (*CreateCertificateResponse, error) {
+	if err := c.ensureAccessTokenExists(); err != nil {
+		return nil, err
+	}
+
+	resp := &CreateCertificateResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, "/nginx/certificates", req, resp)
+	return resp, err
+}
+
+func (c *Client) UploadCertificate(certificateId int64, req *UploadCertificateRequest) (*UploadCertificateResponse, error) {
+	if err := c.ensureAccessTokenExists(); err != nil {
+		return nil, err
+	}
+
+	res, err := c.sendMultipartRequest(http.MethodPost, fmt.Sprintf("/nginx/certificates/%d/upload", certificateId), map[string]string{
+		"certificate":              req.Certificate,
+		"certificate_key":          req.CertificateKey,
+		"intermediate_certificate": req.IntermediateCertificate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &UploadCertificateResponse{}
+	if err := json.Unmarshal(res.Body(), &resp); err != nil {
+		return nil, fmt.Errorf("nginxproxymanager api error: failed to unmarshal response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) ListProxyHosts() (ListProxyHostsResponse, error) {
+	if err := c.ensureAccessTokenExists(); err != nil {
+		return nil, err
+	}
+
+	resp := ListProxyHostsResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/nginx/proxy-hosts", nil, &resp)
+	return resp, err
+}
+
+func (c *Client) UpdateProxyHost(proxyHostId int64, req *UpdateProxyHostRequest) (*UpdateProxyHostResponse, error) {
+	if err := c.ensureAccessTokenExists(); err != nil {
+		return nil, err
+	}
+
+	resp := &UpdateProxyHostResponse{}
+	err := c.sendRequestWithResult(http.MethodPut, fmt.Sprintf("/nginx/proxy-hosts/%d", proxyHostId), req, resp)
+	return resp, err
+}
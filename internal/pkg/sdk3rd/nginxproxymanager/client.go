@@ -0,0 +1,103 @@
+package nginxproxymanager
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Nginx Proxy Manager REST API 客户端。
+// REF: https://github.com/NginxProxyManager/nginx-proxy-manager/tree/develop/backend/schema
+type Client struct {
+	identity string
+	secret   string
+
+	accessToken    string
+	accessTokenExp time.Time
+	accessTokenMtx sync.Mutex
+
+	client *resty.Client
+}
+
+func NewClient(serverUrl, identity, secret string) *Client {
+	client := &Client{
+		identity: identity,
+		secret:   secret,
+	}
+	client.client = resty.New().
+		SetBaseURL(strings.TrimRight(serverUrl, "/")+"/api").
+		SetHeader("User-Agent", "certimate").
+		SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
+			if client.accessToken != "" {
+				req.Header.Set("Authorization", "Bearer "+client.accessToken)
+			}
+
+			return nil
+		})
+
+	return client
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+func (c *Client) sendRequest(method string, path string, params interface{}) (*resty.Response, error) {
+	req := c.client.R()
+	if params != nil {
+		req = req.SetHeader("Content-Type", "application/json").SetBody(params)
+	}
+
+	return c.executeRequest(req, method, path)
+}
+
+func (c *Client) sendMultipartRequest(method string, path string, files map[string]string) (*resty.Response, error) {
+	req := c.client.R()
+	for field, content := range files {
+		if content != "" {
+			req = req.SetFileReader(field, field+".pem", strings.NewReader(content))
+		}
+	}
+
+	return c.executeRequest(req, method, path)
+}
+
+func (c *Client) executeRequest(req *resty.Request, method string, path string) (*resty.Response, error) {
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("nginxproxymanager api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &errorResponse{}
+		if err := json.Unmarshal(resp.Body(), errResp); err == nil && errResp.Error != nil && errResp.Error.Message != "" {
+			return resp, fmt.Errorf("nginxproxymanager api error: unexpected status code: %d, message: %s", resp.StatusCode(), errResp.Error.Message)
+		}
+		return resp, fmt.Errorf("nginxproxymanager api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(method string, path string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(method, path, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("nginxproxymanager api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
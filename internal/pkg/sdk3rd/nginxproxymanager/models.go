@@ -0,0 +1,71 @@
+package nginxproxymanager
+
+type errorResponse struct {
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+type requestTokenRequest struct {
+	Identity string `json:"identity"`
+	Secret   string `json:"secret"`
+}
+
+type requestTokenResponse struct {
+	Token   string `json:"token"`
+	Expires string `json:"expires"`
+}
+
+type CertificateRecord struct {
+	Id          int64    `json:"id"`
+	Provider    string   `json:"provider"`
+	NiceName    string   `json:"nice_name"`
+	DomainNames []string `json:"domain_names"`
+	ExpiresOn   string   `json:"expires_on,omitempty"`
+}
+
+type ProxyHostRecord struct {
+	Id            int64    `json:"id"`
+	DomainNames   []string `json:"domain_names"`
+	CertificateId any      `json:"certificate_id"`
+	SslForced     bool     `json:"ssl_forced"`
+	Enabled       bool     `json:"enabled"`
+}
+
+// 证书 ID 在未绑定时可能为 0 或字符串 "new"，此处统一转换为数值。
+func (r *ProxyHostRecord) GetCertificateId() int64 {
+	if v, ok := r.CertificateId.(float64); ok {
+		return int64(v)
+	}
+	return 0
+}
+
+type CreateCertificateRequest struct {
+	Provider string `json:"provider"`
+	NiceName string `json:"nice_name"`
+}
+
+type CreateCertificateResponse = CertificateRecord
+
+type UploadCertificateRequest struct {
+	Certificate             string
+	CertificateKey          string
+	IntermediateCertificate string
+}
+
+type UploadCertificateResponse struct {
+	Certificate    string `json:"certificate"`
+	CertificateKey string `json:"certificate_key"`
+}
+
+type ListCertificatesResponse = []*CertificateRecord
+
+type ListProxyHostsResponse = []*ProxyHostRecord
+
+type UpdateProxyHostRequest struct {
+	CertificateId int64 `json:"certificate_id"`
+	SslForced     *bool `json:"ssl_forced,omitempty"`
+}
+
+type UpdateProxyHostResponse = ProxyHostRecord
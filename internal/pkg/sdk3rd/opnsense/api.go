@@ -0,0 +1,47 @@
+package opnsense
+
+import (
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) SearchCertificates(searchPhrase string) (*SearchCertificatesResponse, error) {
+	req := map[string]any{
+		"current":      1,
+		"rowCount":     -1,
+		"searchPhrase": searchPhrase,
+	}
+
+	resp := &SearchCertificatesResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, "/trust/cert/search", req, resp)
+	return resp, err
+}
+
+func (c *Client) AddCertificate(req *AddCertificateRequest) (*AddCertificateResponse, error) {
+	result := &mutationResponse{}
+	if err := c.sendRequestWithResult(http.MethodPost, "/trust/cert/add", req, result); err != nil {
+		return nil, err
+	} else if err := result.Err(); err != nil {
+		return nil, err
+	}
+
+	return &AddCertificateResponse{Uuid: result.Uuid}, nil
+}
+
+func (c *Client) SetCertificate(uuid string, req *SetCertificateRequest) error {
+	result := &mutationResponse{}
+	if err := c.sendRequestWithResult(http.MethodPost, "/trust/cert/set/"+url.PathEscape(uuid), req, result); err != nil {
+		return err
+	}
+
+	return result.Err()
+}
+
+func (c *Client) RestartService(name string) error {
+	result := &mutationResponse{}
+	if err := c.sendRequestWithResult(http.MethodPost, "/core/service/restart/"+url.PathEscape(name), nil, result); err != nil {
+		return err
+	}
+
+	return result.Err()
+}
@@ -0,0 +1,72 @@
+package opnsense
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// OPNsense API 客户端。
+// REF: https://docs.opnsense.org/development/how-tos/api.html
+type Client struct {
+	client *resty.Client
+}
+
+func NewClient(serverUrl, apiKey, apiSecret string) *Client {
+	client := resty.New().
+		SetBaseURL(strings.TrimRight(serverUrl, "/")+"/api").
+		SetBasicAuth(apiKey, apiSecret).
+		SetHeader("User-Agent", "certimate")
+
+	return &Client{
+		client: client,
+	}
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+func (c *Client) sendRequest(method string, path string, params interface{}) (*resty.Response, error) {
+	req := c.client.R()
+	if method == http.MethodPost {
+		// OPNsense 要求 POST 请求必须携带 JSON 请求体
+		if params == nil {
+			params = map[string]any{}
+		}
+		req = req.SetHeader("Content-Type", "application/json").SetBody(params)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("opnsense api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		return resp, fmt.Errorf("opnsense api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(method string, path string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(method, path, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("opnsense api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package opnsense
+
+import (
+	"fmt"
+	"strings"
+)
+
+type mutationResponse struct {
+	Result      string         `json:"result"`
+	Uuid        string         `json:"uuid,omitempty"`
+	Validations map[string]any `json:"validations,omitempty"`
+}
+
+func (r *mutationResponse) Err() error {
+	if r.Result == "saved" || r.Result == "ok" {
+		return nil
+	}
+
+	if len(r.Validations) > 0 {
+		messages := make([]string, 0, len(r.Validations))
+		for field, message := range r.Validations {
+			messages = append(messages, fmt.Sprintf("%s: %v", field, message))
+		}
+		return fmt.Errorf("opnsense api error: result='%s', validations='%s'", r.Result, strings.Join(messages, "; "))
+	}
+
+	return fmt.Errorf("opnsense api error: result='%s'", r.Result)
+}
+
+type CertificateRecord struct {
+	Uuid       string `json:"uuid"`
+	Descr      string `json:"descr"`
+	RefId      string `json:"refid"`
+	CommonName string `json:"commonname,omitempty"`
+	ValidTo    string `json:"valid_to,omitempty"`
+	InUse      string `json:"in_use,omitempty"`
+}
+
+type CertificatePayload struct {
+	Action     string `json:"action"`
+	Descr      string `json:"descr"`
+	CrtPayload string `json:"crt_payload"`
+	PrvPayload string `json:"prv_payload"`
+}
+
+type SearchCertificatesResponse struct {
+	Rows     []*CertificateRecord `json:"rows"`
+	RowCount int32                `json:"rowCount"`
+	Total    int32                `json:"total"`
+	Current  int32                `json:"current"`
+}
+
+type AddCertificateRequest struct {
+	Cert *CertificatePayload `json:"cert"`
+}
+
+type AddCertificateResponse struct {
+	Uuid string `json:"uuid"`
+}
+
+type SetCertificateRequest struct {
+	Cert *CertificatePayload `json:"cert"`
+}
@@ -0,0 +1,35 @@
+package pfsense
+
+import (
+	"net/http"
+)
+
+func (c *Client) ListCertificates() (*ListCertificatesResponse, error) {
+	resp := &ListCertificatesResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/system/certificates?limit=0", nil, resp)
+	return resp, err
+}
+
+func (c *Client) CreateCertificate(req *CreateCertificateRequest) (*CreateCertificateResponse, error) {
+	resp := &CreateCertificateResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, "/system/certificate", req, resp)
+	return resp, err
+}
+
+func (c *Client) UpdateCertificate(req *UpdateCertificateRequest) (*UpdateCertificateResponse, error) {
+	resp := &UpdateCertificateResponse{}
+	err := c.sendRequestWithResult(http.MethodPatch, "/system/certificate", req, resp)
+	return resp, err
+}
+
+func (c *Client) GetWebGUISettings() (*GetWebGUISettingsResponse, error) {
+	resp := &GetWebGUISettingsResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/system/webgui/settings", nil, resp)
+	return resp, err
+}
+
+func (c *Client) UpdateWebGUISettings(req *UpdateWebGUISettingsRequest) (*UpdateWebGUISettingsResponse, error) {
+	resp := &UpdateWebGUISettingsResponse{}
+	err := c.sendRequestWithResult(http.MethodPatch, "/system/webgui/settings", req, resp)
+	return resp, err
+}
@@ -0,0 +1,72 @@
+package pfsense
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// pfSense REST API v2 客户端，需安装 pfSense-pkg-RESTAPI 扩展包。
+// REF: https://pfrest.org/
+type Client struct {
+	client *resty.Client
+}
+
+func NewClient(serverUrl, apiKey string) *Client {
+	client := resty.New().
+		SetBaseURL(strings.TrimRight(serverUrl, "/")+"/api/v2").
+		SetHeader("Content-Type", "application/json").
+		SetHeader("User-Agent", "certimate").
+		SetHeader("X-API-Key", apiKey)
+
+	return &Client{
+		client: client,
+	}
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+func (c *Client) sendRequest(method string, path string, params interface{}) (*resty.Response, error) {
+	req := c.client.R()
+	if params != nil {
+		req = req.SetBody(params)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("pfsense api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &baseResponse{}
+		if err := json.Unmarshal(resp.Body(), errResp); err == nil && errResp.Message != "" {
+			return resp, fmt.Errorf("pfsense api error: unexpected status code: %d, code: %s, message: %s", resp.StatusCode(), errResp.ResponseId, errResp.Message)
+		}
+		return resp, fmt.Errorf("pfsense api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(method string, path string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(method, path, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("pfsense api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package pfsense
+
+type baseResponse struct {
+	Code       int32  `json:"code"`
+	Status     string `json:"status"`
+	ResponseId string `json:"response_id"`
+	Message    string `json:"message"`
+}
+
+type CertificateRecord struct {
+	Id    int64  `json:"id"`
+	RefId string `json:"refid"`
+	Descr string `json:"descr"`
+	Type  string `json:"type,omitempty"`
+}
+
+type ListCertificatesResponse struct {
+	baseResponse
+	Data []*CertificateRecord `json:"data"`
+}
+
+type CreateCertificateRequest struct {
+	Descr string `json:"descr"`
+	Type  string `json:"type,omitempty"`
+	Crt   string `json:"crt"`
+	Prv   string `json:"prv"`
+}
+
+type CreateCertificateResponse struct {
+	baseResponse
+	Data *CertificateRecord `json:"data"`
+}
+
+type UpdateCertificateRequest struct {
+	Id    int64  `json:"id"`
+	Descr string `json:"descr,omitempty"`
+	Crt   string `json:"crt"`
+	Prv   string `json:"prv"`
+}
+
+type UpdateCertificateResponse struct {
+	baseResponse
+	Data *CertificateRecord `json:"data"`
+}
+
+type WebGUISettings struct {
+	Port       string `json:"port,omitempty"`
+	Protocol   string `json:"protocol,omitempty"`
+	SslCertRef string `json:"sslcertref,omitempty"`
+}
+
+type GetWebGUISettingsResponse struct {
+	baseResponse
+	Data *WebGUISettings `json:"data"`
+}
+
+type UpdateWebGUISettingsRequest struct {
+	SslCertRef string `json:"sslcertref"`
+}
+
+type UpdateWebGUISettingsResponse struct {
+	baseResponse
+	Data *WebGUISettings `json:"data"`
+}
@@ -0,0 +1,43 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// REF: https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObject.html
+func (c *Client) PutObject(ctx context.Context, req *PutObjectRequest) (*PutObjectResponse, error) {
+	if req.Bucket == "" {
+		return nil, errors.New("s3 api error: invalid parameter: Bucket")
+	}
+	if req.Key == "" {
+		return nil, errors.New("s3 api error: invalid parameter: Key")
+	}
+
+	headers := make(map[string]string)
+	if req.ContentType != "" {
+		headers["Content-Type"] = req.ContentType
+	}
+	if req.ServerSideEncryption != "" {
+		headers["X-Amz-Server-Side-Encryption"] = req.ServerSideEncryption
+		if req.SSEKMSKeyId != "" {
+			headers["X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"] = req.SSEKMSKeyId
+		}
+	}
+
+	body := req.Body
+	if body == nil {
+		body = []byte{}
+	}
+
+	resp, err := c.sendRequest(ctx, http.MethodPut, c.buildObjectUrl(req.Bucket, req.Key), headers, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PutObjectResponse{
+		ETag:      resp.Header().Get("ETag"),
+		VersionId: resp.Header().Get("X-Amz-Version-Id"),
+	}, nil
+}
@@ -0,0 +1,133 @@
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-resty/resty/v2"
+)
+
+// S3 兼容对象存储的最小化客户端，仅实现证书分发所需的接口。
+// 适用于 AWS S3、MinIO、Ceph RGW、Cloudflare R2 等。
+// REF: https://docs.aws.amazon.com/AmazonS3/latest/API/Welcome.html
+type Client struct {
+	client *resty.Client
+
+	endpoint     *url.URL
+	region       string
+	credentials  aws.Credentials
+	usePathStyle bool
+}
+
+const headerContentSha256 = "X-Amz-Content-Sha256"
+
+func NewClient(endpoint, region, accessKeyId, secretAccessKey string) (*Client, error) {
+	if region == "" {
+		region = "us-east-1"
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	} else if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	endpointUrl, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("s3 api error: invalid endpoint: %w", err)
+	}
+
+	client := &Client{
+		endpoint: endpointUrl,
+		region:   region,
+		credentials: aws.Credentials{
+			AccessKeyID:     accessKeyId,
+			SecretAccessKey: secretAccessKey,
+		},
+	}
+	client.client = resty.New().
+		SetHeader("User-Agent", "certimate").
+		SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
+			payloadHash := req.Header.Get(headerContentSha256)
+			signer := v4.NewSigner(func(so *v4.SignerOptions) {
+				so.DisableURIPathEscaping = true
+			})
+			return signer.SignHTTP(req.Context(), client.credentials, req, payloadHash, "s3", client.region, time.Now().UTC())
+		})
+
+	return client, nil
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+func (c *Client) WithSessionToken(sessionToken string) *Client {
+	c.credentials.SessionToken = sessionToken
+	return c
+}
+
+func (c *Client) WithPathStyle(usePathStyle bool) *Client {
+	c.usePathStyle = usePathStyle
+	return c
+}
+
+func (c *Client) buildObjectUrl(bucket, key string) string {
+	key = strings.TrimLeft(key, "/")
+	escapedKey := make([]string, 0)
+	for _, segment := range strings.Split(key, "/") {
+		escapedKey = append(escapedKey, url.PathEscape(segment))
+	}
+
+	u := *c.endpoint
+	basePath := strings.TrimRight(u.EscapedPath(), "/")
+	if c.usePathStyle {
+		u.Path = strings.TrimRight(u.Path, "/") + "/" + bucket + "/" + key
+		u.RawPath = basePath + "/" + url.PathEscape(bucket) + "/" + strings.Join(escapedKey, "/")
+	} else {
+		u.Host = bucket + "." + u.Host
+		u.Path = strings.TrimRight(u.Path, "/") + "/" + key
+		u.RawPath = basePath + "/" + strings.Join(escapedKey, "/")
+	}
+	return u.String()
+}
+
+func (c *Client) sendRequest(ctx context.Context, method string, url string, headers map[string]string, body []byte) (*resty.Response, error) {
+	payloadHash := sha256.Sum256(body)
+
+	req := c.client.R().
+		SetContext(ctx).
+		SetHeader(headerContentSha256, hex.EncodeToString(payloadHash[:])).
+		SetHeaders(headers)
+	if body != nil {
+		req = req.SetBody(body)
+	}
+
+	resp, err := req.Execute(method, url)
+	if err != nil {
+		return resp, fmt.Errorf("s3 api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &errorResponse{}
+		if err := xml.Unmarshal(resp.Body(), errResp); err == nil && errResp.Code != "" {
+			return resp, fmt.Errorf("s3 api error: unexpected status code: %d, code: %s, message: %s", resp.StatusCode(), errResp.Code, errResp.Message)
+		}
+		return resp, fmt.Errorf("s3 api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
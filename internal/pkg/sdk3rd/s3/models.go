@@ -0,0 +1,21 @@
+package s3
+
+type errorResponse struct {
+	Code      string `xml:"Code"`
+	Message   string `xml:"Message"`
+	RequestId string `xml:"RequestId"`
+}
+
+type PutObjectRequest struct {
+	Bucket               string
+	Key                  string
+	Body                 []byte
+	ContentType          string
+	ServerSideEncryption string
+	SSEKMSKeyId          string
+}
+
+type PutObjectResponse struct {
+	ETag      string
+	VersionId string
+}
@@ -0,0 +1,101 @@
+package synology
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+func (c *Client) ensureSessionExists() error {
+	c.sessionMtx.Lock()
+	defer c.sessionMtx.Unlock()
+	if c.sid != "" {
+		return nil
+	}
+
+	data := &loginResponseData{}
+	if err := c.sendRequestWithResult("SYNO.API.Auth", 6, "login", map[string]string{
+		"account":           c.username,
+		"passwd":            c.password,
+		"session":           "Certimate",
+		"format":            "sid",
+		"enable_syno_token": "yes",
+	}, data); err != nil {
+		return err
+	} else if data.Sid == "" {
+		return errors.New("synology login failed: empty sid")
+	}
+
+	c.sid = data.Sid
+	c.synoToken = data.SynoToken
+
+	return nil
+}
+
+func (c *Client) Logout() error {
+	c.sessionMtx.Lock()
+	defer c.sessionMtx.Unlock()
+	if c.sid == "" {
+		return nil
+	}
+
+	err := c.sendRequestWithResult("SYNO.API.Auth", 6, "logout", map[string]string{"session": "Certimate"}, nil)
+	c.sid = ""
+	c.synoToken = ""
+	return err
+}
+
+func (c *Client) ListCertificates() (*ListCertificatesResponse, error) {
+	if err := c.ensureSessionExists(); err != nil {
+		return nil, err
+	}
+
+	resp := &ListCertificatesResponse{}
+	err := c.sendRequestWithResult("SYNO.Core.Certificate.CRT", 1, "list", nil, resp)
+	return resp, err
+}
+
+func (c *Client) ImportCertificate(req *ImportCertificateRequest) (*ImportCertificateResponse, error) {
+	if err := c.ensureSessionExists(); err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{
+		"id":         req.Id,
+		"desc":       req.Desc,
+		"as_default": "",
+	}
+	if req.AsDefault {
+		params["as_default"] = "true"
+	}
+
+	res, err := c.sendMultipartRequest("SYNO.Core.Certificate", 1, "import", params, map[string]string{
+		"key":        req.PrivateKey,
+		"cert":       req.Certificate,
+		"inter_cert": req.InterCert,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ImportCertificateResponse{}
+	if err := unmarshalResponse(res, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) SetCertificateServices(req *SetCertificateServicesRequest) (*SetCertificateServicesResponse, error) {
+	if err := c.ensureSessionExists(); err != nil {
+		return nil, err
+	}
+
+	settings, err := json.Marshal(req.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &SetCertificateServicesResponse{}
+	err = c.sendRequestWithResult("SYNO.Core.Certificate.Service", 1, "set", map[string]string{"settings": string(settings)}, resp)
+	return resp, err
+}
@@ -0,0 +1,133 @@
+package synology
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Synology DSM Web API 客户端。
+// REF: https://global.download.synology.com/download/Document/Software/DeveloperGuide/Os/DSM/All/enu/DSM_Login_Web_API_Guide_enu.pdf
+type Client struct {
+	username string
+	password string
+
+	sid        string
+	synoToken  string
+	sessionMtx sync.Mutex
+
+	client *resty.Client
+}
+
+func NewClient(serverUrl, username, password string) *Client {
+	client := &Client{
+		username: username,
+		password: password,
+	}
+	client.client = resty.New().
+		SetBaseURL(strings.TrimRight(serverUrl, "/")+"/webapi").
+		SetHeader("User-Agent", "certimate").
+		SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
+			if client.sid != "" {
+				qs := req.URL.Query()
+				qs.Set("_sid", client.sid)
+				req.URL.RawQuery = qs.Encode()
+			}
+			if client.synoToken != "" {
+				req.Header.Set("X-SYNO-TOKEN", client.synoToken)
+			}
+
+			return nil
+		})
+
+	return client
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+func (c *Client) sendRequest(api string, version int, method string, params map[string]string) (*resty.Response, error) {
+	form := map[string]string{
+		"api":     api,
+		"version": fmt.Sprintf("%d", version),
+		"method":  method,
+	}
+	for k, v := range params {
+		form[k] = v
+	}
+
+	req := c.client.R().SetFormData(form)
+	return c.executeRequest(req)
+}
+
+func (c *Client) sendMultipartRequest(api string, version int, method string, params map[string]string, files map[string]string) (*resty.Response, error) {
+	// 上传接口要求 api、method、version 等参数位于查询字符串中
+	req := c.client.R().
+		SetQueryParams(map[string]string{
+			"api":     api,
+			"version": fmt.Sprintf("%d", version),
+			"method":  method,
+		}).
+		SetMultipartFormData(params)
+	for field, content := range files {
+		if content != "" {
+			req = req.SetFileReader(field, field+".pem", strings.NewReader(content))
+		}
+	}
+
+	return c.executeRequest(req)
+}
+
+func (c *Client) executeRequest(req *resty.Request) (*resty.Response, error) {
+	resp, err := req.Post("/entry.cgi")
+	if err != nil {
+		return resp, fmt.Errorf("synology api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		return resp, fmt.Errorf("synology api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(api string, version int, method string, params map[string]string, result interface{}) error {
+	resp, err := c.sendRequest(api, version, method, params)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalResponse(resp, result)
+}
+
+func unmarshalResponse(resp *resty.Response, result interface{}) error {
+	baseResp := &baseResponse{}
+	if err := json.Unmarshal(resp.Body(), baseResp); err != nil {
+		return fmt.Errorf("synology api error: failed to unmarshal response: %w", err)
+	} else if !baseResp.Success {
+		code := 0
+		if baseResp.Error != nil {
+			code = baseResp.Error.Code
+		}
+		return fmt.Errorf("synology api error: code='%d'", code)
+	}
+
+	if result != nil && len(baseResp.Data) > 0 {
+		if err := json.Unmarshal(baseResp.Data, result); err != nil {
+			return fmt.Errorf("synology api error: failed to unmarshal response: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package synology
+
+import (
+	"encoding/json"
+)
+
+type baseResponse struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Error   *struct {
+		Code int `json:"code"`
+	} `json:"error,omitempty"`
+}
+
+type loginResponseData struct {
+	Sid       string `json:"sid"`
+	SynoToken string `json:"synotoken"`
+}
+
+type CertificateServiceRecord struct {
+	DisplayName     string `json:"display_name"`
+	DisplayNameI18n string `json:"display_name_i18n,omitempty"`
+	IsPkg           bool   `json:"isPkg"`
+	Owner           string `json:"owner"`
+	Service         string `json:"service"`
+	Subscriber      string `json:"subscriber"`
+	MultipleCert    bool   `json:"multiple_cert,omitempty"`
+	UserSetable     bool   `json:"user_setable,omitempty"`
+}
+
+type CertificateRecord struct {
+	Id        string                      `json:"id"`
+	Desc      string                      `json:"desc"`
+	IsDefault bool                        `json:"is_default"`
+	IsBroken  bool                        `json:"is_broken"`
+	Services  []*CertificateServiceRecord `json:"services"`
+	Subject   *struct {
+		CommonName string   `json:"common_name"`
+		SubAltName []string `json:"sub_alt_name"`
+	} `json:"subject,omitempty"`
+}
+
+type ListCertificatesResponse struct {
+	Certificates []*CertificateRecord `json:"certificates"`
+}
+
+type ImportCertificateRequest struct {
+	Id          string
+	Desc        string
+	AsDefault   bool
+	Certificate string
+	PrivateKey  string
+	InterCert   string
+}
+
+type ImportCertificateResponse struct {
+	Id           string `json:"id"`
+	RestartHttpd bool   `json:"restart_httpd"`
+}
+
+type SetCertificateServiceSetting struct {
+	Service *CertificateServiceRecord `json:"service"`
+	OldId   string                    `json:"old_id"`
+	Id      string                    `json:"id"`
+}
+
+type SetCertificateServicesRequest struct {
+	Settings []*SetCertificateServiceSetting
+}
+
+type SetCertificateServicesResponse struct {
+	RestartHttpd bool `json:"restart_httpd"`
+}
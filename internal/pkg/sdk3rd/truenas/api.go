@@ -0,0 +1,112 @@
+package truenas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func (c *Client) GetJob(jobId int64) (*JobRecord, error) {
+	resp := make([]*JobRecord, 0)
+	if err := c.sendRequestWithResult(http.MethodGet, fmt.Sprintf("/core/get_jobs?id=%d", jobId), nil, &resp); err != nil {
+		return nil, err
+	} else if len(resp) == 0 {
+		return nil, fmt.Errorf("truenas api error: job %d not found", jobId)
+	}
+
+	return resp[0], nil
+}
+
+// 轮询等待异步任务结束。
+func (c *Client) WaitJob(ctx context.Context, jobId int64, interval time.Duration) (*JobRecord, error) {
+	for {
+		job, err := c.GetJob(jobId)
+		if err != nil {
+			return nil, err
+		}
+
+		switch job.State {
+		case "SUCCESS":
+			return job, nil
+		case "FAILED", "ABORTED":
+			return job, fmt.Errorf("truenas job %d %s: %s", jobId, job.State, job.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (c *Client) ListCertificates() (ListCertificatesResponse, error) {
+	resp := ListCertificatesResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/certificate", nil, &resp)
+	return resp, err
+}
+
+// 创建证书，返回异步任务 ID。
+func (c *Client) CreateCertificate(req *CreateCertificateRequest) (int64, error) {
+	var jobId int64
+	err := c.sendRequestWithResult(http.MethodPost, "/certificate", req, &jobId)
+	return jobId, err
+}
+
+// 删除证书，返回异步任务 ID。
+func (c *Client) DeleteCertificate(certificateId int64) (int64, error) {
+	var jobId int64
+	err := c.sendRequestWithResult(http.MethodDelete, fmt.Sprintf("/certificate/id/%d", certificateId), nil, &jobId)
+	return jobId, err
+}
+
+func (c *Client) GetSystemGeneral() (*SystemGeneralRecord, error) {
+	resp := &SystemGeneralRecord{}
+	err := c.sendRequestWithResult(http.MethodGet, "/system/general", nil, resp)
+	return resp, err
+}
+
+func (c *Client) UpdateSystemGeneral(req *UpdateSystemGeneralRequest) error {
+	_, err := c.sendRequest(http.MethodPut, "/system/general", req)
+	return err
+}
+
+func (c *Client) RestartUI() error {
+	_, err := c.sendRequest(http.MethodPost, "/system/general/ui_restart", nil)
+	return err
+}
+
+func (c *Client) ListApps() (ListAppsResponse, error) {
+	resp := ListAppsResponse{}
+	err := c.sendRequestWithResult(http.MethodGet, "/app", nil, &resp)
+	return resp, err
+}
+
+func (c *Client) GetAppConfig(appName string) (map[string]any, error) {
+	// 方法参数须以 JSON 编码后作为请求体
+	params, _ := json.Marshal(appName)
+
+	resp := make(map[string]any)
+	err := c.sendRequestWithResult(http.MethodPost, "/app/config", params, &resp)
+	return resp, err
+}
+
+// 更新应用配置，返回异步任务 ID。
+func (c *Client) UpdateApp(appName string, req *UpdateAppRequest) (int64, error) {
+	var jobId int64
+	err := c.sendRequestWithResult(http.MethodPut, fmt.Sprintf("/app/id/%s", url.PathEscape(appName)), req, &jobId)
+	return jobId, err
+}
+
+// 从创建证书任务的结果中解析证书信息。
+func (c *Client) GetCreatedCertificate(job *JobRecord) (*CertificateRecord, error) {
+	resp := &CertificateRecord{}
+	if err := json.Unmarshal(job.Result, resp); err != nil {
+		return nil, fmt.Errorf("truenas api error: failed to unmarshal job result: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,67 @@
+package truenas
+
+import (
+	"encoding/json"
+)
+
+type JobRecord struct {
+	Id     int64           `json:"id"`
+	Method string          `json:"method"`
+	State  string          `json:"state"`
+	Error  string          `json:"error,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+type CertificateRecord struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Certificate string `json:"certificate,omitempty"`
+}
+
+type CreateCertificateRequest struct {
+	CreateType  string `json:"create_type"`
+	Name        string `json:"name"`
+	Certificate string `json:"certificate"`
+	Privatekey  string `json:"privatekey"`
+}
+
+type ListCertificatesResponse = []*CertificateRecord
+
+type SystemGeneralRecord struct {
+	UiCertificate json.RawMessage `json:"ui_certificate"`
+}
+
+// 获取界面证书 ID，兼容对象和数值两种返回形式。
+func (r *SystemGeneralRecord) GetUiCertificateId() int64 {
+	if len(r.UiCertificate) == 0 {
+		return 0
+	}
+
+	var id int64
+	if err := json.Unmarshal(r.UiCertificate, &id); err == nil {
+		return id
+	}
+
+	cert := &CertificateRecord{}
+	if err := json.Unmarshal(r.UiCertificate, cert); err == nil {
+		return cert.Id
+	}
+
+	return 0
+}
+
+type UpdateSystemGeneralRequest struct {
+	UiCertificate int64 `json:"ui_certificate"`
+}
+
+type AppRecord struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state,omitempty"`
+}
+
+type ListAppsResponse = []*AppRecord
+
+type UpdateAppRequest struct {
+	Values map[string]any `json:"values"`
+}
@@ -0,0 +1,108 @@
+package vault
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func (c *Client) LoginWithAppRole(req *LoginWithAppRoleRequest) (*LoginWithAppRoleResponse, error) {
+	if req.RoleId == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: RoleId")
+	}
+
+	mountPath := req.MountPath
+	if mountPath == "" {
+		mountPath = "approle"
+	}
+
+	resp := &LoginWithAppRoleResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, fmt.Sprintf("/auth/%s/login", trimPath(mountPath)), req, resp)
+	return resp, err
+}
+
+func (c *Client) LoginWithKubernetes(req *LoginWithKubernetesRequest) (*LoginWithKubernetesResponse, error) {
+	if req.Role == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: Role")
+	}
+	if req.Jwt == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: Jwt")
+	}
+
+	mountPath := req.MountPath
+	if mountPath == "" {
+		mountPath = "kubernetes"
+	}
+
+	resp := &LoginWithKubernetesResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, fmt.Sprintf("/auth/%s/login", trimPath(mountPath)), req, resp)
+	return resp, err
+}
+
+func (c *Client) KVv2WriteSecret(req *KVv2WriteSecretRequest) (*KVv2WriteSecretResponse, error) {
+	if req.MountPath == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: MountPath")
+	}
+	if req.Path == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: Path")
+	}
+
+	resp := &KVv2WriteSecretResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, fmt.Sprintf("/%s/data/%s", trimPath(req.MountPath), trimPath(req.Path)), req, resp)
+	return resp, err
+}
+
+func (c *Client) KVv2UpdateMetadata(req *KVv2UpdateMetadataRequest) (*KVv2UpdateMetadataResponse, error) {
+	if req.MountPath == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: MountPath")
+	}
+	if req.Path == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: Path")
+	}
+
+	resp := &KVv2UpdateMetadataResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, fmt.Sprintf("/%s/metadata/%s", trimPath(req.MountPath), trimPath(req.Path)), req, resp)
+	return resp, err
+}
+
+func (c *Client) PKIImportIssuerBundle(req *PKIImportIssuerBundleRequest) (*PKIImportIssuerBundleResponse, error) {
+	if req.MountPath == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: MountPath")
+	}
+	if req.PemBundle == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: PemBundle")
+	}
+
+	resp := &PKIImportIssuerBundleResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, fmt.Sprintf("/%s/issuers/import/bundle", trimPath(req.MountPath)), req, resp)
+	return resp, err
+}
+
+func (c *Client) PKIUpdateIssuer(req *PKIUpdateIssuerRequest) (*PKIUpdateIssuerResponse, error) {
+	if req.MountPath == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: MountPath")
+	}
+	if req.IssuerRef == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: IssuerRef")
+	}
+
+	// 使用 PATCH 仅更新指定字段，POST 会将未指定的字段重置为默认值
+	resp := &PKIUpdateIssuerResponse{}
+	err := c.sendRequestWithResult(http.MethodPatch, fmt.Sprintf("/%s/issuer/%s", trimPath(req.MountPath), url.PathEscape(req.IssuerRef)), req, resp)
+	return resp, err
+}
+
+func (c *Client) PKIUpdateIssuersConfig(req *PKIUpdateIssuersConfigRequest) (*PKIUpdateIssuersConfigResponse, error) {
+	if req.MountPath == "" {
+		return nil, fmt.Errorf("vault api error: invalid parameter: MountPath")
+	}
+
+	resp := &PKIUpdateIssuersConfigResponse{}
+	err := c.sendRequestWithResult(http.MethodPost, fmt.Sprintf("/%s/config/issuers", trimPath(req.MountPath)), req, resp)
+	return resp, err
+}
+
+func trimPath(path string) string {
+	return strings.Trim(path, "/")
+}
@@ -0,0 +1,98 @@
+package vault
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// HashiCorp Vault HTTP API 客户端。
+// REF: https://developer.hashicorp.com/vault/api-docs
+type Client struct {
+	client *resty.Client
+	token  string
+}
+
+func NewClient(address string) *Client {
+	client := &Client{}
+	client.client = resty.New().
+		SetBaseURL(strings.TrimRight(address, "/")+"/v1").
+		SetHeader("User-Agent", "certimate").
+		SetPreRequestHook(func(c *resty.Client, req *http.Request) error {
+			if client.token != "" {
+				req.Header.Set("X-Vault-Token", client.token)
+			}
+
+			return nil
+		})
+
+	return client
+}
+
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+func (c *Client) WithTLSConfig(config *tls.Config) *Client {
+	c.client.SetTLSClientConfig(config)
+	return c
+}
+
+func (c *Client) WithNamespace(namespace string) *Client {
+	if namespace != "" {
+		c.client.SetHeader("X-Vault-Namespace", namespace)
+	}
+	return c
+}
+
+func (c *Client) WithToken(token string) *Client {
+	c.token = token
+	return c
+}
+
+func (c *Client) sendRequest(method string, path string, params interface{}) (*resty.Response, error) {
+	req := c.client.R()
+	if params != nil {
+		contentType := "application/json"
+		if method == http.MethodPatch {
+			contentType = "application/merge-patch+json"
+		}
+		req = req.SetHeader("Content-Type", contentType).SetBody(params)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, fmt.Errorf("vault api error: failed to send request: %w", err)
+	} else if resp.IsError() {
+		errResp := &baseResponse{}
+		if err := json.Unmarshal(resp.Body(), errResp); err == nil && len(errResp.Errors) > 0 {
+			return resp, fmt.Errorf("vault api error: unexpected status code: %d, errors: %s", resp.StatusCode(), strings.Join(errResp.Errors, "; "))
+		}
+		return resp, fmt.Errorf("vault api error: unexpected status code: %d, resp: %s", resp.StatusCode(), resp.String())
+	}
+
+	return resp, nil
+}
+
+func (c *Client) sendRequestWithResult(method string, path string, params interface{}, result interface{}) error {
+	resp, err := c.sendRequest(method, path, params)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Body()) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return fmt.Errorf("vault api error: failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package vault
+
+type baseResponse struct {
+	Errors []string `json:"errors,omitempty"`
+}
+
+type AuthInfo struct {
+	ClientToken   string   `json:"client_token"`
+	Accessor      string   `json:"accessor"`
+	Policies      []string `json:"policies"`
+	LeaseDuration int64    `json:"lease_duration"`
+	Renewable     bool     `json:"renewable"`
+}
+
+type LoginWithAppRoleRequest struct {
+	MountPath string `json:"-"`
+	RoleId    string `json:"role_id"`
+	SecretId  string `json:"secret_id,omitempty"`
+}
+
+type LoginWithAppRoleResponse struct {
+	baseResponse
+	Auth *AuthInfo `json:"auth,omitempty"`
+}
+
+type LoginWithKubernetesRequest struct {
+	MountPath string `json:"-"`
+	Role      string `json:"role"`
+	Jwt       string `json:"jwt"`
+}
+
+type LoginWithKubernetesResponse struct {
+	baseResponse
+	Auth *AuthInfo `json:"auth,omitempty"`
+}
+
+type KVv2WriteSecretRequest struct {
+	MountPath string            `json:"-"`
+	Path      string            `json:"-"`
+	Data      map[string]string `json:"data"`
+	Options   *KVv2WriteOptions `json:"options,omitempty"`
+}
+
+type KVv2WriteOptions struct {
+	Cas *int64 `json:"cas,omitempty"`
+}
+
+type KVv2WriteSecretResponse struct {
+	baseResponse
+	Data *struct {
+		CreatedTime  string `json:"created_time"`
+		DeletionTime string `json:"deletion_time"`
+		Destroyed    bool   `json:"destroyed"`
+		Version      int64  `json:"version"`
+	} `json:"data,omitempty"`
+}
+
+type KVv2UpdateMetadataRequest struct {
+	MountPath      string            `json:"-"`
+	Path           string            `json:"-"`
+	MaxVersions    *int64            `json:"max_versions,omitempty"`
+	CustomMetadata map[string]string `json:"custom_metadata,omitempty"`
+}
+
+type KVv2UpdateMetadataResponse struct {
+	baseResponse
+}
+
+type PKIImportIssuerBundleRequest struct {
+	MountPath string `json:"-"`
+	PemBundle string `json:"pem_bundle"`
+}
+
+type PKIImportIssuerBundleResponse struct {
+	baseResponse
+	Data *struct {
+		ImportedIssuers []string          `json:"imported_issuers"`
+		ImportedKeys    []string          `json:"imported_keys"`
+		ExistingIssuers []string          `json:"existing_issuers"`
+		ExistingKeys    []string          `json:"existing_keys"`
+		Mapping         map[string]string `json:"mapping"`
+	} `json:"data,omitempty"`
+}
+
+type PKIUpdateIssuerRequest struct {
+	MountPath  string `json:"-"`
+	IssuerRef  string `json:"-"`
+	IssuerName string `json:"issuer_name,omitempty"`
+}
+
+type PKIUpdateIssuerResponse struct {
+	baseResponse
+}
+
+type PKIUpdateIssuersConfigRequest struct {
+	MountPath string `json:"-"`
+	Default   string `json:"default"`
+}
+
+type PKIUpdateIssuersConfigResponse struct {
+	baseResponse
+}
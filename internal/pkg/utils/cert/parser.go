@@ -37,6 +37,45 @@ func ParseCertificateFromPEM(certPEM string) (_cert *x509.Certificate, _err erro
 	return cert, nil
 }
 
+// 从 PEM 编码的证书字符串解析并返回全部 x509.Certificate 对象。
+// PEM 内容可能是包含多张证书的证书链，返回的顺序与 PEM 内容中的顺序一致。
+//
+// 入参:
+//   - certPEM: 证书 PEM 内容。
+//
+// 出参:
+//   - certs: x509.Certificate 对象数组。
+//   - err: 错误。
+func ParseCertificatesFromPEM(certPEM string) (_certs []*x509.Certificate, _err error) {
+	certs := make([]*x509.Certificate, 0)
+
+	pemData := []byte(certPEM)
+	for {
+		block, rest := pem.Decode(pemData)
+		if block == nil {
+			break
+		}
+		pemData = rest
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("failed to decode PEM block")
+	}
+
+	return certs, nil
+}
+
 // 从 PEM 编码的私钥字符串解析并返回一个 crypto.PrivateKey 对象。
 //
 // 入参:
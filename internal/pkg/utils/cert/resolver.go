@@ -0,0 +1,251 @@
+package certutil
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 联网下载颁发者证书的默认总超时时间。
+const DefaultResolveRootFetchTimeout = 10 * time.Second
+
+type ResolveRootOptions struct {
+	// 是否允许在离线解析失败时，根据证书的 AIA 扩展（Authority Information Access）联网逐级下载颁发者证书。
+	FetchIssuer bool
+	// 联网下载颁发者证书的总超时时间。
+	// 零值时默认值 [DefaultResolveRootFetchTimeout]。
+	FetchTimeout time.Duration
+}
+
+// 已下载的颁发者证书缓存，以被签发证书的 SHA-256 摘要为键。
+// 缓存条目数量有上限，且超过有效期或颁发者证书过期后失效，以免长期运行时无限增长。
+var issuerCertificateCache = newIssuerCertificateLRU(256, 24*time.Hour)
+
+type issuerCertificateLRU struct {
+	mutex    sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  *list.List
+	elements map[[sha256.Size]byte]*list.Element
+}
+
+type issuerCertificateLRUEntry struct {
+	key       [sha256.Size]byte
+	issuer    *x509.Certificate
+	expiresAt time.Time
+}
+
+func newIssuerCertificateLRU(capacity int, ttl time.Duration) *issuerCertificateLRU {
+	return &issuerCertificateLRU{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  list.New(),
+		elements: make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+func (c *issuerCertificateLRU) Load(key [sha256.Size]byte) (*x509.Certificate, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.elements[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*issuerCertificateLRUEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.entries.Remove(element)
+		delete(c.elements, key)
+		return nil, false
+	}
+
+	c.entries.MoveToFront(element)
+	return entry.issuer, true
+}
+
+func (c *issuerCertificateLRU) Store(key [sha256.Size]byte, issuer *x509.Certificate) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if issuer.NotAfter.Before(expiresAt) {
+		expiresAt = issuer.NotAfter
+	}
+
+	if element, ok := c.elements[key]; ok {
+		element.Value = &issuerCertificateLRUEntry{key: key, issuer: issuer, expiresAt: expiresAt}
+		c.entries.MoveToFront(element)
+		return
+	}
+
+	c.elements[key] = c.entries.PushFront(&issuerCertificateLRUEntry{key: key, issuer: issuer, expiresAt: expiresAt})
+
+	// 超出容量时淘汰最久未使用的条目
+	for c.entries.Len() > c.capacity {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.elements, oldest.Value.(*issuerCertificateLRUEntry).key)
+	}
+}
+
+func (c *issuerCertificateLRU) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.entries.Len()
+}
+
+// 从 PEM 编码的证书链字符串解析并返回根证书。
+// 优先离线解析：证书链末尾为自签名证书时直接返回，否则尝试以系统信任的根证书验证证书链。
+// 离线解析失败且启用联网下载时，将会根据证书的 AIA 扩展逐级下载颁发者证书，直至找到根证书。
+//
+// 入参:
+//   - ctx: 上下文。
+//   - certPEM: 证书 PEM 内容。
+//   - options: 解析选项。零值时不联网下载。
+//
+// 出参:
+//   - rootCertPEM: 根证书的 PEM 内容。
+//   - err: 错误。
+func ResolveRootCertificateFromPEM(ctx context.Context, certPEM string, options *ResolveRootOptions) (_rootCertPEM string, _err error) {
+	if options == nil {
+		options = &ResolveRootOptions{}
+	}
+
+	certs, err := ParseCertificatesFromPEM(certPEM)
+	if err != nil {
+		return "", err
+	}
+
+	if root := resolveRootCertificateOffline(certs); root != nil {
+		return ConvertCertificateToPEM(root)
+	}
+
+	if !options.FetchIssuer {
+		return "", errors.New("failed to resolve root certificate: not found in the certificate chain or the system trust store")
+	}
+
+	timeout := options.FetchTimeout
+	if timeout <= 0 {
+		timeout = DefaultResolveRootFetchTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	const maxDepth = 5
+	current := certs[len(certs)-1]
+	for i := 0; i <= maxDepth; i++ {
+		if isSelfSignedCertificate(current) {
+			return ConvertCertificateToPEM(current)
+		}
+
+		if len(current.IssuingCertificateURL) == 0 {
+			return "", fmt.Errorf("failed to resolve root certificate: no issuing certificate url found in '%s'", current.Subject.String())
+		}
+
+		issuer, err := fetchIssuerCertificate(ctx, current)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve root certificate: %w", err)
+		}
+
+		current = issuer
+	}
+
+	return "", errors.New("failed to resolve root certificate: chain too long")
+}
+
+func resolveRootCertificateOffline(certs []*x509.Certificate) *x509.Certificate {
+	if last := certs[len(certs)-1]; isSelfSignedCertificate(last) {
+		return last
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	// 以证书生效时间验证，避免已过期的证书无法解析
+	chains, err := certs[0].Verify(x509.VerifyOptions{
+		Intermediates: intermediates,
+		CurrentTime:   certs[0].NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil || len(chains) == 0 {
+		return nil
+	}
+
+	chain := chains[0]
+	return chain[len(chain)-1]
+}
+
+func isSelfSignedCertificate(cert *x509.Certificate) bool {
+	if !bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return false
+	}
+
+	return cert.CheckSignatureFrom(cert) == nil
+}
+
+func fetchIssuerCertificate(ctx context.Context, cert *x509.Certificate) (*x509.Certificate, error) {
+	cacheKey := sha256.Sum256(cert.Raw)
+	if cached, ok := issuerCertificateCache.Load(cacheKey); ok {
+		return cached, nil
+	}
+
+	var errs []error
+	for _, url := range cert.IssuingCertificateURL {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		} else if resp.StatusCode != http.StatusOK {
+			errs = append(errs, fmt.Errorf("unexpected status code %d from '%s'", resp.StatusCode, url))
+			continue
+		}
+
+		// 颁发者证书可能是 DER 或 PEM 编码
+		if block, _ := pem.Decode(data); block != nil {
+			data = block.Bytes
+		}
+
+		issuer, err := x509.ParseCertificate(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse certificate from '%s': %w", url, err))
+			continue
+		}
+
+		if err := cert.CheckSignatureFrom(issuer); err != nil {
+			errs = append(errs, fmt.Errorf("certificate from '%s' is not the issuer: %w", url, err))
+			continue
+		}
+
+		issuerCertificateCache.Store(cacheKey, issuer)
+		return issuer, nil
+	}
+
+	return nil, errors.Join(errs...)
+}
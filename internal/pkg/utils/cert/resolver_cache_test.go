@@ -0,0 +1,43 @@
+package certutil
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"testing"
+	"time"
+)
+
+func TestIssuerCertificateLRU(t *testing.T) {
+	issuer := &x509.Certificate{NotAfter: time.Now().Add(time.Hour)}
+	keyOf := func(i byte) [sha256.Size]byte { return [sha256.Size]byte{i} }
+
+	t.Run("EvictLeastRecentlyUsed", func(t *testing.T) {
+		cache := newIssuerCertificateLRU(2, time.Hour)
+		cache.Store(keyOf(1), issuer)
+		cache.Store(keyOf(2), issuer)
+		cache.Load(keyOf(1))
+		cache.Store(keyOf(3), issuer)
+
+		if cache.Len() != 2 {
+			t.Errorf("expected 2 entries, got %d", cache.Len())
+		}
+		if _, ok := cache.Load(keyOf(2)); ok {
+			t.Error("least recently used entry should be evicted")
+		}
+		if _, ok := cache.Load(keyOf(1)); !ok {
+			t.Error("recently used entry should be kept")
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		cache := newIssuerCertificateLRU(2, time.Hour)
+		cache.Store(keyOf(1), &x509.Certificate{NotAfter: time.Now().Add(-time.Second)})
+
+		if _, ok := cache.Load(keyOf(1)); ok {
+			t.Error("entry of an expired issuer certificate should not be returned")
+		}
+		if cache.Len() != 0 {
+			t.Errorf("expired entry should be removed, got %d entries", cache.Len())
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package certutil_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+type testCertificate struct {
+	Cert       *x509.Certificate
+	CertPEM    string
+	PrivkeyPEM string
+
+	key *ecdsa.PrivateKey
+}
+
+// 生成测试证书。未指定颁发者时自签名；issuingCertificateURL 为证书中的颁发者证书下载地址。
+func generateCertificate(t *testing.T, commonName string, isCA bool, issuer *testCertificate, issuingCertificateURL string) *testCertificate {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	serialNumber, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if isCA {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		template.DNSNames = []string{commonName}
+	}
+	if issuingCertificateURL != "" {
+		template.IssuingCertificateURL = []string{issuingCertificateURL}
+	}
+
+	parent, parentKey := template, key
+	if issuer != nil {
+		parent, parentKey = issuer.Cert, issuer.key
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(certDER)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return &testCertificate{
+		Cert:       cert,
+		CertPEM:    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+		PrivkeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+		key:        key,
+	}
+}
+
+func TestResolveRootCertificateFromPEM(t *testing.T) {
+	t.Run("RootInChain", func(t *testing.T) {
+		root := generateCertificate(t, "Test Root CA", true, nil, "")
+		intermediate := generateCertificate(t, "Test Intermediate CA", true, root, "")
+		leafPEM := generateCertificate(t, "example.com", false, intermediate, "").CertPEM
+
+		rootPEM, err := certutil.ResolveRootCertificateFromPEM(context.Background(), leafPEM+intermediate.CertPEM+root.CertPEM, nil)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if rootPEM != root.CertPEM {
+			t.Errorf("unexpected root certificate: %s", rootPEM)
+		}
+	})
+
+	t.Run("FetchDisabled", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+		}))
+		defer server.Close()
+
+		root := generateCertificate(t, "Test Root CA", true, nil, "")
+		leafPEM := generateCertificate(t, "example.com", false, root, server.URL+"/root.cer").CertPEM
+
+		if _, err := certutil.ResolveRootCertificateFromPEM(context.Background(), leafPEM, nil); err == nil {
+			t.Error("expected error when root certificate is not in the chain")
+		}
+		if requests.Load() != 0 {
+			t.Errorf("expected no network requests, got %d", requests.Load())
+		}
+	})
+
+	t.Run("FetchIssuer", func(t *testing.T) {
+		var requests atomic.Int32
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		root := generateCertificate(t, "Test Root CA", true, nil, "")
+		intermediate := generateCertificate(t, "Test Intermediate CA", true, root, server.URL+"/root.cer")
+		leafPEM := generateCertificate(t, "example.com", false, intermediate, server.URL+"/intermediate.cer").CertPEM
+
+		mux.HandleFunc("/root.cer", func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Write(root.Cert.Raw)
+		})
+		mux.HandleFunc("/intermediate.cer", func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Write([]byte(intermediate.CertPEM))
+		})
+
+		options := &certutil.ResolveRootOptions{FetchIssuer: true}
+		rootPEM, err := certutil.ResolveRootCertificateFromPEM(context.Background(), leafPEM, options)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if rootPEM != root.CertPEM {
+			t.Errorf("unexpected root certificate: %s", rootPEM)
+		}
+		if requests.Load() != 2 {
+			t.Errorf("expected 2 requests, got %d", requests.Load())
+		}
+
+		// 再次解析时应命中缓存
+		if _, err := certutil.ResolveRootCertificateFromPEM(context.Background(), leafPEM, options); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if requests.Load() != 2 {
+			t.Errorf("expected issuer certificates to be cached, got %d requests", requests.Load())
+		}
+	})
+
+	t.Run("FetchTimeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer server.Close()
+
+		root := generateCertificate(t, "Test Root CA", true, nil, "")
+		leafPEM := generateCertificate(t, "example.com", false, root, server.URL+"/root.cer").CertPEM
+
+		start := time.Now()
+		_, err := certutil.ResolveRootCertificateFromPEM(context.Background(), leafPEM, &certutil.ResolveRootOptions{FetchIssuer: true, FetchTimeout: 100 * time.Millisecond})
+		if err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+			t.Errorf("expected deadline exceeded error, got: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("expected to time out quickly, took %v", elapsed)
+		}
+	})
+}
@@ -2,8 +2,19 @@ package certutil
 
 import (
 	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/pem"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/pavlo-v-chernykh/keystore-go/v4"
@@ -85,3 +96,261 @@ func TransformCertificateFromPEMToJKS(certPEM string, privkeyPEM string, jksAlia
 
 	return buf.Bytes(), nil
 }
+
+// 将 PEM 编码的证书字符串转换为 DER 格式。
+// DER 格式只能容纳一张证书，因此只转换服务器证书。
+//
+// 入参:
+//   - certPEM: 证书 PEM 内容。
+//
+// 出参:
+//   - data: DER 格式的证书数据。
+//   - err: 错误。
+func TransformCertificateFromPEMToDER(certPEM string) ([]byte, error) {
+	cert, err := ParseCertificateFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert.Raw, nil
+}
+
+// 将 PEM 编码的证书字符串转换为 PKCS#7 格式（即 .p7b 文件）。
+// 转换结果是 DER 编码的、不含签名信息的证书链包。
+//
+// 入参:
+//   - certPEM: 证书 PEM 内容。
+//
+// 出参:
+//   - data: PKCS#7 格式的证书数据。
+//   - err: 错误。
+func TransformCertificateFromPEMToPKCS7(certPEM string) ([]byte, error) {
+	certs, err := ParseCertificatesFromPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	var certsBytes []byte
+	for _, cert := range certs {
+		certsBytes = append(certsBytes, cert.Raw...)
+	}
+
+	signedData, err := asn1.Marshal(pkcs7SignedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{},
+		ContentInfo:      pkcs7ContentInfo{ContentType: oidPKCS7Data},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certsBytes},
+		SignerInfos:      []asn1.RawValue{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(pkcs7ContentInfo{
+		ContentType: oidPKCS7SignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedData},
+	})
+}
+
+// 将 PEM 编码的证书链字符串转换为逆序排列的 PEM 格式，即根证书或中间证书在前、服务器证书在后。
+//
+// 入参:
+//   - certPEM: 证书 PEM 内容。
+//
+// 出参:
+//   - certPEM: 逆序排列的证书 PEM 内容。
+//   - err: 错误。
+func TransformCertificateFromPEMToReversedPEM(certPEM string) (string, error) {
+	certs, err := ParseCertificatesFromPEM(certPEM)
+	if err != nil {
+		return "", err
+	}
+
+	slices.Reverse(certs)
+
+	var sb strings.Builder
+	for _, cert := range certs {
+		sb.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+
+	return sb.String(), nil
+}
+
+// 将 PEM 编码的证书字符串与私钥字符串合并为单个 PEM 文件，即证书链在前、私钥在后（如 HAProxy 所需格式）。
+//
+// 入参:
+//   - certPEM: 证书 PEM 内容。
+//   - privkeyPEM: 私钥 PEM 内容。
+//
+// 出参:
+//   - combinedPEM: 合并后的 PEM 内容。
+//   - err: 错误。
+func TransformCertificateFromPEMToCombinedPEM(certPEM string, privkeyPEM string) (string, error) {
+	if _, err := ParseCertificateFromPEM(certPEM); err != nil {
+		return "", err
+	}
+
+	if block, _ := pem.Decode([]byte(privkeyPEM)); block == nil {
+		return "", errors.New("failed to decode private key PEM")
+	}
+
+	certPEM = strings.TrimRight(certPEM, "\r\n") + "\n"
+	privkeyPEM = strings.TrimRight(privkeyPEM, "\r\n") + "\n"
+	return certPEM + privkeyPEM, nil
+}
+
+// 将 PEM 编码的私钥字符串转换为 DER 编码的 PKCS#8 格式。
+//
+// 入参:
+//   - privkeyPEM: 私钥 PEM 内容。
+//
+// 出参:
+//   - data: PKCS#8 格式的私钥数据。
+//   - err: 错误。
+func TransformPrivateKeyFromPEMToPKCS8(privkeyPEM string) ([]byte, error) {
+	privkey, err := ParsePrivateKeyFromPEM(privkeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := x509.MarshalPKCS8PrivateKey(privkey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PKCS#8 private key: %w", err)
+	}
+
+	return data, nil
+}
+
+// 将 PEM 编码的私钥字符串转换为 PEM 编码的 PKCS#8 格式（即 "PRIVATE KEY"）。
+//
+// 入参:
+//   - privkeyPEM: 私钥 PEM 内容。
+//
+// 出参:
+//   - privkeyPEM: PKCS#8 格式的私钥 PEM 内容。
+//   - err: 错误。
+func TransformPrivateKeyFromPEMToPKCS8PEM(privkeyPEM string) (string, error) {
+	data, err := TransformPrivateKeyFromPEMToPKCS8(privkeyPEM)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: data})), nil
+}
+
+// 将 PEM 编码的私钥字符串转换为 PEM 编码的加密 PKCS#8 格式（即 "ENCRYPTED PRIVATE KEY"）。
+// 加密方案为 PBES2，密钥派生使用 PBKDF2-HMAC-SHA256，加密使用 AES-256-CBC。
+//
+// 入参:
+//   - privkeyPEM: 私钥 PEM 内容。
+//   - password: 加密密码。
+//
+// 出参:
+//   - privkeyPEM: 加密 PKCS#8 格式的私钥 PEM 内容。
+//   - err: 错误。
+func TransformPrivateKeyFromPEMToEncryptedPKCS8PEM(privkeyPEM string, password string) (string, error) {
+	if password == "" {
+		return "", errors.New("password is required for encrypted private key")
+	}
+
+	data, err := TransformPrivateKeyFromPEMToPKCS8(privkeyPEM)
+	if err != nil {
+		return "", err
+	}
+
+	const iterations = 100000
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, iterations, 32)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	// PKCS#7 填充
+	padding := aes.BlockSize - len(data)%aes.BlockSize
+	plaintext := append(data, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+
+	kdfParams, err := asn1.Marshal(pkcs8PBKDF2Params{
+		Salt:           salt,
+		IterationCount: iterations,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	ivParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return "", err
+	}
+
+	pbes2Params, err := asn1.Marshal(pkcs8PBES2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParams}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := asn1.Marshal(pkcs8EncryptedPrivateKeyInfo{
+		EncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: pbes2Params}},
+		EncryptedData:       ciphertext,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: encrypted})), nil
+}
+
+var (
+	oidPKCS7Data       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidPKCS7SignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidPBES2           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA256  = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES256CBC       = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"optional"`
+}
+
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      pkcs7ContentInfo
+	Certificates     asn1.RawValue   `asn1:"optional"`
+	SignerInfos      []asn1.RawValue `asn1:"set"`
+}
+
+type pkcs8EncryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+type pkcs8PBES2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pkcs8PBKDF2Params struct {
+	Salt           []byte
+	IterationCount int
+	PRF            pkix.AlgorithmIdentifier
+}
@@ -0,0 +1,182 @@
+package certutil_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	certutil "github.com/usual2970/certimate/internal/pkg/utils/cert"
+)
+
+func TestTransformCertificateFromPEMToPKCS7(t *testing.T) {
+	root := generateCertificate(t, "Test Root CA", true, nil, "")
+	intermediate := generateCertificate(t, "Test Intermediate CA", true, root, "")
+	leaf := generateCertificate(t, "example.com", false, intermediate, "")
+	chainPEM := leaf.CertPEM + intermediate.CertPEM
+
+	data, err := certutil.TransformCertificateFromPEMToPKCS7(chainPEM)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	var contentInfo struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue
+	}
+	if rest, err := asn1.Unmarshal(data, &contentInfo); err != nil {
+		t.Fatalf("err: %+v", err)
+	} else if len(rest) != 0 {
+		t.Fatalf("unexpected trailing data: %d bytes", len(rest))
+	}
+	if !contentInfo.ContentType.Equal(asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}) {
+		t.Fatalf("unexpected content type: %v", contentInfo.ContentType)
+	}
+
+	var signedData struct {
+		Version          int
+		DigestAlgorithms asn1.RawValue
+		ContentInfo      asn1.RawValue
+		Certificates     asn1.RawValue `asn1:"tag:0"`
+		SignerInfos      asn1.RawValue
+	}
+	if _, err := asn1.Unmarshal(contentInfo.Content.Bytes, &signedData); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	certs, err := x509.ParseCertificates(signedData.Certificates.Bytes)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	expects, _ := certutil.ParseCertificatesFromPEM(chainPEM)
+	if len(certs) != len(expects) {
+		t.Fatalf("expected %d certificates, got %d", len(expects), len(certs))
+	}
+	for i := range certs {
+		if !certs[i].Equal(expects[i]) {
+			t.Errorf("certificate #%d does not match", i)
+		}
+	}
+
+	t.Run("OpenSSL", func(t *testing.T) {
+		output := runOpenSSL(t, data, "pkcs7", "-inform", "DER", "-print_certs", "-noout")
+		if !strings.Contains(output, "example.com") || !strings.Contains(output, "Test Intermediate CA") {
+			t.Errorf("unexpected openssl output: %s", output)
+		}
+	})
+}
+
+func TestTransformPrivateKeyFromPEMToEncryptedPKCS8PEM(t *testing.T) {
+	privkeyPEM := generateCertificate(t, "example.com", false, nil, "").PrivkeyPEM
+	const password = "p@ssw0rd"
+
+	encryptedPEM, err := certutil.TransformPrivateKeyFromPEMToEncryptedPKCS8PEM(privkeyPEM, password)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	block, _ := pem.Decode([]byte(encryptedPEM))
+	if block == nil || block.Type != "ENCRYPTED PRIVATE KEY" {
+		t.Fatalf("unexpected pem block: %v", block)
+	}
+
+	var encryptedInfo struct {
+		EncryptionAlgorithm pkix.AlgorithmIdentifier
+		EncryptedData       []byte
+	}
+	if _, err := asn1.Unmarshal(block.Bytes, &encryptedInfo); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	var pbes2Params struct {
+		KeyDerivationFunc pkix.AlgorithmIdentifier
+		EncryptionScheme  pkix.AlgorithmIdentifier
+	}
+	if _, err := asn1.Unmarshal(encryptedInfo.EncryptionAlgorithm.Parameters.FullBytes, &pbes2Params); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	var kdfParams struct {
+		Salt           []byte
+		IterationCount int
+		PRF            pkix.AlgorithmIdentifier
+	}
+	if _, err := asn1.Unmarshal(pbes2Params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(pbes2Params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, kdfParams.Salt, kdfParams.IterationCount, 32)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	cipherBlock, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	plaintext := make([]byte, len(encryptedInfo.EncryptedData))
+	cipher.NewCBCDecrypter(cipherBlock, iv).CryptBlocks(plaintext, encryptedInfo.EncryptedData)
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(plaintext[len(plaintext)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		t.Fatalf("invalid padding")
+	}
+
+	decrypted, err := x509.ParsePKCS8PrivateKey(plaintext[:len(plaintext)-padding])
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	expect, _ := certutil.ParsePrivateKeyFromPEM(privkeyPEM)
+	if !expect.(interface{ Equal(crypto.PrivateKey) bool }).Equal(decrypted) {
+		t.Errorf("decrypted private key does not match")
+	}
+
+	t.Run("OpenSSL", func(t *testing.T) {
+		output := runOpenSSL(t, []byte(encryptedPEM), "pkey", "-passin", "pass:"+password, "-pubout")
+		if !strings.Contains(output, "-----BEGIN PUBLIC KEY-----") {
+			t.Errorf("unexpected openssl output: %s", output)
+		}
+	})
+
+	t.Run("EmptyPassword", func(t *testing.T) {
+		if _, err := certutil.TransformPrivateKeyFromPEMToEncryptedPKCS8PEM(privkeyPEM, ""); err == nil {
+			t.Error("expected error when password is empty")
+		}
+	})
+}
+
+// 使用 openssl 命令行工具交叉校验输出，未安装时跳过。
+func runOpenSSL(t *testing.T, input []byte, args ...string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("openssl"); err != nil {
+		t.Skip("openssl is not installed")
+	}
+
+	path := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(path, input, 0o600); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	output, err := exec.Command("openssl", append(args, "-in", path)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("openssl error: %v, output: %s", err, output)
+	}
+
+	return string(output)
+}
@@ -3,7 +3,12 @@ package fileutil
 import (
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // 与 [Write] 类似，但写入的是字符串内容。
@@ -49,3 +54,320 @@ func Write(path string, data []byte) error {
 
 	return nil
 }
+
+// 表示文件写入选项的数据结构。
+type WriteOptions struct {
+	// 是否在覆盖前备份已存在的文件。
+	// 备份文件路径参见 [BackupPath]。
+	Backup bool
+	// 保留的备份文件数量上限，超出时将删除最旧的备份。
+	// 零值时默认值 [DefaultBackupLimit]，负值表示不限制。
+	BackupLimit int
+	// 文件权限。
+	// 零值时若文件已存在则保持原有权限，否则默认值 0644。
+	Mode os.FileMode
+	// 文件所有者的用户名或 UID。
+	// 零值时若文件已存在则尽量保持原有所有者。
+	Owner string
+	// 文件所属组的组名或 GID。
+	// 零值时若文件已存在则尽量保持原有所属组。
+	Group string
+}
+
+// 默认保留的备份文件数量上限。
+const DefaultBackupLimit = 5
+
+// 与 [WriteAtomic] 类似，但写入的是字符串内容。
+//
+// 入参:
+//   - path: 文件路径。
+//   - content: 文件内容。
+//   - options: 写入选项。
+//
+// 出参:
+//   - 错误。
+func WriteStringAtomic(path string, content string, options *WriteOptions) error {
+	return WriteAtomic(path, []byte(content), options)
+}
+
+// 将数据原子写入指定路径的文件。
+// 先写入同目录下的临时文件，再重命名替换目标文件，读取方不会读到不完整的内容。
+// 如果目标文件是符号链接，将会写入其最终指向的文件，而不会替换符号链接本身。
+// 如果目录不存在，将会递归创建目录。
+//
+// 入参:
+//   - path: 文件路径。
+//   - data: 文件数据字节数组。
+//   - options: 写入选项。
+//
+// 出参:
+//   - 错误。
+func WriteAtomic(path string, data []byte, options *WriteOptions) error {
+	if options == nil {
+		options = &WriteOptions{}
+	}
+
+	path, err := resolveSymlink(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve symlink: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	mode := options.Mode
+	uid, gid := -1, -1
+
+	fi, err := os.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to stat file: %w", err)
+	} else if err == nil {
+		if fi.IsDir() {
+			return fmt.Errorf("failed to write file: '%s' is a directory", path)
+		}
+
+		if mode == 0 {
+			mode = fi.Mode().Perm()
+		}
+
+		uid, gid = getFileOwner(fi)
+
+		if options.Backup {
+			if err := copyFile(path, BackupPath(path, time.Now()), fi.Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to backup file: %w", err)
+			}
+
+			if err := pruneBackups(path, options.BackupLimit); err != nil {
+				return fmt.Errorf("failed to prune backup files: %w", err)
+			}
+		}
+	}
+	if mode == 0 {
+		mode = 0o644
+	}
+
+	if options.Owner != "" {
+		uid, err = lookupUid(options.Owner)
+		if err != nil {
+			return err
+		}
+	}
+	if options.Group != "" {
+		gid, err = lookupGid(options.Group)
+		if err != nil {
+			return err
+		}
+	}
+
+	tempFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to sync file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	if err := os.Chmod(tempPath, mode); err != nil {
+		return fmt.Errorf("failed to change file mode: %w", err)
+	}
+
+	if uid != -1 || gid != -1 {
+		// 未显式指定所有者时，保持原有所有者仅为尽力而为，失败时不中断
+		if err := os.Chown(tempPath, uid, gid); err != nil && (options.Owner != "" || options.Group != "") {
+			return fmt.Errorf("failed to change file owner: %w", err)
+		}
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+
+	return nil
+}
+
+// 返回指定文件在某一时刻的备份文件路径。
+// 备份文件路径为原文件路径追加时间戳后缀，如 "/path/to/cert.pem.20060102150405.bak"。
+//
+// 入参:
+//   - path: 文件路径。
+//   - t: 备份时间。
+//
+// 出参:
+//   - 备份文件路径。
+func BackupPath(path string, t time.Time) string {
+	return fmt.Sprintf("%s.%s.bak", path, t.Format("20060102150405"))
+}
+
+// 从同目录下的文件名中筛选出指定文件的过期备份，即超出数量上限的最旧的备份。
+//
+// 入参:
+//   - path: 文件路径。
+//   - names: 文件所在目录下的文件名数组。
+//   - limit: 保留的备份文件数量上限。零值时默认值 [DefaultBackupLimit]，负值表示不限制。
+//
+// 出参:
+//   - 过期备份文件路径数组，按从旧到新排序。
+func StaleBackups(path string, names []string, limit int) []string {
+	if limit == 0 {
+		limit = DefaultBackupLimit
+	} else if limit < 0 {
+		return nil
+	}
+
+	// 备份文件名中的时间戳定长，按字典序排序即按时间排序
+	prefix := filepath.Base(path) + "."
+	backups := make([]string, 0)
+	for _, name := range names {
+		timestamp, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		timestamp, ok = strings.CutSuffix(timestamp, ".bak")
+		if !ok || len(timestamp) != len("20060102150405") {
+			continue
+		}
+		if _, err := time.Parse("20060102150405", timestamp); err != nil {
+			continue
+		}
+
+		backups = append(backups, name)
+	}
+	if len(backups) <= limit {
+		return nil
+	}
+
+	sort.Strings(backups)
+	stales := make([]string, 0, len(backups)-limit)
+	for _, name := range backups[:len(backups)-limit] {
+		stales = append(stales, filepath.Join(filepath.Dir(path), name))
+	}
+	return stales
+}
+
+// 将八进制字符串解析为文件权限，如 "0600"、"644"。
+//
+// 入参:
+//   - s: 八进制字符串。
+//
+// 出参:
+//   - 文件权限。零值字符串返回零值。
+//   - 错误。
+func ParseFileMode(s string) (os.FileMode, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	mode, err := strconv.ParseUint(strings.TrimPrefix(s, "0o"), 8, 32)
+	if err != nil || mode > 0o7777 {
+		return 0, fmt.Errorf("invalid file mode '%s'", s)
+	}
+
+	return os.FileMode(mode), nil
+}
+
+func resolveSymlink(path string) (string, error) {
+	// 不使用 [filepath.EvalSymlinks]，以便支持指向尚不存在的文件的符号链接
+	for i := 0; i < 255; i++ {
+		fi, err := os.Lstat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return path, nil
+			}
+			return "", err
+		}
+
+		if fi.Mode()&os.ModeSymlink == 0 {
+			return path, nil
+		}
+
+		target, err := os.Readlink(path)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		path = target
+	}
+
+	return "", fmt.Errorf("too many levels of symbolic links: '%s'", path)
+}
+
+func pruneBackups(path string, limit int) error {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	for _, stale := range StaleBackups(path, names, limit) {
+		if err := os.Remove(stale); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyFile(src string, dst string, perm os.FileMode) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(dst, data, perm)
+}
+
+func lookupUid(owner string) (int, error) {
+	if uid, err := strconv.Atoi(owner); err == nil {
+		return uid, nil
+	}
+
+	u, err := user.Lookup(owner)
+	if err != nil {
+		return -1, fmt.Errorf("failed to lookup user '%s': %w", owner, err)
+	}
+
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return -1, fmt.Errorf("unsupported uid '%s' of user '%s'", u.Uid, owner)
+	}
+
+	return uid, nil
+}
+
+func lookupGid(group string) (int, error) {
+	if gid, err := strconv.Atoi(group); err == nil {
+		return gid, nil
+	}
+
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return -1, fmt.Errorf("failed to lookup group '%s': %w", group, err)
+	}
+
+	gid, err := strconv.Atoi(g.Gid)
+	if err != nil {
+		return -1, fmt.Errorf("unsupported gid '%s' of group '%s'", g.Gid, group)
+	}
+
+	return gid, nil
+}
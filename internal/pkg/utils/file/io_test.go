@@ -0,0 +1,222 @@
+package fileutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	fileutil "github.com/usual2970/certimate/internal/pkg/utils/file"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	t.Run("CreateFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sub", "cert.pem")
+		if err := fileutil.WriteStringAtomic(path, "hello", nil); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		assertFileContent(t, path, "hello")
+		if runtime.GOOS != "windows" {
+			assertFileMode(t, path, 0o644)
+		}
+	})
+
+	t.Run("KeepMode", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file mode is not supported on windows")
+		}
+
+		path := filepath.Join(t.TempDir(), "cert.pem")
+		os.WriteFile(path, []byte("old"), 0o600)
+
+		if err := fileutil.WriteStringAtomic(path, "new", nil); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		assertFileContent(t, path, "new")
+		assertFileMode(t, path, 0o600)
+	})
+
+	t.Run("OverrideMode", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file mode is not supported on windows")
+		}
+
+		path := filepath.Join(t.TempDir(), "cert.pem")
+		os.WriteFile(path, []byte("old"), 0o644)
+
+		if err := fileutil.WriteStringAtomic(path, "new", &fileutil.WriteOptions{Mode: 0o640}); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		assertFileMode(t, path, 0o640)
+	})
+
+	t.Run("Directory", func(t *testing.T) {
+		if err := fileutil.WriteStringAtomic(t.TempDir(), "new", nil); err == nil {
+			t.Error("expected error when writing to a directory")
+		}
+	})
+
+	t.Run("Symlink", func(t *testing.T) {
+		dir := t.TempDir()
+		target := filepath.Join(dir, "certs", "site.pem")
+		link := filepath.Join(dir, "site.pem")
+		os.MkdirAll(filepath.Dir(target), 0o755)
+		os.WriteFile(target, []byte("old"), 0o644)
+		if err := os.Symlink(filepath.Join("certs", "site.pem"), link); err != nil {
+			t.Skipf("symlink is not supported: %v", err)
+		}
+
+		if err := fileutil.WriteStringAtomic(link, "new", nil); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		if fi, err := os.Lstat(link); err != nil || fi.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("expected symlink to be kept, got mode %v (err: %v)", fi.Mode(), err)
+		}
+		assertFileContent(t, target, "new")
+	})
+
+	t.Run("DanglingSymlink", func(t *testing.T) {
+		dir := t.TempDir()
+		target := filepath.Join(dir, "site.pem.real")
+		link := filepath.Join(dir, "site.pem")
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("symlink is not supported: %v", err)
+		}
+
+		if err := fileutil.WriteStringAtomic(link, "new", nil); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		assertFileContent(t, target, "new")
+	})
+
+	t.Run("Backup", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "cert.pem")
+		os.WriteFile(path, []byte("old"), 0o644)
+
+		// 预置若干历史备份，超出数量上限的最旧备份应被清理
+		base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+		for i := 0; i < 3; i++ {
+			os.WriteFile(fileutil.BackupPath(path, base.Add(time.Duration(i)*time.Hour)), []byte("older"), 0o644)
+		}
+
+		if err := fileutil.WriteStringAtomic(path, "new", &fileutil.WriteOptions{Backup: true, BackupLimit: 2}); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		assertFileContent(t, path, "new")
+
+		matches, _ := filepath.Glob(filepath.Join(dir, "cert.pem.*.bak"))
+		if len(matches) != 2 {
+			t.Fatalf("expected 2 backups, got %v", matches)
+		}
+		if matches[0] != fileutil.BackupPath(path, base.Add(2*time.Hour)) {
+			t.Errorf("expected the oldest backups to be pruned, got %v", matches)
+		}
+		assertFileContent(t, matches[1], "old")
+	})
+}
+
+func TestStaleBackups(t *testing.T) {
+	path := filepath.Join("dir", "cert.pem")
+	names := []string{
+		"cert.pem",
+		"cert.pem.20240103000000.bak",
+		"cert.pem.20240101000000.bak",
+		"cert.pem.20240102000000.bak",
+		"cert.pem.invalid.bak",
+		"key.pem.20240101000000.bak",
+	}
+
+	testCases := []struct {
+		name   string
+		limit  int
+		expect []string
+	}{
+		{"Limit", 1, []string{filepath.Join("dir", "cert.pem.20240101000000.bak"), filepath.Join("dir", "cert.pem.20240102000000.bak")}},
+		{"WithinLimit", 3, nil},
+		{"DefaultLimit", 0, nil},
+		{"Unlimited", -1, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stales := fileutil.StaleBackups(path, names, tc.limit)
+			if len(stales) != len(tc.expect) {
+				t.Fatalf("expected %v, got %v", tc.expect, stales)
+			}
+			for i := range stales {
+				if stales[i] != tc.expect[i] {
+					t.Errorf("expected %v, got %v", tc.expect, stales)
+				}
+			}
+		})
+	}
+}
+
+func TestBackupPath(t *testing.T) {
+	path := fileutil.BackupPath("/etc/ssl/cert.pem", time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC))
+	if path != "/etc/ssl/cert.pem.20240506070809.bak" {
+		t.Errorf("unexpected backup path: %s", path)
+	}
+}
+
+func TestParseFileMode(t *testing.T) {
+	testCases := []struct {
+		input  string
+		expect os.FileMode
+		valid  bool
+	}{
+		{"", 0, true},
+		{"0600", 0o600, true},
+		{"644", 0o644, true},
+		{" 0o755 ", 0o755, true},
+		{"0999", 0, false},
+		{"17777", 0, false},
+		{"rw-r--r--", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			mode, err := fileutil.ParseFileMode(tc.input)
+			if tc.valid && err != nil {
+				t.Fatalf("err: %+v", err)
+			} else if !tc.valid && err == nil {
+				t.Fatalf("expected error, got mode %v", mode)
+			}
+			if mode != tc.expect {
+				t.Errorf("expected %v, got %v", tc.expect, mode)
+			}
+		})
+	}
+}
+
+func assertFileContent(t *testing.T, path string, expect string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	if string(data) != expect {
+		t.Errorf("expected content %q, got %q", expect, string(data))
+	}
+}
+
+func assertFileMode(t *testing.T, path string, expect os.FileMode) {
+	t.Helper()
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	if fi.Mode().Perm() != expect {
+		t.Errorf("expected mode %v, got %v", expect, fi.Mode().Perm())
+	}
+}
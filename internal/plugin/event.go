@@ -0,0 +1,32 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/usual2970/certimate/internal/app"
+	pluginsdk "github.com/usual2970/certimate/pkg/plugin"
+)
+
+var manager *Manager
+
+// Register 从指定目录中发现并加载外部插件。
+func Register(dir string) error {
+	manager = NewManager(dir, app.GetLogger())
+	return manager.Discover(context.Background())
+}
+
+func Unregister() {
+	if manager != nil {
+		manager.Shutdown()
+	}
+}
+
+// GetClient 返回指定名称的插件客户端。
+func GetClient(ctx context.Context, name string) (*pluginsdk.Client, error) {
+	if manager == nil {
+		return nil, errors.New("plugins are not enabled, please specify the plugins directory by '--pluginsDir'")
+	}
+
+	return manager.Get(ctx, name)
+}
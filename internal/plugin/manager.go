@@ -0,0 +1,154 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	pluginsdk "github.com/usual2970/certimate/pkg/plugin"
+)
+
+// Manager 管理插件目录中的外部插件。
+// 插件目录中的每个可执行文件即是一个插件，文件名（不含扩展名）即插件名称。
+// 插件进程在首次使用时启动并常驻，退出后会在下一次使用时重新启动。
+type Manager struct {
+	dir    string
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	clients map[string]*pluginsdk.Client
+}
+
+func NewManager(dir string, logger *slog.Logger) *Manager {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	return &Manager{
+		dir:     dir,
+		logger:  logger,
+		clients: make(map[string]*pluginsdk.Client),
+	}
+}
+
+// Discover 扫描插件目录并启动其中的所有插件。
+// 单个插件加载失败时只记录日志，不影响其他插件。
+func (m *Manager) Discover(ctx context.Context) error {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read plugins directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name, ok := pluginNameOf(entry)
+		if !ok {
+			continue
+		}
+
+		client, err := m.Get(ctx, name)
+		if err != nil {
+			m.logger.Error("failed to load plugin", slog.String("plugin", name), slog.Any("error", err))
+			continue
+		}
+
+		info := client.Info()
+		m.logger.Info("plugin loaded", slog.String("plugin", name), slog.String("version", info.Version), slog.Any("kinds", info.Kinds))
+	}
+
+	return nil
+}
+
+// Get 返回指定名称的插件客户端，插件进程未启动或已退出时会启动它。
+func (m *Manager) Get(ctx context.Context, name string) (*pluginsdk.Client, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid plugin name '%s'", name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if client, ok := m.clients[name]; ok {
+		if !client.Exited() {
+			return client, nil
+		}
+
+		m.logger.Warn("plugin process has exited, restarting ...", slog.String("plugin", name))
+		delete(m.clients, name)
+	}
+
+	path := filepath.Join(m.dir, name)
+	if runtime.GOOS == "windows" {
+		path += ".exe"
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("plugin '%s' not found", name)
+		}
+		return nil, err
+	}
+
+	client := pluginsdk.NewClient(path, m.logger)
+	if err := client.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	m.clients[name] = client
+	return client, nil
+}
+
+// List 返回所有已加载的插件信息，以插件名称为键。
+func (m *Manager) List() map[string]*pluginsdk.Info {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	infos := make(map[string]*pluginsdk.Info, len(m.clients))
+	for name, client := range m.clients {
+		if !client.Exited() {
+			infos[name] = client.Info()
+		}
+	}
+	return infos
+}
+
+// Shutdown 结束所有插件进程。
+func (m *Manager) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for name, client := range m.clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Kill()
+		}()
+		delete(m.clients, name)
+	}
+	wg.Wait()
+}
+
+func pluginNameOf(entry os.DirEntry) (string, bool) {
+	name := entry.Name()
+	if entry.IsDir() || strings.HasPrefix(name, ".") {
+		return "", false
+	}
+
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(name), ".exe") {
+			return "", false
+		}
+		return strings.TrimSuffix(name, filepath.Ext(name)), true
+	}
+
+	info, err := entry.Info()
+	if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,122 @@
+package plugin_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/usual2970/certimate/internal/plugin"
+	pluginsdk "github.com/usual2970/certimate/pkg/plugin"
+)
+
+// 测试时将测试二进制自身复制到插件目录中作为插件。
+const helperEnvKey = "CERTIMATE_PLUGIN_TEST_HELPER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(helperEnvKey) == "1" {
+		pluginsdk.Serve(&pluginsdk.ServeConfig{
+			Name:     "example",
+			Notifier: &testNotifier{},
+		})
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+type testNotifier struct{}
+
+func (n *testNotifier) Notify(ctx context.Context, req *pluginsdk.NotifyRequest) (*pluginsdk.NotifyResponse, error) {
+	return &pluginsdk.NotifyResponse{ExtendedData: map[string]any{"subject": req.Subject}}, nil
+}
+
+func newTestPluginsDir(t *testing.T, names ...string) string {
+	t.Helper()
+	t.Setenv(helperEnvKey, "1")
+
+	dir := t.TempDir()
+	for _, name := range names {
+		if runtime.GOOS == "windows" {
+			name += ".exe"
+		}
+
+		src, err := os.Open(os.Args[0])
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		defer src.Close()
+
+		dst, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY, 0o755)
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if _, err := io.Copy(dst, src); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		dst.Close()
+	}
+
+	// 非可执行文件和隐藏文件应被忽略
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("readme"), 0o644)
+	os.WriteFile(filepath.Join(dir, ".hidden"), []byte("hidden"), 0o755)
+
+	return dir
+}
+
+func TestDiscover(t *testing.T) {
+	manager := plugin.NewManager(newTestPluginsDir(t, "foo", "bar"), nil)
+	t.Cleanup(manager.Shutdown)
+
+	if err := manager.Discover(context.Background()); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	infos := manager.List()
+	if len(infos) != 2 || infos["foo"] == nil || infos["bar"] == nil {
+		t.Fatalf("unexpected plugins: %+v", infos)
+	}
+	if !infos["foo"].HasKind(pluginsdk.KindNotifier) {
+		t.Errorf("expected notifier kind, got %v", infos["foo"].Kinds)
+	}
+}
+
+func TestGet(t *testing.T) {
+	manager := plugin.NewManager(newTestPluginsDir(t, "foo"), nil)
+	t.Cleanup(manager.Shutdown)
+
+	t.Run("NotFound", func(t *testing.T) {
+		if _, err := manager.Get(context.Background(), "missing"); err == nil {
+			t.Error("expected error for missing plugin")
+		}
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		if _, err := manager.Get(context.Background(), "../foo"); err == nil {
+			t.Error("expected error for invalid plugin name")
+		}
+	})
+
+	t.Run("RestartAfterExit", func(t *testing.T) {
+		client, err := manager.Get(context.Background(), "foo")
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		client.Kill()
+
+		client, err = manager.Get(context.Background(), "foo")
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		resp, err := client.Notify(context.Background(), &pluginsdk.NotifyRequest{Subject: "hello"})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if resp.ExtendedData["subject"] != "hello" {
+			t.Errorf("unexpected response: %+v", resp)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package registry
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// 表示提供商的用途，与授权提供商的用途一一对应。
+type Capability string
+
+const (
+	CapabilityDns          = Capability("dns")
+	CapabilityHosting      = Capability("hosting")
+	CapabilityNotification = Capability("notification")
+)
+
+// Provider 表示一个已注册的提供商。
+type Provider struct {
+	// 提供商类型。
+	Type string `json:"type"`
+	// 提供商用途。
+	Capability Capability `json:"capability"`
+	// 授权提供商类型。
+	AccessProvider string `json:"accessProvider"`
+	// 节点配置的 Schema。
+	ConfigSchema *Schema `json:"configSchema"`
+
+	// 授权配置的 Schema。
+	AccessSchema *Schema `json:"-"`
+	// 提供商的工厂函数，其具体类型由注册方约定。
+	Factory any `json:"-"`
+}
+
+// AccessProvider 表示一个授权提供商，由使用它的各个提供商汇总而来。
+type AccessProvider struct {
+	// 授权提供商类型。
+	Type string `json:"type"`
+	// 授权提供商的用途。
+	Capabilities []Capability `json:"capabilities"`
+	// 授权配置的 Schema。
+	AccessSchema *Schema `json:"accessSchema"`
+}
+
+var (
+	mu            sync.RWMutex
+	providers     = make(map[Capability]map[string]*Provider)
+	accessSchemas = make(map[string]*Schema)
+)
+
+// Register 注册一个提供商。
+// 重复注册同一用途、同一类型的提供商，或同一授权提供商的授权配置 Schema 不一致时，将会引发 panic。
+func Register(provider *Provider) {
+	if provider == nil {
+		panic("provider is nil")
+	}
+	if provider.Type == "" || provider.Capability == "" || provider.AccessProvider == "" {
+		panic("provider type, capability and access provider are required")
+	}
+	if provider.Factory == nil {
+		panic(fmt.Sprintf("provider '%s' has no factory", provider.Type))
+	}
+
+	if provider.AccessSchema == nil {
+		provider.AccessSchema = ObjectSchema(nil)
+	}
+	if provider.ConfigSchema == nil {
+		provider.ConfigSchema = ObjectSchema(nil)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if providers[provider.Capability] == nil {
+		providers[provider.Capability] = make(map[string]*Provider)
+	}
+	if _, ok := providers[provider.Capability][provider.Type]; ok {
+		panic(fmt.Sprintf("provider '%s' with capability '%s' is already registered", provider.Type, provider.Capability))
+	}
+	if accessSchema, ok := accessSchemas[provider.AccessProvider]; ok && !reflect.DeepEqual(accessSchema, provider.AccessSchema) {
+		panic(fmt.Sprintf("provider '%s' declares an access schema different from other providers of access provider '%s'", provider.Type, provider.AccessProvider))
+	}
+
+	providers[provider.Capability][provider.Type] = provider
+	accessSchemas[provider.AccessProvider] = provider.AccessSchema
+}
+
+// Get 返回指定用途、指定类型的提供商。
+func Get(capability Capability, providerType string) (*Provider, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	provider, ok := providers[capability][providerType]
+	return provider, ok
+}
+
+// List 返回所有已注册的提供商，按用途与类型排序。
+func List() []*Provider {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]*Provider, 0)
+	for _, m := range providers {
+		for _, provider := range m {
+			list = append(list, provider)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Capability != list[j].Capability {
+			return list[i].Capability < list[j].Capability
+		}
+		return list[i].Type < list[j].Type
+	})
+	return list
+}
+
+// ListAccessProviders 返回所有授权提供商，按类型排序。
+func ListAccessProviders() []*AccessProvider {
+	accessProviders := make(map[string]*AccessProvider)
+	for _, provider := range List() {
+		accessProvider, ok := accessProviders[provider.AccessProvider]
+		if !ok {
+			accessProvider = &AccessProvider{
+				Type:         provider.AccessProvider,
+				Capabilities: make([]Capability, 0),
+				AccessSchema: getAccessSchema(provider.AccessProvider),
+			}
+			accessProviders[provider.AccessProvider] = accessProvider
+		}
+
+		if !slices.Contains(accessProvider.Capabilities, provider.Capability) {
+			accessProvider.Capabilities = append(accessProvider.Capabilities, provider.Capability)
+		}
+	}
+
+	list := make([]*AccessProvider, 0, len(accessProviders))
+	for _, accessProvider := range accessProviders {
+		list = append(list, accessProvider)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Type < list[j].Type
+	})
+	return list
+}
+
+func getAccessSchema(accessProvider string) *Schema {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return accessSchemas[accessProvider]
+}
+
+// ValidateConfig 校验节点配置是否符合指定提供商的 Schema。
+// 提供商未注册时不做校验。
+func ValidateConfig(capability Capability, providerType string, config map[string]any) error {
+	provider, ok := Get(capability, providerType)
+	if !ok {
+		return nil
+	}
+
+	if config == nil {
+		return nil
+	}
+
+	return provider.ConfigSchema.Validate(config)
+}
+
+// ProviderList 是所有已注册的提供商与授权提供商的汇总。
+type ProviderList struct {
+	Providers       []*Provider       `json:"providers"`
+	AccessProviders []*AccessProvider `json:"accessProviders"`
+}
@@ -0,0 +1,120 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/usual2970/certimate/internal/registry"
+)
+
+func TestRegister(t *testing.T) {
+	accessSchema := registry.ObjectSchema(registry.Properties{
+		"apiKey": registry.StringSchema(),
+	}, "apiKey")
+
+	registry.Register(&registry.Provider{
+		Type:           "test-foo",
+		Capability:     registry.CapabilityHosting,
+		AccessProvider: "test",
+		AccessSchema:   accessSchema,
+		ConfigSchema: registry.ObjectSchema(registry.Properties{
+			"port": registry.IntegerSchema(),
+		}),
+		Factory: func() {},
+	})
+	registry.Register(&registry.Provider{
+		Type:           "bar",
+		Capability:     registry.CapabilityNotification,
+		AccessProvider: "test",
+		AccessSchema:   accessSchema,
+		Factory:        func() {},
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		provider, ok := registry.Get(registry.CapabilityHosting, "test-foo")
+		if !ok {
+			t.Fatal("expected provider to be registered")
+		}
+		if provider.AccessProvider != "test" {
+			t.Errorf("unexpected access provider: %s", provider.AccessProvider)
+		}
+
+		if _, ok := registry.Get(registry.CapabilityDns, "test-foo"); ok {
+			t.Error("expected provider not to be registered for dns")
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for duplicate registration")
+			}
+		}()
+
+		registry.Register(&registry.Provider{
+			Type:           "test-foo",
+			Capability:     registry.CapabilityHosting,
+			AccessProvider: "test",
+			Factory:        func() {},
+		})
+	})
+
+	t.Run("MissingAccessProvider", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for missing access provider")
+			}
+		}()
+
+		registry.Register(&registry.Provider{
+			Type:       "test-baz",
+			Capability: registry.CapabilityHosting,
+			Factory:    func() {},
+		})
+	})
+
+	t.Run("ConflictingAccessSchema", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for conflicting access schema")
+			}
+		}()
+
+		registry.Register(&registry.Provider{
+			Type:           "test-qux",
+			Capability:     registry.CapabilityDns,
+			AccessProvider: "test",
+			AccessSchema:   registry.ObjectSchema(nil),
+			Factory:        func() {},
+		})
+	})
+
+	t.Run("ListAccessProviders", func(t *testing.T) {
+		for _, accessProvider := range registry.ListAccessProviders() {
+			if accessProvider.Type != "test" {
+				continue
+			}
+
+			if len(accessProvider.Capabilities) != 2 {
+				t.Errorf("unexpected capabilities: %v", accessProvider.Capabilities)
+			}
+			if accessProvider.AccessSchema != accessSchema {
+				t.Errorf("unexpected access schema: %+v", accessProvider.AccessSchema)
+			}
+			return
+		}
+
+		t.Error("expected access provider 'test'")
+	})
+
+	t.Run("ValidateConfig", func(t *testing.T) {
+		if err := registry.ValidateConfig(registry.CapabilityHosting, "test-foo", map[string]any{"port": "abc"}); err == nil {
+			t.Error("expected error for invalid config")
+		}
+		if err := registry.ValidateConfig(registry.CapabilityHosting, "test-foo", map[string]any{"port": float64(22)}); err != nil {
+			t.Errorf("err: %+v", err)
+		}
+		if err := registry.ValidateConfig(registry.CapabilityHosting, "unknown", map[string]any{"port": "abc"}); err != nil {
+			t.Errorf("expected unregistered provider to be skipped, got: %+v", err)
+		}
+	})
+}
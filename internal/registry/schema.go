@@ -0,0 +1,308 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema 是 JSON Schema 的一个子集，足以描述提供商的授权配置与节点配置。
+//
+// 注意：为了与 maputil.Populate 的弱类型解码行为保持一致，标量类型的属性同时接受可被转换的其他标量值，
+// 如整数和数字类型接受可被解析的数字字符串（包括空字符串）、字符串类型接受数字与布尔值等；
+// 所有属性均可为 null，表示未填写。
+type Schema struct {
+	Type       []string           `json:"type,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Pattern    string             `json:"pattern,omitempty"`
+	Enum       []any              `json:"enum,omitempty"`
+}
+
+type Properties = map[string]*Schema
+
+const (
+	typeArray   = "array"
+	typeBoolean = "boolean"
+	typeInteger = "integer"
+	typeNull    = "null"
+	typeNumber  = "number"
+	typeObject  = "object"
+	typeString  = "string"
+)
+
+func StringSchema() *Schema {
+	return &Schema{Type: []string{typeString, typeNull}}
+}
+
+func IntegerSchema() *Schema {
+	return &Schema{Type: []string{typeInteger, typeNull}}
+}
+
+func NumberSchema() *Schema {
+	return &Schema{Type: []string{typeNumber, typeNull}}
+}
+
+func BooleanSchema() *Schema {
+	return &Schema{Type: []string{typeBoolean, typeNull}}
+}
+
+func ArraySchema(items *Schema) *Schema {
+	return &Schema{Type: []string{typeArray, typeNull}, Items: items}
+}
+
+func ObjectSchema(properties Properties, required ...string) *Schema {
+	if properties == nil {
+		properties = make(Properties)
+	}
+
+	return &Schema{Type: []string{typeObject, typeNull}, Properties: properties, Required: required}
+}
+
+// SchemaOf 根据结构体的 `json` 标签生成对应的 Schema。
+// 未标记 `omitempty` 的字段将被视为必填字段。
+func SchemaOf(v any) *Schema {
+	return schemaOfType(reflect.TypeOf(v))
+}
+
+func schemaOfType(t reflect.Type) *Schema {
+	if t == nil {
+		return ObjectSchema(nil)
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return StringSchema()
+
+	case reflect.Bool:
+		return BooleanSchema()
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return IntegerSchema()
+
+	case reflect.Float32, reflect.Float64:
+		return NumberSchema()
+
+	case reflect.Slice, reflect.Array:
+		return ArraySchema(schemaOfType(t.Elem()))
+
+	case reflect.Map:
+		return ObjectSchema(nil)
+
+	case reflect.Struct:
+		properties := make(Properties)
+		required := make([]string, 0)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			properties[name] = schemaOfType(field.Type)
+			if flags := strings.Split(opts, ","); !slices.Contains(flags, "omitempty") && !slices.Contains(flags, "omitzero") {
+				required = append(required, name)
+			}
+		}
+		return ObjectSchema(properties, required...)
+
+	default:
+		return &Schema{}
+	}
+}
+
+// Validate 校验给定的值是否符合 Schema。
+// 值应当是由 JSON 解码得到的，即只包含 map[string]any、[]any、string、float64、bool 和 nil。
+func (s *Schema) Validate(value any) error {
+	errs := s.validate("", value)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Schema) validate(path string, value any) []error {
+	if s == nil {
+		return nil
+	}
+
+	valueType := jsonTypeOf(value)
+	if len(s.Type) > 0 && !slices.Contains(s.Type, valueType) {
+		if !slices.ContainsFunc(s.Type, func(t string) bool { return isWeaklyConvertible(value, t) }) {
+			return []error{fmt.Errorf("%s: expected %s, but got %s", displayPath(path), strings.Join(s.Type, " or "), describeValue(value, valueType))}
+		}
+		// 经过弱类型转换的值不再校验格式
+		return nil
+	}
+
+	if len(s.Enum) > 0 && value != nil && !slices.ContainsFunc(s.Enum, func(e any) bool { return reflect.DeepEqual(e, value) }) {
+		return []error{fmt.Errorf("%s: value must be one of %v", displayPath(path), s.Enum)}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if s.Pattern != "" {
+			if matched, _ := regexp.MatchString(s.Pattern, v); !matched {
+				return []error{fmt.Errorf("%s: value '%s' does not match pattern '%s'", displayPath(path), v, s.Pattern)}
+			}
+		}
+
+	case []any:
+		errs := make([]error, 0)
+		for i, item := range v {
+			errs = append(errs, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+		}
+		return errs
+
+	case map[string]any:
+		errs := make([]error, 0)
+		for _, key := range s.Required {
+			if item, ok := v[key]; !ok || item == nil {
+				errs = append(errs, fmt.Errorf("%s: is required", displayPath(joinPath(path, key))))
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			// 未声明的属性不做校验
+			if property, ok := s.Properties[key]; ok {
+				errs = append(errs, property.validate(joinPath(path, key), v[key])...)
+			}
+		}
+		return errs
+	}
+
+	return nil
+}
+
+func jsonTypeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return typeNull
+	case string:
+		return typeString
+	case bool:
+		return typeBoolean
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return typeInteger
+		}
+		return typeNumber
+	case float32:
+		return jsonTypeOf(float64(v))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return typeInteger
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return typeInteger
+		}
+		return typeNumber
+	case []any:
+		return typeArray
+	case map[string]any:
+		return typeObject
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// isWeaklyConvertible 判断给定的值能否被 maputil.Populate 弱类型解码为目标类型。
+// 规则与 mapstructure 的 WeaklyTypedInput 保持一致，但整数类型仍拒绝带小数部分的数字。
+func isWeaklyConvertible(value any, targetType string) bool {
+	valueType := jsonTypeOf(value)
+
+	switch targetType {
+	case typeString:
+		return valueType == typeBoolean || valueType == typeInteger || valueType == typeNumber
+
+	case typeInteger:
+		switch valueType {
+		case typeBoolean:
+			return true
+		case typeString:
+			str := value.(string)
+			if str == "" {
+				return true
+			}
+			_, err := strconv.ParseInt(str, 0, 64)
+			if err != nil {
+				_, err = strconv.ParseUint(str, 0, 64)
+			}
+			return err == nil
+		}
+
+	case typeNumber:
+		switch valueType {
+		case typeBoolean, typeInteger:
+			return true
+		case typeString:
+			str := value.(string)
+			if str == "" {
+				return true
+			}
+			_, err := strconv.ParseFloat(str, 64)
+			return err == nil
+		}
+
+	case typeBoolean:
+		switch valueType {
+		case typeInteger, typeNumber:
+			return true
+		case typeString:
+			str := value.(string)
+			if str == "" {
+				return true
+			}
+			_, err := strconv.ParseBool(str)
+			return err == nil
+		}
+	}
+
+	return false
+}
+
+func describeValue(value any, valueType string) string {
+	if str, ok := value.(string); ok {
+		return fmt.Sprintf("%s '%s'", valueType, str)
+	}
+	return valueType
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return "'" + path + "'"
+}
@@ -0,0 +1,72 @@
+package registry_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/usual2970/certimate/internal/registry"
+)
+
+func TestSchemaOf(t *testing.T) {
+	type access struct {
+		ApiKey     string `json:"apiKey"`
+		Port       int32  `json:"port,omitempty"`
+		Insecure   bool   `json:"allowInsecureConnections,omitempty"`
+		Ignored    string `json:"-"`
+		unexported string
+	}
+
+	schema := registry.SchemaOf(access{})
+	if len(schema.Properties) != 3 {
+		t.Fatalf("unexpected properties: %+v", schema.Properties)
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "apiKey" {
+		t.Errorf("unexpected required: %+v", schema.Required)
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := registry.ObjectSchema(registry.Properties{
+		"domain":      registry.StringSchema(),
+		"instanceId":  registry.IntegerSchema(),
+		"autoRestart": registry.BooleanSchema(),
+		"hosts":       registry.ArraySchema(registry.StringSchema()),
+		"ratio":       registry.NumberSchema(),
+	}, "domain")
+
+	testCases := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"Valid", `{"domain":"example.com","instanceId":1,"autoRestart":true,"hosts":["a"]}`, true},
+		{"StringifiedValues", `{"domain":"example.com","instanceId":"1","autoRestart":"false"}`, true},
+		{"EmptyStrings", `{"domain":"","instanceId":"","autoRestart":"","ratio":""}`, true},
+		{"NumericStrings", `{"domain":"example.com","instanceId":"0x1f","ratio":"0.5"}`, true},
+		{"WeaklyTypedScalars", `{"domain":123,"autoRestart":1,"hosts":[true]}`, true},
+		{"UndeclaredKeys", `{"domain":"example.com","foo":{"bar":1}}`, true},
+		{"MissingRequired", `{"instanceId":1}`, false},
+		{"WrongType", `{"domain":{"name":"example.com"}}`, false},
+		{"NotAnInteger", `{"domain":"example.com","instanceId":1.5}`, false},
+		{"BadIntegerString", `{"domain":"example.com","instanceId":"abc"}`, false},
+		{"BadNumberString", `{"domain":"example.com","ratio":"1/2"}`, false},
+		{"BadBooleanString", `{"domain":"example.com","autoRestart":"yes"}`, false},
+		{"BadArrayItem", `{"domain":"example.com","hosts":[["a"]]}`, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var input map[string]any
+			if err := json.Unmarshal([]byte(tc.input), &input); err != nil {
+				t.Fatalf("err: %+v", err)
+			}
+
+			err := schema.Validate(input)
+			if tc.valid && err != nil {
+				t.Errorf("expected valid, got error: %v", err)
+			} else if !tc.valid && err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package registry
+
+import (
+	"context"
+)
+
+type ProviderService struct{}
+
+func NewProviderService() *ProviderService {
+	return &ProviderService{}
+}
+
+func (s *ProviderService) List(ctx context.Context) (*ProviderList, error) {
+	return &ProviderList{
+		Providers:       List(),
+		AccessProviders: ListAccessProviders(),
+	}, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+
+	"github.com/usual2970/certimate/internal/app"
+	"github.com/usual2970/certimate/internal/domain"
+)
+
+type AgentRepository struct{}
+
+func NewAgentRepository() *AgentRepository {
+	return &AgentRepository{}
+}
+
+func (r *AgentRepository) GetById(ctx context.Context, id string) (*domain.Agent, error) {
+	record, err := app.GetApp().FindRecordById(domain.CollectionNameAgent, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return r.castRecordToModel(record)
+}
+
+func (r *AgentRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Agent, error) {
+	record, err := app.GetApp().FindFirstRecordByFilter(
+		domain.CollectionNameAgent,
+		"tokenHash={:tokenHash}",
+		dbx.Params{"tokenHash": tokenHash},
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return r.castRecordToModel(record)
+}
+
+func (r *AgentRepository) Save(ctx context.Context, agent *domain.Agent) (*domain.Agent, error) {
+	collection, err := app.GetApp().FindCollectionByNameOrId(domain.CollectionNameAgent)
+	if err != nil {
+		return agent, err
+	}
+
+	var record *core.Record
+	if agent.Id == "" {
+		record = core.NewRecord(collection)
+	} else {
+		record, err = app.GetApp().FindRecordById(collection, agent.Id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return agent, domain.ErrRecordNotFound
+			}
+			return agent, err
+		}
+	}
+
+	record.Set("name", agent.Name)
+	record.Set("tokenHash", agent.TokenHash)
+	record.Set("enabled", agent.Enabled)
+	record.Set("lastSeenAt", agent.LastSeenAt)
+	record.Set("lastIp", agent.LastIp)
+	if err := app.GetApp().Save(record); err != nil {
+		return agent, err
+	}
+
+	agent.Id = record.Id
+	agent.CreatedAt = record.GetDateTime("created").Time()
+	agent.UpdatedAt = record.GetDateTime("updated").Time()
+	return agent, nil
+}
+
+func (r *AgentRepository) castRecordToModel(record *core.Record) (*domain.Agent, error) {
+	if record == nil {
+		return nil, fmt.Errorf("record is nil")
+	}
+
+	agent := &domain.Agent{
+		Meta: domain.Meta{
+			Id:        record.Id,
+			CreatedAt: record.GetDateTime("created").Time(),
+			UpdatedAt: record.GetDateTime("updated").Time(),
+		},
+		Name:      record.GetString("name"),
+		TokenHash: record.GetString("tokenHash"),
+		Enabled:   record.GetBool("enabled"),
+		LastIp:    record.GetString("lastIp"),
+	}
+
+	if lastSeenAt := record.GetDateTime("lastSeenAt").Time(); !lastSeenAt.IsZero() {
+		agent.LastSeenAt = &lastSeenAt
+	}
+
+	return agent, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
@@ -42,6 +43,29 @@ func (r *CertificateRepository) ListExpireSoon(ctx context.Context) ([]*domain.C
 	return certificates, nil
 }
 
+func (r *CertificateRepository) ListUnexpired(ctx context.Context) ([]*domain.Certificate, error) {
+	records, err := app.GetApp().FindAllRecords(
+		domain.CollectionNameCertificate,
+		dbx.NewExp("expireAt>DATETIME('now')"),
+		dbx.NewExp("deleted=''"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := make([]*domain.Certificate, 0)
+	for _, record := range records {
+		certificate, err := r.castRecordToModel(record)
+		if err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
 func (r *CertificateRepository) GetById(ctx context.Context, id string) (*domain.Certificate, error) {
 	record, err := app.GetApp().FindRecordById(domain.CollectionNameCertificate, id)
 	if err != nil {
@@ -97,6 +121,35 @@ func (r *CertificateRepository) GetByWorkflowRunIdAndNodeId(ctx context.Context,
 	return r.castRecordToModel(records[0])
 }
 
+func (r *CertificateRepository) GetLatestByDomain(ctx context.Context, domainName string) (*domain.Certificate, error) {
+	records, err := app.GetApp().FindRecordsByFilter(
+		domain.CollectionNameCertificate,
+		"subjectAltNames~{:domain} && expireAt>@now && deleted=null",
+		"-expireAt",
+		0, 0,
+		dbx.Params{"domain": domainName},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		certificate, err := r.castRecordToModel(record)
+		if err != nil {
+			return nil, err
+		}
+
+		// 模糊查询的结果中可能包含子域名等，需精确匹配
+		for _, san := range strings.Split(certificate.SubjectAltNames, ";") {
+			if strings.EqualFold(strings.TrimSpace(san), domainName) {
+				return certificate, nil
+			}
+		}
+	}
+
+	return nil, domain.ErrRecordNotFound
+}
+
 func (r *CertificateRepository) Save(ctx context.Context, certificate *domain.Certificate) (*domain.Certificate, error) {
 	collection, err := app.GetApp().FindCollectionByNameOrId(domain.CollectionNameCertificate)
 	if err != nil {
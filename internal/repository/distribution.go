@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+
+	"github.com/usual2970/certimate/internal/app"
+	"github.com/usual2970/certimate/internal/domain"
+)
+
+type DistributionRepository struct{}
+
+func NewDistributionRepository() *DistributionRepository {
+	return &DistributionRepository{}
+}
+
+func (r *DistributionRepository) GetByName(ctx context.Context, name string) (*domain.Distribution, error) {
+	record, err := app.GetApp().FindFirstRecordByFilter(
+		domain.CollectionNameDistribution,
+		"name={:name}",
+		dbx.Params{"name": name},
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return r.castRecordToModel(record)
+}
+
+func (r *DistributionRepository) castRecordToModel(record *core.Record) (*domain.Distribution, error) {
+	if record == nil {
+		return nil, fmt.Errorf("record is nil")
+	}
+
+	distribution := &domain.Distribution{
+		Meta: domain.Meta{
+			Id:        record.Id,
+			CreatedAt: record.GetDateTime("created").Time(),
+			UpdatedAt: record.GetDateTime("updated").Time(),
+		},
+		Name:           record.GetString("name"),
+		WorkflowNodeId: record.GetString("workflowNodeId"),
+		Domain:         record.GetString("domain"),
+	}
+	return distribution, nil
+}
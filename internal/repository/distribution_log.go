@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/usual2970/certimate/internal/app"
+	"github.com/usual2970/certimate/internal/domain"
+)
+
+type DistributionLogRepository struct{}
+
+func NewDistributionLogRepository() *DistributionLogRepository {
+	return &DistributionLogRepository{}
+}
+
+func (r *DistributionLogRepository) Save(ctx context.Context, distributionLog *domain.DistributionLog) (*domain.DistributionLog, error) {
+	collection, err := app.GetApp().FindCollectionByNameOrId(domain.CollectionNameDistributionLog)
+	if err != nil {
+		return distributionLog, err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("distributionId", distributionLog.DistributionId)
+	record.Set("tokenId", distributionLog.TokenId)
+	record.Set("file", distributionLog.File)
+	record.Set("clientIp", distributionLog.ClientIp)
+	record.Set("userAgent", distributionLog.UserAgent)
+	record.Set("statusCode", distributionLog.StatusCode)
+	record.Set("requestSerialNumber", distributionLog.RequestSerialNumber)
+	record.Set("serialNumber", distributionLog.SerialNumber)
+	if err := app.GetApp().Save(record); err != nil {
+		return distributionLog, err
+	}
+
+	distributionLog.Id = record.Id
+	distributionLog.CreatedAt = record.GetDateTime("created").Time()
+	return distributionLog, nil
+}
+
+func (r *DistributionLogRepository) DeleteWhere(ctx context.Context, exprs ...dbx.Expression) (int, error) {
+	records, err := app.GetApp().FindAllRecords(domain.CollectionNameDistributionLog, exprs...)
+	if err != nil {
+		return 0, nil
+	}
+
+	var ret int
+	var errs []error
+	for _, record := range records {
+		if err := app.GetApp().Delete(record); err != nil {
+			errs = append(errs, err)
+		} else {
+			ret++
+		}
+	}
+
+	if len(errs) > 0 {
+		return ret, errors.Join(errs...)
+	}
+
+	return ret, nil
+}
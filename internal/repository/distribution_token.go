@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+
+	"github.com/usual2970/certimate/internal/app"
+	"github.com/usual2970/certimate/internal/domain"
+)
+
+type DistributionTokenRepository struct{}
+
+func NewDistributionTokenRepository() *DistributionTokenRepository {
+	return &DistributionTokenRepository{}
+}
+
+func (r *DistributionTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.DistributionToken, error) {
+	record, err := app.GetApp().FindFirstRecordByFilter(
+		domain.CollectionNameDistributionToken,
+		"tokenHash={:tokenHash}",
+		dbx.Params{"tokenHash": tokenHash},
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return r.castRecordToModel(record)
+}
+
+func (r *DistributionTokenRepository) Save(ctx context.Context, token *domain.DistributionToken) (*domain.DistributionToken, error) {
+	collection, err := app.GetApp().FindCollectionByNameOrId(domain.CollectionNameDistributionToken)
+	if err != nil {
+		return token, err
+	}
+
+	var record *core.Record
+	if token.Id == "" {
+		record = core.NewRecord(collection)
+	} else {
+		record, err = app.GetApp().FindRecordById(collection, token.Id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return token, domain.ErrRecordNotFound
+			}
+			return token, err
+		}
+	}
+
+	record.Set("name", token.Name)
+	record.Set("tokenHash", token.TokenHash)
+	record.Set("distributionIds", token.DistributionIds)
+	record.Set("allowedIps", token.AllowedIps)
+	record.Set("enabled", token.Enabled)
+	record.Set("expireAt", token.ExpireAt)
+	record.Set("lastUsedAt", token.LastUsedAt)
+	if err := app.GetApp().Save(record); err != nil {
+		return token, err
+	}
+
+	token.Id = record.Id
+	token.CreatedAt = record.GetDateTime("created").Time()
+	token.UpdatedAt = record.GetDateTime("updated").Time()
+	return token, nil
+}
+
+func (r *DistributionTokenRepository) castRecordToModel(record *core.Record) (*domain.DistributionToken, error) {
+	if record == nil {
+		return nil, fmt.Errorf("record is nil")
+	}
+
+	allowedIps := make([]string, 0)
+	if err := record.UnmarshalJSONField("allowedIps", &allowedIps); err != nil {
+		return nil, err
+	}
+
+	token := &domain.DistributionToken{
+		Meta: domain.Meta{
+			Id:        record.Id,
+			CreatedAt: record.GetDateTime("created").Time(),
+			UpdatedAt: record.GetDateTime("updated").Time(),
+		},
+		Name:            record.GetString("name"),
+		TokenHash:       record.GetString("tokenHash"),
+		DistributionIds: record.GetStringSlice("distributionIds"),
+		AllowedIps:      allowedIps,
+		Enabled:         record.GetBool("enabled"),
+	}
+
+	if expireAt := record.GetDateTime("expireAt").Time(); !expireAt.IsZero() {
+		token.ExpireAt = &expireAt
+	}
+
+	if lastUsedAt := record.GetDateTime("lastUsedAt").Time(); !lastUsedAt.IsZero() {
+		token.LastUsedAt = &lastUsedAt
+	}
+
+	return token, nil
+}
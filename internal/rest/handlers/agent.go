@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+
+	"github.com/usual2970/certimate/internal/domain"
+	"github.com/usual2970/certimate/internal/domain/dtos"
+	"github.com/usual2970/certimate/internal/rest/resp"
+)
+
+type agentService interface {
+	RegisterAgent(ctx context.Context, req *dtos.AgentRegisterReq) (*dtos.AgentRegisterResp, error)
+	RevokeAgent(ctx context.Context, req *dtos.AgentRevokeReq) error
+	Authenticate(ctx context.Context, token string, clientIp string) (*domain.Agent, error)
+	Serve(agentId string, ws *websocket.Conn)
+}
+
+type AgentHandler struct {
+	service  agentService
+	upgrader websocket.Upgrader
+}
+
+func NewAgentHandler(router *router.RouterGroup[*core.RequestEvent], service agentService) {
+	handler := &AgentHandler{
+		service: service,
+	}
+
+	group := router.Group("/agents")
+	group.POST("", handler.register)
+	group.POST("/{agentId}/revoke", handler.revoke)
+}
+
+// NewAgentConnectHandler 注册供远程代理建立长连接的接口。
+// 该接口不使用管理员鉴权，而是使用代理令牌鉴权，且出错时直接返回对应的 HTTP 状态码。
+func NewAgentConnectHandler(router *router.RouterGroup[*core.RequestEvent], service agentService) {
+	handler := &AgentHandler{
+		service: service,
+		upgrader: websocket.Upgrader{
+			// 代理不是浏览器，不会携带 Origin 头
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+
+	group := router.Group("/agents")
+	group.GET("/connect", handler.connect)
+}
+
+func (handler *AgentHandler) register(e *core.RequestEvent) error {
+	req := &dtos.AgentRegisterReq{}
+	if err := e.BindBody(req); err != nil {
+		return resp.Err(e, err)
+	}
+
+	if res, err := handler.service.RegisterAgent(e.Request.Context(), req); err != nil {
+		return resp.Err(e, err)
+	} else {
+		return resp.Ok(e, res)
+	}
+}
+
+func (handler *AgentHandler) revoke(e *core.RequestEvent) error {
+	req := &dtos.AgentRevokeReq{}
+	req.AgentId = e.Request.PathValue("agentId")
+
+	if err := handler.service.RevokeAgent(e.Request.Context(), req); err != nil {
+		return resp.Err(e, err)
+	} else {
+		return resp.Ok(e, nil)
+	}
+}
+
+func (handler *AgentHandler) connect(e *core.RequestEvent) error {
+	token := strings.TrimPrefix(e.Request.Header.Get("Authorization"), "Bearer ")
+
+	agent, err := handler.service.Authenticate(e.Request.Context(), token, e.RealIP())
+	if err != nil {
+		code := http.StatusInternalServerError
+		if xerr, ok := err.(*domain.Error); ok {
+			code = xerr.Code
+		}
+		return e.String(code, http.StatusText(code))
+	}
+
+	ws, err := handler.upgrader.Upgrade(e.Response, e.Request, nil)
+	if err != nil {
+		// Upgrade 失败时已经写出了错误响应
+		return nil
+	}
+
+	handler.service.Serve(agent.Id, ws)
+	return nil
+}
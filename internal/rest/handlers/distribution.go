@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+
+	"github.com/usual2970/certimate/internal/domain"
+	"github.com/usual2970/certimate/internal/domain/dtos"
+	"github.com/usual2970/certimate/internal/rest/resp"
+)
+
+type distributionService interface {
+	CreateToken(ctx context.Context, req *dtos.DistributionCreateTokenReq) (*dtos.DistributionCreateTokenResp, error)
+	Fetch(ctx context.Context, req *dtos.DistributionFetchReq) (*dtos.DistributionFetchResp, error)
+}
+
+type DistributionHandler struct {
+	service distributionService
+}
+
+func NewDistributionHandler(router *router.RouterGroup[*core.RequestEvent], service distributionService) {
+	handler := &DistributionHandler{
+		service: service,
+	}
+
+	group := router.Group("/distributions")
+	group.POST("/tokens", handler.createToken)
+}
+
+// NewDistributionPullHandler 注册供客户端拉取证书的接口。
+// 该接口不使用管理员鉴权，而是使用分发令牌鉴权，且出错时直接返回对应的 HTTP 状态码。
+func NewDistributionPullHandler(router *router.RouterGroup[*core.RequestEvent], service distributionService) {
+	handler := &DistributionHandler{
+		service: service,
+	}
+
+	group := router.Group("/dist")
+	group.GET("/{name}/{file}", handler.fetch)
+}
+
+func (handler *DistributionHandler) createToken(e *core.RequestEvent) error {
+	req := &dtos.DistributionCreateTokenReq{}
+	if err := e.BindBody(req); err != nil {
+		return resp.Err(e, err)
+	}
+
+	if res, err := handler.service.CreateToken(e.Request.Context(), req); err != nil {
+		return resp.Err(e, err)
+	} else {
+		return resp.Ok(e, res)
+	}
+}
+
+func (handler *DistributionHandler) fetch(e *core.RequestEvent) error {
+	req := &dtos.DistributionFetchReq{}
+	req.Name = e.Request.PathValue("name")
+	req.File = e.Request.PathValue("file")
+	req.Token = strings.TrimPrefix(e.Request.Header.Get("Authorization"), "Bearer ")
+	req.ClientIp = e.RealIP()
+	req.UserAgent = e.Request.UserAgent()
+	req.IfNoneMatch = e.Request.Header.Get("If-None-Match")
+
+	res, err := handler.service.Fetch(e.Request.Context(), req)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if xerr, ok := err.(*domain.Error); ok {
+			code = xerr.Code
+		}
+		return e.String(code, http.StatusText(code))
+	}
+
+	e.Response.Header().Set("Cache-Control", "no-cache")
+	e.Response.Header().Set("ETag", res.ETag)
+	if res.NotModified {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.Blob(http.StatusOK, "application/x-pem-file", res.Content)
+}
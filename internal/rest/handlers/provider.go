@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/router"
+
+	"github.com/usual2970/certimate/internal/registry"
+	"github.com/usual2970/certimate/internal/rest/resp"
+)
+
+type providerService interface {
+	List(ctx context.Context) (*registry.ProviderList, error)
+}
+
+type ProviderHandler struct {
+	service providerService
+}
+
+func NewProviderHandler(router *router.RouterGroup[*core.RequestEvent], service providerService) {
+	handler := &ProviderHandler{
+		service: service,
+	}
+
+	router.GET("/providers", handler.list)
+}
+
+func (handler *ProviderHandler) list(e *core.RequestEvent) error {
+	if providers, err := handler.service.List(e.Request.Context()); err != nil {
+		return resp.Err(e, err)
+	} else {
+		return resp.Ok(e, providers)
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tools/router"
 
+	"github.com/usual2970/certimate/internal/agent"
 	"github.com/usual2970/certimate/internal/certificate"
+	"github.com/usual2970/certimate/internal/distribution"
 	"github.com/usual2970/certimate/internal/notify"
+	"github.com/usual2970/certimate/internal/registry"
 	"github.com/usual2970/certimate/internal/repository"
 	"github.com/usual2970/certimate/internal/rest/handlers"
 	"github.com/usual2970/certimate/internal/statistics"
@@ -16,10 +19,13 @@ import (
 )
 
 var (
-	certificateSvc *certificate.CertificateService
-	workflowSvc    *workflow.WorkflowService
-	statisticsSvc  *statistics.StatisticsService
-	notifySvc      *notify.NotifyService
+	certificateSvc  *certificate.CertificateService
+	workflowSvc     *workflow.WorkflowService
+	statisticsSvc   *statistics.StatisticsService
+	notifySvc       *notify.NotifyService
+	distributionSvc *distribution.DistributionService
+	agentSvc        *agent.AgentService
+	providerSvc     *registry.ProviderService
 )
 
 func Register(router *router.Router[*core.RequestEvent]) {
@@ -28,11 +34,18 @@ func Register(router *router.Router[*core.RequestEvent]) {
 	certificateRepo := repository.NewCertificateRepository()
 	settingsRepo := repository.NewSettingsRepository()
 	statisticsRepo := repository.NewStatisticsRepository()
+	distributionRepo := repository.NewDistributionRepository()
+	distributionTokenRepo := repository.NewDistributionTokenRepository()
+	distributionLogRepo := repository.NewDistributionLogRepository()
+	agentRepo := repository.NewAgentRepository()
 
 	certificateSvc = certificate.NewCertificateService(certificateRepo, settingsRepo)
 	workflowSvc = workflow.NewWorkflowService(workflowRepo, workflowRunRepo, settingsRepo)
 	statisticsSvc = statistics.NewStatisticsService(statisticsRepo)
 	notifySvc = notify.NewNotifyService(settingsRepo)
+	distributionSvc = distribution.NewDistributionService(distributionRepo, distributionTokenRepo, distributionLogRepo, certificateRepo, settingsRepo)
+	agentSvc = agent.NewAgentService(agentRepo)
+	providerSvc = registry.NewProviderService()
 
 	group := router.Group("/api")
 	group.Bind(apis.RequireSuperuserAuth())
@@ -40,6 +53,14 @@ func Register(router *router.Router[*core.RequestEvent]) {
 	handlers.NewWorkflowHandler(group, workflowSvc)
 	handlers.NewStatisticsHandler(group, statisticsSvc)
 	handlers.NewNotifyHandler(group, notifySvc)
+	handlers.NewDistributionHandler(group, distributionSvc)
+	handlers.NewAgentHandler(group, agentSvc)
+	handlers.NewProviderHandler(group, providerSvc)
+
+	// 证书拉取接口与代理连接接口使用各自的令牌鉴权，不能挂在需要管理员鉴权的路由组下
+	pullGroup := router.Group("/api")
+	handlers.NewDistributionPullHandler(pullGroup, distributionSvc)
+	handlers.NewAgentConnectHandler(pullGroup, agentSvc)
 }
 
 func Unregister() {
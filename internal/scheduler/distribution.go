@@ -0,0 +1,11 @@
+package scheduler
+
+import "context"
+
+type distributionService interface {
+	InitSchedule(ctx context.Context) error
+}
+
+func InitDistributionScheduler(service distributionService) error {
+	return service.InitSchedule(context.Background())
+}
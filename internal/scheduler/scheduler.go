@@ -3,6 +3,7 @@ package scheduler
 import (
 	"github.com/usual2970/certimate/internal/app"
 	"github.com/usual2970/certimate/internal/certificate"
+	"github.com/usual2970/certimate/internal/distribution"
 	"github.com/usual2970/certimate/internal/repository"
 	"github.com/usual2970/certimate/internal/workflow"
 )
@@ -12,9 +13,13 @@ func Register() {
 	workflowRunRepo := repository.NewWorkflowRunRepository()
 	certificateRepo := repository.NewCertificateRepository()
 	settingsRepo := repository.NewSettingsRepository()
+	distributionRepo := repository.NewDistributionRepository()
+	distributionTokenRepo := repository.NewDistributionTokenRepository()
+	distributionLogRepo := repository.NewDistributionLogRepository()
 
 	workflowSvc := workflow.NewWorkflowService(workflowRepo, workflowRunRepo, settingsRepo)
 	certificateSvc := certificate.NewCertificateService(certificateRepo, settingsRepo)
+	distributionSvc := distribution.NewDistributionService(distributionRepo, distributionTokenRepo, distributionLogRepo, certificateRepo, settingsRepo)
 
 	if err := InitWorkflowScheduler(workflowSvc); err != nil {
 		app.GetLogger().Error("failed to init workflow scheduler", "err", err)
@@ -23,4 +28,8 @@ func Register() {
 	if err := InitCertificateScheduler(certificateSvc); err != nil {
 		app.GetLogger().Error("failed to init certificate scheduler", "err", err)
 	}
+
+	if err := InitDistributionScheduler(distributionSvc); err != nil {
+		app.GetLogger().Error("failed to init distribution scheduler", "err", err)
+	}
 }
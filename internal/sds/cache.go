@@ -0,0 +1,162 @@
+package sds
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+
+	"github.com/usual2970/certimate/internal/domain"
+)
+
+type secretEntry struct {
+	certificateId string
+	expireAt      time.Time
+	revision      uint64
+	secret        *tlsv3.Secret
+}
+
+// secretCache 保存可供 SDS 下发的证书，每张证书会同时以证书 ID 和其中的各个域名作为资源名称。
+// 当同一域名对应多张证书时，以过期时间最晚的为准。
+type secretCache struct {
+	mu       sync.RWMutex
+	version  uint64
+	entries  map[string]*secretEntry
+	watchers map[chan struct{}]struct{}
+}
+
+func newSecretCache() *secretCache {
+	return &secretCache{
+		entries:  make(map[string]*secretEntry),
+		watchers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// Put 添加或更新一张证书，并通知所有正在监听的连接。
+func (c *secretCache) Put(certificate *domain.Certificate) {
+	if !isPublishable(certificate) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	putEntries(c.entries, certificate, c.version)
+	c.notifyLocked()
+}
+
+// Reset 使用给定的证书列表替换缓存中的全部内容，并通知所有正在监听的连接。
+// 新的资源集合会先在锁外构建完成再整体替换，监听者只会收到一次通知，不会观察到中间状态。
+func (c *secretCache) Reset(certificates []*domain.Certificate) {
+	entries := make(map[string]*secretEntry)
+	for _, certificate := range certificates {
+		if isPublishable(certificate) {
+			putEntries(entries, certificate, 0)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 未发生变化的资源保留原有的修订号，避免向 Envoy 重复推送
+	c.version++
+	for name, entry := range entries {
+		if prev, ok := c.entries[name]; ok && prev.certificateId == entry.certificateId {
+			entry.revision = prev.revision
+		} else {
+			entry.revision = c.version
+		}
+	}
+
+	c.entries = entries
+	c.notifyLocked()
+}
+
+// Get 返回指定名称的资源。
+// 名称列表为空时不返回任何资源：SDS 不支持通配订阅，避免未指定名称的请求获取到全部私钥。
+func (c *secretCache) Get(names []string) (uint64, map[string]*secretEntry) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entries := make(map[string]*secretEntry)
+	for _, name := range names {
+		if entry, ok := c.entries[name]; ok {
+			entries[name] = entry
+		}
+	}
+
+	return c.version, entries
+}
+
+func (c *secretCache) Watch() chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	c.watchers[ch] = struct{}{}
+	return ch
+}
+
+func (c *secretCache) Unwatch(ch chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.watchers, ch)
+}
+
+func (c *secretCache) notifyLocked() {
+	for ch := range c.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func isPublishable(certificate *domain.Certificate) bool {
+	return certificate != nil && certificate.Id != "" && certificate.Certificate != "" && certificate.PrivateKey != ""
+}
+
+// 将证书以其 ID 和各个域名作为资源名称写入资源集合。
+func putEntries(entries map[string]*secretEntry, certificate *domain.Certificate, revision uint64) {
+	names := []string{certificate.Id}
+	for _, san := range strings.Split(certificate.SubjectAltNames, ";") {
+		if san = strings.TrimSpace(san); san != "" {
+			names = append(names, san)
+		}
+	}
+
+	for i, name := range names {
+		if i > 0 {
+			if existing, ok := entries[name]; ok && existing.certificateId != certificate.Id && existing.expireAt.After(certificate.ExpireAt) {
+				continue
+			}
+		}
+
+		entries[name] = &secretEntry{
+			certificateId: certificate.Id,
+			expireAt:      certificate.ExpireAt,
+			revision:      revision,
+			secret:        buildTlsSecret(name, certificate),
+		}
+	}
+}
+
+func buildTlsSecret(name string, certificate *domain.Certificate) *tlsv3.Secret {
+	return &tlsv3.Secret{
+		Name: name,
+		Type: &tlsv3.Secret_TlsCertificate{
+			TlsCertificate: &tlsv3.TlsCertificate{
+				CertificateChain: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineBytes{InlineBytes: []byte(certificate.Certificate)},
+				},
+				PrivateKey: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineBytes{InlineBytes: []byte(certificate.PrivateKey)},
+				},
+			},
+		},
+	}
+}
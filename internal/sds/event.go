@@ -0,0 +1,138 @@
+package sds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"google.golang.org/grpc"
+
+	"github.com/usual2970/certimate/internal/app"
+	"github.com/usual2970/certimate/internal/domain"
+	"github.com/usual2970/certimate/internal/repository"
+)
+
+type certificateRepository interface {
+	ListUnexpired(ctx context.Context) ([]*domain.Certificate, error)
+	GetById(ctx context.Context, id string) (*domain.Certificate, error)
+}
+
+var (
+	grpcServer   *grpc.Server
+	secretServer *SecretServer
+)
+
+// Register 在指定地址上启动 SDS 服务，并在证书保存或删除时同步更新已发布的 Secret。
+// 地址形如 "127.0.0.1:18000" 或 "unix:/path/to/sds.sock"。
+//
+// SDS 服务会下发证书私钥：令牌为空时仅允许监听本地回环地址或 Unix 套接字；
+// 令牌非空时要求客户端携带该令牌访问，但传输本身未加密，请仅在可信网络中监听非本地地址。
+func Register(address string, token string) error {
+	network, address := "tcp", address
+	if strings.HasPrefix(address, "unix:") {
+		network, address = "unix", strings.TrimPrefix(address, "unix:")
+	}
+
+	if network == "tcp" && token == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return fmt.Errorf("invalid sds server address '%s': %w", address, err)
+		}
+		if !isLoopbackHost(host) {
+			return fmt.Errorf("refusing to listen on non-loopback address '%s' without an access token", address)
+		}
+	}
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	certificateRepo := repository.NewCertificateRepository()
+	secretServer = NewSecretServer(app.GetLogger())
+	if err := reloadCertificates(context.Background(), certificateRepo); err != nil {
+		listener.Close()
+		return err
+	}
+
+	app := app.GetApp()
+	app.OnRecordAfterCreateSuccess(domain.CollectionNameCertificate).BindFunc(func(e *core.RecordEvent) error {
+		onCertificateRecordSave(e.Context, certificateRepo, e.Record)
+		return e.Next()
+	})
+	app.OnRecordAfterUpdateSuccess(domain.CollectionNameCertificate).BindFunc(func(e *core.RecordEvent) error {
+		onCertificateRecordSave(e.Context, certificateRepo, e.Record)
+		return e.Next()
+	})
+	app.OnRecordAfterDeleteSuccess(domain.CollectionNameCertificate).BindFunc(func(e *core.RecordEvent) error {
+		if err := reloadCertificates(e.Context, certificateRepo); err != nil {
+			app.Logger().Error("failed to reload sds secrets", "err", err)
+		}
+		return e.Next()
+	})
+
+	var serverOptions []grpc.ServerOption
+	if token != "" {
+		serverOptions = TokenAuth(token)
+	}
+
+	grpcServer = grpc.NewServer(serverOptions...)
+	secretServer.Register(grpcServer)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			app.Logger().Error("sds server stopped unexpectedly", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+func Unregister() {
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
+}
+
+func onCertificateRecordSave(ctx context.Context, certificateRepo certificateRepository, record *core.Record) {
+	if secretServer == nil {
+		return
+	}
+
+	certificate, err := certificateRepo.GetById(ctx, record.Id)
+	if err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			// 证书被软删除时，重新加载全部证书，以便域名回落到其他有效的证书上
+			if err := reloadCertificates(ctx, certificateRepo); err != nil {
+				app.GetLogger().Error("failed to reload sds secrets", "err", err)
+			}
+			return
+		}
+
+		app.GetLogger().Error("failed to get certificate", "id", record.Id, "err", err)
+		return
+	}
+
+	secretServer.Publish(certificate)
+}
+
+func reloadCertificates(ctx context.Context, certificateRepo certificateRepository) error {
+	certificates, err := certificateRepo.ListUnexpired(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	secretServer.Reload(certificates)
+	return nil
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
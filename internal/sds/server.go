@@ -0,0 +1,224 @@
+package sds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	secretv3 "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/usual2970/certimate/internal/domain"
+)
+
+const secretTypeUrl = "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret"
+
+// SecretServer 实现了 Envoy 的 Secret Discovery Service（SotW 模式）。
+// 连接的 Envoy 订阅证书 ID 或域名后，每当证书更新都会实时推送新的 Secret。
+type SecretServer struct {
+	secretv3.UnimplementedSecretDiscoveryServiceServer
+
+	cache  *secretCache
+	logger *slog.Logger
+}
+
+var _ secretv3.SecretDiscoveryServiceServer = (*SecretServer)(nil)
+
+func NewSecretServer(logger *slog.Logger) *SecretServer {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	return &SecretServer{
+		cache:  newSecretCache(),
+		logger: logger,
+	}
+}
+
+// Register 将服务注册到 gRPC 服务器。
+func (s *SecretServer) Register(grpcServer *grpc.Server) {
+	secretv3.RegisterSecretDiscoveryServiceServer(grpcServer, s)
+}
+
+// Publish 发布或更新一张证书。
+func (s *SecretServer) Publish(certificate *domain.Certificate) {
+	s.cache.Put(certificate)
+}
+
+// Reload 使用给定的证书列表替换全部已发布的证书。
+func (s *SecretServer) Reload(certificates []*domain.Certificate) {
+	s.cache.Reset(certificates)
+}
+
+func (s *SecretServer) StreamSecrets(stream secretv3.SecretDiscoveryService_StreamSecretsServer) error {
+	ctx := stream.Context()
+
+	reqCh := make(chan *discoveryv3.DiscoveryRequest)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			select {
+			case reqCh <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	watchCh := s.cache.Watch()
+	defer s.cache.Unwatch(watchCh)
+
+	var (
+		subscribed    []string
+		subscribing   bool
+		lastNonce     string
+		lastSignature string
+		nonceCounter  int64
+	)
+
+	send := func(force bool) error {
+		version, entries := s.cache.Get(subscribed)
+		if len(entries) == 0 {
+			// 请求的资源暂不存在时不作响应，Envoy 会一直等待直到资源可用
+			return nil
+		}
+
+		signature := buildSignature(entries)
+		if !force && signature == lastSignature {
+			return nil
+		}
+
+		resp, err := buildDiscoveryResponse(version, entries)
+		if err != nil {
+			return err
+		}
+
+		nonceCounter++
+		resp.Nonce = strconv.FormatInt(nonceCounter, 10)
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+
+		lastNonce = resp.Nonce
+		lastSignature = signature
+		s.logger.Debug("sds secrets pushed", slog.String("version", resp.VersionInfo), slog.Any("names", sortedNames(entries)))
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case err := <-errCh:
+			if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return err
+
+		case req := <-reqCh:
+			if req.GetTypeUrl() != "" && req.GetTypeUrl() != secretTypeUrl {
+				return status.Errorf(codes.InvalidArgument, "unsupported type url '%s'", req.GetTypeUrl())
+			}
+
+			if req.GetErrorDetail() != nil {
+				s.logger.Warn("sds secrets rejected by envoy", slog.String("node", req.GetNode().GetId()), slog.String("error", req.GetErrorDetail().GetMessage()))
+			}
+
+			// 忽略过期的响应确认
+			if req.GetResponseNonce() != "" && req.GetResponseNonce() != lastNonce {
+				continue
+			}
+
+			names := slices.Clone(req.GetResourceNames())
+			sort.Strings(names)
+			if subscribing && req.GetResponseNonce() != "" && slices.Equal(names, subscribed) {
+				// 确认（ACK/NACK）已下发的资源，无需再次响应
+				continue
+			}
+
+			subscribed = names
+			subscribing = true
+			if err := send(true); err != nil {
+				return err
+			}
+
+		case <-watchCh:
+			if !subscribing {
+				continue
+			}
+
+			if err := send(false); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *SecretServer) FetchSecrets(ctx context.Context, req *discoveryv3.DiscoveryRequest) (*discoveryv3.DiscoveryResponse, error) {
+	if req.GetTypeUrl() != "" && req.GetTypeUrl() != secretTypeUrl {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported type url '%s'", req.GetTypeUrl())
+	}
+
+	if len(req.GetResourceNames()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "resource names are required")
+	}
+
+	version, entries := s.cache.Get(req.GetResourceNames())
+	if len(entries) == 0 {
+		return nil, status.Errorf(codes.NotFound, "secrets '%s' not found", strings.Join(req.GetResourceNames(), ","))
+	}
+
+	return buildDiscoveryResponse(version, entries)
+}
+
+func buildDiscoveryResponse(version uint64, entries map[string]*secretEntry) (*discoveryv3.DiscoveryResponse, error) {
+	resources := make([]*anypb.Any, 0, len(entries))
+	for _, name := range sortedNames(entries) {
+		resource, err := anypb.New(entries[name].secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal secret '%s': %w", name, err)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return &discoveryv3.DiscoveryResponse{
+		VersionInfo: strconv.FormatUint(version, 10),
+		Resources:   resources,
+		TypeUrl:     secretTypeUrl,
+	}, nil
+}
+
+func buildSignature(entries map[string]*secretEntry) string {
+	parts := make([]string, 0, len(entries))
+	for _, name := range sortedNames(entries) {
+		parts = append(parts, name+"@"+strconv.FormatUint(entries[name].revision, 10))
+	}
+	return strings.Join(parts, ",")
+}
+
+func sortedNames(entries map[string]*secretEntry) []string {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
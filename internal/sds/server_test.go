@@ -0,0 +1,259 @@
+package sds_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	secretv3 "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/usual2970/certimate/internal/domain"
+	"github.com/usual2970/certimate/internal/sds"
+)
+
+const secretTypeUrl = "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret"
+
+func newTestClient(t *testing.T, server *sds.SecretServer, serverOptions ...grpc.ServerOption) secretv3.SecretDiscoveryServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(serverOptions...)
+	server.Register(grpcServer)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return secretv3.NewSecretDiscoveryServiceClient(conn)
+}
+
+func newTestCertificate(id string, sans string, content string, expireAt time.Time) *domain.Certificate {
+	return &domain.Certificate{
+		Meta:            domain.Meta{Id: id},
+		SubjectAltNames: sans,
+		Certificate:     content,
+		PrivateKey:      content + "-key",
+		ExpireAt:        expireAt,
+	}
+}
+
+func recvSecrets(t *testing.T, stream secretv3.SecretDiscoveryService_StreamSecretsClient) (*discoveryv3.DiscoveryResponse, map[string]string) {
+	t.Helper()
+
+	type result struct {
+		resp *discoveryv3.DiscoveryResponse
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		resp, err := stream.Recv()
+		ch <- result{resp, err}
+	}()
+
+	select {
+	case r := <-ch:
+		if r.err != nil {
+			t.Fatalf("err: %+v", r.err)
+		}
+
+		secrets := make(map[string]string)
+		for _, resource := range r.resp.GetResources() {
+			secret := &tlsv3.Secret{}
+			if err := resource.UnmarshalTo(secret); err != nil {
+				t.Fatalf("err: %+v", err)
+			}
+			secrets[secret.GetName()] = string(secret.GetTlsCertificate().GetCertificateChain().GetInlineBytes())
+		}
+		return r.resp, secrets
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for discovery response")
+		return nil, nil
+	}
+}
+
+func TestStreamSecrets(t *testing.T) {
+	now := time.Now()
+	server := sds.NewSecretServer(nil)
+	server.Publish(newTestCertificate("cert1", "example.com;*.example.com", "cert1", now.Add(30*24*time.Hour)))
+	server.Publish(newTestCertificate("cert2", "example.org", "cert2", now.Add(30*24*time.Hour)))
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.StreamSecrets(ctx)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	t.Run("Subscribe", func(t *testing.T) {
+		if err := stream.Send(&discoveryv3.DiscoveryRequest{TypeUrl: secretTypeUrl, ResourceNames: []string{"example.com", "cert2"}}); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		resp, secrets := recvSecrets(t, stream)
+		if len(secrets) != 2 || secrets["example.com"] != "cert1" || secrets["cert2"] != "cert2" {
+			t.Fatalf("unexpected secrets: %v", secrets)
+		}
+
+		// ACK
+		if err := stream.Send(&discoveryv3.DiscoveryRequest{TypeUrl: secretTypeUrl, ResourceNames: []string{"example.com", "cert2"}, VersionInfo: resp.GetVersionInfo(), ResponseNonce: resp.GetNonce()}); err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+	})
+
+	t.Run("PushOnRenewal", func(t *testing.T) {
+		// 未订阅的资源变化不应触发推送，续期后的证书应被立即推送
+		server.Publish(newTestCertificate("cert3", "example.net", "cert3", now.Add(60*24*time.Hour)))
+		server.Publish(newTestCertificate("cert4", "example.com;*.example.com", "cert4", now.Add(90*24*time.Hour)))
+
+		resp, secrets := recvSecrets(t, stream)
+		if len(secrets) != 2 || secrets["example.com"] != "cert4" || secrets["cert2"] != "cert2" {
+			t.Fatalf("unexpected secrets: %v", secrets)
+		}
+		if resp.GetTypeUrl() != secretTypeUrl {
+			t.Fatalf("unexpected type url: %s", resp.GetTypeUrl())
+		}
+	})
+
+	t.Run("KeepNewerCertificate", func(t *testing.T) {
+		// 过期时间更早的证书不应覆盖同域名的资源
+		server.Publish(newTestCertificate("cert5", "example.com", "cert5", now.Add(10*24*time.Hour)))
+
+		fetchResp, err := client.FetchSecrets(ctx, &discoveryv3.DiscoveryRequest{TypeUrl: secretTypeUrl, ResourceNames: []string{"example.com", "cert5"}})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+
+		names := make(map[string]string)
+		for _, resource := range fetchResp.GetResources() {
+			secret := &tlsv3.Secret{}
+			resource.UnmarshalTo(secret)
+			names[secret.GetName()] = string(secret.GetTlsCertificate().GetCertificateChain().GetInlineBytes())
+		}
+		if names["example.com"] != "cert4" || names["cert5"] != "cert5" {
+			t.Fatalf("unexpected secrets: %v", names)
+		}
+	})
+}
+
+func TestFetchSecrets(t *testing.T) {
+	now := time.Now()
+	server := sds.NewSecretServer(nil)
+	server.Publish(newTestCertificate("cert1", "example.com", "cert1", now.Add(30*24*time.Hour)))
+	client := newTestClient(t, server)
+
+	t.Run("EmptyResourceNames", func(t *testing.T) {
+		// 未指定资源名称时不得返回全部证书
+		_, err := client.FetchSecrets(context.Background(), &discoveryv3.DiscoveryRequest{TypeUrl: secretTypeUrl})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected invalid argument error, got: %v", err)
+		}
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		server.Reload([]*domain.Certificate{
+			newTestCertificate("cert2", "example.org", "cert2", now.Add(30*24*time.Hour)),
+		})
+
+		if _, err := client.FetchSecrets(context.Background(), &discoveryv3.DiscoveryRequest{TypeUrl: secretTypeUrl, ResourceNames: []string{"example.com"}}); status.Code(err) != codes.NotFound {
+			t.Errorf("expected removed secret to be not found, got: %v", err)
+		}
+		if _, err := client.FetchSecrets(context.Background(), &discoveryv3.DiscoveryRequest{TypeUrl: secretTypeUrl, ResourceNames: []string{"example.org"}}); err != nil {
+			t.Errorf("err: %+v", err)
+		}
+	})
+}
+
+func TestStreamSecrets_Reload(t *testing.T) {
+	now := time.Now()
+	server := sds.NewSecretServer(nil)
+	server.Publish(newTestCertificate("cert1", "example.com", "cert1", now.Add(30*24*time.Hour)))
+	server.Publish(newTestCertificate("cert2", "example.org", "cert2", now.Add(30*24*time.Hour)))
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.StreamSecrets(ctx)
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	names := []string{"example.com", "example.org"}
+	if err := stream.Send(&discoveryv3.DiscoveryRequest{TypeUrl: secretTypeUrl, ResourceNames: names}); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	resp, _ := recvSecrets(t, stream)
+	if err := stream.Send(&discoveryv3.DiscoveryRequest{TypeUrl: secretTypeUrl, ResourceNames: names, VersionInfo: resp.GetVersionInfo(), ResponseNonce: resp.GetNonce()}); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+
+	// 重新加载后应一次性推送最终结果，而不是先推送清空后的中间状态
+	server.Reload([]*domain.Certificate{
+		newTestCertificate("cert1", "example.com", "cert1", now.Add(30*24*time.Hour)),
+		newTestCertificate("cert3", "example.org", "cert3", now.Add(60*24*time.Hour)),
+	})
+
+	_, secrets := recvSecrets(t, stream)
+	if len(secrets) != 2 || secrets["example.com"] != "cert1" || secrets["example.org"] != "cert3" {
+		t.Fatalf("unexpected secrets: %v", secrets)
+	}
+}
+
+func TestTokenAuth(t *testing.T) {
+	server := sds.NewSecretServer(nil)
+	server.Publish(newTestCertificate("cert1", "example.com", "cert1", time.Now().Add(30*24*time.Hour)))
+	client := newTestClient(t, server, sds.TokenAuth("s3cr3t")...)
+
+	req := &discoveryv3.DiscoveryRequest{TypeUrl: secretTypeUrl, ResourceNames: []string{"example.com"}}
+
+	testCases := []struct {
+		name          string
+		authorization string
+		expectCode    codes.Code
+	}{
+		{"Missing", "", codes.Unauthenticated},
+		{"Invalid", "Bearer wrong", codes.Unauthenticated},
+		{"Valid", "Bearer s3cr3t", codes.OK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tc.authorization)
+			}
+
+			if _, err := client.FetchSecrets(ctx, req); status.Code(err) != tc.expectCode {
+				t.Errorf("expected %v, got: %v", tc.expectCode, err)
+			}
+
+			// 流式接口同样需要校验令牌
+			stream, err := client.StreamSecrets(ctx)
+			if err != nil {
+				t.Fatalf("err: %+v", err)
+			}
+			stream.Send(req)
+			if _, err := stream.Recv(); status.Code(err) != tc.expectCode {
+				t.Errorf("expected %v, got: %v", tc.expectCode, err)
+			}
+			stream.CloseSend()
+		})
+	}
+}
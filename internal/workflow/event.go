@@ -4,17 +4,23 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pocketbase/pocketbase/apis"
 	"github.com/pocketbase/pocketbase/core"
 
 	"github.com/usual2970/certimate/internal/app"
 	"github.com/usual2970/certimate/internal/domain"
 	"github.com/usual2970/certimate/internal/domain/dtos"
+	"github.com/usual2970/certimate/internal/registry"
 	"github.com/usual2970/certimate/internal/repository"
 )
 
 func Register() {
 	app := app.GetApp()
 	app.OnRecordCreateRequest(domain.CollectionNameWorkflow).BindFunc(func(e *core.RecordRequestEvent) error {
+		if err := validateWorkflowRecord(e.Record); err != nil {
+			return apis.NewBadRequestError(err.Error(), nil)
+		}
+
 		if err := e.Next(); err != nil {
 			return err
 		}
@@ -26,6 +32,10 @@ func Register() {
 		return nil
 	})
 	app.OnRecordUpdateRequest(domain.CollectionNameWorkflow).BindFunc(func(e *core.RecordRequestEvent) error {
+		if err := validateWorkflowRecord(e.Record); err != nil {
+			return apis.NewBadRequestError(err.Error(), nil)
+		}
+
 		if err := e.Next(); err != nil {
 			return err
 		}
@@ -87,3 +97,53 @@ func onWorkflowRecordDelete(_ context.Context, record *core.Record) error {
 
 	return nil
 }
+
+func validateWorkflowRecord(record *core.Record) error {
+	// 保存前按提供商注册时声明的 Schema 校验各节点的提供商配置，草稿与正式内容均需校验
+	for _, field := range []string{"draft", "content"} {
+		if raw := record.GetString(field); raw == "" || raw == "null" {
+			continue
+		}
+
+		node := &domain.WorkflowNode{}
+		if err := record.UnmarshalJSONField(field, node); err != nil {
+			return fmt.Errorf("invalid workflow %s: %w", field, err)
+		}
+
+		if err := validateWorkflowNode(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateWorkflowNode(node *domain.WorkflowNode) error {
+	for ; node != nil; node = node.Next {
+		var err error
+		switch node.Type {
+		case domain.WorkflowNodeTypeApply:
+			config := node.GetConfigForApply()
+			err = registry.ValidateConfig(registry.CapabilityDns, config.Provider, config.ProviderConfig)
+
+		case domain.WorkflowNodeTypeDeploy:
+			config := node.GetConfigForDeploy()
+			err = registry.ValidateConfig(registry.CapabilityHosting, config.Provider, config.ProviderConfig)
+
+		case domain.WorkflowNodeTypeNotify:
+			config := node.GetConfigForNotify()
+			err = registry.ValidateConfig(registry.CapabilityNotification, config.Provider, config.ProviderConfig)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid provider config of workflow node '%s': %w", node.Name, err)
+		}
+
+		for i := range node.Branches {
+			if err := validateWorkflowNode(&node.Branches[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
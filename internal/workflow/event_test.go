@@ -0,0 +1,57 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/usual2970/certimate/internal/domain"
+)
+
+func TestValidateWorkflowNode(t *testing.T) {
+	// 由 UI 保存的工作流草稿，其中自由输入的 ID 以字符串形式存储
+	const draft = `{
+		"id": "start",
+		"type": "start",
+		"name": "start",
+		"config": { "trigger": "manual" },
+		"next": {
+			"id": "deploy",
+			"type": "deploy",
+			"name": "deploy to 1panel",
+			"config": {
+				"certificate": "apply#certificate",
+				"provider": "1panel-site",
+				"providerAccessId": "access",
+				"providerConfig": { "resourceType": "website", "websiteId": %s }
+			}
+		}
+	}`
+
+	testCases := []struct {
+		name      string
+		websiteId string
+		valid     bool
+	}{
+		{"NumericString", `"123"`, true},
+		{"Number", `123`, true},
+		{"EmptyString", `""`, true},
+		{"NotANumber", `"abc"`, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := &domain.WorkflowNode{}
+			if err := json.Unmarshal([]byte(fmt.Sprintf(draft, tc.websiteId)), node); err != nil {
+				t.Fatalf("err: %+v", err)
+			}
+
+			err := validateWorkflowNode(node)
+			if tc.valid && err != nil {
+				t.Errorf("expected valid, got error: %v", err)
+			} else if !tc.valid && err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
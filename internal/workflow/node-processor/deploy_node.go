@@ -58,6 +58,7 @@ func (n *deployNode) Process(ctx context.Context) error {
 	}
 
 	// 检测是否可以跳过本次执行
+	var succeededHosts []string
 	if lastOutput != nil && certificate.CreatedAt.Before(lastOutput.UpdatedAt) {
 		if skippable, reason := n.checkCanSkip(ctx, lastOutput); skippable {
 			n.outputs[outputKeyForNodeSkipped] = strconv.FormatBool(true)
@@ -66,6 +67,8 @@ func (n *deployNode) Process(ctx context.Context) error {
 		} else if reason != "" {
 			n.logger.Info(fmt.Sprintf("re-deploy, because %s", reason))
 		}
+
+		succeededHosts = n.getLastSucceededHosts(lastOutput)
 	}
 
 	// 初始化部署器
@@ -74,6 +77,7 @@ func (n *deployNode) Process(ctx context.Context) error {
 		Logger:         n.logger,
 		CertificatePEM: certificate.Certificate,
 		PrivateKeyPEM:  certificate.PrivateKey,
+		SucceededHosts: succeededHosts,
 	})
 	if err != nil {
 		n.logger.Warn("failed to create deployer provider")
@@ -81,18 +85,35 @@ func (n *deployNode) Process(ctx context.Context) error {
 	}
 
 	// 部署证书
-	if err := deployer.Deploy(ctx); err != nil {
-		n.logger.Warn("failed to deploy certificate")
-		return err
-	}
+	deployRes, deployErr := deployer.Deploy(ctx)
 
 	// 保存执行结果
+	// 批量部署到多台主机时，即使部署失败也需记录各主机的部署结果，以便下次执行时仅重试失败的主机
 	output := &domain.WorkflowOutput{
 		WorkflowId: getContextWorkflowId(ctx),
 		RunId:      getContextWorkflowRunId(ctx),
 		NodeId:     n.node.Id,
 		Node:       n.node,
-		Succeeded:  true,
+		Succeeded:  deployErr == nil,
+	}
+	if deployRes != nil && deployRes.ExtendedData != nil && deployRes.ExtendedData["hosts"] != nil {
+		output.Outputs = append(output.Outputs, domain.WorkflowNodeIO{
+			Label: "部署主机",
+			Name:  domain.WorkflowNodeIONameDeployHosts,
+			Type:  "object",
+			Value: deployRes.ExtendedData["hosts"],
+		})
+	}
+	if deployErr != nil {
+		n.logger.Warn("failed to deploy certificate")
+
+		if len(output.Outputs) > 0 {
+			if _, err := n.outputRepo.Save(ctx, output); err != nil {
+				n.logger.Warn("failed to save node output")
+			}
+		}
+
+		return deployErr
 	}
 	if _, err := n.outputRepo.Save(ctx, output); err != nil {
 		n.logger.Warn("failed to save node output")
@@ -119,6 +140,13 @@ func (n *deployNode) checkCanSkip(ctx context.Context, lastOutput *domain.Workfl
 			return false, "the configuration item 'ProviderConfig' changed"
 		}
 
+		// 批量部署到多台主机时，存在失败的主机则需要重试
+		for _, host := range lastOutput.GetDeployHosts() {
+			if !host.Succeeded {
+				return false, "some hosts failed to deploy last time"
+			}
+		}
+
 		if thisNodeCfg.SkipOnLastSucceeded {
 			return true, "the certificate has already been deployed"
 		}
@@ -126,3 +154,23 @@ func (n *deployNode) checkCanSkip(ctx context.Context, lastOutput *domain.Workfl
 
 	return false, ""
 }
+
+func (n *deployNode) getLastSucceededHosts(lastOutput *domain.WorkflowOutput) []string {
+	// 仅在启用了跳过已部署成功的选项、且关键配置未变更时，才跳过上次已部署成功的主机
+	thisNodeCfg := n.node.GetConfigForDeploy()
+	lastNodeCfg := lastOutput.Node.GetConfigForDeploy()
+	if !thisNodeCfg.SkipOnLastSucceeded ||
+		thisNodeCfg.ProviderAccessId != lastNodeCfg.ProviderAccessId ||
+		!maps.Equal(thisNodeCfg.ProviderConfig, lastNodeCfg.ProviderConfig) {
+		return nil
+	}
+
+	hosts := make([]string, 0)
+	for _, host := range lastOutput.GetDeployHosts() {
+		if host.Succeeded {
+			hosts = append(hosts, host.Host)
+		}
+	}
+
+	return hosts
+}
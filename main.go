@@ -13,9 +13,12 @@ import (
 	"github.com/pocketbase/pocketbase/plugins/migratecmd"
 	"github.com/pocketbase/pocketbase/tools/hook"
 
+	"github.com/usual2970/certimate/internal/agent"
 	"github.com/usual2970/certimate/internal/app"
+	"github.com/usual2970/certimate/internal/plugin"
 	"github.com/usual2970/certimate/internal/rest/routes"
 	"github.com/usual2970/certimate/internal/scheduler"
+	"github.com/usual2970/certimate/internal/sds"
 	"github.com/usual2970/certimate/internal/workflow"
 	"github.com/usual2970/certimate/ui"
 
@@ -27,11 +30,26 @@ func main() {
 
 	var flagHttp string
 	flag.StringVar(&flagHttp, "http", "127.0.0.1:8090", "HTTP server address")
+	var flagSds string
+	flag.StringVar(&flagSds, "sds", "", "Envoy SDS gRPC server address (e.g. 127.0.0.1:18000 or unix:/path/to/sds.sock)")
+	var flagSdsToken string
+	flag.StringVar(&flagSdsToken, "sdsToken", "", "Envoy SDS access token, required when listening on a non-loopback address (or env CERTIMATE_SDS_TOKEN)")
+	var flagPluginsDir string
+	flag.StringVar(&flagPluginsDir, "pluginsDir", "", "the directory of external provider plugins")
 	if len(os.Args) < 2 {
 		slog.Error("[CERTIMATE] missing exec args")
 		os.Exit(1)
 		return
 	}
+	if os.Args[1] == "agent" {
+		// 远程代理模式只与服务端保持出站连接，不需要启动 PocketBase
+		cmd := agent.NewCommand()
+		cmd.SetArgs(os.Args[2:])
+		if err := cmd.Execute(); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
 	_ = flag.CommandLine.Parse(os.Args[2:]) // skip the first two arguments: "main.go serve"
 
 	migratecmd.MustRegister(app, app.RootCmd, migratecmd.Config{
@@ -40,9 +58,15 @@ func main() {
 		Automigrate: strings.HasPrefix(os.Args[0], os.TempDir()),
 	})
 
+	// the flags are parsed above, but still need to be declared so that the "serve" command accepts them
+	app.RootCmd.PersistentFlags().String("sds", "", "Envoy SDS gRPC server address (e.g. 127.0.0.1:18000 or unix:/path/to/sds.sock)")
+	app.RootCmd.PersistentFlags().String("sdsToken", "", "Envoy SDS access token, required when listening on a non-loopback address (or env CERTIMATE_SDS_TOKEN)")
+	app.RootCmd.PersistentFlags().String("pluginsDir", "", "the directory of external provider plugins")
+
 	app.OnServe().BindFunc(func(e *core.ServeEvent) error {
 		scheduler.Register()
 		workflow.Register()
+		agent.Register()
 		routes.Register(e.Router)
 		return e.Next()
 	})
@@ -57,6 +81,31 @@ func main() {
 		Priority: 999,
 	})
 
+	app.OnServe().BindFunc(func(e *core.ServeEvent) error {
+		if flagSds != "" {
+			if flagSdsToken == "" {
+				flagSdsToken = os.Getenv("CERTIMATE_SDS_TOKEN")
+			}
+			if err := sds.Register(flagSds, flagSdsToken); err != nil {
+				return err
+			}
+
+			slog.Info("[CERTIMATE] Envoy SDS server is listening on: " + flagSds)
+		}
+		return e.Next()
+	})
+
+	app.OnServe().BindFunc(func(e *core.ServeEvent) error {
+		if flagPluginsDir != "" {
+			if err := plugin.Register(flagPluginsDir); err != nil {
+				return err
+			}
+
+			slog.Info("[CERTIMATE] External plugins are loaded from: " + flagPluginsDir)
+		}
+		return e.Next()
+	})
+
 	app.OnServe().BindFunc(func(e *core.ServeEvent) error {
 		slog.Info("[CERTIMATE] Visit the website: http://" + flagHttp)
 		return e.Next()
@@ -64,6 +113,8 @@ func main() {
 
 	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
 		routes.Unregister()
+		sds.Unregister()
+		plugin.Unregister()
 		slog.Info("[CERTIMATE] Exit!")
 		return e.Next()
 	})
@@ -0,0 +1,438 @@
+package migrations
+
+import (
+	"encoding/json"
+
+	"github.com/pocketbase/pocketbase/core"
+	m "github.com/pocketbase/pocketbase/migrations"
+)
+
+func init() {
+	m.Register(func(app core.App) error {
+		tracer := NewTracer("(v0.3)1749600000")
+		tracer.Printf("go ...")
+
+		// create collection `distribution`
+		{
+			jsonData := `{
+				"createRule": null,
+				"deleteRule": null,
+				"fields": [
+					{
+						"autogeneratePattern": "[a-z0-9]{15}",
+						"hidden": false,
+						"id": "text3208210256",
+						"max": 15,
+						"min": 15,
+						"name": "id",
+						"pattern": "^[a-z0-9]+$",
+						"presentable": false,
+						"primaryKey": true,
+						"required": true,
+						"system": true,
+						"type": "text"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": false,
+						"id": "text2916285310",
+						"max": 0,
+						"min": 0,
+						"name": "name",
+						"pattern": "^[a-zA-Z0-9._-]+$",
+						"presentable": true,
+						"primaryKey": false,
+						"required": true,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": false,
+						"id": "text1814925476",
+						"max": 0,
+						"min": 0,
+						"name": "workflowNodeId",
+						"pattern": "",
+						"presentable": false,
+						"primaryKey": false,
+						"required": false,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": false,
+						"id": "text3418932557",
+						"max": 0,
+						"min": 0,
+						"name": "domain",
+						"pattern": "",
+						"presentable": false,
+						"primaryKey": false,
+						"required": false,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"hidden": false,
+						"id": "autodate2990389176",
+						"name": "created",
+						"onCreate": true,
+						"onUpdate": false,
+						"presentable": false,
+						"system": false,
+						"type": "autodate"
+					},
+					{
+						"hidden": false,
+						"id": "autodate3332085495",
+						"name": "updated",
+						"onCreate": true,
+						"onUpdate": true,
+						"presentable": false,
+						"system": false,
+						"type": "autodate"
+					}
+				],
+				"id": "pbc_2859376345",
+				"indexes": [
+					"CREATE UNIQUE INDEX ` + "`" + `idx_Yj5xNZQalJ` + "`" + ` ON ` + "`" + `distribution` + "`" + ` (` + "`" + `name` + "`" + `)"
+				],
+				"listRule": null,
+				"name": "distribution",
+				"system": false,
+				"type": "base",
+				"updateRule": null,
+				"viewRule": null
+			}`
+
+			collection := &core.Collection{}
+			if err := json.Unmarshal([]byte(jsonData), &collection); err != nil {
+				return err
+			}
+
+			if err := app.Save(collection); err != nil {
+				return err
+			}
+
+			tracer.Printf("collection '%s' created", collection.Name)
+		}
+
+		// create collection `distribution_token`
+		{
+			jsonData := `{
+				"createRule": null,
+				"deleteRule": null,
+				"fields": [
+					{
+						"autogeneratePattern": "[a-z0-9]{15}",
+						"hidden": false,
+						"id": "text3208210256",
+						"max": 15,
+						"min": 15,
+						"name": "id",
+						"pattern": "^[a-z0-9]+$",
+						"presentable": false,
+						"primaryKey": true,
+						"required": true,
+						"system": true,
+						"type": "text"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": false,
+						"id": "text1272651214",
+						"max": 0,
+						"min": 0,
+						"name": "name",
+						"pattern": "",
+						"presentable": true,
+						"primaryKey": false,
+						"required": true,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": true,
+						"id": "text1269873366",
+						"max": 0,
+						"min": 0,
+						"name": "tokenHash",
+						"pattern": "",
+						"presentable": false,
+						"primaryKey": false,
+						"required": true,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"cascadeDelete": false,
+						"collectionId": "pbc_2859376345",
+						"hidden": false,
+						"id": "relation3859933501",
+						"maxSelect": 999,
+						"minSelect": 0,
+						"name": "distributionIds",
+						"presentable": false,
+						"required": false,
+						"system": false,
+						"type": "relation"
+					},
+					{
+						"hidden": false,
+						"id": "json2116109578",
+						"maxSize": 0,
+						"name": "allowedIps",
+						"presentable": false,
+						"required": false,
+						"system": false,
+						"type": "json"
+					},
+					{
+						"hidden": false,
+						"id": "bool1499133578",
+						"name": "enabled",
+						"presentable": false,
+						"required": false,
+						"system": false,
+						"type": "bool"
+					},
+					{
+						"hidden": false,
+						"id": "date3657033293",
+						"max": "",
+						"min": "",
+						"name": "expireAt",
+						"presentable": false,
+						"required": false,
+						"system": false,
+						"type": "date"
+					},
+					{
+						"hidden": false,
+						"id": "date1038656544",
+						"max": "",
+						"min": "",
+						"name": "lastUsedAt",
+						"presentable": false,
+						"required": false,
+						"system": false,
+						"type": "date"
+					},
+					{
+						"hidden": false,
+						"id": "autodate2990389176",
+						"name": "created",
+						"onCreate": true,
+						"onUpdate": false,
+						"presentable": false,
+						"system": false,
+						"type": "autodate"
+					},
+					{
+						"hidden": false,
+						"id": "autodate3332085495",
+						"name": "updated",
+						"onCreate": true,
+						"onUpdate": true,
+						"presentable": false,
+						"system": false,
+						"type": "autodate"
+					}
+				],
+				"id": "pbc_1108563201",
+				"indexes": [
+					"CREATE UNIQUE INDEX ` + "`" + `idx_rt5I66UFOT` + "`" + ` ON ` + "`" + `distribution_token` + "`" + ` (` + "`" + `tokenHash` + "`" + `)"
+				],
+				"listRule": null,
+				"name": "distribution_token",
+				"system": false,
+				"type": "base",
+				"updateRule": null,
+				"viewRule": null
+			}`
+
+			collection := &core.Collection{}
+			if err := json.Unmarshal([]byte(jsonData), &collection); err != nil {
+				return err
+			}
+
+			if err := app.Save(collection); err != nil {
+				return err
+			}
+
+			tracer.Printf("collection '%s' created", collection.Name)
+		}
+
+		// create collection `distribution_logs`
+		{
+			jsonData := `{
+				"createRule": null,
+				"deleteRule": null,
+				"fields": [
+					{
+						"autogeneratePattern": "[a-z0-9]{15}",
+						"hidden": false,
+						"id": "text3208210256",
+						"max": 15,
+						"min": 15,
+						"name": "id",
+						"pattern": "^[a-z0-9]+$",
+						"presentable": false,
+						"primaryKey": true,
+						"required": true,
+						"system": true,
+						"type": "text"
+					},
+					{
+						"cascadeDelete": true,
+						"collectionId": "pbc_2859376345",
+						"hidden": false,
+						"id": "relation3353031405",
+						"maxSelect": 1,
+						"minSelect": 0,
+						"name": "distributionId",
+						"presentable": false,
+						"required": false,
+						"system": false,
+						"type": "relation"
+					},
+					{
+						"cascadeDelete": false,
+						"collectionId": "pbc_1108563201",
+						"hidden": false,
+						"id": "relation1688356486",
+						"maxSelect": 1,
+						"minSelect": 0,
+						"name": "tokenId",
+						"presentable": false,
+						"required": false,
+						"system": false,
+						"type": "relation"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": false,
+						"id": "text3558439682",
+						"max": 0,
+						"min": 0,
+						"name": "file",
+						"pattern": "",
+						"presentable": false,
+						"primaryKey": false,
+						"required": false,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": false,
+						"id": "text4046224336",
+						"max": 0,
+						"min": 0,
+						"name": "clientIp",
+						"pattern": "",
+						"presentable": false,
+						"primaryKey": false,
+						"required": false,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": false,
+						"id": "text1396720670",
+						"max": 0,
+						"min": 0,
+						"name": "userAgent",
+						"pattern": "",
+						"presentable": false,
+						"primaryKey": false,
+						"required": false,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"hidden": false,
+						"id": "number1910260331",
+						"max": null,
+						"min": null,
+						"name": "statusCode",
+						"onlyInt": true,
+						"presentable": false,
+						"required": false,
+						"system": false,
+						"type": "number"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": false,
+						"id": "text2307840832",
+						"max": 0,
+						"min": 0,
+						"name": "requestSerialNumber",
+						"pattern": "",
+						"presentable": false,
+						"primaryKey": false,
+						"required": false,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"autogeneratePattern": "",
+						"hidden": false,
+						"id": "text2731592977",
+						"max": 0,
+						"min": 0,
+						"name": "serialNumber",
+						"pattern": "",
+						"presentable": false,
+						"primaryKey": false,
+						"required": false,
+						"system": false,
+						"type": "text"
+					},
+					{
+						"hidden": false,
+						"id": "autodate2990389176",
+						"name": "created",
+						"onCreate": true,
+						"onUpdate": false,
+						"presentable": false,
+						"system": false,
+						"type": "autodate"
+					}
+				],
+				"id": "pbc_3482016790",
+				"indexes": [
+					"CREATE INDEX ` + "`" + `idx_Kw6q3aX6zj` + "`" + ` ON ` + "`" + `distribution_logs` + "`" + ` (` + "`" + `distributionId` + "`" + `)",
+					"CREATE INDEX ` + "`" + `idx_nK1KlNXif6` + "`" + ` ON ` + "`" + `distribution_logs` + "`" + ` (` + "`" + `tokenId` + "`" + `)"
+				],
+				"listRule": null,
+				"name": "distribution_logs",
+				"system": false,
+				"type": "base",
+				"updateRule": null,
+				"viewRule": null
+			}`
+
+			collection := &core.Collection{}
+			if err := json.Unmarshal([]byte(jsonData), &collection); err != nil {
+				return err
+			}
+
+			if err := app.Save(collection); err != nil {
+				return err
+			}
+
+			tracer.Printf("collection '%s' created", collection.Name)
+		}
+
+		tracer.Printf("done")
+		return nil
+	}, func(app core.App) error {
+		return nil
+	})
+}
@@ -0,0 +1,244 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	startTimeout = 10 * time.Second
+	killTimeout  = 5 * time.Second
+)
+
+var errPluginExited = errors.New("plugin process has exited")
+
+// Client 运行在主机一侧，负责启动插件进程并通过 gRPC 调用插件。
+type Client struct {
+	path   string
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	conn   *stdioConn
+	grpc   *grpc.ClientConn
+	info   *Info
+	exited chan struct{}
+}
+
+func NewClient(path string, logger *slog.Logger) *Client {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	return &Client{
+		path:   path,
+		logger: logger,
+	}
+}
+
+// Start 启动插件进程并完成握手。
+func (c *Client) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cmd != nil {
+		return errors.New("plugin has already been started")
+	}
+
+	cmd := exec.Command(c.path)
+	cmd.Env = append(os.Environ(), MagicCookieKey+"="+MagicCookieValue)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start plugin '%s': %w", c.path, err)
+	}
+
+	name := filepath.Base(c.path)
+	exited := make(chan struct{})
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			c.logger.Info(scanner.Text(), slog.String("plugin", name))
+		}
+	}()
+	go func() {
+		err := cmd.Wait()
+		c.logger.Debug("plugin process exited", slog.String("plugin", name), slog.Any("error", err))
+		close(exited)
+	}()
+
+	conn := newStdioConn(stdout, stdin)
+	var dialed sync.Once
+	grpcConn, err := grpc.NewClient("passthrough:///"+name,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			// 管道只能使用一次，进程退出后不能重连
+			var res net.Conn
+			dialed.Do(func() { res = conn })
+			if res == nil {
+				return nil, errPluginExited
+			}
+			return res, nil
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})),
+		// 禁用空闲模式，否则连接空闲时会被关闭，进而导致插件进程退出
+		grpc.WithIdleTimeout(0),
+	)
+	if err != nil {
+		conn.Close()
+		cmd.Process.Kill()
+		return fmt.Errorf("failed to connect to plugin '%s': %w", c.path, err)
+	}
+
+	c.cmd = cmd
+	c.conn = conn
+	c.grpc = grpcConn
+	c.exited = exited
+
+	handshakeCtx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+
+	info := &Info{}
+	if err := c.invoke(handshakeCtx, methodGetInfo, &infoRequest{}, info); err != nil {
+		c.kill()
+		return fmt.Errorf("failed to handshake with plugin '%s': %w", c.path, err)
+	}
+	if info.ProtocolVersion != ProtocolVersion {
+		c.kill()
+		return fmt.Errorf("plugin '%s' uses protocol version %d, but %d is required", c.path, info.ProtocolVersion, ProtocolVersion)
+	}
+
+	c.info = info
+	return nil
+}
+
+// Info 返回插件在握手时上报的信息。
+func (c *Client) Info() *Info {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.info
+}
+
+// Exited 判断插件进程是否已退出。
+func (c *Client) Exited() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.exited == nil {
+		return true
+	}
+
+	select {
+	case <-c.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// Kill 关闭与插件的连接，插件进程未能及时退出时将被强制结束。
+func (c *Client) Kill() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.kill()
+}
+
+func (c *Client) kill() {
+	if c.cmd == nil {
+		return
+	}
+
+	c.grpc.Close()
+	c.conn.Close()
+
+	select {
+	case <-c.exited:
+	case <-time.After(killTimeout):
+		c.cmd.Process.Kill()
+		<-c.exited
+	}
+}
+
+func (c *Client) Deploy(ctx context.Context, req *DeployRequest) (*DeployResponse, error) {
+	resp := &DeployResponse{}
+	if err := c.call(ctx, methodDeploy, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) Upload(ctx context.Context, req *UploadRequest) (*UploadResponse, error) {
+	resp := &UploadResponse{}
+	if err := c.call(ctx, methodUpload, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) Notify(ctx context.Context, req *NotifyRequest) (*NotifyResponse, error) {
+	resp := &NotifyResponse{}
+	if err := c.call(ctx, methodNotify, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) Present(ctx context.Context, req *ChallengeRequest) error {
+	return c.call(ctx, methodPresent, req, &ChallengeResponse{})
+}
+
+func (c *Client) CleanUp(ctx context.Context, req *ChallengeRequest) error {
+	return c.call(ctx, methodCleanUp, req, &ChallengeResponse{})
+}
+
+func (c *Client) call(ctx context.Context, method string, req any, resp any) error {
+	err := c.invoke(ctx, method, req, resp)
+	if status.Code(err) == codes.Unavailable {
+		// 管道无法重连，传输层出错后插件已不可用，结束插件进程以便在下一次使用时重新启动
+		c.logger.Warn("plugin transport failed, killing the plugin process ...", slog.String("plugin", filepath.Base(c.path)), slog.Any("error", err))
+		c.Kill()
+	}
+
+	return err
+}
+
+func (c *Client) invoke(ctx context.Context, method string, req any, resp any) error {
+	if c.grpc == nil {
+		return errors.New("plugin has not been started")
+	}
+
+	if err := c.grpc.Invoke(ctx, fullMethodName(method), req, resp); err != nil {
+		// 插件返回的普通错误不需要携带 gRPC 状态码前缀
+		if st, ok := status.FromError(err); ok && st.Code() == codes.Unknown {
+			return errors.New(st.Message())
+		}
+		return err
+	}
+
+	return nil
+}
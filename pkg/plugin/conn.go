@@ -0,0 +1,97 @@
+package plugin
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// stdioConn 将一对管道包装为 [net.Conn]，使 gRPC 可以直接运行在插件进程的标准输入输出上。
+type stdioConn struct {
+	r io.ReadCloser
+	w io.WriteCloser
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ net.Conn = (*stdioConn)(nil)
+
+func newStdioConn(r io.ReadCloser, w io.WriteCloser) *stdioConn {
+	return &stdioConn{
+		r:      r,
+		w:      w,
+		closed: make(chan struct{}),
+	}
+}
+
+func (c *stdioConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *stdioConn) Write(b []byte) (int, error) {
+	return c.w.Write(b)
+}
+
+func (c *stdioConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = errors.Join(c.w.Close(), c.r.Close())
+		close(c.closed)
+	})
+	return err
+}
+
+func (c *stdioConn) LocalAddr() net.Addr { return stdioAddr{} }
+
+func (c *stdioConn) RemoteAddr() net.Addr { return stdioAddr{} }
+
+// 管道不支持超时，gRPC 仅在握手阶段设置超时，忽略即可。
+func (c *stdioConn) SetDeadline(t time.Time) error { return nil }
+
+func (c *stdioConn) SetReadDeadline(t time.Time) error { return nil }
+
+func (c *stdioConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type stdioAddr struct{}
+
+func (stdioAddr) Network() string { return "stdio" }
+
+func (stdioAddr) String() string { return "stdio" }
+
+// stdioListener 是只会返回一个连接的 [net.Listener]，连接关闭后 Accept 将返回错误。
+type stdioListener struct {
+	conn *stdioConn
+	once sync.Once
+}
+
+var _ net.Listener = (*stdioListener)(nil)
+
+func newStdioListener(conn *stdioConn) *stdioListener {
+	return &stdioListener{
+		conn: conn,
+	}
+}
+
+func (l *stdioListener) Accept() (net.Conn, error) {
+	var conn net.Conn
+	l.once.Do(func() {
+		conn = l.conn
+	})
+	if conn != nil {
+		return conn, nil
+	}
+
+	<-l.conn.closed
+	return nil, net.ErrClosed
+}
+
+func (l *stdioListener) Close() error {
+	return l.conn.Close()
+}
+
+func (l *stdioListener) Addr() net.Addr {
+	return stdioAddr{}
+}
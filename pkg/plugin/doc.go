@@ -0,0 +1,21 @@
+/*
+Package plugin 实现了 Certimate 外部插件协议。
+
+插件是独立构建的可执行文件，放置在通过 `--pluginsDir` 指定的目录中即可被发现，文件名即插件名称。
+主机会以子进程的方式启动插件，并通过插件进程的标准输入输出运行 gRPC（消息以 JSON 编码）。
+插件可以实现部署器、上传器、消息通知器或 DNS-01 质询提供商中的一种或多种；
+每次调用都会以 JSON 形式携带授权配置与节点配置。
+
+一个最简单的插件如下：
+
+	func main() {
+		plugin.Serve(&plugin.ServeConfig{
+			Name:     "my-deployer",
+			Version:  "v1.0.0",
+			Deployer: &myDeployer{},
+		})
+	}
+
+插件的标准输出被用作通信通道，日志请写入标准错误，它们会被转发到 Certimate 的日志中。
+*/
+package plugin
@@ -0,0 +1,201 @@
+package plugin_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/usual2970/certimate/pkg/plugin"
+)
+
+// 测试时将测试二进制自身作为插件启动。
+const helperEnvKey = "CERTIMATE_PLUGIN_TEST_HELPER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(helperEnvKey) {
+	case "1":
+		plugin.Serve(&plugin.ServeConfig{
+			Name:              "test",
+			Version:           "v0.0.1",
+			Deployer:          &testDeployer{},
+			ChallengeProvider: &testChallengeProvider{},
+		})
+		os.Exit(0)
+
+	case "uploader":
+		plugin.Serve(&plugin.ServeConfig{
+			Name:     "test-uploader",
+			Version:  "v0.0.1",
+			Uploader: &testUploader{},
+		})
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+type testDeployer struct{}
+
+func (d *testDeployer) Deploy(ctx context.Context, req *plugin.DeployRequest) (*plugin.DeployResponse, error) {
+	var access struct {
+		Endpoint string `json:"endpoint"`
+	}
+	if err := json.Unmarshal(req.AccessConfig, &access); err != nil {
+		return nil, err
+	}
+	if access.Endpoint == "" {
+		return nil, errors.New("endpoint is required")
+	}
+	if access.Endpoint == "broken" {
+		// 向通信通道写入无效数据，模拟传输层故障
+		os.NewFile(uintptr(syscall.Stdout), "stdout").Write([]byte("broken pipe data"))
+		return &plugin.DeployResponse{}, nil
+	}
+
+	// 插件向标准输出的写入不应破坏通信
+	fmt.Println("deploying to", access.Endpoint)
+
+	return &plugin.DeployResponse{
+		ExtendedData: map[string]any{
+			"endpoint":    access.Endpoint,
+			"certificate": req.Certificate,
+		},
+	}, nil
+}
+
+type testUploader struct{}
+
+func (u *testUploader) Upload(ctx context.Context, req *plugin.UploadRequest) (*plugin.UploadResponse, error) {
+	return &plugin.UploadResponse{
+		CertId:   "cert-1",
+		CertName: "certimate",
+	}, nil
+}
+
+type testChallengeProvider struct{}
+
+func (p *testChallengeProvider) Present(ctx context.Context, req *plugin.ChallengeRequest) error {
+	return nil
+}
+
+func (p *testChallengeProvider) CleanUp(ctx context.Context, req *plugin.ChallengeRequest) error {
+	return nil
+}
+
+func startTestPlugin(t *testing.T) *plugin.Client {
+	t.Helper()
+	return startTestPluginWithMode(t, "1")
+}
+
+func startTestPluginWithMode(t *testing.T, mode string) *plugin.Client {
+	t.Helper()
+	t.Setenv(helperEnvKey, mode)
+
+	client := plugin.NewClient(os.Args[0], nil)
+	if err := client.Start(context.Background()); err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	t.Cleanup(client.Kill)
+
+	return client
+}
+
+func TestHandshake(t *testing.T) {
+	client := startTestPlugin(t)
+
+	info := client.Info()
+	if info.Name != "test" || info.ProtocolVersion != plugin.ProtocolVersion {
+		t.Fatalf("unexpected plugin info: %+v", info)
+	}
+	if !info.HasKind(plugin.KindDeployer) || !info.HasKind(plugin.KindDns01) {
+		t.Errorf("expected deployer and dns01 kinds, got %v", info.Kinds)
+	}
+	if info.HasKind(plugin.KindNotifier) || info.HasKind(plugin.KindUploader) {
+		t.Errorf("unexpected kinds: %v", info.Kinds)
+	}
+}
+
+func TestDeploy(t *testing.T) {
+	client := startTestPlugin(t)
+
+	t.Run("Deploy", func(t *testing.T) {
+		resp, err := client.Deploy(context.Background(), &plugin.DeployRequest{
+			AccessConfig: json.RawMessage(`{"endpoint":"https://example.com"}`),
+			Certificate:  "cert",
+			PrivateKey:   "key",
+		})
+		if err != nil {
+			t.Fatalf("err: %+v", err)
+		}
+		if resp.ExtendedData["endpoint"] != "https://example.com" || resp.ExtendedData["certificate"] != "cert" {
+			t.Errorf("unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("DeployError", func(t *testing.T) {
+		_, err := client.Deploy(context.Background(), &plugin.DeployRequest{
+			AccessConfig: json.RawMessage(`{}`),
+		})
+		if err == nil || err.Error() != "endpoint is required" {
+			t.Errorf("expected plugin error, got %v", err)
+		}
+	})
+
+	t.Run("Unimplemented", func(t *testing.T) {
+		if _, err := client.Notify(context.Background(), &plugin.NotifyRequest{}); err == nil {
+			t.Error("expected error for unimplemented notifier")
+		}
+	})
+}
+
+func TestKill(t *testing.T) {
+	client := startTestPlugin(t)
+
+	client.Kill()
+	if !client.Exited() {
+		t.Fatal("expected plugin process to exit")
+	}
+	if _, err := client.Deploy(context.Background(), &plugin.DeployRequest{}); err == nil {
+		t.Error("expected error after plugin exited")
+	}
+}
+
+func TestUpload(t *testing.T) {
+	client := startTestPluginWithMode(t, "uploader")
+
+	info := client.Info()
+	if !info.HasKind(plugin.KindUploader) || info.HasKind(plugin.KindDeployer) {
+		t.Fatalf("expected uploader kind only, got %v", info.Kinds)
+	}
+
+	resp, err := client.Upload(context.Background(), &plugin.UploadRequest{
+		Certificate: "cert",
+		PrivateKey:  "key",
+	})
+	if err != nil {
+		t.Fatalf("err: %+v", err)
+	}
+	if resp.CertId != "cert-1" || resp.CertName != "certimate" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestTransportFailure(t *testing.T) {
+	client := startTestPlugin(t)
+
+	_, err := client.Deploy(context.Background(), &plugin.DeployRequest{
+		AccessConfig: json.RawMessage(`{"endpoint":"broken"}`),
+	})
+	if err == nil {
+		t.Fatal("expected transport error, got nil")
+	}
+
+	// 传输层出错后客户端应被结束并标记为已退出，以便管理器重新启动插件
+	if !client.Exited() {
+		t.Error("expected plugin to be marked as exited after transport failure")
+	}
+}
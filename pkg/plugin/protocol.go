@@ -0,0 +1,191 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	// 协议版本号。主机与插件的协议版本号不一致时，插件将被拒绝加载。
+	ProtocolVersion = 1
+
+	// 由主机在启动插件进程时设置的环境变量，用于防止插件被用户直接执行。
+	MagicCookieKey   = "CERTIMATE_PLUGIN_MAGIC_COOKIE"
+	MagicCookieValue = "0b7a3d1bdc8a4c37a1f4d5f3c9e2b6a8"
+)
+
+// 表示插件所实现的能力。
+type Kind string
+
+const (
+	KindDeployer = Kind("deployer")
+	KindDns01    = Kind("dns01")
+	KindNotifier = Kind("notifier")
+	KindUploader = Kind("uploader")
+)
+
+type Info struct {
+	Name            string `json:"name"`
+	Version         string `json:"version,omitempty"`
+	ProtocolVersion int    `json:"protocolVersion"`
+	Kinds           []Kind `json:"kinds"`
+}
+
+// HasKind 判断插件是否实现了指定的能力。
+func (i *Info) HasKind(kind Kind) bool {
+	for _, k := range i.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+type DeployRequest struct {
+	AccessConfig  json.RawMessage `json:"accessConfig,omitempty"`
+	ServiceConfig json.RawMessage `json:"serviceConfig,omitempty"`
+	Certificate   string          `json:"certificate"`
+	PrivateKey    string          `json:"privateKey"`
+}
+
+type DeployResponse struct {
+	ExtendedData map[string]any `json:"extendedData,omitempty"`
+}
+
+type UploadRequest struct {
+	AccessConfig  json.RawMessage `json:"accessConfig,omitempty"`
+	ServiceConfig json.RawMessage `json:"serviceConfig,omitempty"`
+	Certificate   string          `json:"certificate"`
+	PrivateKey    string          `json:"privateKey"`
+}
+
+type UploadResponse struct {
+	CertId       string         `json:"certId"`
+	CertName     string         `json:"certName,omitempty"`
+	ExtendedData map[string]any `json:"extendedData,omitempty"`
+}
+
+type NotifyRequest struct {
+	AccessConfig  json.RawMessage `json:"accessConfig,omitempty"`
+	ServiceConfig json.RawMessage `json:"serviceConfig,omitempty"`
+	Subject       string          `json:"subject"`
+	Message       string          `json:"message"`
+}
+
+type NotifyResponse struct {
+	ExtendedData map[string]any `json:"extendedData,omitempty"`
+}
+
+type ChallengeRequest struct {
+	AccessConfig  json.RawMessage `json:"accessConfig,omitempty"`
+	ServiceConfig json.RawMessage `json:"serviceConfig,omitempty"`
+	Domain        string          `json:"domain"`
+	Token         string          `json:"token"`
+	KeyAuth       string          `json:"keyAuth"`
+}
+
+type ChallengeResponse struct{}
+
+type infoRequest struct{}
+
+// 插件进程需要实现的各类接口。
+// 每次调用都会携带完整的配置，插件不应依赖调用之间的状态。
+type (
+	Deployer interface {
+		Deploy(ctx context.Context, req *DeployRequest) (*DeployResponse, error)
+	}
+
+	Uploader interface {
+		Upload(ctx context.Context, req *UploadRequest) (*UploadResponse, error)
+	}
+
+	Notifier interface {
+		Notify(ctx context.Context, req *NotifyRequest) (*NotifyResponse, error)
+	}
+
+	ChallengeProvider interface {
+		Present(ctx context.Context, req *ChallengeRequest) error
+		CleanUp(ctx context.Context, req *ChallengeRequest) error
+	}
+)
+
+const serviceName = "certimate.plugin.v1.Plugin"
+
+const (
+	methodGetInfo = "GetInfo"
+	methodDeploy  = "Deploy"
+	methodUpload  = "Upload"
+	methodNotify  = "Notify"
+	methodPresent = "Present"
+	methodCleanUp = "CleanUp"
+)
+
+// 由于消息使用 JSON 编码，这里手写服务描述而不依赖 protoc 生成的代码。
+type pluginService interface {
+	getInfo(ctx context.Context, req *infoRequest) (*Info, error)
+	deploy(ctx context.Context, req *DeployRequest) (*DeployResponse, error)
+	upload(ctx context.Context, req *UploadRequest) (*UploadResponse, error)
+	notify(ctx context.Context, req *NotifyRequest) (*NotifyResponse, error)
+	present(ctx context.Context, req *ChallengeRequest) (*ChallengeResponse, error)
+	cleanUp(ctx context.Context, req *ChallengeRequest) (*ChallengeResponse, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*pluginService)(nil),
+	Methods: []grpc.MethodDesc{
+		newMethodDesc(methodGetInfo, pluginService.getInfo),
+		newMethodDesc(methodDeploy, pluginService.deploy),
+		newMethodDesc(methodUpload, pluginService.upload),
+		newMethodDesc(methodNotify, pluginService.notify),
+		newMethodDesc(methodPresent, pluginService.present),
+		newMethodDesc(methodCleanUp, pluginService.cleanUp),
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func newMethodDesc[TReq any, TResp any](name string, fn func(pluginService, context.Context, *TReq) (*TResp, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			req := new(TReq)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+
+			if interceptor == nil {
+				return fn(srv.(pluginService), ctx, req)
+			}
+
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: fullMethodName(name),
+			}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return fn(srv.(pluginService), ctx, req.(*TReq))
+			}
+			return interceptor(ctx, req, info, handler)
+		},
+	}
+}
+
+func fullMethodName(name string) string {
+	return "/" + serviceName + "/" + name
+}
+
+// jsonCodec 使用 JSON 代替 Protobuf 作为 gRPC 的消息编码，便于其他语言实现插件。
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
@@ -0,0 +1,129 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ServeConfig struct {
+	// 插件名称。
+	Name string
+	// 插件版本。
+	Version string
+	// 证书部署器实现。
+	// 选填。
+	Deployer Deployer
+	// 证书上传器实现。
+	// 选填。
+	Uploader Uploader
+	// 消息通知器实现。
+	// 选填。
+	Notifier Notifier
+	// DNS-01 质询提供商实现。
+	// 选填。
+	ChallengeProvider ChallengeProvider
+}
+
+// Serve 在插件进程中启动 gRPC 服务，并通过标准输入输出与主机通信，直至主机关闭连接。
+// 插件的 `main` 函数中应当只调用此函数；插件的日志请写入标准错误，它会被转发到主机的日志中。
+func Serve(config *ServeConfig) {
+	if config == nil {
+		panic("config is nil")
+	}
+
+	if os.Getenv(MagicCookieKey) != MagicCookieValue {
+		fmt.Fprintln(os.Stderr, "This binary is a plugin for Certimate. It is not meant to be executed directly.")
+		os.Exit(1)
+	}
+
+	conn := newStdioConn(os.Stdin, os.Stdout)
+
+	// 标准输出已被用作通信通道，任何其他写入都会破坏数据流，因此将其重定向到标准错误
+	os.Stdout = os.Stderr
+
+	server := grpc.NewServer(grpc.ForceServerCodec(jsonCodec{}))
+	server.RegisterService(&serviceDesc, &pluginServer{config: config})
+	if err := server.Serve(newStdioListener(conn)); err != nil {
+		select {
+		case <-conn.closed:
+			// 主机关闭了连接，正常退出
+		default:
+			fmt.Fprintf(os.Stderr, "plugin server stopped: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+type pluginServer struct {
+	config *ServeConfig
+}
+
+var _ pluginService = (*pluginServer)(nil)
+
+func (s *pluginServer) getInfo(ctx context.Context, req *infoRequest) (*Info, error) {
+	info := &Info{
+		Name:            s.config.Name,
+		Version:         s.config.Version,
+		ProtocolVersion: ProtocolVersion,
+		Kinds:           make([]Kind, 0),
+	}
+	if s.config.Deployer != nil {
+		info.Kinds = append(info.Kinds, KindDeployer)
+	}
+	if s.config.Uploader != nil {
+		info.Kinds = append(info.Kinds, KindUploader)
+	}
+	if s.config.Notifier != nil {
+		info.Kinds = append(info.Kinds, KindNotifier)
+	}
+	if s.config.ChallengeProvider != nil {
+		info.Kinds = append(info.Kinds, KindDns01)
+	}
+	return info, nil
+}
+
+func (s *pluginServer) deploy(ctx context.Context, req *DeployRequest) (*DeployResponse, error) {
+	if s.config.Deployer == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin '%s' does not implement '%s'", s.config.Name, KindDeployer)
+	}
+	return s.config.Deployer.Deploy(ctx, req)
+}
+
+func (s *pluginServer) upload(ctx context.Context, req *UploadRequest) (*UploadResponse, error) {
+	if s.config.Uploader == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin '%s' does not implement '%s'", s.config.Name, KindUploader)
+	}
+	return s.config.Uploader.Upload(ctx, req)
+}
+
+func (s *pluginServer) notify(ctx context.Context, req *NotifyRequest) (*NotifyResponse, error) {
+	if s.config.Notifier == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin '%s' does not implement '%s'", s.config.Name, KindNotifier)
+	}
+	return s.config.Notifier.Notify(ctx, req)
+}
+
+func (s *pluginServer) present(ctx context.Context, req *ChallengeRequest) (*ChallengeResponse, error) {
+	if s.config.ChallengeProvider == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin '%s' does not implement '%s'", s.config.Name, KindDns01)
+	}
+	if err := s.config.ChallengeProvider.Present(ctx, req); err != nil {
+		return nil, err
+	}
+	return &ChallengeResponse{}, nil
+}
+
+func (s *pluginServer) cleanUp(ctx context.Context, req *ChallengeRequest) (*ChallengeResponse, error) {
+	if s.config.ChallengeProvider == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin '%s' does not implement '%s'", s.config.Name, KindDns01)
+	}
+	if err := s.config.ChallengeProvider.CleanUp(ctx, req); err != nil {
+		return nil, err
+	}
+	return &ChallengeResponse{}, nil
+}
@@ -240,5 +240,6 @@ export type SSLProviderSettingsContent = {
 export type PersistenceSettingsContent = {
   workflowRunsMaxDaysRetention?: number;
   expiredCertificatesMaxDaysRetention?: number;
+  distributionLogsMaxDaysRetention?: number;
 };
 // #endregion
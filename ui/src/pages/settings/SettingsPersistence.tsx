@@ -39,6 +39,9 @@ const SettingsPersistence = () => {
     expiredCertificatesMaxDaysRetention: z
       .number({ message: t("settings.persistence.form.expired_certificates_max_days.placeholder") })
       .gte(0, t("settings.persistence.form.expired_certificates_max_days.placeholder")),
+    distributionLogsMaxDaysRetention: z
+      .number({ message: t("settings.persistence.form.distribution_logs_max_days.placeholder") })
+      .gte(0, t("settings.persistence.form.distribution_logs_max_days.placeholder")),
   });
   const formRule = createSchemaFieldRule(formSchema);
   const {
@@ -49,6 +52,7 @@ const SettingsPersistence = () => {
     initialValues: {
       workflowRunsMaxDaysRetention: settings?.content?.workflowRunsMaxDaysRetention ?? 0,
       expiredCertificatesMaxDaysRetention: settings?.content?.expiredCertificatesMaxDaysRetention ?? 0,
+      distributionLogsMaxDaysRetention: settings?.content?.distributionLogsMaxDaysRetention ?? 0,
     },
     onSubmit: async (values) => {
       try {
@@ -57,6 +61,7 @@ const SettingsPersistence = () => {
             draft.content ??= {} as PersistenceSettingsContent;
             draft.content.workflowRunsMaxDaysRetention = values.workflowRunsMaxDaysRetention;
             draft.content.expiredCertificatesMaxDaysRetention = values.expiredCertificatesMaxDaysRetention;
+            draft.content.distributionLogsMaxDaysRetention = values.distributionLogsMaxDaysRetention;
           })
         );
 
@@ -73,7 +78,8 @@ const SettingsPersistence = () => {
   const handleInputChange = () => {
     const changed =
       formInst.getFieldValue("workflowRunsMaxDaysRetention") !== formProps.initialValues?.workflowRunsMaxDaysRetention ||
-      formInst.getFieldValue("expiredCertificatesMaxDaysRetention") !== formProps.initialValues?.workflowRunsMaxDaysRetention;
+      formInst.getFieldValue("expiredCertificatesMaxDaysRetention") !== formProps.initialValues?.expiredCertificatesMaxDaysRetention ||
+      formInst.getFieldValue("distributionLogsMaxDaysRetention") !== formProps.initialValues?.distributionLogsMaxDaysRetention;
     setFormChanged(changed);
   };
 
@@ -117,6 +123,22 @@ const SettingsPersistence = () => {
               />
             </Form.Item>
 
+            <Form.Item
+              name="distributionLogsMaxDaysRetention"
+              label={t("settings.persistence.form.distribution_logs_max_days.label")}
+              extra={<span dangerouslySetInnerHTML={{ __html: t("settings.persistence.form.distribution_logs_max_days.extra") }}></span>}
+              rules={[formRule]}
+            >
+              <InputNumber
+                className="w-full"
+                min={0}
+                max={36500}
+                placeholder={t("settings.persistence.form.distribution_logs_max_days.placeholder")}
+                addonAfter={t("settings.persistence.form.distribution_logs_max_days.unit")}
+                onChange={handleInputChange}
+              />
+            </Form.Item>
+
             <Form.Item>
               <Button type="primary" htmlType="submit" disabled={!formChanged} loading={formPending}>
                 {t("common.button.save")}